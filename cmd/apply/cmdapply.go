@@ -33,7 +33,16 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 		Use:                   "apply (DIRECTORY | STDIN)",
 		DisableFlagsInUseLine: true,
 		Short:                 i18n.T("Apply a configuration to a resource by package directory or stdin"),
-		RunE:                  r.RunE,
+		Long: i18n.T(`Apply a configuration to a resource by package directory or stdin.
+
+Exit codes:
+  0  all objects were applied, pruned, and reconciled, or skipped
+  1  the apply failed with an error
+  2  one or more objects failed to apply
+  3  one or more objects failed to prune
+  4  one or more objects failed to reconcile, or timed out
+  5  the apply did not finish before the --timeout`),
+		RunE: r.RunE,
 	}
 
 	cmd.Flags().BoolVar(&r.serverSideOptions.ServerSideApply, "server-side", false,
@@ -43,16 +52,27 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 	cmd.Flags().StringVar(&r.serverSideOptions.FieldManager, "field-manager", common.DefaultFieldManager,
 		"The client owner of the fields being applied on the server-side.")
 
-	cmd.Flags().StringVar(&r.output, "output", printers.DefaultPrinter(),
-		fmt.Sprintf("Output format, must be one of %s", strings.Join(printers.SupportedPrinters(), ",")))
+	cmd.Flags().StringVarP(&r.output, "output", "o", printers.DefaultPrinter(),
+		fmt.Sprintf("Output format, must be one of %s. The json output uses the versioned %s schema.",
+			strings.Join(printers.SupportedPrinters(), ","), printers.JSONV2Printer))
+	cmd.Flags().StringVar(&r.color, flagutils.ColorFlag, string(printers.ColorNever), flagutils.ColorFlagUsage)
 	cmd.Flags().DurationVar(&r.reconcileTimeout, "reconcile-timeout", time.Duration(0),
 		"Timeout threshold for waiting for all resources to reach the Current status.")
+	cmd.Flags().BoolVar(&r.waitForJobCompletion, "wait-for-job-completion", false,
+		"If true, wait for the applied Jobs to complete, instead of only reaching the Current status.")
 	cmd.Flags().BoolVar(&r.noPrune, "no-prune", r.noPrune,
 		"If true, do not prune previously applied objects.")
 	cmd.Flags().StringVar(&r.prunePropagationPolicy, "prune-propagation-policy",
 		"Background", "Propagation policy for pruning")
 	cmd.Flags().DurationVar(&r.pruneTimeout, "prune-timeout", time.Duration(0),
 		"Timeout threshold for waiting for all pruned resources to be deleted")
+	cmd.Flags().StringSliceVar(&r.pruneAllowlist, "prune-allowlist", nil,
+		"Kinds of the objects that can be pruned, formatted as Kind.group, like Deployment.apps or ConfigMap. "+
+			"The objects of the other kinds are skipped. By default, objects of all kinds can be pruned.")
+	cmd.Flags().StringSliceVar(&r.pruneSkipNamespaces, "prune-skip-namespace", nil,
+		"Namespaces of the objects that are not pruned.")
+	cmd.Flags().BoolVar(&r.noPruneClusterScoped, "no-prune-cluster-scoped", false,
+		"If true, do not prune cluster-scoped objects, like Namespaces and CRDs.")
 	cmd.Flags().StringVar(&r.inventoryPolicy, flagutils.InventoryPolicyFlag, flagutils.InventoryPolicyStrict,
 		"It determines the behavior when the resources don't belong to current inventory. Available options "+
 			fmt.Sprintf("%q, %q and %q.", flagutils.InventoryPolicyStrict, flagutils.InventoryPolicyAdopt, flagutils.InventoryPolicyForceAdopt))
@@ -80,12 +100,17 @@ type Runner struct {
 	serverSideOptions      common.ServerSideOptions
 	output                 string
 	reconcileTimeout       time.Duration
+	waitForJobCompletion   bool
 	noPrune                bool
 	prunePropagationPolicy string
 	pruneTimeout           time.Duration
+	pruneAllowlist         []string
+	pruneSkipNamespaces    []string
+	noPruneClusterScoped   bool
 	inventoryPolicy        string
 	timeout                time.Duration
 	printStatusEvents      bool
+	color                  string
 }
 
 func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
@@ -105,16 +130,26 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	pruneAllowGroupKinds, err := flagutils.ConvertGroupKinds(r.pruneAllowlist)
+	if err != nil {
+		return err
+	}
 
 	if found := printers.ValidatePrinterType(r.output); !found {
 		return fmt.Errorf("unknown output type %q", r.output)
 	}
+	colorMode, err := printers.ParseColorMode(r.color)
+	if err != nil {
+		return err
+	}
 
 	// TODO: Fix DemandOneDirectory to no longer return FileNameFlags
 	// since we are no longer using them.
-	_, err = common.DemandOneDirectory(args)
-	if err != nil {
-		return err
+	if !manifestreader.IsRemotePath(flagutils.PathFromArgs(args)) {
+		_, err = common.DemandOneDirectory(args)
+		if err != nil {
+			return err
+		}
 	}
 	reader, err := r.loader.ManifestReader(cmd.InOrStdin(), flagutils.PathFromArgs(args))
 	if err != nil {
@@ -152,8 +187,9 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	}
 
 	ch := a.Run(ctx, inv, objs, apply.ApplierOptions{
-		ServerSideOptions: r.serverSideOptions,
-		ReconcileTimeout:  r.reconcileTimeout,
+		ServerSideOptions:    r.serverSideOptions,
+		ReconcileTimeout:     r.reconcileTimeout,
+		WaitForJobCompletion: r.waitForJobCompletion,
 		// If we are not waiting for status, tell the applier to not
 		// emit the events.
 		EmitStatusEvents:       r.printStatusEvents,
@@ -161,11 +197,22 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		DryRunStrategy:         common.DryRunNone,
 		PrunePropagationPolicy: prunePropPolicy,
 		PruneTimeout:           r.pruneTimeout,
+		PruneAllowGroupKinds:   pruneAllowGroupKinds,
+		PruneSkipNamespaces:    r.pruneSkipNamespaces,
+		NoPruneClusterScoped:   r.noPruneClusterScoped,
 		InventoryPolicy:        inventoryPolicy,
 	})
 
 	// The printer will print updates from the channel. It will block
 	// until the channel is closed.
-	printer := printers.GetPrinter(r.output, r.ioStreams)
+	// The apply command writes json with the versioned schema. The original
+	// json schema remains available in the other commands.
+	output := r.output
+	if output == printers.JSONPrinter {
+		output = printers.JSONV2Printer
+	}
+	printer := printers.GetPrinterWithOptions(output, r.ioStreams, printers.Options{
+		Color: colorMode,
+	})
 	return printer.Print(ch, common.DryRunNone, r.printStatusEvents)
 }
@@ -15,7 +15,6 @@ import (
 	"k8s.io/kubectl/pkg/util/i18n"
 	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/pkg/apply"
-	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
 	"sigs.k8s.io/cli-utils/pkg/printers"
@@ -37,8 +36,14 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 		RunE:                  r.RunE,
 	}
 
-	cmd.Flags().StringVar(&r.output, "output", printers.DefaultPrinter(),
-		fmt.Sprintf("Output format, must be one of %s", strings.Join(printers.SupportedPrinters(), ",")))
+	cmd.Flags().StringVarP(&r.output, "output", "o", printers.DefaultPrinter(),
+		fmt.Sprintf("Output format, must be one of %s. The json output uses the versioned %s schema.",
+			strings.Join(printers.SupportedPrinters(), ","), printers.JSONV2Printer))
+	cmd.Flags().StringVar(&r.dryRun, flagutils.DryRunFlag, flagutils.DryRunNone,
+		"Must be one of none, client, or server. If client or server, print the objects that would be deleted, "+
+			"in order, and the objects that would be skipped, without deleting them. If server, the deletions are "+
+			"sent to the server as dry-run requests.")
+	cmd.Flags().StringVar(&r.color, flagutils.ColorFlag, string(printers.ColorNever), flagutils.ColorFlagUsage)
 	cmd.Flags().StringVar(&r.inventoryPolicy, flagutils.InventoryPolicyFlag, flagutils.InventoryPolicyStrict,
 		"It determines the behavior when the resources don't belong to current inventory. Available options "+
 			fmt.Sprintf("%q, %q and %q.", flagutils.InventoryPolicyStrict, flagutils.InventoryPolicyAdopt, flagutils.InventoryPolicyForceAdopt))
@@ -50,6 +55,12 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 		"How long to wait before exiting")
 	cmd.Flags().BoolVar(&r.printStatusEvents, "status-events", false,
 		"Print status events (always enabled for table output)")
+	cmd.Flags().BoolVar(&r.keepNamespaces, "keep-namespaces", false,
+		"Keep the Namespaces, instead of deleting them, and remove them from the inventory")
+	cmd.Flags().BoolVar(&r.keepCRDs, "keep-crds", false,
+		"Keep the CustomResourceDefinitions, instead of deleting them, and remove them from the inventory")
+	cmd.Flags().BoolVar(&r.keepInventory, "keep-inventory", false,
+		"Keep the empty inventory object, instead of deleting it, after all the objects are deleted")
 
 	r.Command = cmd
 	return r
@@ -70,11 +81,16 @@ type Runner struct {
 	loader     manifestreader.ManifestLoader
 
 	output                  string
+	dryRun                  string
 	deleteTimeout           time.Duration
 	deletePropagationPolicy string
 	inventoryPolicy         string
 	timeout                 time.Duration
 	printStatusEvents       bool
+	keepNamespaces          bool
+	keepCRDs                bool
+	keepInventory           bool
+	color                   string
 }
 
 func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
@@ -94,10 +110,18 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	dryRunStrategy, err := flagutils.ConvertDryRunStrategy(r.dryRun)
+	if err != nil {
+		return err
+	}
 
 	if found := printers.ValidatePrinterType(r.output); !found {
 		return fmt.Errorf("unknown output type %q", r.output)
 	}
+	colorMode, err := printers.ParseColorMode(r.color)
+	if err != nil {
+		return err
+	}
 
 	// Retrieve the inventory object.
 	reader, err := r.loader.ManifestReader(cmd.InOrStdin(), flagutils.PathFromArgs(args))
@@ -137,11 +161,23 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		DeleteTimeout:           r.deleteTimeout,
 		DeletePropagationPolicy: deletePropPolicy,
 		InventoryPolicy:         inventoryPolicy,
+		DryRunStrategy:          dryRunStrategy,
 		EmitStatusEvents:        r.printStatusEvents,
+		KeepNamespaces:          r.keepNamespaces,
+		KeepCRDs:                r.keepCRDs,
+		KeepInventory:           r.keepInventory,
 	})
 
 	// The printer will print updates from the channel. It will block
 	// until the channel is closed.
-	printer := printers.GetPrinter(r.output, r.ioStreams)
-	return printer.Print(ch, common.DryRunNone, r.printStatusEvents)
+	// The destroy command writes json with the versioned schema, which
+	// includes the error codes of the skipped objects.
+	output := r.output
+	if output == printers.JSONPrinter {
+		output = printers.JSONV2Printer
+	}
+	printer := printers.GetPrinterWithOptions(output, r.ioStreams, printers.Options{
+		Color: colorMode,
+	})
+	return printer.Print(ch, dryRunStrategy, r.printStatusEvents)
 }
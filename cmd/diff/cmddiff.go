@@ -17,24 +17,46 @@ import (
 
 const tmpDirPrefix = "diff-cmd"
 
-// NewCommand returns cobra command to implement client-side diff of package
-// directory. For each local config file, get the resource in the cluster
-// and diff the local config resource against the resource in the cluster.
+// NewCommand returns cobra command to implement diff of package directory.
+// For each local config file, get the resource in the cluster and diff the
+// result of a server-side dry-run apply of the local config resource
+// against the resource in the cluster.
 func NewCommand(f util.Factory, ioStreams genericclioptions.IOStreams) *cobra.Command {
 	options := diff.NewDiffOptions(ioStreams)
+	summary := false
 	cmd := &cobra.Command{
 		Use:                   "diff (DIRECTORY | STDIN)",
 		DisableFlagsInUseLine: true,
 		Short:                 i18n.T("Diff local config against cluster applied version"),
-		Args:                  cobra.MaximumNArgs(1),
+		Long: i18n.T(`Diff local config against cluster applied version.
+
+The diff is computed with a dry-run apply on the server. The diff program
+is "diff -u -N" by default, and can be replaced by setting the
+KUBECTL_EXTERNAL_DIFF environment variable, like "KUBECTL_EXTERNAL_DIFF=meld".
+
+Exits with code 0 if there are no differences, and 1 if there are
+differences or an error occurred.`),
+		Args: cobra.MaximumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			cleanupFunc, err := Initialize(options, f, args)
 			defer cleanupFunc()
 			util.CheckErr(err)
+			if summary {
+				options.Diff.Exec = &summaryExec{Interface: options.Diff.Exec}
+			}
 			util.CheckErr(options.Run())
 		},
 	}
 
+	cmd.Flags().BoolVar(&options.ServerSideApply, "server-side", false,
+		"If true, diff against the result of a server-side apply instead of a client-side apply.")
+	cmd.Flags().BoolVar(&options.ForceConflicts, "force-conflicts", false,
+		"If true during server-side diff, overwrite fields owned by other field managers.")
+	cmd.Flags().StringVar(&options.FieldManager, "field-manager", common.DefaultFieldManager,
+		"The client owner of the fields being applied on the server-side.")
+	cmd.Flags().BoolVar(&summary, "summary", false,
+		"If true, only list the objects that would be created, modified, or deleted, instead of the diff.")
+
 	return cmd
 }
 
@@ -90,9 +112,9 @@ func Initialize(o *diff.DiffOptions, f util.Factory, args []string) (func(), err
 
 	o.Builder = f.NewBuilder()
 
-	// We don't support server-side apply diffing yet.
-	o.ServerSideApply = false
-	o.ForceConflicts = false
+	if !o.ServerSideApply {
+		o.ForceConflicts = false
+	}
 
 	return cleanupFunc, nil
 }
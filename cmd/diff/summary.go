@@ -0,0 +1,133 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"k8s.io/utils/exec"
+)
+
+// Change types listed by the summary, for each object that differs.
+const (
+	Created  = "created"
+	Modified = "modified"
+	Deleted  = "deleted"
+)
+
+// summaryExec replaces the external diff program with a summary of the
+// changed objects. The differ calls the program with the LIVE and MERGED
+// directories as the last two arguments.
+type summaryExec struct {
+	exec.Interface
+}
+
+func (e *summaryExec) Command(_ string, args ...string) exec.Cmd {
+	cmd := &summaryCmd{}
+	if len(args) >= 2 {
+		cmd.from = args[len(args)-2]
+		cmd.to = args[len(args)-1]
+	}
+	return cmd
+}
+
+// summaryCmd implements the subset of exec.Cmd used by the differ.
+type summaryCmd struct {
+	exec.Cmd
+	from   string
+	to     string
+	stdout io.Writer
+}
+
+func (c *summaryCmd) SetStdout(out io.Writer) {
+	c.stdout = out
+}
+
+func (c *summaryCmd) SetStderr(io.Writer) {}
+
+// Run prints one line per changed object and, like diff(1), exits with
+// code 1 if any objects changed.
+func (c *summaryCmd) Run() error {
+	changes, err := summarize(c.from, c.to)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if _, err := fmt.Fprintf(c.stdout, "%s %s\n", change.Name, change.Type); err != nil {
+			return err
+		}
+	}
+	if len(changes) > 0 {
+		return exec.CodeExitError{Err: errors.New("objects changed"), Code: 1}
+	}
+	return nil
+}
+
+// change is an object that differs between the live and merged state.
+type change struct {
+	Name string
+	Type string
+}
+
+// summarize compares the objects written by the differ to the from and to
+// directories, sorted by name.
+func summarize(from, to string) ([]change, error) {
+	fromFiles, err := readFiles(from)
+	if err != nil {
+		return nil, err
+	}
+	toFiles, err := readFiles(to)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []change
+	for name, toData := range toFiles {
+		fromData, found := fromFiles[name]
+		switch {
+		case !found:
+			changes = append(changes, change{Name: name, Type: Created})
+		case !bytes.Equal(fromData, toData):
+			changes = append(changes, change{Name: name, Type: Modified})
+		}
+	}
+	for name := range fromFiles {
+		if _, found := toFiles[name]; !found {
+			changes = append(changes, change{Name: name, Type: Deleted})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes, nil
+}
+
+func readFiles(dir string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]byte, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		// The differ writes an empty file for objects that don't exist.
+		if len(data) == 0 {
+			continue
+		}
+		files[entry.Name()] = data
+	}
+	return files, nil
+}
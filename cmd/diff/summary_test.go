@@ -0,0 +1,79 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/exec"
+)
+
+func TestSummary(t *testing.T) {
+	testCases := map[string]struct {
+		live           map[string]string
+		merged         map[string]string
+		expectedOutput string
+		expectedCode   int
+	}{
+		"no changes": {
+			live: map[string]string{
+				"v1.ConfigMap.default.cm": "data: a",
+			},
+			merged: map[string]string{
+				"v1.ConfigMap.default.cm": "data: a",
+			},
+		},
+		"created, modified, and deleted objects": {
+			live: map[string]string{
+				"apps.v1.Deployment.default.dep": "replicas: 1",
+				"v1.ConfigMap.default.cm":        "data: a",
+				"v1.Secret.default.pruned":       "data: b",
+				"v1.Service.default.new":         "",
+			},
+			merged: map[string]string{
+				"apps.v1.Deployment.default.dep": "replicas: 2",
+				"v1.ConfigMap.default.cm":        "data: a",
+				"v1.Service.default.new":         "spec: {}",
+			},
+			expectedOutput: `apps.v1.Deployment.default.dep modified
+v1.Secret.default.pruned deleted
+v1.Service.default.new created
+`,
+			expectedCode: 1,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			from := writeFiles(t, tc.live)
+			to := writeFiles(t, tc.merged)
+
+			var out bytes.Buffer
+			cmd := (&summaryExec{}).Command("diff", "-u", "-N", from, to)
+			cmd.SetStdout(&out)
+			err := cmd.Run()
+			if tc.expectedCode != 0 {
+				var exitErr exec.ExitError
+				require.ErrorAs(t, err, &exitErr)
+				assert.Equal(t, tc.expectedCode, exitErr.ExitStatus())
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedOutput, out.String())
+		})
+	}
+}
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, data := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(data), 0600))
+	}
+	return dir
+}
@@ -0,0 +1,37 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package flagutils
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+	"sigs.k8s.io/cli-utils/pkg/manifestreader"
+)
+
+// RemoteManifestFlags are the flags of the readers that fetch manifests over
+// the network, like an https URL or an OCI artifact.
+type RemoteManifestFlags struct {
+	Checksum    string
+	HTTPTimeout time.Duration
+	HTTPProxy   string
+}
+
+// AddFlags adds the flags to the flag set.
+func (f *RemoteManifestFlags) AddFlags(flags *pflag.FlagSet) {
+	flags.StringVar(&f.Checksum, "checksum", "",
+		"Expected SHA256 checksum of the manifests fetched from an https URL.")
+	flags.DurationVar(&f.HTTPTimeout, "http-timeout", manifestreader.DefaultHTTPTimeout,
+		"Timeout of the requests that fetch the manifests from an https URL or an OCI registry.")
+	flags.StringVar(&f.HTTPProxy, "http-proxy", "",
+		"URL of the proxy of the requests that fetch the manifests from an https URL or an OCI registry. "+
+			"Defaults to the HTTPS_PROXY, HTTP_PROXY, and NO_PROXY environment variables.")
+}
+
+// SetReaderOptions sets the values of the flags in the reader options.
+func (f *RemoteManifestFlags) SetReaderOptions(options *manifestreader.ReaderOptions) {
+	options.Checksum = f.Checksum
+	options.HTTPTimeout = f.HTTPTimeout
+	options.HTTPProxy = f.HTTPProxy
+}
@@ -5,9 +5,13 @@ package flagutils
 
 import (
 	"fmt"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/printers"
 )
 
 const (
@@ -15,8 +19,20 @@ const (
 	InventoryPolicyStrict     = "strict"
 	InventoryPolicyAdopt      = "adopt"
 	InventoryPolicyForceAdopt = "force-adopt"
+
+	DryRunFlag   = "dry-run"
+	DryRunNone   = "none"
+	DryRunClient = "client"
+	DryRunServer = "server"
+
+	ColorFlag = "color"
 )
 
+// ColorFlagUsage is the usage of the color flag, with the accepted modes.
+var ColorFlagUsage = fmt.Sprintf("Highlight the events and quiet output with colors. Must be one of %s. "+
+	"If auto, colors are only used if the output is a terminal and NO_COLOR is not set.",
+	strings.Join(printers.ColorModes(), ", "))
+
 // ConvertPropagationPolicy converts a propagationPolicy described as a
 // string to a DeletionPropagation type that is passed into the Applier.
 func ConvertPropagationPolicy(propagationPolicy string) (metav1.DeletionPropagation, error) {
@@ -47,6 +63,36 @@ func ConvertInventoryPolicy(policy string) (inventory.Policy, error) {
 	}
 }
 
+// ConvertDryRunStrategy converts a dry-run strategy described as a string
+// to a DryRunStrategy.
+func ConvertDryRunStrategy(strategy string) (common.DryRunStrategy, error) {
+	switch strategy {
+	case DryRunNone:
+		return common.DryRunNone, nil
+	case DryRunClient:
+		return common.DryRunClient, nil
+	case DryRunServer:
+		return common.DryRunServer, nil
+	default:
+		return common.DryRunNone, fmt.Errorf(
+			"dry-run strategy must be one of none, client, server")
+	}
+}
+
+// ConvertGroupKinds converts the kinds described as Kind.group strings,
+// like Deployment.apps or ConfigMap, to GroupKinds.
+func ConvertGroupKinds(values []string) ([]schema.GroupKind, error) {
+	var groupKinds []schema.GroupKind
+	for _, value := range values {
+		gk := schema.ParseGroupKind(value)
+		if gk.Kind == "" {
+			return nil, fmt.Errorf("invalid kind %q, must be formatted as Kind.group", value)
+		}
+		groupKinds = append(groupKinds, gk)
+	}
+	return groupKinds, nil
+}
+
 // PathFromArgs returns the path which is a positional arg from args list
 // returns "-" if there is length of args is 0, which implies no path is provided
 func PathFromArgs(args []string) string {
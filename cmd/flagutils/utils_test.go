@@ -7,6 +7,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 )
 
@@ -50,3 +54,72 @@ func TestConvertInventoryPolicy(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertGroupKinds(t *testing.T) {
+	testcases := map[string]struct {
+		values      []string
+		groupKinds  []schema.GroupKind
+		expectedErr string
+	}{
+		"no kinds": {},
+		"core and grouped kinds": {
+			values: []string{"ConfigMap", "Deployment.apps", "Certificate.cert-manager.io"},
+			groupKinds: []schema.GroupKind{
+				{Kind: "ConfigMap"},
+				{Group: "apps", Kind: "Deployment"},
+				{Group: "cert-manager.io", Kind: "Certificate"},
+			},
+		},
+		"missing kind": {
+			values:      []string{".apps"},
+			expectedErr: `invalid kind ".apps", must be formatted as Kind.group`,
+		},
+	}
+	for tn, tc := range testcases {
+		t.Run(tn, func(t *testing.T) {
+			groupKinds, err := ConvertGroupKinds(tc.values)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.groupKinds, groupKinds)
+		})
+	}
+}
+
+func TestConvertDryRunStrategy(t *testing.T) {
+	testcases := map[string]struct {
+		value       string
+		strategy    common.DryRunStrategy
+		expectedErr string
+	}{
+		"none": {
+			value:    "none",
+			strategy: common.DryRunNone,
+		},
+		"client": {
+			value:    "client",
+			strategy: common.DryRunClient,
+		},
+		"server": {
+			value:    "server",
+			strategy: common.DryRunServer,
+		},
+		"unknown": {
+			value:       "true",
+			expectedErr: "dry-run strategy must be one of none, client, server",
+		},
+	}
+	for tn, tc := range testcases {
+		t.Run(tn, func(t *testing.T) {
+			strategy, err := ConvertDryRunStrategy(tc.value)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.strategy, strategy)
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package initcmd
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
@@ -34,6 +36,12 @@ func GetInitRunner(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *In
 		},
 	}
 	cmd.Flags().StringVarP(&io.InventoryID, "inventory-id", "i", "", "Identifier for group of applied resources. Must be composed of valid label characters.")
+	cmd.Flags().StringToStringVar(&io.Labels, "label", nil,
+		"Extra labels of the inventory object, as key=value pairs, like --label team=shop,env=prod.")
+	cmd.Flags().StringVar(&io.Output, "output", "",
+		fmt.Sprintf("Path of the file the inventory object template is written to, or %q for stdout. "+
+			"Defaults to the inventory-template.yaml file in DIRECTORY. The namespace of the template is "+
+			"set with --namespace, or else detected from the objects in DIRECTORY.", config.StdoutOutput))
 	i := &InitRunner{
 		Command:     cmd,
 		InitOptions: io,
@@ -20,12 +20,15 @@ import (
 	"sigs.k8s.io/cli-utils/cmd/apply"
 	"sigs.k8s.io/cli-utils/cmd/destroy"
 	"sigs.k8s.io/cli-utils/cmd/diff"
+	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/cmd/initcmd"
 	"sigs.k8s.io/cli-utils/cmd/preview"
 	"sigs.k8s.io/cli-utils/cmd/status"
+	"sigs.k8s.io/cli-utils/pkg/features"
 	"sigs.k8s.io/cli-utils/pkg/flowcontrol"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
 
 	// This is here rather than in the libraries because of
 	// https://github.com/kubernetes-sigs/kustomize/issues/2060
@@ -50,6 +53,7 @@ func main() {
 	matchVersionKubeConfigFlags := util.NewMatchVersionFlags(kubeConfigFlags)
 	matchVersionKubeConfigFlags.AddFlags(flags)
 	flags.AddGoFlagSet(flag.CommandLine)
+	features.DefaultMutableFeatureGate.AddFlag(flags)
 	f := util.NewFactory(matchVersionKubeConfigFlags)
 
 	// Update ConfigFlags before subcommands run that talk to the server.
@@ -61,7 +65,9 @@ func main() {
 		ErrOut: os.Stderr,
 	}
 
-	loader := manifestreader.NewManifestLoader(f)
+	remoteFlags := &flagutils.RemoteManifestFlags{}
+	remoteFlags.AddFlags(flags)
+	loader := manifestreader.NewManifestLoaderWithOptions(f, remoteFlags.SetReaderOptions)
 	invFactory := inventory.ClusterClientFactory{StatusPolicy: inventory.StatusPolicyNone}
 
 	names := []string{"init", "apply", "destroy", "diff", "preview", "status"}
@@ -79,8 +85,12 @@ func main() {
 		cmd.AddCommand(subCmd)
 	}
 
-	code := cli.Run(cmd)
-	os.Exit(code)
+	// Map the result to an exit code, so scripts can distinguish failed
+	// apply, prune, reconcile, and timeouts from other errors.
+	if err := cli.RunNoErrOutput(cmd); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(printcommon.ExitCodeFromError(err))
+	}
 }
 
 // updateHelp replaces `kubectl` help messaging with `kapply` help messaging
@@ -94,8 +104,11 @@ func updateHelp(names []string, c *cobra.Command) {
 }
 
 // newConfigFilerPreRunE returns a cobra command PreRunE function that
-// performs a lookup to determine if server-side throttling is enabled. If so,
-// client-side throttling is disabled in the ConfigFlags.
+// performs a lookup to determine if server-side throttling is enabled, and
+// configures adaptive client-side throttling in the ConfigFlags. If
+// server-side throttling is enabled, requests are not throttled by the client
+// until the server rejects them. Otherwise, the QPS of the ConfigFlags is the
+// maximum QPS.
 func newConfigFilerPreRunE(f util.Factory, configFlags *genericclioptions.ConfigFlags) func(*cobra.Command, []string) error {
 	return func(_ *cobra.Command, args []string) error {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -109,15 +122,24 @@ func newConfigFilerPreRunE(f util.Factory, configFlags *genericclioptions.Config
 		if err != nil {
 			return fmt.Errorf("checking server-side throttling enablement: %w", err)
 		}
+		qps, burst := restConfig.QPS, restConfig.Burst
+		if qps == 0 {
+			qps = rest.DefaultQPS
+		}
+		if burst == 0 {
+			burst = rest.DefaultBurst
+		}
 		if enabled {
-			// Disable client-side throttling.
+			// Disable client-side throttling, until the server pushes back.
 			klog.V(3).Infof("Client-side throttling disabled")
-			// WrapConfigFn will affect future Factory.ToRESTConfig() calls.
-			configFlags.WrapConfigFn = func(cfg *rest.Config) *rest.Config {
-				cfg.QPS = -1
-				cfg.Burst = -1
-				return cfg
-			}
+			qps = -1
+		}
+		// Share the rate limiter between all the clients.
+		limiter := flowcontrol.NewAdaptiveRateLimiter(qps, burst)
+		// WrapConfigFn will affect future Factory.ToRESTConfig() calls.
+		configFlags.WrapConfigFn = func(cfg *rest.Config) *rest.Config {
+			limiter.Configure(cfg)
+			return cfg
 		}
 		return nil
 	}
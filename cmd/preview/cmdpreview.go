@@ -45,15 +45,20 @@ func GetRunner(factory cmdutil.Factory, invFactory inventory.ClientFactory,
 	}
 
 	cmd.Flags().BoolVar(&noPrune, "no-prune", noPrune, "If true, do not prune previously applied objects.")
+	cmd.Flags().StringVar(&r.dryRun, flagutils.DryRunFlag, flagutils.DryRunServer,
+		fmt.Sprintf("Must be %q or %q. A server dry-run runs the admission webhooks and defaulting of the server, "+
+			"and falls back to a client dry-run for the objects the server cannot dry-run.",
+			flagutils.DryRunServer, flagutils.DryRunClient))
 	cmd.Flags().BoolVar(&r.serverSideOptions.ServerSideApply, "server-side", false,
-		"If true, preview runs in the server instead of the client.")
+		"If true, preview uses server-side apply. Requires a server dry-run.")
 	cmd.Flags().BoolVar(&r.serverSideOptions.ForceConflicts, "force-conflicts", false,
 		"If true during server-side preview, do not report field conflicts.")
 	cmd.Flags().StringVar(&r.serverSideOptions.FieldManager, "field-manager", common.DefaultFieldManager,
 		"If true during server-side preview, sets field owner.")
 	cmd.Flags().BoolVar(&previewDestroy, "destroy", previewDestroy, "If true, preview of destroy operations will be displayed.")
-	cmd.Flags().StringVar(&r.output, "output", printers.DefaultPrinter(),
+	cmd.Flags().StringVarP(&r.output, "output", "o", printers.DefaultPrinter(),
 		fmt.Sprintf("Output format, must be one of %s", strings.Join(printers.SupportedPrinters(), ",")))
+	cmd.Flags().StringVar(&r.color, flagutils.ColorFlag, string(printers.ColorNever), flagutils.ColorFlagUsage)
 	cmd.Flags().StringVar(&r.inventoryPolicy, flagutils.InventoryPolicyFlag, flagutils.InventoryPolicyStrict,
 		"It determines the behavior when the resources don't belong to current inventory. Available options "+
 			fmt.Sprintf("%q, %q and %q.", flagutils.InventoryPolicyStrict, flagutils.InventoryPolicyAdopt, flagutils.InventoryPolicyForceAdopt))
@@ -79,9 +84,11 @@ type Runner struct {
 	ioStreams  genericclioptions.IOStreams
 
 	serverSideOptions common.ServerSideOptions
+	dryRun            string
 	output            string
 	inventoryPolicy   string
 	timeout           time.Duration
+	color             string
 }
 
 // RunE is the function run from the cobra command.
@@ -96,15 +103,19 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 
 	var ch <-chan event.Event
 
-	drs := common.DryRunClient
-	if r.serverSideOptions.ServerSideApply {
-		drs = common.DryRunServer
+	drs, err := previewDryRunStrategy(r.dryRun, r.serverSideOptions.ServerSideApply)
+	if err != nil {
+		return err
 	}
 
 	inventoryPolicy, err := flagutils.ConvertInventoryPolicy(r.inventoryPolicy)
 	if err != nil {
 		return err
 	}
+	colorMode, err := printers.ParseColorMode(r.color)
+	if err != nil {
+		return err
+	}
 
 	reader, err := r.loader.ManifestReader(cmd.InOrStdin(), flagutils.PathFromArgs(args))
 	if err != nil {
@@ -134,9 +145,11 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 	// if destroy flag is set in preview, transmit it to destroyer DryRunStrategy flag
 	// and pivot execution to destroy with dry-run
 	if !previewDestroy {
-		_, err = common.DemandOneDirectory(args)
-		if err != nil {
-			return err
+		if !manifestreader.IsRemotePath(flagutils.PathFromArgs(args)) {
+			_, err = common.DemandOneDirectory(args)
+			if err != nil {
+				return err
+			}
 		}
 		a, err := apply.NewApplierBuilder().
 			WithFactory(r.factory).
@@ -148,13 +161,7 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 
 		// Run the applier. It will return a channel where we can receive updates
 		// to keep track of progress and any issues.
-		ch = a.Run(ctx, inv, objs, apply.ApplierOptions{
-			EmitStatusEvents:  false,
-			NoPrune:           noPrune,
-			DryRunStrategy:    drs,
-			ServerSideOptions: r.serverSideOptions,
-			InventoryPolicy:   inventoryPolicy,
-		})
+		ch = a.Run(ctx, inv, objs, r.applierOptions(drs, inventoryPolicy))
 	} else {
 		d, err := apply.NewDestroyerBuilder().
 			WithFactory(r.factory).
@@ -169,17 +176,62 @@ func (r *Runner) RunE(cmd *cobra.Command, args []string) error {
 		})
 	}
 
-	// Print the preview strategy unless the output format is json.
-	if r.output != printers.JSONPrinter {
+	// Print the preview strategy, unless the output format is machine
+	// readable or only prints failures.
+	if printsPreviewStrategy(r.output) {
 		if drs.ServerDryRun() {
-			fmt.Println("Preview strategy: server")
+			fmt.Fprintln(r.ioStreams.Out, "Preview strategy: server")
 		} else {
-			fmt.Println("Preview strategy: client")
+			fmt.Fprintln(r.ioStreams.Out, "Preview strategy: client")
 		}
 	}
 
 	// The printer will print updates from the channel. It will block
 	// until the channel is closed.
-	printer := printers.GetPrinter(r.output, r.ioStreams)
+	printer := printers.GetPrinterWithOptions(r.output, r.ioStreams, printers.Options{
+		Color: colorMode,
+	})
 	return printer.Print(ch, drs, false) // Do not print status
 }
+
+// previewDryRunStrategy returns the dry-run strategy of the preview.
+// Server-side apply can only be previewed with a server dry-run.
+func previewDryRunStrategy(dryRun string, serverSideApply bool) (common.DryRunStrategy, error) {
+	switch dryRun {
+	case flagutils.DryRunServer:
+		return common.DryRunServer, nil
+	case flagutils.DryRunClient:
+		if serverSideApply {
+			return common.DryRunNone, fmt.Errorf("--server-side requires --%s=%s", flagutils.DryRunFlag, flagutils.DryRunServer)
+		}
+		return common.DryRunClient, nil
+	default:
+		return common.DryRunNone, fmt.Errorf("preview dry-run strategy must be one of %s, %s",
+			flagutils.DryRunServer, flagutils.DryRunClient)
+	}
+}
+
+// applierOptions returns the options of the previewed apply. The fields
+// changed by the apply are listed in the events, so the printers show the
+// changes of the objects that already exist.
+func (r *Runner) applierOptions(drs common.DryRunStrategy, inventoryPolicy inventory.Policy) apply.ApplierOptions {
+	return apply.ApplierOptions{
+		EmitStatusEvents:  false,
+		NoPrune:           noPrune,
+		DryRunStrategy:    drs,
+		ServerSideOptions: r.serverSideOptions,
+		InventoryPolicy:   inventoryPolicy,
+		FieldDiffs:        true,
+	}
+}
+
+// printsPreviewStrategy returns true if the preview strategy line can be
+// printed before the output of the printer.
+func printsPreviewStrategy(output string) bool {
+	switch output {
+	case printers.JSONPrinter, printers.JSONV2Printer, printers.JUnitPrinter, printers.QuietPrinter:
+		return false
+	default:
+		return true
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package preview
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/cli-utils/cmd/flagutils"
+	"sigs.k8s.io/cli-utils/pkg/apply"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/printers"
+)
+
+func TestApplierOptions(t *testing.T) {
+	testCases := map[string]struct {
+		serverSideOptions common.ServerSideOptions
+		drs               common.DryRunStrategy
+		expected          apply.ApplierOptions
+	}{
+		"client-side preview": {
+			drs: common.DryRunClient,
+			expected: apply.ApplierOptions{
+				DryRunStrategy:  common.DryRunClient,
+				InventoryPolicy: inventory.PolicyMustMatch,
+				FieldDiffs:      true,
+			},
+		},
+		"server-side preview": {
+			serverSideOptions: common.ServerSideOptions{
+				ServerSideApply: true,
+				FieldManager:    common.DefaultFieldManager,
+			},
+			drs: common.DryRunServer,
+			expected: apply.ApplierOptions{
+				DryRunStrategy: common.DryRunServer,
+				ServerSideOptions: common.ServerSideOptions{
+					ServerSideApply: true,
+					FieldManager:    common.DefaultFieldManager,
+				},
+				InventoryPolicy: inventory.PolicyMustMatch,
+				FieldDiffs:      true,
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			r := &Runner{
+				serverSideOptions: tc.serverSideOptions,
+			}
+			assert.Equal(t, tc.expected, r.applierOptions(tc.drs, inventory.PolicyMustMatch))
+		})
+	}
+}
+
+func TestPrintsPreviewStrategy(t *testing.T) {
+	testCases := map[string]struct {
+		output   string
+		expected bool
+	}{
+		"events": {
+			output:   printers.EventsPrinter,
+			expected: true,
+		},
+		"table": {
+			output:   printers.TablePrinter,
+			expected: true,
+		},
+		"json": {
+			output:   printers.JSONPrinter,
+			expected: false,
+		},
+		"jsonv2": {
+			output:   printers.JSONV2Printer,
+			expected: false,
+		},
+		"quiet": {
+			output:   printers.QuietPrinter,
+			expected: false,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, printsPreviewStrategy(tc.output))
+		})
+	}
+}
+
+func TestPreviewDryRunStrategy(t *testing.T) {
+	testCases := map[string]struct {
+		dryRun          string
+		serverSideApply bool
+		expected        common.DryRunStrategy
+		expectedErr     string
+	}{
+		"server dry-run": {
+			dryRun:   flagutils.DryRunServer,
+			expected: common.DryRunServer,
+		},
+		"server dry-run with server-side apply": {
+			dryRun:          flagutils.DryRunServer,
+			serverSideApply: true,
+			expected:        common.DryRunServer,
+		},
+		"client dry-run": {
+			dryRun:   flagutils.DryRunClient,
+			expected: common.DryRunClient,
+		},
+		"client dry-run with server-side apply": {
+			dryRun:          flagutils.DryRunClient,
+			serverSideApply: true,
+			expectedErr:     "--server-side requires --dry-run=server",
+		},
+		"no dry-run": {
+			dryRun:      flagutils.DryRunNone,
+			expectedErr: "preview dry-run strategy must be one of server, client",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			drs, err := previewDryRunStrategy(tc.dryRun, tc.serverSideApply)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, drs)
+		})
+	}
+}
@@ -16,17 +16,21 @@ import (
 	"sigs.k8s.io/cli-utils/cmd/flagutils"
 	"sigs.k8s.io/cli-utils/cmd/status/printers"
 	"sigs.k8s.io/cli-utils/cmd/status/printers/printer"
+	"sigs.k8s.io/cli-utils/cmd/status/printers/table"
 	"sigs.k8s.io/cli-utils/pkg/apply/poller"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/features"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/aggregator"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/collector"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
+	printtable "sigs.k8s.io/cli-utils/pkg/print/table"
 	pkgprinters "sigs.k8s.io/cli-utils/pkg/printers"
 )
 
@@ -49,11 +53,12 @@ var (
 func GetRunner(ctx context.Context, factory cmdutil.Factory,
 	invFactory inventory.ClientFactory, loader Loader) *Runner {
 	r := &Runner{
-		ctx:               ctx,
-		factory:           factory,
-		invFactory:        invFactory,
-		loader:            loader,
-		PollerFactoryFunc: pollerFactoryFunc,
+		ctx:                ctx,
+		factory:            factory,
+		invFactory:         invFactory,
+		loader:             loader,
+		PollerFactoryFunc:  pollerFactoryFunc,
+		WatcherFactoryFunc: watcherFactoryFunc,
 	}
 	c := &cobra.Command{
 		Use:     "status (DIRECTORY | STDIN)",
@@ -65,12 +70,23 @@ func GetRunner(ctx context.Context, factory cmdutil.Factory,
 	c.Flags().StringVar(&r.pollUntil, "poll-until", "known",
 		"When to stop polling. Must be one of 'known', 'current', 'deleted', or 'forever'.")
 	c.Flags().StringVar(&r.output, "output", "events", "Output format.")
+	c.Flags().StringVar(&r.color, flagutils.ColorFlag, string(pkgprinters.ColorNever), flagutils.ColorFlagUsage)
 	c.Flags().DurationVar(&r.timeout, "timeout", 0,
 		"How long to wait before exiting")
+	c.Flags().BoolVar(&r.watch, "watch", false,
+		"Watch for status changes, instead of polling.")
+	c.Flags().StringVar(&r.forTarget, "for", "",
+		"Wait until all objects have a condition: condition=TYPE or condition=TYPE=STATUS. "+
+			"Overrides --poll-until, and prints a final report.")
 	c.Flags().StringVar(&r.invType, "inv-type", Local, "Type of the inventory info, must be local or remote")
 	c.Flags().StringVar(&r.inventoryNames, "inv-names", "", "Names of targeted inventory: inv1,inv2,...")
 	c.Flags().StringVar(&r.namespaces, "namespaces", "", "Names of targeted namespaces: ns1,ns2,...")
 	c.Flags().StringVar(&r.statuses, "statuses", "", "Targeted status: st1,st2...")
+	c.Flags().StringVar(&r.columns, "columns", "",
+		"Columns of the table output: col1,col2,... Each column is a pre-defined column name, "+
+			"HEADER:{.json.path}, or HEADER:condition=TYPE.")
+	c.Flags().StringVar(&r.groupBy, "group-by", "none",
+		"How to group resources in the table output. Must be one of 'none', 'namespace', or 'groupkind'.")
 
 	r.Command = c
 	return r
@@ -94,6 +110,11 @@ type Runner struct {
 	pollUntil string
 	timeout   time.Duration
 	output    string
+	color     string
+	colorMode pkgprinters.ColorMode
+	watch     bool
+	forTarget string
+	target    *conditionTarget
 
 	invType          string
 	inventoryNames   string
@@ -103,7 +124,13 @@ type Runner struct {
 	statuses         string
 	statusSet        map[string]bool
 
-	PollerFactoryFunc func(cmdutil.Factory) (poller.Poller, error)
+	columns       string
+	tableColumns  []printtable.ColumnDefinition
+	groupBy       string
+	parsedGroupBy printtable.GroupBy
+
+	PollerFactoryFunc  func(cmdutil.Factory) (poller.Poller, error)
+	WatcherFactoryFunc func(cmdutil.Factory) (watcher.StatusWatcher, error)
 }
 
 func (r *Runner) preRunE(*cobra.Command, []string) error {
@@ -115,6 +142,12 @@ func (r *Runner) preRunE(*cobra.Command, []string) error {
 		return fmt.Errorf("unknown output type %q", r.output)
 	}
 
+	colorMode, err := pkgprinters.ParseColorMode(r.color)
+	if err != nil {
+		return err
+	}
+	r.colorMode = colorMode
+
 	if r.invType != Local && r.invType != Remote {
 		return fmt.Errorf("inv-type flag should be either local or remote")
 	}
@@ -145,6 +178,28 @@ func (r *Runner) preRunE(*cobra.Command, []string) error {
 		}
 	}
 
+	if r.columns != "" {
+		columns, err := table.ParseColumns(r.columns)
+		if err != nil {
+			return err
+		}
+		r.tableColumns = columns
+	}
+
+	groupBy, err := printtable.ParseGroupBy(r.groupBy)
+	if err != nil {
+		return err
+	}
+	r.parsedGroupBy = groupBy
+
+	if r.forTarget != "" {
+		target, err := parseConditionTarget(r.forTarget)
+		if err != nil {
+			return err
+		}
+		r.target = target
+	}
+
 	return nil
 }
 
@@ -164,7 +219,7 @@ func (r *Runner) loadInvFromDisk(cmd *cobra.Command, args []string) (*printer.Pr
 
 	// Based on the inventory template manifest we look up the inventory
 	// from the live state using the inventory client.
-	identifiers, err := invClient.GetClusterObjs(inv)
+	identifiers, err := invClient.GetClusterObjs(r.ctx, inv)
 	if err != nil {
 		return nil, err
 	}
@@ -247,24 +302,33 @@ func (r *Runner) runE(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	printData.Columns = r.tableColumns
+	printData.GroupBy = r.parsedGroupBy
+
 	// Exit here if the inventory is empty.
 	if len(printData.Identifiers) == 0 {
 		_, _ = fmt.Fprint(cmd.OutOrStdout(), "no resources found in the inventory\n")
 		return nil
 	}
 
-	statusPoller, err := r.PollerFactoryFunc(r.factory)
+	// The WatchStatus feature makes watching the default.
+	if !cmd.Flags().Changed("watch") && features.DefaultFeatureGate.Enabled(features.WatchStatus) {
+		r.watch = true
+	}
+	statusPoller, err := r.newPoller()
 	if err != nil {
 		return err
 	}
 
 	// Fetch a printer implementation based on the desired output format as
 	// specified in the output flag.
-	printer, err := printers.CreatePrinter(r.output, genericclioptions.IOStreams{
+	printer, err := printers.CreatePrinterWithOptions(r.output, genericclioptions.IOStreams{
 		In:     cmd.InOrStdin(),
 		Out:    cmd.OutOrStdout(),
 		ErrOut: cmd.ErrOrStderr(),
-	}, printData)
+	}, printData, pkgprinters.Options{
+		Color: r.colorMode,
+	})
 	if err != nil {
 		return fmt.Errorf("error creating printer: %w", err)
 	}
@@ -283,14 +347,18 @@ func (r *Runner) runE(cmd *cobra.Command, args []string) error {
 	// Choose the appropriate ObserverFunc based on the criteria for when
 	// the command should exit.
 	var cancelFunc collector.ObserverFunc
-	switch r.pollUntil {
-	case "known":
+	var report *targetReport
+	switch {
+	case r.target != nil:
+		report = &targetReport{target: r.target}
+		cancelFunc = report.notifierFunc(cancel)
+	case r.pollUntil == Known:
 		cancelFunc = allKnownNotifierFunc(cancel)
-	case "current":
+	case r.pollUntil == Current:
 		cancelFunc = desiredStatusNotifierFunc(cancel, status.CurrentStatus)
-	case "deleted":
+	case r.pollUntil == Deleted:
 		cancelFunc = desiredStatusNotifierFunc(cancel, status.NotFoundStatus)
-	case "forever":
+	case r.pollUntil == Forever:
 		cancelFunc = func(*collector.ResourceStatusCollector, event.Event) {}
 	default:
 		return fmt.Errorf("unknown value for pollUntil: %q", r.pollUntil)
@@ -300,7 +368,29 @@ func (r *Runner) runE(cmd *cobra.Command, args []string) error {
 		PollInterval: r.period,
 	})
 
-	return printer.Print(eventChannel, printData.Identifiers, cancelFunc)
+	if err := printer.Print(eventChannel, printData.Identifiers, cancelFunc); err != nil {
+		return err
+	}
+	if report == nil {
+		return nil
+	}
+	if err := report.print(cmd.OutOrStdout(), r.output, printData.Identifiers); err != nil {
+		return err
+	}
+	return report.err(ctx, printData.Identifiers)
+}
+
+// newPoller returns the poller for the status of the objects, or a watcher
+// adapted to the Poller interface if --watch is used.
+func (r *Runner) newPoller() (poller.Poller, error) {
+	if !r.watch {
+		return r.PollerFactoryFunc(r.factory)
+	}
+	statusWatcher, err := r.WatcherFactoryFunc(r.factory)
+	if err != nil {
+		return nil, err
+	}
+	return &watcherPoller{watcher: statusWatcher}, nil
 }
 
 // desiredStatusNotifierFunc returns an Observer function for the
@@ -338,6 +428,18 @@ func pollerFactoryFunc(f cmdutil.Factory) (poller.Poller, error) {
 	return polling.NewStatusPollerFromFactory(f, polling.Options{})
 }
 
+func watcherFactoryFunc(f cmdutil.Factory) (watcher.StatusWatcher, error) {
+	dynamicClient, err := f.DynamicClient()
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := f.ToRESTMapper()
+	if err != nil {
+		return nil, err
+	}
+	return watcher.NewDefaultStatusWatcher(dynamicClient, mapper), nil
+}
+
 type Loader interface {
 	GetInvInfo(cmd *cobra.Command, args []string) (inventory.Info, error)
 }
@@ -353,9 +455,11 @@ func NewInventoryLoader(loader manifestreader.ManifestLoader) *InventoryLoader {
 }
 
 func (ir *InventoryLoader) GetInvInfo(cmd *cobra.Command, args []string) (inventory.Info, error) {
-	_, err := common.DemandOneDirectory(args)
-	if err != nil {
-		return nil, err
+	if !manifestreader.IsRemotePath(flagutils.PathFromArgs(args)) {
+		_, err := common.DemandOneDirectory(args)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	reader, err := ir.Loader.ManifestReader(cmd.InOrStdin(), flagutils.PathFromArgs(args))
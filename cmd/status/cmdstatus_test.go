@@ -13,14 +13,21 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/scheme"
 	"sigs.k8s.io/cli-utils/pkg/apply/poller"
+	"sigs.k8s.io/cli-utils/pkg/features"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	kstatustesting "sigs.k8s.io/cli-utils/pkg/kstatus/testing"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 	"sigs.k8s.io/cli-utils/pkg/manifestreader"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
@@ -54,27 +61,15 @@ metadata:
 	}
 )
 
-type fakePoller struct {
-	events []pollevent.Event
-}
-
-func (f *fakePoller) Poll(ctx context.Context, _ object.ObjMetadataSet,
-	_ polling.PollOptions) <-chan pollevent.Event {
-	eventChannel := make(chan pollevent.Event)
-	go func() {
-		defer close(eventChannel)
-		for _, e := range f.events {
-			eventChannel <- e
-		}
-		<-ctx.Done()
-	}()
-	return eventChannel
+func newFakePoller(events []pollevent.Event) *kstatustesting.FakeStatusPoller {
+	return kstatustesting.NewFakeStatusPoller(kstatustesting.Script(events...)...)
 }
 
 func TestCommand(t *testing.T) {
 	testCases := map[string]struct {
 		pollUntil      string
 		printer        string
+		color          string
 		timeout        time.Duration
 		input          string
 		inventory      object.ObjMetadataSet
@@ -125,6 +120,43 @@ foo/deployment.apps/default/foo is InProgress: inProgress
 foo/statefulset.apps/default/bar is Current: current
 `,
 		},
+		"colored events": {
+			pollUntil: "known",
+			printer:   "events",
+			color:     "always",
+			input:     inventoryTemplate,
+			inventory: object.ObjMetadataSet{
+				depObject,
+				stsObject,
+			},
+			events: []pollevent.Event{
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: depObject,
+						Status:     status.InProgressStatus,
+						Message:    "inProgress",
+					},
+				},
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: stsObject,
+						Status:     status.CurrentStatus,
+						Message:    "current",
+					},
+				},
+			},
+			expectedOutput: "foo/deployment.apps/default/foo is \x1b[33mInProgress\x1b[0m: inProgress\n" +
+				"foo/statefulset.apps/default/bar is \x1b[32mCurrent\x1b[0m: current\n",
+		},
+		"unknown color mode": {
+			pollUntil:      "known",
+			printer:        "events",
+			color:          "sometimes",
+			input:          inventoryTemplate,
+			expectedErrMsg: `unknown color mode "sometimes": must be one of never, auto, always`,
+		},
 		"wait for all current": {
 			pollUntil: "current",
 			printer:   "events",
@@ -505,11 +537,12 @@ foo/deployment.apps/default/foo is InProgress: inProgress
 				invFactory: inventory.FakeClientFactory(tc.inventory),
 				loader:     NewInventoryLoader(loader),
 				PollerFactoryFunc: func(c cmdutil.Factory) (poller.Poller, error) {
-					return &fakePoller{tc.events}, nil
+					return newFakePoller(tc.events), nil
 				},
 
 				pollUntil: tc.pollUntil,
 				output:    tc.printer,
+				color:     tc.color,
 				timeout:   tc.timeout,
 				invType:   Local,
 			}
@@ -549,7 +582,7 @@ foo/deployment.apps/default/foo is InProgress: inProgress
 				invFactory: inventory.FakeClientFactory(tc.inventory),
 				loader:     NewInventoryLoader(loader),
 				PollerFactoryFunc: func(c cmdutil.Factory) (poller.Poller, error) {
-					return &fakePoller{tc.events}, nil
+					return newFakePoller(tc.events), nil
 				},
 
 				pollUntil: tc.pollUntil,
@@ -610,3 +643,339 @@ func assertOutput(t *testing.T, expectedOutput []map[string]interface{}, actual
 	}
 	return true
 }
+
+type fakeWatcher struct {
+	events []pollevent.Event
+}
+
+func (f *fakeWatcher) Watch(ctx context.Context, ids object.ObjMetadataSet,
+	_ watcher.Options) <-chan pollevent.Event {
+	return newFakePoller(f.events).Poll(ctx, ids, polling.PollOptions{})
+}
+
+func withConditions(id object.ObjMetadata, conditions ...map[string]interface{}) *unstructured.Unstructured {
+	conds := make([]interface{}, 0, len(conditions))
+	for _, c := range conditions {
+		conds = append(conds, c)
+	}
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(id.GroupKind.WithVersion("v1"))
+	u.SetNamespace(id.Namespace)
+	u.SetName(id.Name)
+	_ = unstructured.SetNestedSlice(u.Object, conds, "status", "conditions")
+	return u
+}
+
+func TestCommandForTarget(t *testing.T) {
+	ready := map[string]interface{}{"type": "Ready", "status": "True"}
+	notReady := map[string]interface{}{"type": "Ready", "status": "False"}
+
+	testCases := map[string]struct {
+		forTarget      string
+		printer        string
+		watch          bool
+		timeout        time.Duration
+		events         []pollevent.Event
+		expectedErrMsg string
+		expectedOutput string
+	}{
+		"invalid target": {
+			forTarget:      "Ready",
+			printer:        "events",
+			expectedErrMsg: `invalid --for value "Ready": must be condition=TYPE or condition=TYPE=STATUS`,
+		},
+		"all objects ready": {
+			forTarget: "condition=Ready",
+			printer:   "events",
+			events: []pollevent.Event{
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: depObject,
+						Status:     status.CurrentStatus,
+						Message:    "current",
+						Resource:   withConditions(depObject, ready),
+					},
+				},
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: stsObject,
+						Status:     status.CurrentStatus,
+						Message:    "current",
+						Resource:   withConditions(stsObject, ready),
+					},
+				},
+			},
+			expectedOutput: `
+foo/deployment.apps/default/foo is Current: current
+foo/statefulset.apps/default/bar is Current: current
+condition=Ready=True: 2/2 objects met
+`,
+		},
+		"watch until all objects ready": {
+			forTarget: "condition=Ready=True",
+			printer:   "events",
+			watch:     true,
+			events: []pollevent.Event{
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: depObject,
+						Status:     status.CurrentStatus,
+						Message:    "current",
+						Resource:   withConditions(depObject, ready),
+					},
+				},
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: stsObject,
+						Status:     status.CurrentStatus,
+						Message:    "current",
+						Resource:   withConditions(stsObject, ready),
+					},
+				},
+			},
+			expectedOutput: `
+foo/deployment.apps/default/foo is Current: current
+foo/statefulset.apps/default/bar is Current: current
+condition=Ready=True: 2/2 objects met
+`,
+		},
+		"timeout before all objects ready": {
+			forTarget: "condition=Ready",
+			printer:   "events",
+			timeout:   time.Second,
+			events: []pollevent.Event{
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: depObject,
+						Status:     status.InProgressStatus,
+						Message:    "inProgress",
+						Resource:   withConditions(depObject, notReady),
+					},
+				},
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: stsObject,
+						Status:     status.CurrentStatus,
+						Message:    "current",
+						Resource:   withConditions(stsObject, ready),
+					},
+				},
+			},
+			expectedOutput: `
+foo/deployment.apps/default/foo is InProgress: inProgress
+foo/statefulset.apps/default/bar is Current: current
+condition=Ready=True: 1/2 objects met
+deployment.apps/foo not met: InProgress
+`,
+			expectedErrMsg: "timed out waiting for condition=Ready=True: 1 of 2 objects not met",
+		},
+		"json report": {
+			forTarget: "condition=Ready",
+			printer:   "json",
+			events: []pollevent.Event{
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: depObject,
+						Status:     status.CurrentStatus,
+						Message:    "current",
+						Resource:   withConditions(depObject, ready),
+					},
+				},
+				{
+					Type: pollevent.ResourceUpdateEvent,
+					Resource: &pollevent.ResourceStatus{
+						Identifier: stsObject,
+						Status:     status.CurrentStatus,
+						Message:    "current",
+						Resource:   withConditions(stsObject, ready),
+					},
+				},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("namespace")
+			defer tf.Cleanup()
+
+			inv := object.ObjMetadataSet{depObject, stsObject}
+			loader := manifestreader.NewFakeLoader(tf, inv)
+			runner := &Runner{
+				factory:    tf,
+				invFactory: inventory.FakeClientFactory(inv),
+				loader:     NewInventoryLoader(loader),
+				PollerFactoryFunc: func(c cmdutil.Factory) (poller.Poller, error) {
+					if tc.watch {
+						t.Fatal("poller used with --watch")
+					}
+					return newFakePoller(tc.events), nil
+				},
+				WatcherFactoryFunc: func(c cmdutil.Factory) (watcher.StatusWatcher, error) {
+					return &fakeWatcher{tc.events}, nil
+				},
+
+				pollUntil: Known,
+				forTarget: tc.forTarget,
+				watch:     tc.watch,
+				output:    tc.printer,
+				timeout:   tc.timeout,
+				invType:   Local,
+				groupBy:   "none",
+			}
+
+			cmd := &cobra.Command{
+				PreRunE:      runner.preRunE,
+				RunE:         runner.runE,
+				SilenceUsage: true,
+			}
+			cmd.SetIn(strings.NewReader(inventoryTemplate))
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetArgs([]string{})
+
+			err := cmd.Execute()
+			if tc.expectedErrMsg != "" {
+				assert.EqualError(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tc.printer == "json" {
+				lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+				var report map[string]interface{}
+				assert.NoError(t, json.Unmarshal([]byte(lines[len(lines)-1]), &report))
+				assert.Equal(t, "report", report["type"])
+				assert.Equal(t, "condition=Ready=True", report["target"])
+				assert.Equal(t, true, report["met"])
+				assert.Len(t, report["objects"], 2)
+				return
+			}
+			assert.Equal(t, strings.TrimSpace(tc.expectedOutput), strings.TrimSpace(buf.String()))
+		})
+	}
+}
+
+func TestGetRunnerWatch(t *testing.T) {
+	fooConfigMap := object.ObjMetadata{
+		Name:      "foo",
+		Namespace: "default",
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+	}
+	barConfigMap := object.ObjMetadata{
+		Name:      "bar",
+		Namespace: "default",
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+	}
+
+	testCases := map[string]struct {
+		args        []string
+		watchStatus bool
+	}{
+		"watch flag": {
+			args: []string{"--watch"},
+		},
+		"WatchStatus feature": {
+			watchStatus: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if tc.watchStatus {
+				require.NoError(t, features.DefaultMutableFeatureGate.Set("WatchStatus=true"))
+				defer func() {
+					require.NoError(t, features.DefaultMutableFeatureGate.Set("WatchStatus=false"))
+				}()
+			}
+			tf := cmdtesting.NewTestFactory().WithNamespace("namespace")
+			defer tf.Cleanup()
+			tf.FakeDynamicClient = dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+				liveObject(fooConfigMap), liveObject(barConfigMap))
+
+			inv := object.ObjMetadataSet{fooConfigMap, barConfigMap}
+			loader := manifestreader.NewFakeLoader(tf, inv)
+			runner := GetRunner(context.Background(), tf, inventory.FakeClientFactory(inv), NewInventoryLoader(loader))
+			runner.PollerFactoryFunc = func(cmdutil.Factory) (poller.Poller, error) {
+				t.Fatal("poller used instead of the watcher")
+				return nil, nil
+			}
+
+			cmd := runner.Command
+			cmd.SilenceUsage = true
+			cmd.SetIn(strings.NewReader(inventoryTemplate))
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetArgs(append(tc.args, "--timeout=30s"))
+
+			// The status of the objects is known as soon as the watcher
+			// has listed them.
+			require.NoError(t, cmd.Execute())
+			assert.Contains(t, buf.String(), "configmap/default/foo is Current")
+			assert.Contains(t, buf.String(), "configmap/default/bar is Current")
+		})
+	}
+}
+
+// liveObject returns the core/v1 object of the cluster with the identifier.
+func liveObject(id object.ObjMetadata) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{}
+	u.SetAPIVersion("v1")
+	u.SetKind(id.GroupKind.Kind)
+	u.SetNamespace(id.Namespace)
+	u.SetName(id.Name)
+	return u
+}
+
+func TestTargetReportErr(t *testing.T) {
+	target, err := parseConditionTarget("condition=Ready")
+	require.NoError(t, err)
+
+	timedOut, cancelTimedOut := context.WithTimeout(context.Background(), 0)
+	defer cancelTimedOut()
+	<-timedOut.Done()
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	testCases := map[string]struct {
+		ctx            context.Context
+		met            bool
+		expectedErrMsg string
+	}{
+		"met": {
+			ctx: cancelled,
+			met: true,
+		},
+		"timed out": {
+			ctx:            timedOut,
+			expectedErrMsg: "timed out waiting for condition=Ready=True: 2 of 2 objects not met",
+		},
+		"cancelled": {
+			ctx:            cancelled,
+			expectedErrMsg: "cancelled waiting for condition=Ready=True: 2 of 2 objects not met",
+		},
+		"stopped": {
+			ctx:            context.Background(),
+			expectedErrMsg: "stopped waiting for condition=Ready=True: 2 of 2 objects not met",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			report := &targetReport{target: target, met: tc.met}
+			err := report.err(tc.ctx, object.ObjMetadataSet{depObject, stsObject})
+			if tc.expectedErrMsg != "" {
+				assert.EqualError(t, err, tc.expectedErrMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/collector"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
 	"sigs.k8s.io/cli-utils/pkg/print/list"
 	"sigs.k8s.io/cli-utils/pkg/printers/events"
 )
@@ -24,6 +25,8 @@ type Printer struct {
 	Formatter list.Formatter
 	IOStreams genericclioptions.IOStreams
 	Data      *printer.PrintData
+	// Color highlights the status of the resources with ANSI colors.
+	Color bool
 }
 
 // NewPrinter returns a new instance of the eventPrinter.
@@ -35,6 +38,17 @@ func NewPrinter(ioStreams genericclioptions.IOStreams, printData *printer.PrintD
 	}
 }
 
+// NewColorPrinter returns a new instance of the eventPrinter that
+// highlights the status of the resources and the errors with ANSI colors.
+func NewColorPrinter(ioStreams genericclioptions.IOStreams, printData *printer.PrintData) *Printer {
+	return &Printer{
+		Formatter: events.NewColorFormatter(ioStreams, common.DryRunNone),
+		IOStreams: ioStreams,
+		Data:      printData,
+		Color:     true,
+	}
+}
+
 // Print takes an event channel and outputs the status events on the channel
 // until the channel is closed. The provided cancelFunc is consulted on
 // every event and is responsible for stopping the poller when appropriate.
@@ -77,6 +91,9 @@ func (ep *Printer) printStatusEvent(se pollevent.Event) error {
 		if _, ok := ep.Data.StatusSet[strings.ToLower(statusString)]; len(ep.Data.StatusSet) != 0 && !ok {
 			return nil
 		}
+		if color, ok := printcommon.ColorForStatus(se.Resource.Status); ok && ep.Color {
+			statusString = printcommon.SprintfWithColor(color, "%s", statusString)
+		}
 		_, err := fmt.Fprintf(ep.IOStreams.Out, "%s/%s/%s/%s is %s: %s\n", invName,
 			strings.ToLower(id.GroupKind.String()), id.Namespace, id.Name, statusString, se.Resource.Message)
 		return err
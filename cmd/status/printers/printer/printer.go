@@ -7,6 +7,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/collector"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/print/table"
 )
 
 // PrintData records data required for printing
@@ -14,6 +15,11 @@ type PrintData struct {
 	Identifiers object.ObjMetadataSet
 	InvNameMap  map[object.ObjMetadata]string
 	StatusSet   map[string]bool
+	// Columns overrides the default columns of the table printer, if not
+	// empty.
+	Columns []table.ColumnDefinition
+	// GroupBy optionally groups the resources of the table printer.
+	GroupBy table.GroupBy
 }
 
 // Printer defines an interface for outputting information about status of
@@ -9,6 +9,7 @@ import (
 	"sigs.k8s.io/cli-utils/cmd/status/printers/json"
 	"sigs.k8s.io/cli-utils/cmd/status/printers/printer"
 	"sigs.k8s.io/cli-utils/cmd/status/printers/table"
+	pkgprinters "sigs.k8s.io/cli-utils/pkg/printers"
 )
 
 // CreatePrinter return an implementation of the Printer interface. The
@@ -23,3 +24,18 @@ func CreatePrinter(printerType string, ioStreams genericclioptions.IOStreams, pr
 		return event.NewPrinter(ioStreams, printData), nil
 	}
 }
+
+// CreatePrinterWithOptions returns an implementation of the Printer
+// interface, like CreatePrinter, configured with the provided options. Only
+// the events output is colored.
+func CreatePrinterWithOptions(printerType string, ioStreams genericclioptions.IOStreams, printData *printer.PrintData,
+	opts pkgprinters.Options) (printer.Printer, error) {
+	switch printerType {
+	case "table", "json":
+	default:
+		if opts.Color.Enabled(ioStreams.Out) {
+			return event.NewColorPrinter(ioStreams, printData), nil
+		}
+	}
+	return CreatePrinter(printerType, ioStreams, printData)
+}
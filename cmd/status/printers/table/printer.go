@@ -86,6 +86,15 @@ var invNameColumn = table.ColumnDef{
 	},
 }
 
+// ParseColumns parses a comma separated list of column specs, as described
+// in table.ParseColumns. In addition to the pre-defined columns, the
+// "inventory_name" column may be referenced by name.
+func ParseColumns(spec string) ([]table.ColumnDefinition, error) {
+	return table.ParseColumns(spec, map[string]table.ColumnDefinition{
+		invNameColumn.ColumnName: invNameColumn,
+	})
+}
+
 var columns = []table.ColumnDefinition{
 	table.MustColumn("namespace"),
 	table.MustColumn("resource"),
@@ -104,6 +113,10 @@ func (t *Printer) runPrintLoop(coll *CollectorAdapter, stop <-chan struct{}) <-c
 	baseTablePrinter := table.BaseTablePrinter{
 		IOStreams: t.IOStreams,
 		Columns:   columns,
+		GroupBy:   t.PrintData.GroupBy,
+	}
+	if len(t.PrintData.Columns) > 0 {
+		baseTablePrinter.Columns = t.PrintData.Columns
 	}
 
 	linesPrinted := baseTablePrinter.PrintTable(coll.LatestStatus(), 0)
@@ -0,0 +1,224 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/collector"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const conditionTargetPrefix = "condition="
+
+// conditionTarget is the condition all objects must have for the command to
+// exit, specified with the --for flag.
+type conditionTarget struct {
+	Type   string
+	Status string
+}
+
+// parseConditionTarget parses a target like "condition=Ready", which
+// requires the Ready condition to be True, or "condition=Stalled=False".
+func parseConditionTarget(s string) (*conditionTarget, error) {
+	if !strings.HasPrefix(s, conditionTargetPrefix) {
+		return nil, fmt.Errorf("invalid --for value %q: must be condition=TYPE or condition=TYPE=STATUS", s)
+	}
+	spec := strings.TrimPrefix(s, conditionTargetPrefix)
+	conditionType, conditionStatus, found := strings.Cut(spec, "=")
+	if !found {
+		conditionStatus = "True"
+	}
+	if conditionType == "" || conditionStatus == "" {
+		return nil, fmt.Errorf("invalid --for value %q: must be condition=TYPE or condition=TYPE=STATUS", s)
+	}
+	return &conditionTarget{
+		Type:   conditionType,
+		Status: conditionStatus,
+	}, nil
+}
+
+func (t *conditionTarget) String() string {
+	return fmt.Sprintf("%s%s=%s", conditionTargetPrefix, t.Type, t.Status)
+}
+
+// Met returns true if the latest known state of the object has the target
+// condition.
+func (t *conditionTarget) Met(rs *event.ResourceStatus) bool {
+	if rs == nil || rs.Resource == nil {
+		return false
+	}
+	conditions, found, err := unstructured.NestedSlice(rs.Resource.Object, "status", "conditions")
+	if !found || err != nil {
+		return false
+	}
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == t.Type {
+			return strings.EqualFold(fmt.Sprint(condition["status"]), t.Status)
+		}
+	}
+	return false
+}
+
+// targetReport records the latest status of each object, for the final
+// report when waiting for a target.
+type targetReport struct {
+	target   *conditionTarget
+	statuses map[object.ObjMetadata]*event.ResourceStatus
+	met      bool
+}
+
+// notifierFunc returns an Observer function for the ResourceStatusCollector
+// that records the latest statuses and cancels the context (using the
+// cancelFunc) when all resources have the target condition.
+func (r *targetReport) notifierFunc(cancelFunc context.CancelFunc) collector.ObserverFunc {
+	return func(rsc *collector.ResourceStatusCollector, _ event.Event) {
+		r.statuses = make(map[object.ObjMetadata]*event.ResourceStatus, len(rsc.ResourceStatuses))
+		for id, rs := range rsc.ResourceStatuses {
+			r.statuses[id] = rs
+		}
+		for _, rs := range r.statuses {
+			if !r.target.Met(rs) {
+				return
+			}
+		}
+		r.met = true
+		cancelFunc()
+	}
+}
+
+// reportObject is the status of one object in the final report.
+type reportObject struct {
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Met       bool   `json:"met"`
+}
+
+// reportRecord is the final report, in json output.
+type reportRecord struct {
+	Type      string         `json:"type"`
+	Timestamp string         `json:"timestamp"`
+	Target    string         `json:"target"`
+	Met       bool           `json:"met"`
+	Objects   []reportObject `json:"objects"`
+}
+
+func (r *targetReport) objects(ids object.ObjMetadataSet) []reportObject {
+	objs := make([]reportObject, 0, len(ids))
+	for _, id := range ids {
+		rs := r.statuses[id]
+		obj := reportObject{
+			Group:     id.GroupKind.Group,
+			Kind:      id.GroupKind.Kind,
+			Namespace: id.Namespace,
+			Name:      id.Name,
+			Status:    "Unknown",
+			Met:       r.target.Met(rs),
+		}
+		if rs != nil {
+			obj.Status = rs.Status.String()
+		}
+		objs = append(objs, obj)
+	}
+	sort.SliceStable(objs, func(i, j int) bool {
+		// List the objects that did not meet the target first.
+		return !objs[i].Met && objs[j].Met
+	})
+	return objs
+}
+
+// print writes the final report, as a single json record if the output
+// format is json, or as text otherwise.
+func (r *targetReport) print(w io.Writer, output string, ids object.ObjMetadataSet) error {
+	objs := r.objects(ids)
+	if output == "json" {
+		b, err := json.Marshal(reportRecord{
+			Type:      "report",
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Target:    r.target.String(),
+			Met:       r.met,
+			Objects:   objs,
+		})
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", b)
+		return err
+	}
+	metCount := 0
+	for _, obj := range objs {
+		if obj.Met {
+			metCount++
+		}
+	}
+	if _, err := fmt.Fprintf(w, "%s: %d/%d objects met\n", r.target, metCount, len(objs)); err != nil {
+		return err
+	}
+	for _, obj := range objs {
+		if obj.Met {
+			continue
+		}
+		gk := strings.ToLower(obj.Kind)
+		if obj.Group != "" {
+			gk += "." + obj.Group
+		}
+		if _, err := fmt.Fprintf(w, "%s/%s not met: %s\n", gk, obj.Name, obj.Status); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// err returns an error if the target was not met by all the objects. The
+// error of the context of the run tells whether the wait timed out or was
+// cancelled.
+func (r *targetReport) err(ctx context.Context, ids object.ObjMetadataSet) error {
+	if r.met {
+		return nil
+	}
+	notMet := 0
+	for _, obj := range r.objects(ids) {
+		if !obj.Met {
+			notMet++
+		}
+	}
+	var reason string
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		reason = "timed out"
+	case errors.Is(ctx.Err(), context.Canceled):
+		reason = "cancelled"
+	default:
+		reason = "stopped"
+	}
+	return fmt.Errorf("%s waiting for %s: %d of %d objects not met", reason, r.target, notMet, len(ids))
+}
+
+// watcherPoller adapts a StatusWatcher to the Poller interface, so it can
+// be used in place of the poller with --watch.
+type watcherPoller struct {
+	watcher watcher.StatusWatcher
+}
+
+func (w *watcherPoller) Poll(ctx context.Context, ids object.ObjMetadataSet, _ polling.PollOptions) <-chan event.Event {
+	return w.watcher.Watch(ctx, ids, watcher.Options{})
+}
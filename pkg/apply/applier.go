@@ -8,14 +8,22 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/openapi3"
+	"k8s.io/component-base/featuregate"
 	"k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/cmd/util"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
 	"sigs.k8s.io/cli-utils/pkg/apply/info"
@@ -24,9 +32,11 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/apply/solver"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/features"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/transform"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 )
 
@@ -46,51 +56,84 @@ type Applier struct {
 	invClient     inventory.Client
 	client        dynamic.Interface
 	openAPIGetter discovery.OpenAPISchemaInterface
+	serverVersion discovery.ServerVersionInterface
+	discoClient   discovery.CachedDiscoveryInterface
 	mapper        meta.RESTMapper
 	infoHelper    info.Helper
+	clientFactory util.Factory
+	logger        logr.Logger
+	// cluster is the host of the cluster, so the runs of Appliers of
+	// different clusters don't lock each other's inventories.
+	cluster string
 }
 
-// prepareObjects returns the set of objects to apply and to prune or
-// an error if one occurred.
-func (a *Applier) prepareObjects(localInv inventory.Info, localObjs object.UnstructuredSet,
-	o ApplierOptions) (object.UnstructuredSet, object.UnstructuredSet, error) {
+// newInfoHelper returns the info helper of a run, and the recorder of the
+// warnings of the server for the objects applied by it. Each run gets its
+// own clients and recorder, so concurrent runs don't see each other's
+// warnings. If the clients were provided explicitly, the warnings are not
+// recorded and the returned recorder is nil.
+func (a *Applier) newInfoHelper() (info.Helper, *info.WarningRecorder) {
+	if a.clientFactory == nil {
+		return a.infoHelper, nil
+	}
+	warnings := &info.WarningRecorder{}
+	factory := withWarningHandler(a.clientFactory, warnings)
+	return info.NewHelper(a.mapper, factory.UnstructuredClientForMapping), warnings
+}
+
+// InvalidateDiscovery clears the cached discovery documents and RESTMapper
+// of the Applier, so they are fetched again in the next run. Use it when
+// long-lived Appliers need to see types changed by other clients.
+func (a *Applier) InvalidateDiscovery() {
+	invalidateDiscovery(a.discoClient, a.mapper)
+}
+
+// prepareObjects returns the set of objects to apply and to prune, and the
+// stale inventory entries, or an error if one occurred. The excluded objects
+// are neither applied nor pruned.
+func (a *Applier) prepareObjects(ctx context.Context, localInv inventory.Info, localObjs, excludedObjs object.UnstructuredSet,
+	o ApplierOptions) (object.UnstructuredSet, object.UnstructuredSet, []prune.StaleEntry, error) {
 	if localInv == nil {
-		return nil, nil, fmt.Errorf("the local inventory can't be nil")
+		return nil, nil, nil, fmt.Errorf("the local inventory can't be nil")
 	}
 	if err := inventory.ValidateNoInventory(localObjs); err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	// Add the inventory annotation to the resources being applied.
 	for _, localObj := range localObjs {
 		inventory.AddInventoryIDAnnotation(localObj, localInv)
 	}
-	// If the inventory uses the Name strategy and an inventory ID is provided,
-	// verify that the existing inventory object (if there is one) has an ID
-	// label that matches.
-	// TODO(seans): This inventory id validation should happen in destroy and status.
-	if localInv.Strategy() == inventory.NameStrategy && localInv.ID() != "" {
-		prevInvObjs, err := a.invClient.GetClusterInventoryObjs(localInv)
-		if err != nil {
-			return nil, nil, err
-		}
-		if len(prevInvObjs) > 1 {
-			panic(fmt.Errorf("found %d inv objects with Name strategy", len(prevInvObjs)))
-		}
-		if len(prevInvObjs) == 1 {
-			invObj := prevInvObjs[0]
-			val := invObj.GetLabels()[common.InventoryLabel]
-			if val != localInv.ID() {
-				return nil, nil, fmt.Errorf("inventory-id of inventory object in cluster doesn't match provided id %q", localInv.ID())
-			}
-		}
+	if err := validateInventoryID(ctx, a.invClient, localInv); err != nil {
+		return nil, nil, nil, err
 	}
-	pruneObjs, err := a.pruner.GetPruneObjs(localInv, localObjs, prune.Options{
+	keepObjs := append(append(object.UnstructuredSet{}, localObjs...), excludedObjs...)
+	pruneObjs, stale, err := a.pruner.PlanPrune(ctx, localInv, keepObjs, prune.Options{
 		DryRunStrategy: o.DryRunStrategy,
 	})
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	return localObjs, pruneObjs, nil
+	return localObjs, pruneObjs, stale, nil
+}
+
+// validateInventoryID verifies that the existing inventory object, if there
+// is one, has an ID label that matches, if the inventory uses the Name
+// strategy and an inventory ID is provided.
+func validateInventoryID(ctx context.Context, invClient inventory.Client, localInv inventory.Info) error {
+	if localInv.Strategy() != inventory.NameStrategy || localInv.ID() == "" {
+		return nil
+	}
+	prevInvObjs, err := invClient.GetClusterInventoryObjs(ctx, localInv)
+	if err != nil {
+		return err
+	}
+	if len(prevInvObjs) > 1 {
+		panic(fmt.Errorf("found %d inv objects with Name strategy", len(prevInvObjs)))
+	}
+	if len(prevInvObjs) == 1 {
+		return inventory.ValidateInventoryID(prevInvObjs[0], localInv)
+	}
+	return nil
 }
 
 // Run performs the Apply step. This happens asynchronously with updates
@@ -102,155 +145,290 @@ func (a *Applier) prepareObjects(localInv inventory.Info, localObjs object.Unstr
 // cancellation or timeout will only affect how long we Wait for the
 // resources to become current.
 func (a *Applier) Run(ctx context.Context, invInfo inventory.Info, objects object.UnstructuredSet, options ApplierOptions) <-chan event.Event {
-	klog.V(4).Infof("apply run for %d objects", len(objects))
-	eventChannel := make(chan event.Event)
+	ctx = runContext(ctx, options.Logger, a.logger)
+	klog.FromContext(ctx).V(4).Info("apply run", "objects", len(objects))
 	setDefaults(&options)
+	eventChannel := make(chan event.Event, options.EventBufferSize)
 	go func() {
 		defer close(eventChannel)
-		// Validate the resources to make sure we catch those problems early
-		// before anything has been updated in the cluster.
-		vCollector := &validation.Collector{}
-		validator := &validation.Validator{
-			Collector: vCollector,
-			Mapper:    a.mapper,
-		}
-		validator.Validate(objects)
+		a.run(ctx, invInfo, objects, options, eventChannel, &clusterInfo{applier: a})
+	}()
+	return eventChannel
+}
 
-		// Decide which objects to apply and which to prune
-		applyObjs, pruneObjs, err := a.prepareObjects(invInfo, objects, options)
+// run performs the Apply step, sending the events to the eventChannel.
+// The cluster information needed to validate the objects is fetched from
+// the clusterInfo, so it can be fetched before the objects are known.
+func (a *Applier) run(ctx context.Context, invInfo inventory.Info, objects object.UnstructuredSet, options ApplierOptions,
+	eventChannel chan event.Event, clusterInfo *clusterInfo) {
+	// The tasks of the run read the inventory object once.
+	ctx = inventory.WithCache(ctx)
+	logger := klog.FromContext(ctx)
+	// Only one run at a time may use the inventory.
+	if invInfo != nil {
+		endRun, err := runs.start(ctx, a.cluster, invInfo, options.WaitForConcurrentRun)
 		if err != nil {
 			handleError(eventChannel, err)
 			return
 		}
-		klog.V(4).Infof("calculated %d apply objs; %d prune objs", len(applyObjs), len(pruneObjs))
-
-		// Build a TaskContext for passing info between tasks
-		resourceCache := cache.NewResourceCacheMap()
-		taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
-
-		// Fetch the queue (channel) of tasks that should be executed.
-		klog.V(4).Infoln("applier building task queue...")
-		// Build list of apply validation filters.
-		applyFilters := []filter.ValidationFilter{
-			filter.InventoryPolicyApplyFilter{
-				Client:    a.client,
-				Mapper:    a.mapper,
-				Inv:       invInfo,
-				InvPolicy: options.InventoryPolicy,
-			},
-			filter.DependencyFilter{
-				TaskContext:       taskContext,
-				ActuationStrategy: actuation.ActuationStrategyApply,
-				DryRunStrategy:    options.DryRunStrategy,
-			},
-		}
-		// Build list of prune validation filters.
-		pruneFilters := []filter.ValidationFilter{
-			filter.PreventRemoveFilter{},
-			filter.InventoryPolicyPruneFilter{
-				Inv:       invInfo,
-				InvPolicy: options.InventoryPolicy,
-			},
-			filter.LocalNamespacesFilter{
-				LocalNamespaces: localNamespaces(invInfo, object.UnstructuredSetToObjMetadataSet(objects)),
-			},
-			filter.DependencyFilter{
-				TaskContext:       taskContext,
-				ActuationStrategy: actuation.ActuationStrategyDelete,
-				DryRunStrategy:    options.DryRunStrategy,
-			},
+		defer endRun()
+	}
+
+	// Transform the resources before validating them, so the final
+	// resources are validated.
+	if options.Transformer != nil {
+		var err error
+		objects, err = options.Transformer.Transform(objects)
+		if err != nil {
+			handleError(eventChannel, err)
+			return
 		}
-		// Build list of apply mutators.
-		applyMutators := []mutator.Interface{
-			&mutator.ApplyTimeMutator{
-				Client:        a.client,
-				Mapper:        a.mapper,
-				ResourceCache: resourceCache,
-			},
+	}
+
+	// Name the resources with a generateName before validating them, so
+	// their IDs are known.
+	if err := a.resolveGeneratedNames(ctx, invInfo, objects, options.DryRunStrategy); err != nil {
+		handleError(eventChannel, err)
+		return
+	}
+
+	// Exclude the resources not selected by the filter from the run, before
+	// validating them.
+	objects, excludedObjs := options.ObjectFilter.Split(objects)
+	if len(excludedObjs) > 0 {
+		logger.V(4).Info("excluded objects by filter", "objects", len(excludedObjs))
+	}
+
+	// Validate the resources to make sure we catch those problems early
+	// before anything has been updated in the cluster.
+	vCollector := &validation.Collector{}
+	validator := &validation.Validator{
+		Collector: vCollector,
+		Mapper:    a.mapper,
+		Redactor:  options.Redactor,
+	}
+	validator.Validate(objects)
+	if options.DeprecatedAPIPolicy != validation.IgnoreDeprecatedAPIs {
+		serverVersion, err := clusterInfo.ServerVersion()
+		if err != nil {
+			handleError(eventChannel, err)
+			return
 		}
-		taskBuilder := &solver.TaskQueueBuilder{
-			Pruner:        a.pruner,
-			DynamicClient: a.client,
-			OpenAPIGetter: a.openAPIGetter,
-			InfoHelper:    a.infoHelper,
+		deprecationValidator := &validation.DeprecationValidator{
+			ServerVersion: serverVersion,
 			Mapper:        a.mapper,
-			InvClient:     a.invClient,
+			Policy:        options.DeprecatedAPIPolicy,
 			Collector:     vCollector,
-			ApplyFilters:  applyFilters,
-			ApplyMutators: applyMutators,
-			PruneFilters:  pruneFilters,
 		}
-		opts := solver.Options{
-			ServerSideOptions:      options.ServerSideOptions,
-			ReconcileTimeout:       options.ReconcileTimeout,
-			Destroy:                false,
-			Prune:                  !options.NoPrune,
-			DryRunStrategy:         options.DryRunStrategy,
-			PrunePropagationPolicy: options.PrunePropagationPolicy,
-			PruneTimeout:           options.PruneTimeout,
-			InventoryPolicy:        options.InventoryPolicy,
+		deprecationValidator.Validate(objects)
+	}
+	if options.ValidateSchema {
+		schemaValidator := &validation.SchemaValidator{
+			OpenAPI:   clusterInfo.OpenAPI(),
+			Collector: vCollector,
 		}
+		schemaValidator.Validate(objects)
+	}
 
-		// Build the ordered set of tasks to execute.
-		taskQueue := taskBuilder.
-			WithApplyObjects(applyObjs).
-			WithPruneObjects(pruneObjs).
-			WithInventory(invInfo).
-			Build(taskContext, opts)
-
-		klog.V(4).Infof("validation errors: %d", len(vCollector.Errors))
-		klog.V(4).Infof("invalid objects: %d", len(vCollector.InvalidIds))
-
-		// Handle validation errors
-		switch options.ValidationPolicy {
-		case validation.ExitEarly:
-			err = vCollector.ToError()
-			if err != nil {
-				handleError(eventChannel, err)
-				return
-			}
-		case validation.SkipInvalid:
-			for _, err := range vCollector.Errors {
-				handleValidationError(eventChannel, err)
-			}
-		default:
-			handleError(eventChannel, fmt.Errorf("invalid ValidationPolicy: %q", options.ValidationPolicy))
+	// Decide which objects to apply and which to prune
+	applyObjs, pruneObjs, stale, err := a.prepareObjects(ctx, invInfo, objects, excludedObjs, options)
+	if err != nil {
+		handleError(eventChannel, err)
+		return
+	}
+	logger.V(4).Info("calculated apply and prune objects", "applyObjects", len(applyObjs), "pruneObjects", len(pruneObjs),
+		"staleEntries", len(stale))
+
+	// Evaluate the admission rules once the objects to prune are known.
+	if len(options.AdmissionRules) > 0 {
+		admissionValidator := &validation.AdmissionValidator{
+			Rules:     options.AdmissionRules,
+			Collector: vCollector,
+		}
+		admissionValidator.Validate(applyObjs, validation.AdmissionApply)
+		admissionValidator.Validate(pruneObjs, validation.AdmissionPrune)
+	}
+	if options.PolicyEvaluator != nil {
+		policyValidator := &validation.PolicyValidator{
+			Evaluator: options.PolicyEvaluator,
+			Collector: vCollector,
+		}
+		if err := policyValidator.Validate(ctx, applyObjs, pruneObjs); err != nil {
+			handleError(eventChannel, err)
 			return
 		}
+	}
 
-		// Register invalid objects to be retained in the inventory, if present.
-		for _, id := range vCollector.InvalidIds {
-			taskContext.AddInvalidObject(id)
-		}
+	// Build a TaskContext for passing info between tasks
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
+	taskContext.SetSlimEvents(options.SlimEvents)
+	taskContext.SetKeepAppliedObjects(options.KeepAppliedObjects)
+	taskContext.SetRedactor(options.Redactor)
+	taskContext.SetLogger(logger)
+	taskContext.SetContext(ctx)
 
-		// Send event to inform the caller about the resources that
-		// will be applied/pruned.
-		eventChannel <- event.Event{
-			Type: event.InitType,
-			InitEvent: event.InitEvent{
-				ActionGroups: taskQueue.ToActionGroups(),
-			},
-		}
-		// Create a new TaskStatusRunner to execute the taskQueue.
-		klog.V(4).Infoln("applier building TaskStatusRunner...")
-		allIds := object.UnstructuredSetToObjMetadataSet(append(applyObjs, pruneObjs...))
-		statusWatcher := a.statusWatcher
-		// Disable watcher for dry runs
-		if opts.DryRunStrategy.ClientOrServerDryRun() {
-			statusWatcher = watcher.BlindStatusWatcher{}
+	// Objects already in the inventory don't need to be retrieved to
+	// verify the inventory policy, if server-side apply is used. Their
+	// owners are verified from the results of their apply instead.
+	skipInventoryPolicyGet := options.SkipInventoryPolicyGet ||
+		options.FeatureGates.Enabled(features.ServerSideApplyFastPath)
+	var inventoryIds map[object.ObjMetadata]struct{}
+	if skipInventoryPolicyGet && options.ServerSideOptions.ServerSideApply &&
+		options.InventoryPolicy != inventory.PolicyAdoptAll {
+		clusterObjs, err := a.invClient.GetClusterObjs(ctx, invInfo)
+		if err != nil {
+			handleError(eventChannel, err)
+			return
 		}
-		runner := taskrunner.NewTaskStatusRunner(allIds, statusWatcher)
-		klog.V(4).Infoln("applier running TaskStatusRunner...")
-		err = runner.Run(ctx, taskContext, taskQueue.ToChannel(), taskrunner.Options{
-			EmitStatusEvents:         options.EmitStatusEvents,
-			WatcherRESTScopeStrategy: options.WatcherRESTScopeStrategy,
+		inventoryIds = clusterObjs.ToMap()
+	}
+
+	// Fetch the queue (channel) of tasks that should be executed.
+	logger.V(4).Info("applier building task queue...")
+	// Build list of apply validation filters.
+	applyFilters := []filter.ValidationFilter{
+		filter.InventoryPolicyApplyFilter{
+			Client:       a.client,
+			Mapper:       a.mapper,
+			Inv:          invInfo,
+			InvPolicy:    options.InventoryPolicy,
+			InventoryIds: inventoryIds,
+		},
+		filter.DependencyFilter{
+			TaskContext:       taskContext,
+			ActuationStrategy: actuation.ActuationStrategyApply,
+			DryRunStrategy:    options.DryRunStrategy,
+		},
+	}
+	// Build list of prune validation filters.
+	pruneFilters := []filter.ValidationFilter{
+		filter.PreventRemoveFilter{},
+		filter.InventoryPolicyPruneFilter{
+			Inv:       invInfo,
+			InvPolicy: options.InventoryPolicy,
+		},
+		filter.LocalNamespacesFilter{
+			LocalNamespaces: localNamespaces(invInfo, object.UnstructuredSetToObjMetadataSet(objects)),
+		},
+	}
+	if scopeFilter, found := options.pruneScopeFilter(); found {
+		pruneFilters = append(pruneFilters, scopeFilter)
+	}
+	pruneFilters = append(pruneFilters, filter.DependencyFilter{
+		TaskContext:       taskContext,
+		ActuationStrategy: actuation.ActuationStrategyDelete,
+		DryRunStrategy:    options.DryRunStrategy,
+	})
+	// Build list of apply mutators.
+	applyMutators := []mutator.Interface{
+		&mutator.ApplyTimeMutator{
+			Client:        a.client,
+			Mapper:        a.mapper,
+			ResourceCache: resourceCache,
+			Redactor:      options.Redactor,
+		},
+	}
+	if len(options.CommonLabels) > 0 || len(options.CommonAnnotations) > 0 {
+		applyMutators = append(applyMutators, &mutator.MetadataMutator{
+			Labels:      options.CommonLabels,
+			Annotations: options.CommonAnnotations,
 		})
+	}
+	infoHelper, warnings := a.newInfoHelper()
+	taskBuilder := &solver.TaskQueueBuilder{
+		Pruner:        a.pruner,
+		DynamicClient: a.client,
+		OpenAPIGetter: a.openAPIGetter,
+		InfoHelper:    infoHelper,
+		Mapper:        a.mapper,
+		InvClient:     a.invClient,
+		Warnings:      warnings,
+		Collector:     vCollector,
+		ApplyFilters:  applyFilters,
+		ApplyMutators: applyMutators,
+		PruneFilters:  pruneFilters,
+	}
+	opts := solver.Options{
+		ServerSideOptions:         options.ServerSideOptions,
+		ReconcileTimeout:          options.ReconcileTimeout,
+		WaitForJobCompletion:      options.WaitForJobCompletion,
+		Destroy:                   false,
+		Prune:                     !options.NoPrune,
+		DryRunStrategy:            options.DryRunStrategy,
+		PrunePropagationPolicy:    options.PrunePropagationPolicy,
+		PruneTimeout:              options.PruneTimeout,
+		InventoryPolicy:           options.InventoryPolicy,
+		Redactor:                  options.Redactor,
+		SkipUnchangedInventoryAdd: options.SkipPendingInventoryUpdate,
+		FieldDiffs:                options.FieldDiffs && !options.SlimEvents,
+	}
+
+	// Build the ordered set of tasks to execute.
+	taskQueue := taskBuilder.
+		WithApplyObjects(applyObjs).
+		WithPruneObjects(pruneObjs).
+		WithStaleEntries(stale).
+		WithExcludedObjects(excludedObjs).
+		WithInventory(invInfo).
+		Build(taskContext, opts)
+
+	logger.V(4).Info("validated objects", "errors", len(vCollector.Errors), "invalidObjects", len(vCollector.InvalidIds))
+
+	for _, err := range vCollector.Warnings {
+		handleValidationWarning(eventChannel, err)
+	}
+
+	// Handle validation errors
+	switch options.ValidationPolicy {
+	case validation.ExitEarly:
+		err = vCollector.ToError()
 		if err != nil {
 			handleError(eventChannel, err)
 			return
 		}
-	}()
-	return eventChannel
+	case validation.SkipInvalid:
+		for _, err := range vCollector.Errors {
+			handleValidationError(eventChannel, err)
+		}
+	default:
+		handleError(eventChannel, fmt.Errorf("invalid ValidationPolicy: %q", options.ValidationPolicy))
+		return
+	}
+
+	// Register invalid objects to be retained in the inventory, if present.
+	for _, id := range vCollector.InvalidIds {
+		taskContext.AddInvalidObject(id)
+	}
+
+	// Send event to inform the caller about the resources that
+	// will be applied/pruned.
+	eventChannel <- event.Event{
+		Type:      event.InitType,
+		Timestamp: time.Now(),
+		InitEvent: event.InitEvent{
+			ActionGroups: taskQueue.ToActionGroups(),
+		},
+	}
+	// Create a new TaskStatusRunner to execute the taskQueue.
+	logger.V(4).Info("applier building TaskStatusRunner...")
+	allIds := object.UnstructuredSetToObjMetadataSet(append(applyObjs, pruneObjs...))
+	statusWatcher := a.statusWatcher
+	// Disable watcher for dry runs
+	if opts.DryRunStrategy.ClientOrServerDryRun() {
+		statusWatcher = watcher.BlindStatusWatcher{}
+	}
+	runner := taskrunner.NewTaskStatusRunner(allIds, statusWatcher)
+	logger.V(4).Info("applier running TaskStatusRunner...")
+	err = runner.Run(ctx, taskContext, taskQueue.ToChannel(), taskrunner.Options{
+		EmitStatusEvents:          options.EmitStatusEvents,
+		WatcherRESTScopeStrategy:  options.WatcherRESTScopeStrategy,
+		StatusEventOverflowPolicy: options.StatusEventOverflowPolicy,
+	})
+	if err != nil {
+		handleError(eventChannel, err)
+		return
+	}
 }
 
 type ApplierOptions struct {
@@ -262,10 +440,50 @@ type ApplierOptions struct {
 	// how long to wait.
 	ReconcileTimeout time.Duration
 
+	// WaitForJobCompletion defines whether the applier waits for the
+	// applied Jobs to complete, instead of only being Current. Jobs that
+	// fail are reconcile failures. The
+	// cli-utils.sigs.k8s.io/wait-for-completion annotation overrides it
+	// for a single Job or Pod.
+	WaitForJobCompletion bool
+
 	// EmitStatusEvents defines whether status events should be
 	// emitted on the eventChannel to the caller.
 	EmitStatusEvents bool
 
+	// EventBufferSize defines the size of the buffer of the eventChannel
+	// returned to the caller. By default, the eventChannel is unbuffered.
+	EventBufferSize int
+
+	// StatusEventOverflowPolicy defines what to do with status events when
+	// the eventChannel is full, so a slow caller does not stall the
+	// processing of the tasks. By default, the processing blocks until the
+	// caller has received the status event.
+	StatusEventOverflowPolicy taskrunner.OverflowPolicy
+
+	// SlimEvents defines whether the objects should be omitted from the
+	// events emitted on the eventChannel. The events still contain the
+	// identifiers, statuses, and errors of the objects.
+	SlimEvents bool
+
+	// KeepAppliedObjects defines whether successful apply events keep the
+	// object returned by the server, with its defaulted fields, UID, and
+	// resourceVersion, even if SlimEvents is true. This lets callers act on
+	// the applied objects without getting them again, while the other
+	// objects are still omitted.
+	KeepAppliedObjects bool
+
+	// WaitForConcurrentRun defines whether to wait for another run using
+	// the same inventory in this process to end. By default, the run fails
+	// with a ConcurrentRunError.
+	WaitForConcurrentRun bool
+
+	// FieldDiffs defines whether the apply events should list the fields
+	// changed by the apply, compared to the object in the cluster before
+	// the apply. This requires a GET of each object before it is applied.
+	// Ignored if SlimEvents is true.
+	FieldDiffs bool
+
 	// NoPrune defines whether pruning of previously applied
 	// objects should happen after apply.
 	NoPrune bool
@@ -284,15 +502,159 @@ type ApplierOptions struct {
 	// wait.
 	PruneTimeout time.Duration
 
+	// PruneAllowGroupKinds are the kinds of the objects that can be
+	// pruned. The objects of the other kinds are skipped, and stay in the
+	// inventory. By default, objects of all kinds can be pruned.
+	PruneAllowGroupKinds []schema.GroupKind
+
+	// PruneSkipNamespaces are the namespaces of the objects that are not
+	// pruned. The skipped objects stay in the inventory.
+	PruneSkipNamespaces []string
+
+	// NoPruneClusterScoped skips the prune of the cluster-scoped objects,
+	// like Namespaces and CRDs. The skipped objects stay in the inventory.
+	NoPruneClusterScoped bool
+
 	// InventoryPolicy defines the inventory policy of apply.
 	InventoryPolicy inventory.Policy
 
 	// ValidationPolicy defines how to handle invalid objects.
 	ValidationPolicy validation.Policy
 
+	// DeprecatedAPIPolicy defines how to handle objects with API versions
+	// that are deprecated or removed in the version of the cluster. By
+	// default, the API versions are not checked.
+	DeprecatedAPIPolicy validation.DeprecatedAPIPolicy
+
+	// ValidateSchema defines whether the objects should be validated
+	// against the OpenAPI v3 schemas of the cluster before apply, including
+	// the structural schemas of the installed CRDs.
+	ValidateSchema bool
+
+	// AdmissionRules are evaluated against the objects before they are
+	// applied or pruned. Denied objects are handled like invalid objects,
+	// according to the ValidationPolicy. Warnings are sent as validation
+	// events with the WarningSeverity, and the objects are still actuated.
+	AdmissionRules []validation.AdmissionRule
+
+	// PolicyEvaluator evaluates policies against the objects to apply and
+	// prune before anything is changed in the cluster. Violations are
+	// handled like invalid objects, according to the ValidationPolicy.
+	PolicyEvaluator validation.PolicyEvaluator
+
+	// SkipInventoryPolicyGet skips getting the objects already in the
+	// inventory from the cluster before they are applied, to verify the
+	// inventory policy. Their owning inventory is verified from the
+	// server-side apply response instead. If the apply fails with a
+	// conflict, the object may be owned by another inventory, so it is
+	// retrieved to verify the policy. This halves the number of requests
+	// when most objects are unchanged. Only used with server-side apply.
+	// Objects that are not in the inventory yet, objects applied with
+	// client-side apply, and, with PolicyMustMatch, objects applied with
+	// ForceConflicts, are still retrieved before they are applied.
+	// Adoption by another inventory is only detected if the inventories
+	// are applied with different field managers.
+	SkipInventoryPolicyGet bool
+
+	// SkipPendingInventoryUpdate skips the inventory update at the start of
+	// the run, which records the objects to apply as pending, if all of them
+	// are already in the inventory. The inventory object is then only
+	// updated once per run, at the end. New objects are still added to the
+	// inventory before they are applied, so they can be pruned later even
+	// if the run is interrupted.
+	SkipPendingInventoryUpdate bool
+
+	// Redactor redacts sensitive values of the objects from the events and
+	// errors. The values of Secrets are always redacted.
+	Redactor *object.Redactor
+
 	// RESTScopeStrategy specifies which strategy to use when listing and
 	// watching resources. By default, the strategy is selected automatically.
 	WatcherRESTScopeStrategy watcher.RESTScopeStrategy
+
+	// Transformer modifies the objects before they are validated and
+	// applied, like a transform.Pipeline, which works on copies of the
+	// objects of the caller. Optional.
+	Transformer transform.Transformer
+
+	// ObjectFilter selects the objects to apply, after they are
+	// transformed. The other objects are excluded from the run: they are
+	// neither applied nor pruned, and stay in the inventory. By default,
+	// all the objects are applied.
+	ObjectFilter *ObjectFilter
+
+	// CommonLabels and CommonAnnotations are added to every applied object,
+	// right before it is applied, like a revision or the SHA of a commit.
+	// Their keys are recorded in the cli-utils.sigs.k8s.io/injected-metadata
+	// annotation, and their changes are not listed in the field diffs of
+	// the apply events. Label selectors and pod template labels are not
+	// changed.
+	CommonLabels      map[string]string
+	CommonAnnotations map[string]string
+
+	// FeatureGates enables the experimental features of the run. By
+	// default, the features of the process are used, which are read from
+	// the CLI_UTILS_FEATURE_GATES environment variable.
+	FeatureGates featuregate.FeatureGate
+
+	// Logger is the logger of the run, overriding the logger of the
+	// ApplierBuilder. By default, the logger of the context is used, which
+	// falls back to the global klog logger.
+	// The entries of the run have the "runID" value.
+	Logger logr.Logger
+}
+
+// pruneScopeFilter returns the filter that skips the objects out of the
+// prune scope of the options, if the scope is restricted.
+func (o ApplierOptions) pruneScopeFilter() (filter.PruneScopeFilter, bool) {
+	scopeFilter := filter.PruneScopeFilter{
+		AllowGroupKinds:   o.PruneAllowGroupKinds,
+		SkipNamespaces:    o.PruneSkipNamespaces,
+		SkipClusterScoped: o.NoPruneClusterScoped,
+	}
+	found := len(o.PruneAllowGroupKinds) > 0 || len(o.PruneSkipNamespaces) > 0 || o.NoPruneClusterScoped
+	return scopeFilter, found
+}
+
+// getServerVersion returns the version of the cluster.
+func (a *Applier) getServerVersion() (*version.Version, error) {
+	info, err := a.serverVersion.ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the server version: %w", err)
+	}
+	v, err := version.ParseGeneric(info.GitVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the server version %q: %w", info.GitVersion, err)
+	}
+	return v, nil
+}
+
+// getOpenAPIRoot returns the OpenAPI v3 document of the cluster. The
+// schemas of the GroupVersions are fetched, and cached by the discovery
+// client, when they are first used.
+func (a *Applier) getOpenAPIRoot() openapi3.Root {
+	return openapi3.NewRoot(a.discoClient.OpenAPIV3())
+}
+
+// runContext returns the context of a run, with the logger of the run. The
+// logger has a new run ID, so the entries of concurrent runs, including the
+// entries of the inventory client, can be told apart.
+func runContext(ctx context.Context, options, builder logr.Logger) context.Context {
+	logger := runLogger(ctx, options, builder).WithValues("runID", uuid.NewString())
+	return klog.NewContext(ctx, logger)
+}
+
+// runLogger returns the logger of a run: the logger of the options, if set,
+// or else the logger of the builder, or else the logger of the context.
+func runLogger(ctx context.Context, options, builder logr.Logger) logr.Logger {
+	switch {
+	case options.GetSink() != nil:
+		return options
+	case builder.GetSink() != nil:
+		return builder
+	default:
+		return klog.FromContext(ctx)
+	}
 }
 
 // setDefaults set the options to the default values if they
@@ -301,13 +663,21 @@ func setDefaults(o *ApplierOptions) {
 	if o.PrunePropagationPolicy == "" {
 		o.PrunePropagationPolicy = metav1.DeletePropagationBackground
 	}
+	if o.EventBufferSize < 0 {
+		o.EventBufferSize = 0
+	}
+	if o.FeatureGates == nil {
+		o.FeatureGates = features.DefaultFeatureGate
+	}
 }
 
 func handleError(eventChannel chan event.Event, err error) {
 	eventChannel <- event.Event{
-		Type: event.ErrorType,
+		Type:      event.ErrorType,
+		Timestamp: time.Now(),
 		ErrorEvent: event.ErrorEvent{
-			Err: err,
+			Err:  err,
+			Hint: applyerror.HintFor(err),
 		},
 	}
 }
@@ -331,22 +701,36 @@ func localNamespaces(localInv inventory.Info, localObjs []object.ObjMetadata) se
 }
 
 func handleValidationError(eventChannel chan<- event.Event, err error) {
+	sendValidationEvent(eventChannel, err, event.ErrorSeverity)
+}
+
+// handleValidationWarning sends a validation event for a problem that does
+// not make the objects invalid, like a deprecated API version.
+func handleValidationWarning(eventChannel chan<- event.Event, err error) {
+	sendValidationEvent(eventChannel, err, event.WarningSeverity)
+}
+
+func sendValidationEvent(eventChannel chan<- event.Event, err error, severity event.ValidationSeverity) {
 	switch tErr := err.(type) {
 	case *validation.Error:
 		// handle validation error about one or more specific objects
 		eventChannel <- event.Event{
-			Type: event.ValidationType,
+			Type:      event.ValidationType,
+			Timestamp: time.Now(),
 			ValidationEvent: event.ValidationEvent{
 				Identifiers: tErr.Identifiers(),
 				Error:       tErr,
+				Severity:    severity,
 			},
 		}
 	default:
 		// handle general validation error (no specific object)
 		eventChannel <- event.Event{
-			Type: event.ValidationType,
+			Type:      event.ValidationType,
+			Timestamp: time.Now(),
 			ValidationEvent: event.ValidationEvent{
-				Error: tErr,
+				Error:    tErr,
+				Severity: severity,
 			},
 		}
 	}
@@ -4,6 +4,7 @@
 package apply
 
 import (
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
@@ -42,8 +43,13 @@ func (b *ApplierBuilder) Build() (*Applier, error) {
 		invClient:     bx.invClient,
 		client:        bx.client,
 		openAPIGetter: bx.discoClient,
+		discoClient:   bx.discoClient,
+		serverVersion: bx.discoClient,
 		mapper:        bx.mapper,
 		infoHelper:    info.NewHelper(bx.mapper, bx.unstructuredClientForMapping),
+		logger:        bx.logger,
+		clientFactory: bx.clientFactory,
+		cluster:       bx.restConfig.Host,
 	}, nil
 }
 
@@ -57,6 +63,14 @@ func (b *ApplierBuilder) WithInventoryClient(invClient inventory.Client) *Applie
 	return b
 }
 
+// WithInventoryClientFactory sets the factory of the inventory client, if
+// no inventory client is provided. The client is created from the factory
+// of the builder, so it impersonates the user set with WithImpersonation.
+func (b *ApplierBuilder) WithInventoryClientFactory(invClientFactory inventory.ClientFactory) *ApplierBuilder {
+	b.invClientFactory = invClientFactory
+	return b
+}
+
 func (b *ApplierBuilder) WithDynamicClient(client dynamic.Interface) *ApplierBuilder {
 	b.client = client
 	return b
@@ -77,6 +91,10 @@ func (b *ApplierBuilder) WithRestConfig(restConfig *rest.Config) *ApplierBuilder
 	return b
 }
 
+// WithUnstructuredClientForMapping sets the function that returns the
+// clients used to apply the objects. If not set, the clients are created
+// from the factory for each run, and the warnings of the server are
+// attached to the apply events of that run.
 func (b *ApplierBuilder) WithUnstructuredClientForMapping(unstructuredClientForMapping func(*meta.RESTMapping) (resource.RESTClient, error)) *ApplierBuilder {
 	b.unstructuredClientForMapping = unstructuredClientForMapping
 	return b
@@ -86,3 +104,31 @@ func (b *ApplierBuilder) WithStatusWatcher(statusWatcher watcher.StatusWatcher)
 	b.statusWatcher = statusWatcher
 	return b
 }
+
+// WithImpersonation sets the user, groups, and UID impersonated by the
+// clients created from the factory, so the RBAC rules of the impersonated
+// user are enforced. Clients provided explicitly are used as-is, so the
+// inventory client should be set with WithInventoryClientFactory, or be
+// created with NewImpersonatingFactory.
+func (b *ApplierBuilder) WithImpersonation(impersonate rest.ImpersonationConfig) *ApplierBuilder {
+	b.impersonate = impersonate
+	return b
+}
+
+// WithDiscoveryCache sets the discovery client and the RESTMapper to the
+// ones of the cache, so they can be shared with other Appliers and
+// Destroyers, and reused across runs.
+func (b *ApplierBuilder) WithDiscoveryCache(cache *DiscoveryCache) *ApplierBuilder {
+	b.discoClient = cache.DiscoveryClient()
+	b.mapper = cache.RESTMapper()
+	return b
+}
+
+// WithLogger sets the logger of the runs of the Applier. The logger can be
+// overridden per run with ApplierOptions.Logger. If not set, the logger of
+// the context of the run is used, which falls back to the global klog
+// logger.
+func (b *ApplierBuilder) WithLogger(logger logr.Logger) *ApplierBuilder {
+	b.logger = logger
+	return b
+}
@@ -11,10 +11,16 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
 	"k8s.io/kubectl/pkg/scheme"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
@@ -23,6 +29,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
+	testlog "sigs.k8s.io/cli-utils/pkg/testutil/log"
 )
 
 var (
@@ -1449,6 +1456,10 @@ func TestApplier(t *testing.T) {
 					} else {
 						t.Errorf("Applier.Run failed to exit (timeout: %s)", testTimeout)
 					}
+					// Drain the events, so the run ends and releases
+					// the inventory for the next test.
+					for range eventChannel {
+					}
 					break loop
 
 				case e, ok := <-eventChannel:
@@ -1943,7 +1954,7 @@ func TestApplierCancel(t *testing.T) {
 
 func TestReadAndPrepareObjectsNilInv(t *testing.T) {
 	applier := Applier{}
-	_, _, err := applier.prepareObjects(nil, object.UnstructuredSet{}, ApplierOptions{})
+	_, _, _, err := applier.prepareObjects(context.TODO(), nil, object.UnstructuredSet{}, nil, ApplierOptions{})
 	assert.Error(t, err)
 }
 
@@ -1962,6 +1973,8 @@ func TestReadAndPrepareObjects(t *testing.T) {
 		invInfo inventoryInfo
 		// resources input to applier
 		resources object.UnstructuredSet
+		// resources excluded from the run
+		excludedObjs object.UnstructuredSet
 		// expected objects to apply
 		applyObjs object.UnstructuredSet
 		// expected objects to prune
@@ -2038,6 +2051,21 @@ func TestReadAndPrepareObjects(t *testing.T) {
 			applyObjs: object.UnstructuredSet{obj1, obj2, clusterScopedObj},
 			pruneObjs: object.UnstructuredSet{},
 		},
+		"excluded object in inventory, not pruned": {
+			clusterObjs: object.UnstructuredSet{obj2},
+			invInfo: inventoryInfo{
+				name:      inventory.Name(),
+				namespace: inventory.Namespace(),
+				id:        inventory.ID(),
+				set: object.ObjMetadataSet{
+					object.UnstructuredToObjMetadata(obj2),
+				},
+			},
+			resources:    object.UnstructuredSet{obj1},
+			excludedObjs: object.UnstructuredSet{obj2},
+			applyObjs:    object.UnstructuredSet{obj1},
+			pruneObjs:    object.UnstructuredSet{},
+		},
 	}
 
 	for name, tc := range testCases {
@@ -2050,7 +2078,7 @@ func TestReadAndPrepareObjects(t *testing.T) {
 				watcher.BlindStatusWatcher{},
 			)
 
-			applyObjs, pruneObjs, err := applier.prepareObjects(tc.invInfo.toWrapped(), tc.resources, ApplierOptions{})
+			applyObjs, pruneObjs, _, err := applier.prepareObjects(context.TODO(), tc.invInfo.toWrapped(), tc.resources, tc.excludedObjs, ApplierOptions{})
 			if tc.isError {
 				assert.Error(t, err)
 				return
@@ -2067,3 +2095,194 @@ func TestReadAndPrepareObjects(t *testing.T) {
 		})
 	}
 }
+
+func TestApplierLogger(t *testing.T) {
+	testCases := map[string]struct {
+		builderLogger bool
+		optionsLogger bool
+		// true if the entries are expected in the builder logger, instead
+		// of the options logger
+		expectBuilderLogger bool
+	}{
+		"options logger": {
+			optionsLogger: true,
+		},
+		"builder logger": {
+			builderLogger:       true,
+			expectBuilderLogger: true,
+		},
+		"options logger overrides builder logger": {
+			builderLogger: true,
+			optionsLogger: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			invInfo := inventoryInfo{
+				name:      "abc-123",
+				namespace: "test",
+				id:        "test",
+			}
+			objs := object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+			}
+			applier := newTestApplier(t, invInfo, objs, object.UnstructuredSet{}, watcher.BlindStatusWatcher{})
+
+			builderSink := testlog.NewSink(4)
+			optionsSink := testlog.NewSink(4)
+			if tc.builderLogger {
+				applier.logger = builderSink.Logger()
+			}
+			options := ApplierOptions{
+				NoPrune:         true,
+				InventoryPolicy: inventory.PolicyMustMatch,
+				DryRunStrategy:  common.DryRunClient,
+			}
+			if tc.optionsLogger {
+				options.Logger = optionsSink.Logger()
+			}
+
+			for e := range applier.Run(context.TODO(), invInfo.toWrapped(), objs, options) {
+				if e.Type == event.ErrorType {
+					t.Fatalf("unexpected error event: %v", e.ErrorEvent.Err)
+				}
+			}
+
+			sink, otherSink := optionsSink, builderSink
+			if tc.expectBuilderLogger {
+				sink, otherSink = builderSink, optionsSink
+			}
+			sink.AssertLogged(t, 4, "apply run")
+			sink.AssertLogged(t, 2, "apply task starting")
+			found := sink.Find(2, "apply task starting")
+			if len(found) > 0 {
+				taskName, _ := found[0].Value("task")
+				assert.Equal(t, "apply-0", taskName)
+			}
+			// All the entries of the run have its ID.
+			runID, _ := sink.Entries()[0].Value("runID")
+			assert.NotEmpty(t, runID)
+			for _, e := range sink.Entries() {
+				id, _ := e.Value("runID")
+				assert.Equal(t, runID, id, e.String())
+			}
+			assert.Empty(t, otherSink.Entries())
+		})
+	}
+}
+
+func TestApplierValidationWarnings(t *testing.T) {
+	deploymentID := testutil.ToIdentifier(t, resources["deployment"])
+
+	testCases := map[string]struct {
+		// apiVersion of the deployment, if set
+		apiVersion       string
+		serverVersion    string
+		options          ApplierOptions
+		expectedWarnings []testutil.ExpEvent
+	}{
+		"deprecated API version warning": {
+			apiVersion:    "apps/v1beta2",
+			serverVersion: "v1.12.0",
+			options: ApplierOptions{
+				DeprecatedAPIPolicy: validation.WarnDeprecatedAPIs,
+			},
+			expectedWarnings: []testutil.ExpEvent{
+				{
+					EventType: event.ValidationType,
+					ValidationEvent: &testutil.ExpValidationEvent{
+						Identifiers: object.ObjMetadataSet{deploymentID},
+						Error: testutil.EqualErrorString(validation.NewError(&validation.DeprecatedAPIError{
+							GroupVersionKind: schema.GroupVersionKind{Group: "apps", Version: "v1beta2", Kind: "Deployment"},
+							ServerVersion:    "1.12.0",
+							DeprecatedIn:     "1.9",
+							RemovedIn:        "1.16",
+							Replacement:      schema.GroupVersion{Group: "apps", Version: "v1"},
+						}, deploymentID).Error()),
+						Severity: event.WarningSeverity,
+					},
+				},
+			},
+		},
+		"admission rule warning": {
+			options: ApplierOptions{
+				AdmissionRules: []validation.AdmissionRule{
+					{
+						Name:    "no-latest",
+						Action:  validation.AdmissionWarn,
+						Message: "images must not use the latest tag",
+						Allow: func(*unstructured.Unstructured) (bool, error) {
+							return false, nil
+						},
+					},
+				},
+			},
+			expectedWarnings: []testutil.ExpEvent{
+				{
+					EventType: event.ValidationType,
+					ValidationEvent: &testutil.ExpValidationEvent{
+						Identifiers: object.ObjMetadataSet{deploymentID},
+						Error: testutil.EqualErrorString(validation.NewError(&validation.AdmissionError{
+							Rule:      "no-latest",
+							Operation: validation.AdmissionApply,
+							Action:    validation.AdmissionWarn,
+							Message:   "images must not use the latest tag",
+						}, deploymentID).Error()),
+						Severity: event.WarningSeverity,
+					},
+				},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			invInfo := inventoryInfo{
+				name:      "abc-123",
+				namespace: "test",
+				id:        "test",
+			}
+			deployment := testutil.Unstructured(t, resources["deployment"])
+			if tc.apiVersion != "" {
+				deployment.SetAPIVersion(tc.apiVersion)
+			}
+			objs := object.UnstructuredSet{deployment}
+			applier := newTestApplier(t, invInfo, objs, object.UnstructuredSet{}, watcher.BlindStatusWatcher{})
+			if tc.serverVersion != "" {
+				applier.serverVersion = &fakediscovery.FakeDiscovery{
+					Fake:               &clienttesting.Fake{},
+					FakedServerVersion: &version.Info{GitVersion: tc.serverVersion},
+				}
+			}
+
+			options := tc.options
+			options.NoPrune = true
+			options.InventoryPolicy = inventory.PolicyMustMatch
+			options.DryRunStrategy = common.DryRunClient
+
+			var events []event.Event
+			for e := range applier.Run(context.TODO(), invInfo.toWrapped(), objs, options) {
+				if e.Type == event.ErrorType {
+					t.Fatalf("unexpected error event: %v", e.ErrorEvent.Err)
+				}
+				events = append(events, e)
+			}
+
+			var warnings []testutil.ExpEvent
+			var applied bool
+			for _, e := range testutil.EventsToExpEvents(events) {
+				switch e.EventType {
+				case event.ValidationType:
+					warnings = append(warnings, e)
+				case event.ApplyType:
+					applied = e.ApplyEvent.Identifier == deploymentID &&
+						e.ApplyEvent.Status == event.ApplySuccessful
+				}
+			}
+			testutil.AssertEqual(t, tc.expectedWarnings, warnings)
+			// Warnings don't prevent the apply.
+			assert.True(t, applied, "the object with warnings was not applied")
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
@@ -21,19 +22,50 @@ type commonBuilder struct {
 	// factory is only used to retrieve things that have not been provided explicitly.
 	factory                      util.Factory
 	invClient                    inventory.Client
+	invClientFactory             inventory.ClientFactory
 	client                       dynamic.Interface
 	discoClient                  discovery.CachedDiscoveryInterface
 	mapper                       meta.RESTMapper
 	restConfig                   *rest.Config
 	unstructuredClientForMapping func(*meta.RESTMapping) (resource.RESTClient, error)
 	statusWatcher                watcher.StatusWatcher
+	// impersonate is the user impersonated by the clients created from the
+	// factory and the REST config. Clients provided explicitly are used
+	// as-is.
+	impersonate rest.ImpersonationConfig
+	// clientFactory is the factory the clients applying the objects are
+	// created from, if unstructuredClientForMapping was not provided
+	// explicitly. The Applier creates new clients from it for each run, so
+	// the warnings of the server are recorded per run.
+	clientFactory util.Factory
+	// logger is the default logger of the runs.
+	logger logr.Logger
 }
 
 func (cb *commonBuilder) finalize() (*commonBuilder, error) {
 	cx := *cb // make a copy before mutating any fields. Shallow copy is good enough.
 	var err error
+	if isImpersonating(cx.impersonate) {
+		if cx.factory != nil {
+			cx.factory = NewImpersonatingFactory(cx.factory, cx.impersonate)
+		}
+		if cx.restConfig != nil {
+			cx.restConfig = withImpersonation(cx.restConfig, cx.impersonate)
+		}
+	}
 	if cx.invClient == nil {
-		return nil, errors.New("inventory client must be provided")
+		if cx.invClientFactory == nil {
+			return nil, errors.New("inventory client must be provided")
+		}
+		if cx.factory == nil {
+			return nil, errors.New("a factory must be provided to create the inventory client")
+		}
+		// The factory impersonates the user, if set, so the inventory is
+		// read and written with the permissions of the user.
+		cx.invClient, err = cx.invClientFactory.NewClient(cx.factory)
+		if err != nil {
+			return nil, fmt.Errorf("error creating inventory client: %w", err)
+		}
 	}
 	if cx.client == nil {
 		if cx.factory == nil {
@@ -75,6 +107,7 @@ func (cb *commonBuilder) finalize() (*commonBuilder, error) {
 		if cx.factory == nil {
 			return nil, fmt.Errorf("a factory must be provided or all other options: %v", err)
 		}
+		cx.clientFactory = cx.factory
 		cx.unstructuredClientForMapping = cx.factory.UnstructuredClientForMapping
 	}
 	if cx.statusWatcher == nil {
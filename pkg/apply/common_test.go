@@ -5,7 +5,6 @@ package apply
 
 import (
 	"bytes"
-	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -31,6 +30,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/jsonpath"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	kstatustesting "sigs.k8s.io/cli-utils/pkg/kstatus/testing"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
@@ -94,6 +94,7 @@ func newTestApplier(
 	// Inject the fakeInfoHelper to allow generating Info
 	// objects that use the FakeRESTClient as the UnstructuredClient.
 	applier.infoHelper = infoHelper
+	applier.clientFactory = nil
 
 	return applier
 }
@@ -347,40 +348,13 @@ func (n *nsHandler) handle(t *testing.T, req *http.Request) (*http.Response, boo
 	return nil, false, nil
 }
 
-type fakeWatcher struct {
-	start  chan struct{}
-	events []pollevent.Event
-}
-
-func newFakeWatcher(statusEvents []pollevent.Event) *fakeWatcher {
-	return &fakeWatcher{
-		events: statusEvents,
-		start:  make(chan struct{}),
+func newFakeWatcher(statusEvents []pollevent.Event) *kstatustesting.FakeStatusWatcher {
+	return &kstatustesting.FakeStatusWatcher{
+		Steps:        kstatustesting.Script(statusEvents...),
+		WaitForStart: true,
 	}
 }
 
-// Start events being sent on the status channel
-func (f *fakeWatcher) Start() {
-	close(f.start)
-}
-
-func (f *fakeWatcher) Watch(ctx context.Context, _ object.ObjMetadataSet, _ watcher.Options) <-chan pollevent.Event {
-	eventChannel := make(chan pollevent.Event)
-	go func() {
-		defer close(eventChannel)
-		// send sync event immediately
-		eventChannel <- pollevent.Event{Type: pollevent.SyncEvent}
-		// wait until started to send the events
-		<-f.start
-		for _, f := range f.events {
-			eventChannel <- f
-		}
-		// wait until cancelled to close the event channel and exit
-		<-ctx.Done()
-	}()
-	return eventChannel
-}
-
 type fakeInfoHelper struct {
 	factory *cmdtesting.TestFactory
 }
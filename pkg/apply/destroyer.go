@@ -8,8 +8,11 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/klog/v2"
@@ -37,7 +40,19 @@ type Destroyer struct {
 	mapper        meta.RESTMapper
 	client        dynamic.Interface
 	openAPIGetter discovery.OpenAPISchemaInterface
+	discoClient   discovery.CachedDiscoveryInterface
 	infoHelper    info.Helper
+	logger        logr.Logger
+	// cluster is the host of the cluster, so the runs of Destroyers of
+	// different clusters don't lock each other's inventories.
+	cluster string
+}
+
+// InvalidateDiscovery clears the cached discovery documents and RESTMapper
+// of the Destroyer, so they are fetched again in the next run. Use it when
+// long-lived Destroyers need to see types changed by other clients.
+func (d *Destroyer) InvalidateDiscovery() {
+	invalidateDiscovery(d.discoClient, d.mapper)
 }
 
 type DestroyerOptions struct {
@@ -61,28 +76,160 @@ type DestroyerOptions struct {
 	// emitted on the eventChannel to the caller.
 	EmitStatusEvents bool
 
+	// EventBufferSize defines the size of the buffer of the eventChannel
+	// returned to the caller. By default, the eventChannel is unbuffered.
+	EventBufferSize int
+
+	// StatusEventOverflowPolicy defines what to do with status events when
+	// the eventChannel is full, so a slow caller does not stall the
+	// processing of the tasks. By default, the processing blocks until the
+	// caller has received the status event.
+	StatusEventOverflowPolicy taskrunner.OverflowPolicy
+
+	// SlimEvents defines whether the objects should be omitted from the
+	// events emitted on the eventChannel. The events still contain the
+	// identifiers, statuses, and errors of the objects.
+	SlimEvents bool
+
+	// WaitForConcurrentRun defines whether to wait for another run using
+	// the same inventory in this process to end. By default, the run fails
+	// with a ConcurrentRunError.
+	WaitForConcurrentRun bool
+
+	// DeleteConcurrency defines how many objects are deleted in parallel.
+	// Objects that depend on each other are still deleted in order. By
+	// default, the objects are deleted one at a time.
+	DeleteConcurrency int
+
+	// DeleteQPS limits the deletions per second of each of the
+	// DeleteConcurrency workers. By default, the deletions are only limited
+	// by the rate limiter of the client.
+	DeleteQPS float32
+
+	// KeepNamespaces defines whether the Namespaces are kept, instead of
+	// deleted, like namespaces shared with other environments. Kept
+	// objects are abandoned: they are skipped and removed from the
+	// inventory.
+	KeepNamespaces bool
+
+	// KeepCRDs defines whether the CustomResourceDefinitions are kept,
+	// instead of deleted, with the custom resources of other inventories.
+	KeepCRDs bool
+
+	// KeepGroupKinds lists more kinds of objects that are kept, instead of
+	// deleted.
+	KeepGroupKinds []schema.GroupKind
+
+	// KeepInventory keeps the inventory object, emptied of the deleted
+	// objects, instead of deleting it after all the objects are deleted,
+	// so the environment can be re-created with the same inventory. The
+	// Finished event of the inventory action group reports what happened
+	// to the inventory.
+	KeepInventory bool
+
+	// DeleteFilters are custom filters that skip the deletion of objects,
+	// like the objects of other teams. Objects are skipped if a filter
+	// returns an error, and fail if it returns a filter.FatalError.
+	DeleteFilters []filter.ValidationFilter
+
 	// ValidationPolicy defines how to handle invalid objects.
 	ValidationPolicy validation.Policy
+
+	// AdmissionRules are evaluated against the objects before they are
+	// deleted, as AdmissionPrune operations. Denied objects are handled
+	// like invalid objects, according to the ValidationPolicy. Warnings are
+	// sent as validation events with the WarningSeverity, and the objects
+	// are still deleted.
+	AdmissionRules []validation.AdmissionRule
+
+	// PolicyEvaluator evaluates policies against the objects to delete, as
+	// objects to prune, before anything is deleted. Violations are handled
+	// like invalid objects, according to the ValidationPolicy.
+	PolicyEvaluator validation.PolicyEvaluator
+
+	// Logger is the logger of the run, overriding the logger of the
+	// DestroyerBuilder. By default, the logger of the context is used,
+	// which falls back to the global klog logger.
+	// The entries of the run have the "runID" value.
+	Logger logr.Logger
+}
+
+// destroyerFilters returns the filters that decide which objects are
+// deleted, and which are skipped. The DeleteFilters of the options run after
+// the built-in filters, except the DependencyFilter, which runs last.
+func destroyerFilters(invInfo inventory.Info, taskContext *taskrunner.TaskContext, options DestroyerOptions) []filter.ValidationFilter {
+	deleteFilters := []filter.ValidationFilter{
+		filter.PreventRemoveFilter{},
+		filter.InventoryPolicyPruneFilter{
+			Inv:       invInfo,
+			InvPolicy: options.InventoryPolicy,
+		},
+	}
+	if keepGroupKinds := options.keepGroupKinds(); len(keepGroupKinds) > 0 {
+		// Only the objects owned by the inventory are abandoned, so
+		// the kinds are filtered after the inventory policy.
+		deleteFilters = append(deleteFilters, filter.KeepKindsFilter{GroupKinds: keepGroupKinds})
+	}
+	deleteFilters = append(deleteFilters, options.DeleteFilters...)
+	return append(deleteFilters, filter.DependencyFilter{
+		TaskContext:       taskContext,
+		ActuationStrategy: actuation.ActuationStrategyDelete,
+		DryRunStrategy:    options.DryRunStrategy,
+	})
+}
+
+// keepGroupKinds returns the kinds of the objects that are kept, instead of
+// deleted.
+func (o DestroyerOptions) keepGroupKinds() []schema.GroupKind {
+	groupKinds := append([]schema.GroupKind{}, o.KeepGroupKinds...)
+	if o.KeepNamespaces {
+		groupKinds = append(groupKinds, schema.GroupKind{Kind: "Namespace"})
+	}
+	if o.KeepCRDs {
+		groupKinds = append(groupKinds, schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"})
+	}
+	return groupKinds
 }
 
 func setDestroyerDefaults(o *DestroyerOptions) {
 	if o.DeletePropagationPolicy == "" {
 		o.DeletePropagationPolicy = metav1.DeletePropagationBackground
 	}
+	if o.EventBufferSize < 0 {
+		o.EventBufferSize = 0
+	}
 }
 
 // Run performs the destroy step. Passes the inventory object. This
 // happens asynchronously on progress and any errors are reported
 // back on the event channel.
 func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options DestroyerOptions) <-chan event.Event {
-	eventChannel := make(chan event.Event)
+	ctx = runContext(ctx, options.Logger, d.logger)
+	// The tasks of the run read the inventory object once.
+	ctx = inventory.WithCache(ctx)
+	logger := klog.FromContext(ctx)
 	setDestroyerDefaults(&options)
+	eventChannel := make(chan event.Event, options.EventBufferSize)
 	go func() {
 		defer close(eventChannel)
+		// Only one run at a time may use the inventory.
+		if invInfo != nil {
+			endRun, err := runs.start(ctx, d.cluster, invInfo, options.WaitForConcurrentRun)
+			if err != nil {
+				handleError(eventChannel, err)
+				return
+			}
+			defer endRun()
+			if err := validateInventoryID(ctx, d.invClient, invInfo); err != nil {
+				handleError(eventChannel, err)
+				return
+			}
+		}
+
 		// Retrieve the objects to be deleted from the cluster. Second parameter is empty
 		// because no local objects returns all inventory objects for deletion.
 		emptyLocalObjs := object.UnstructuredSet{}
-		deleteObjs, err := d.pruner.GetPruneObjs(invInfo, emptyLocalObjs, prune.Options{
+		deleteObjs, stale, err := d.pruner.PlanPrune(ctx, invInfo, emptyLocalObjs, prune.Options{
 			DryRunStrategy: options.DryRunStrategy,
 		})
 		if err != nil {
@@ -98,24 +245,33 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 			Mapper:    d.mapper,
 		}
 		validator.Validate(deleteObjs)
+		if len(options.AdmissionRules) > 0 {
+			admissionValidator := &validation.AdmissionValidator{
+				Rules:     options.AdmissionRules,
+				Collector: vCollector,
+			}
+			admissionValidator.Validate(deleteObjs, validation.AdmissionPrune)
+		}
+		if options.PolicyEvaluator != nil {
+			policyValidator := &validation.PolicyValidator{
+				Evaluator: options.PolicyEvaluator,
+				Collector: vCollector,
+			}
+			if err := policyValidator.Validate(ctx, nil, deleteObjs); err != nil {
+				handleError(eventChannel, err)
+				return
+			}
+		}
 
 		// Build a TaskContext for passing info between tasks
 		resourceCache := cache.NewResourceCacheMap()
 		taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
+		taskContext.SetSlimEvents(options.SlimEvents)
+		taskContext.SetLogger(logger)
+		taskContext.SetContext(ctx)
 
-		klog.V(4).Infoln("destroyer building task queue...")
-		deleteFilters := []filter.ValidationFilter{
-			filter.PreventRemoveFilter{},
-			filter.InventoryPolicyPruneFilter{
-				Inv:       invInfo,
-				InvPolicy: options.InventoryPolicy,
-			},
-			filter.DependencyFilter{
-				TaskContext:       taskContext,
-				ActuationStrategy: actuation.ActuationStrategyDelete,
-				DryRunStrategy:    options.DryRunStrategy,
-			},
-		}
+		logger.V(4).Info("destroyer building task queue...")
+		deleteFilters := destroyerFilters(invInfo, taskContext, options)
 		taskBuilder := &solver.TaskQueueBuilder{
 			Pruner:        d.pruner,
 			DynamicClient: d.client,
@@ -128,21 +284,28 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 		}
 		opts := solver.Options{
 			Destroy:                true,
+			KeepInventory:          options.KeepInventory,
 			Prune:                  true,
 			DryRunStrategy:         options.DryRunStrategy,
 			PrunePropagationPolicy: options.DeletePropagationPolicy,
 			PruneTimeout:           options.DeleteTimeout,
 			InventoryPolicy:        options.InventoryPolicy,
+			DeleteConcurrency:      options.DeleteConcurrency,
+			DeleteQPS:              options.DeleteQPS,
 		}
 
 		// Build the ordered set of tasks to execute.
 		taskQueue := taskBuilder.
 			WithPruneObjects(deleteObjs).
+			WithStaleEntries(stale).
 			WithInventory(invInfo).
 			Build(taskContext, opts)
 
-		klog.V(4).Infof("validation errors: %d", len(vCollector.Errors))
-		klog.V(4).Infof("invalid objects: %d", len(vCollector.InvalidIds))
+		logger.V(4).Info("validated objects", "errors", len(vCollector.Errors), "invalidObjects", len(vCollector.InvalidIds))
+
+		for _, err := range vCollector.Warnings {
+			handleValidationWarning(eventChannel, err)
+		}
 
 		// Handle validation errors
 		switch options.ValidationPolicy {
@@ -169,13 +332,14 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 		// Send event to inform the caller about the resources that
 		// will be pruned.
 		eventChannel <- event.Event{
-			Type: event.InitType,
+			Type:      event.InitType,
+			Timestamp: time.Now(),
 			InitEvent: event.InitEvent{
 				ActionGroups: taskQueue.ToActionGroups(),
 			},
 		}
 		// Create a new TaskStatusRunner to execute the taskQueue.
-		klog.V(4).Infoln("destroyer building TaskStatusRunner...")
+		logger.V(4).Info("destroyer building TaskStatusRunner...")
 		deleteIds := object.UnstructuredSetToObjMetadataSet(deleteObjs)
 		statusWatcher := d.statusWatcher
 		// Disable watcher for dry runs
@@ -183,9 +347,10 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 			statusWatcher = watcher.BlindStatusWatcher{}
 		}
 		runner := taskrunner.NewTaskStatusRunner(deleteIds, statusWatcher)
-		klog.V(4).Infoln("destroyer running TaskStatusRunner...")
+		logger.V(4).Info("destroyer running TaskStatusRunner...")
 		err = runner.Run(ctx, taskContext, taskQueue.ToChannel(), taskrunner.Options{
-			EmitStatusEvents: options.EmitStatusEvents,
+			EmitStatusEvents:          options.EmitStatusEvents,
+			StatusEventOverflowPolicy: options.StatusEventOverflowPolicy,
 		})
 		if err != nil {
 			handleError(eventChannel, err)
@@ -194,3 +359,85 @@ func (d *Destroyer) Run(ctx context.Context, invInfo inventory.Info, options Des
 	}()
 	return eventChannel
 }
+
+// RunOrphanedChildren destroys the child inventories of the parent
+// inventory that the parent doesn't list anymore, one run at a time, with
+// the objects they own. The children of an orphan are destroyed before it,
+// so none is left with a parent inventory that doesn't exist; an orphan
+// that still has children after that is not destroyed, and an error event
+// is sent. A failed run does not stop the next runs. The events of all the
+// runs are sent on the returned channel.
+func (d *Destroyer) RunOrphanedChildren(ctx context.Context, parent inventory.Info, options DestroyerOptions) <-chan event.Event {
+	eventChannel := make(chan event.Event, options.EventBufferSize)
+	go func() {
+		defer close(eventChannel)
+		orphans, err := inventory.OrphanedChildren(ctx, d.client, parent)
+		if err != nil {
+			handleError(eventChannel, err)
+			return
+		}
+		tree := &inventoryTreeDestroyer{
+			client: d.client,
+			dryRun: options.DryRunStrategy.ClientOrServerDryRun(),
+			run: func(inv inventory.Info) <-chan event.Event {
+				return d.Run(ctx, inv, options)
+			},
+			eventChannel: eventChannel,
+			visited:      sets.New[string](parent.ID()),
+		}
+		for _, orphan := range orphans {
+			klog.FromContext(ctx).V(4).Info("destroying orphaned child inventory", "parent", parent.ID(), "inventory", orphan.ID())
+			tree.destroy(ctx, orphan)
+		}
+	}()
+	return eventChannel
+}
+
+// inventoryTreeDestroyer destroys inventories after their descendants.
+type inventoryTreeDestroyer struct {
+	client dynamic.Interface
+	// dryRun is true if the inventories are not deleted by the runs, so
+	// the children are not expected to be gone after their runs.
+	dryRun       bool
+	run          func(inventory.Info) <-chan event.Event
+	eventChannel chan event.Event
+	// visited are the IDs of the inventories already destroyed, or being
+	// destroyed, which guards against cycles.
+	visited sets.Set[string]
+}
+
+// destroy destroys the child inventories of the inventory, depth first, and
+// then the inventory, if it has no children left.
+func (t *inventoryTreeDestroyer) destroy(ctx context.Context, inv inventory.Info) {
+	if t.visited.Has(inv.ID()) {
+		return
+	}
+	t.visited.Insert(inv.ID())
+	children, err := inventory.LabeledChildren(ctx, t.client, inv)
+	if err != nil {
+		handleError(t.eventChannel, err)
+		return
+	}
+	for _, child := range children {
+		klog.FromContext(ctx).V(4).Info("destroying child inventory", "parent", inv.ID(), "inventory", child.ID())
+		t.destroy(ctx, child)
+	}
+	if len(children) > 0 && !t.dryRun {
+		remaining, err := inventory.LabeledChildren(ctx, t.client, inv)
+		if err != nil {
+			handleError(t.eventChannel, err)
+			return
+		}
+		if len(remaining) > 0 {
+			ids := make([]string, len(remaining))
+			for i, child := range remaining {
+				ids[i] = child.ID()
+			}
+			handleError(t.eventChannel, fmt.Errorf("inventory %q was not destroyed: child inventories %v were not destroyed", inv.ID(), ids))
+			return
+		}
+	}
+	for e := range t.run(inv) {
+		t.eventChannel <- e
+	}
+}
@@ -4,6 +4,7 @@
 package apply
 
 import (
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
@@ -43,7 +44,10 @@ func (b *DestroyerBuilder) Build() (*Destroyer, error) {
 		mapper:        bx.mapper,
 		client:        bx.client,
 		openAPIGetter: bx.discoClient,
+		discoClient:   bx.discoClient,
 		infoHelper:    info.NewHelper(bx.mapper, bx.unstructuredClientForMapping),
+		logger:        bx.logger,
+		cluster:       bx.restConfig.Host,
 	}, nil
 }
 
@@ -57,6 +61,14 @@ func (b *DestroyerBuilder) WithInventoryClient(invClient inventory.Client) *Dest
 	return b
 }
 
+// WithInventoryClientFactory sets the factory of the inventory client, if
+// no inventory client is provided. The client is created from the factory
+// of the builder, so it impersonates the user set with WithImpersonation.
+func (b *DestroyerBuilder) WithInventoryClientFactory(invClientFactory inventory.ClientFactory) *DestroyerBuilder {
+	b.invClientFactory = invClientFactory
+	return b
+}
+
 func (b *DestroyerBuilder) WithDynamicClient(client dynamic.Interface) *DestroyerBuilder {
 	b.client = client
 	return b
@@ -86,3 +98,31 @@ func (b *DestroyerBuilder) WithStatusWatcher(statusWatcher watcher.StatusWatcher
 	b.statusWatcher = statusWatcher
 	return b
 }
+
+// WithImpersonation sets the user, groups, and UID impersonated by the
+// clients created from the factory, so the RBAC rules of the impersonated
+// user are enforced. Clients provided explicitly are used as-is, so the
+// inventory client should be set with WithInventoryClientFactory, or be
+// created with NewImpersonatingFactory.
+func (b *DestroyerBuilder) WithImpersonation(impersonate rest.ImpersonationConfig) *DestroyerBuilder {
+	b.impersonate = impersonate
+	return b
+}
+
+// WithDiscoveryCache sets the discovery client and the RESTMapper to the
+// ones of the cache, so they can be shared with other Appliers and
+// Destroyers, and reused across runs.
+func (b *DestroyerBuilder) WithDiscoveryCache(cache *DiscoveryCache) *DestroyerBuilder {
+	b.discoClient = cache.DiscoveryClient()
+	b.mapper = cache.RESTMapper()
+	return b
+}
+
+// WithLogger sets the logger of the runs of the Destroyer. The logger can be
+// overridden per run with DestroyerOptions.Logger. If not set, the logger of
+// the context of the run is used, which falls back to the global klog
+// logger.
+func (b *DestroyerBuilder) WithLogger(logger logr.Logger) *DestroyerBuilder {
+	b.logger = logger
+	return b
+}
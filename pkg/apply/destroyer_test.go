@@ -5,12 +5,24 @@ package apply
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
@@ -393,3 +405,145 @@ func TestDestroyerCancel(t *testing.T) {
 		})
 	}
 }
+
+// labelFilter skips the objects without the label.
+type labelFilter struct {
+	key, value string
+}
+
+func (f labelFilter) Name() string {
+	return "labelFilter"
+}
+
+func (f labelFilter) Filter(obj *unstructured.Unstructured) error {
+	if obj.GetLabels()[f.key] != f.value {
+		return fmt.Errorf("label %s is not %s", f.key, f.value)
+	}
+	return nil
+}
+
+func TestDestroyerFilters(t *testing.T) {
+	testCases := map[string]struct {
+		options       DestroyerOptions
+		expectedNames []string
+	}{
+		"default": {
+			expectedNames: []string{
+				"PreventRemoveFilter",
+				"InventoryPolicyFilter",
+				"DependencyFilter",
+			},
+		},
+		"kept kinds and custom filters": {
+			options: DestroyerOptions{
+				KeepNamespaces: true,
+				DeleteFilters:  []filter.ValidationFilter{labelFilter{key: "team", value: "mine"}},
+			},
+			expectedNames: []string{
+				"PreventRemoveFilter",
+				"InventoryPolicyFilter",
+				"KeepKindsFilter",
+				"labelFilter",
+				"DependencyFilter",
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			invInfo := inventoryInfo{name: "inv", namespace: "default", id: "test"}.toWrapped()
+			taskContext := taskrunner.NewTaskContext(make(chan event.Event), cache.NewResourceCacheMap())
+			var names []string
+			for _, f := range destroyerFilters(invInfo, taskContext, tc.options) {
+				names = append(names, f.Name())
+			}
+			assert.Equal(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestInventoryTreeDestroyer(t *testing.T) {
+	invObj := func(id, parentID, childIDs string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace("test")
+		u.SetName(id)
+		labels := map[string]string{common.InventoryLabel: id}
+		if parentID != "" {
+			labels[common.ParentInventoryLabel] = parentID
+		}
+		u.SetLabels(labels)
+		if childIDs != "" {
+			u.SetAnnotations(map[string]string{common.ChildInventoriesAnnotation: childIDs})
+		}
+		return u
+	}
+
+	testCases := map[string]struct {
+		dryRun bool
+		// failed are the IDs of the inventories that the run doesn't delete
+		failed       []string
+		expectedRun  []string
+		expectedErrs []string
+	}{
+		"children are destroyed first": {
+			expectedRun: []string{"grandchild", "child", "orphan"},
+		},
+		"parent of a child that was not destroyed": {
+			failed:      []string{"grandchild"},
+			expectedRun: []string{"grandchild"},
+			expectedErrs: []string{
+				`inventory "child" was not destroyed: child inventories [grandchild] were not destroyed`,
+				`inventory "orphan" was not destroyed: child inventories [child] were not destroyed`,
+			},
+		},
+		"dry-run": {
+			dryRun:      true,
+			failed:      []string{"grandchild", "child", "orphan"},
+			expectedRun: []string{"grandchild", "child", "orphan"},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			orphanObj := invObj("orphan", "parent", "child")
+			dc := dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+				invObj("parent", "", ""),
+				orphanObj,
+				invObj("child", "orphan", "grandchild"),
+				invObj("grandchild", "child", ""),
+			)
+			failed := sets.New[string](tc.failed...)
+			var run []string
+			eventChannel := make(chan event.Event, 10)
+			tree := &inventoryTreeDestroyer{
+				client: dc,
+				dryRun: tc.dryRun,
+				run: func(inv inventory.Info) <-chan event.Event {
+					run = append(run, inv.ID())
+					if !failed.Has(inv.ID()) {
+						err := dc.Resource(corev1.SchemeGroupVersion.WithResource("configmaps")).Namespace(inv.Namespace()).
+							Delete(context.Background(), inv.Name(), metav1.DeleteOptions{})
+						require.NoError(t, err)
+					}
+					ch := make(chan event.Event)
+					close(ch)
+					return ch
+				},
+				eventChannel: eventChannel,
+				visited:      sets.New[string]("parent"),
+			}
+			tree.destroy(context.Background(), inventory.WrapInventoryInfoObj(orphanObj))
+			close(eventChannel)
+
+			assert.Equal(t, tc.expectedRun, run)
+			var errs []string
+			for e := range eventChannel {
+				require.Equal(t, event.ErrorType, e.Type)
+				errs = append(errs, e.ErrorEvent.Err.Error())
+			}
+			assert.Equal(t, tc.expectedErrs, errs)
+		})
+	}
+}
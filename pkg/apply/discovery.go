@@ -0,0 +1,54 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/restmapper"
+)
+
+// DiscoveryCache is a cached discovery client and a RESTMapper backed by it,
+// which can be shared by long-lived Appliers and Destroyers, so the
+// discovery documents are not fetched again for every run.
+// DiscoveryCache is safe for concurrent use.
+type DiscoveryCache struct {
+	discoClient discovery.CachedDiscoveryInterface
+	mapper      *restmapper.DeferredDiscoveryRESTMapper
+}
+
+// NewDiscoveryCache returns a DiscoveryCache that caches the discovery
+// documents of the client in memory.
+func NewDiscoveryCache(client discovery.DiscoveryInterface) *DiscoveryCache {
+	discoClient := memory.NewMemCacheClient(client)
+	return &DiscoveryCache{
+		discoClient: discoClient,
+		mapper:      restmapper.NewDeferredDiscoveryRESTMapper(discoClient),
+	}
+}
+
+// DiscoveryClient returns the cached discovery client.
+func (c *DiscoveryCache) DiscoveryClient() discovery.CachedDiscoveryInterface {
+	return c.discoClient
+}
+
+// RESTMapper returns the RESTMapper backed by the cached discovery client.
+func (c *DiscoveryCache) RESTMapper() meta.RESTMapper {
+	return c.mapper
+}
+
+// Invalidate clears the cache, so the discovery documents are fetched again
+// when they are next needed, like after CRDs were changed by another client.
+func (c *DiscoveryCache) Invalidate() {
+	// Resetting the mapper also invalidates its discovery client.
+	c.mapper.Reset()
+}
+
+// invalidateDiscovery clears the cached discovery documents of the
+// discovery client and the RESTMapper.
+func invalidateDiscovery(discoClient discovery.CachedDiscoveryInterface, mapper meta.RESTMapper) {
+	discoClient.Invalidate()
+	meta.MaybeResetRESTMapper(mapper)
+}
@@ -0,0 +1,54 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestDiscoveryCache(t *testing.T) {
+	fakeDisco := &fakediscovery.FakeDiscovery{
+		Fake: &clienttesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "apps/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "deployments", Namespaced: true, Kind: "Deployment"},
+					},
+				},
+			},
+		},
+	}
+	cache := NewDiscoveryCache(fakeDisco)
+	deploymentGK := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+
+	_, err := cache.RESTMapper().RESTMapping(deploymentGK)
+	require.NoError(t, err)
+	requests := len(fakeDisco.Actions())
+	assert.NotZero(t, requests)
+
+	// the discovery documents are cached
+	_, err = cache.RESTMapper().RESTMapping(deploymentGK)
+	require.NoError(t, err)
+	assert.Len(t, fakeDisco.Actions(), requests)
+
+	// new types are found after invalidation
+	fakeDisco.Resources = append(fakeDisco.Resources, &metav1.APIResourceList{
+		GroupVersion: "batch/v1",
+		APIResources: []metav1.APIResource{
+			{Name: "jobs", Namespaced: true, Kind: "Job"},
+		},
+	})
+	cache.Invalidate()
+	_, err = cache.RESTMapper().RESTMapping(schema.GroupKind{Group: "batch", Kind: "Job"})
+	require.NoError(t, err)
+	assert.Greater(t, len(fakeDisco.Actions()), requests)
+}
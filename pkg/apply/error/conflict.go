@@ -0,0 +1,203 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package error
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	conflictsPrefix       = "Apply failed with "
+	singleConflictPrefix  = "conflict with "
+	managerConflictPrefix = "conflicts with "
+	subresourcePrefix     = " with subresource "
+)
+
+// FieldConflict is a field of an object that is owned by another field
+// manager.
+type FieldConflict struct {
+	// Path is the path of the field, like ".spec.replicas".
+	Path string
+	// Manager is the name of the field manager that owns the field.
+	Manager string
+	// Subresource is the subresource the manager used to update the field,
+	// if any.
+	Subresource string
+}
+
+// ConflictError is returned when a server-side apply fails, because fields
+// of the object are owned by other field managers. Applying with
+// ForceConflicts takes the ownership of the fields.
+type ConflictError struct {
+	Conflicts []FieldConflict
+	err       error
+}
+
+func (e *ConflictError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the error returned by the apply.
+func (e *ConflictError) Unwrap() error {
+	return e.err
+}
+
+// Managers returns the sorted names of the field managers that own the
+// conflicting fields.
+func (e *ConflictError) Managers() []string {
+	seen := make(map[string]struct{})
+	var managers []string
+	for _, c := range e.Conflicts {
+		if _, found := seen[c.Manager]; found {
+			continue
+		}
+		seen[c.Manager] = struct{}{}
+		managers = append(managers, c.Manager)
+	}
+	sort.Strings(managers)
+	return managers
+}
+
+// ParseConflictError returns a ConflictError listing the conflicts of the
+// server-side apply that returned the error. The conflicts are read from
+// the details of the status error, or, if the status error has been
+// formatted into another error, from its message. It returns false if the
+// error is not a server-side apply conflict.
+func ParseConflictError(err error) (*ConflictError, bool) {
+	if err == nil {
+		return nil, false
+	}
+	var conflicts []FieldConflict
+	var apiStatus apierrors.APIStatus
+	if errors.As(err, &apiStatus) && apierrors.IsConflict(err) {
+		conflicts = conflictsFromStatus(apiStatus.Status())
+	} else {
+		conflicts = conflictsFromMessage(err.Error())
+	}
+	if len(conflicts) == 0 {
+		return nil, false
+	}
+	return &ConflictError{Conflicts: conflicts, err: err}, true
+}
+
+// conflictsFromStatus returns the conflicts listed in the causes of the
+// status.
+func conflictsFromStatus(status metav1.Status) []FieldConflict {
+	if status.Details == nil {
+		return nil
+	}
+	var conflicts []FieldConflict
+	for _, cause := range status.Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		manager, subresource, _, ok := parseManager(strings.TrimPrefix(cause.Message, singleConflictPrefix))
+		if !ok {
+			continue
+		}
+		conflicts = append(conflicts, FieldConflict{
+			Path:        cause.Field,
+			Manager:     manager,
+			Subresource: subresource,
+		})
+	}
+	return conflicts
+}
+
+// conflictsFromMessage returns the conflicts listed in the message of the
+// apply conflict status, which is formatted by the apiserver like:
+//
+//	Apply failed with 1 conflict: conflict with "manager" using apps/v1: .spec.replicas
+//
+// or, for multiple conflicts:
+//
+//	Apply failed with 2 conflicts: conflicts with "manager" using apps/v1:
+//	- .spec.replicas
+//	- .spec.paused
+func conflictsFromMessage(msg string) []FieldConflict {
+	i := strings.Index(msg, conflictsPrefix)
+	if i < 0 {
+		return nil
+	}
+	msg = msg[i+len(conflictsPrefix):]
+	i = strings.Index(msg, ": ")
+	if i < 0 {
+		return nil
+	}
+	lines := strings.Split(msg[i+len(": "):], "\n")
+
+	// A single conflict is on one line.
+	if strings.HasPrefix(lines[0], singleConflictPrefix) {
+		manager, subresource, rest, ok := parseManager(strings.TrimPrefix(lines[0], singleConflictPrefix))
+		if !ok {
+			return nil
+		}
+		i := strings.Index(rest, ": ")
+		if i < 0 {
+			return nil
+		}
+		return []FieldConflict{{
+			Path:        rest[i+len(": "):],
+			Manager:     manager,
+			Subresource: subresource,
+		}}
+	}
+
+	// Multiple conflicts are grouped by manager, with one path per line.
+	var conflicts []FieldConflict
+	var manager, subresource string
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, managerConflictPrefix):
+			var ok bool
+			manager, subresource, _, ok = parseManager(strings.TrimPrefix(line, managerConflictPrefix))
+			if !ok {
+				return conflicts
+			}
+		case strings.HasPrefix(line, "- ") && manager != "":
+			conflicts = append(conflicts, FieldConflict{
+				Path:        strings.TrimPrefix(line, "- "),
+				Manager:     manager,
+				Subresource: subresource,
+			})
+		default:
+			// End of the conflicts.
+			return conflicts
+		}
+	}
+	return conflicts
+}
+
+// parseManager parses the quoted manager, optionally followed by the quoted
+// subresource, at the start of s, and returns the rest of s.
+func parseManager(s string) (manager, subresource, rest string, ok bool) {
+	quoted, err := strconv.QuotedPrefix(s)
+	if err != nil {
+		return "", "", "", false
+	}
+	manager, err = strconv.Unquote(quoted)
+	if err != nil {
+		return "", "", "", false
+	}
+	rest = s[len(quoted):]
+	if strings.HasPrefix(rest, subresourcePrefix) {
+		rest = strings.TrimPrefix(rest, subresourcePrefix)
+		quoted, err := strconv.QuotedPrefix(rest)
+		if err != nil {
+			return "", "", "", false
+		}
+		subresource, err = strconv.Unquote(quoted)
+		if err != nil {
+			return "", "", "", false
+		}
+		rest = rest[len(quoted):]
+	}
+	return manager, subresource, rest, true
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package error
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestParseConflictError(t *testing.T) {
+	deployments := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	statusErr := &apierrors.StatusError{ErrStatus: metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    409,
+		Reason:  metav1.StatusReasonConflict,
+		Message: `Apply failed with 2 conflicts: conflicts with "kube-controller-manager" using apps/v1:` + "\n- .spec.replicas",
+		Details: &metav1.StatusDetails{
+			Group: deployments.Group,
+			Kind:  deployments.Resource,
+			Name:  "foo",
+			Causes: []metav1.StatusCause{
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "kube-controller-manager" using apps/v1`,
+					Field:   ".spec.replicas",
+				},
+				{
+					Type:    metav1.CauseTypeFieldManagerConflict,
+					Message: `conflict with "hpa" with subresource "scale" using autoscaling/v1 at 2023-01-02T03:04:05Z`,
+					Field:   ".spec.replicas",
+				},
+			},
+		},
+	}}
+
+	testCases := map[string]struct {
+		err               error
+		expectedOK        bool
+		expectedConflicts []FieldConflict
+	}{
+		"nil error": {
+			err:        nil,
+			expectedOK: false,
+		},
+		"not a conflict": {
+			err:        apierrors.NewNotFound(deployments, "foo"),
+			expectedOK: false,
+		},
+		"optimistic lock conflict": {
+			err:        apierrors.NewConflict(deployments, "foo", errors.New("the object has been modified")),
+			expectedOK: false,
+		},
+		"status error causes": {
+			err:        statusErr,
+			expectedOK: true,
+			expectedConflicts: []FieldConflict{
+				{Path: ".spec.replicas", Manager: "kube-controller-manager"},
+				{Path: ".spec.replicas", Manager: "hpa", Subresource: "scale"},
+			},
+		},
+		"wrapped status error": {
+			err:        fmt.Errorf("failed to apply: %w", statusErr),
+			expectedOK: true,
+			expectedConflicts: []FieldConflict{
+				{Path: ".spec.replicas", Manager: "kube-controller-manager"},
+				{Path: ".spec.replicas", Manager: "hpa", Subresource: "scale"},
+			},
+		},
+		"formatted single conflict": {
+			err: fmt.Errorf("%v\nPlease review the fields above--they currently have other managers.",
+				`Apply failed with 1 conflict: conflict with "kubectl-edit" using apps/v1: .spec.replicas`),
+			expectedOK: true,
+			expectedConflicts: []FieldConflict{
+				{Path: ".spec.replicas", Manager: "kubectl-edit"},
+			},
+		},
+		"formatted multiple conflicts": {
+			err: errors.New(`Apply failed with 3 conflicts: conflicts with "kubectl-edit" using apps/v1 at 2023-01-02T03:04:05Z:` +
+				"\n- .spec.replicas\n- .spec.template.spec.containers[name=\"nginx\"].image\n" +
+				`conflicts with "hpa" with subresource "scale":` + "\n- .spec.replicas\n" +
+				"Please review the fields above--they currently have other managers."),
+			expectedOK: true,
+			expectedConflicts: []FieldConflict{
+				{Path: ".spec.replicas", Manager: "kubectl-edit"},
+				{Path: `.spec.template.spec.containers[name="nginx"].image`, Manager: "kubectl-edit"},
+				{Path: ".spec.replicas", Manager: "hpa", Subresource: "scale"},
+			},
+		},
+		"malformed message": {
+			err:        errors.New("Apply failed with 1 conflict: conflict with kubectl"),
+			expectedOK: false,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			conflictErr, ok := ParseConflictError(tc.err)
+			assert.Equal(t, tc.expectedOK, ok)
+			if !tc.expectedOK {
+				assert.Nil(t, conflictErr)
+				return
+			}
+			assert.Equal(t, tc.expectedConflicts, conflictErr.Conflicts)
+			assert.Equal(t, tc.err.Error(), conflictErr.Error())
+			assert.True(t, errors.Is(conflictErr, tc.err))
+		})
+	}
+}
+
+func TestConflictError_Managers(t *testing.T) {
+	conflictErr := &ConflictError{
+		Conflicts: []FieldConflict{
+			{Path: ".spec.replicas", Manager: "kubectl-edit"},
+			{Path: ".spec.replicas", Manager: "hpa", Subresource: "scale"},
+			{Path: ".spec.paused", Manager: "kubectl-edit"},
+		},
+		err: errors.New("conflict"),
+	}
+	assert.Equal(t, []string{"hpa", "kubectl-edit"}, conflictErr.Managers())
+}
@@ -22,6 +22,11 @@ func (e *ApplyRunError) Error() string {
 	return e.err.Error()
 }
 
+// Unwrap returns the error returned by the apply.
+func (e *ApplyRunError) Unwrap() error {
+	return e.err
+}
+
 func NewApplyRunError(err error) *ApplyRunError {
 	return &ApplyRunError{err: err}
 }
@@ -0,0 +1,91 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package error
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+const (
+	immutableFieldMessage       = "field is immutable"
+	namespaceTerminatingMessage = "because it is being terminated"
+)
+
+// webhookDeniedPattern matches the message of errors returned by the
+// apiserver when an admission webhook denies a request.
+var webhookDeniedPattern = regexp.MustCompile(`admission webhook "([^"]+)" denied the request`)
+
+// HintFor returns a Hint for the error, or nil if the error is not a
+// well-known failure. The error is matched by type, if available, and
+// otherwise by message, since kubectl formats some api errors into new
+// errors.
+func HintFor(err error) *event.Hint {
+	if err == nil {
+		return nil
+	}
+	var policyErr *inventory.PolicyPreventedActuationError
+	var unknownTypeErr *UnknownTypeError
+	msg := err.Error()
+	webhook := webhookDeniedPattern.FindStringSubmatch(msg)
+	switch {
+	case errors.As(err, &policyErr):
+		return inventoryOverlapHint(policyErr)
+	case meta.IsNoMatchError(err), errors.As(err, &unknownTypeErr):
+		return &event.Hint{
+			Reason: event.MissingCRDHint,
+			Message: "The kind of the object is not served by the cluster. " +
+				"Install the CRD that defines it, or apply the CRD in the same run, before the object.",
+		}
+	case webhook != nil:
+		return &event.Hint{
+			Reason: event.WebhookDeniedHint,
+			Message: fmt.Sprintf("The admission webhook %q rejected the object. "+
+				"Change the object to comply with the policy enforced by the webhook, "+
+				"or ask the owner of the webhook to allow it.", webhook[1]),
+		}
+	case isNamespaceTerminating(err), strings.Contains(msg, namespaceTerminatingMessage):
+		return &event.Hint{
+			Reason: event.NamespaceTerminatingHint,
+			Message: "The namespace of the object is being deleted. " +
+				"Wait for the deletion of the namespace to finish, then apply again to recreate it.",
+		}
+	case strings.Contains(msg, immutableFieldMessage):
+		return &event.Hint{
+			Reason: event.ImmutableFieldHint,
+			Message: "The apply changes a field that cannot be changed after the object is created. " +
+				"Revert the change, or delete the object so that it is recreated with the new value.",
+		}
+	default:
+		return nil
+	}
+}
+
+func inventoryOverlapHint(err *inventory.PolicyPreventedActuationError) *event.Hint {
+	if err.Status == inventory.Empty {
+		return &event.Hint{
+			Reason: event.InventoryOverlapHint,
+			Message: "The object already exists, but is not owned by any inventory. " +
+				"To take ownership of it, use the AdoptIfNoInventory or AdoptAll inventory policy.",
+		}
+	}
+	return &event.Hint{
+		Reason: event.InventoryOverlapHint,
+		Message: "The object is owned by another inventory. " +
+			"Remove it from one of the inventories, or, to take ownership of it, use the AdoptAll inventory policy.",
+	}
+}
+
+func isNamespaceTerminating(err error) bool {
+	_, found := apierrors.StatusCause(err, corev1.NamespaceTerminatingCause)
+	return found
+}
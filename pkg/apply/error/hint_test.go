@@ -0,0 +1,124 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package error
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+func TestHintFor(t *testing.T) {
+	deployments := schema.GroupResource{Group: "apps", Resource: "deployments"}
+	deploymentKind := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+
+	testCases := map[string]struct {
+		err            error
+		expectedReason event.HintReason
+	}{
+		"nil error": {
+			err: nil,
+		},
+		"unrecognized error": {
+			err: errors.New("failed"),
+		},
+		"object owned by another inventory": {
+			err: &inventory.PolicyPreventedActuationError{
+				Strategy: actuation.ActuationStrategyApply,
+				Policy:   inventory.PolicyMustMatch,
+				Status:   inventory.NoMatch,
+			},
+			expectedReason: event.InventoryOverlapHint,
+		},
+		"object not owned by any inventory": {
+			err: &inventory.PolicyPreventedActuationError{
+				Strategy: actuation.ActuationStrategyApply,
+				Policy:   inventory.PolicyMustMatch,
+				Status:   inventory.Empty,
+			},
+			expectedReason: event.InventoryOverlapHint,
+		},
+		"no match": {
+			err:            &meta.NoKindMatchError{GroupKind: schema.GroupKind{Group: "example.com", Kind: "Foo"}},
+			expectedReason: event.MissingCRDHint,
+		},
+		"unknown type": {
+			err:            NewUnknownTypeError(errors.New("unknown type")),
+			expectedReason: event.MissingCRDHint,
+		},
+		"immutable field": {
+			err: NewApplyRunError(apierrors.NewInvalid(deploymentKind, "foo", field.ErrorList{
+				field.Invalid(field.NewPath("spec", "selector"), nil, "field is immutable"),
+			})),
+			expectedReason: event.ImmutableFieldHint,
+		},
+		"immutable field formatted by kubectl": {
+			err: fmt.Errorf("error when patching %q: %v", "deployment.yaml",
+				apierrors.NewInvalid(deploymentKind, "foo", field.ErrorList{
+					field.Invalid(field.NewPath("spec", "selector"), nil, "field is immutable"),
+				})),
+			expectedReason: event.ImmutableFieldHint,
+		},
+		"namespace terminating": {
+			err: &apierrors.StatusError{ErrStatus: metav1.Status{
+				Status:  metav1.StatusFailure,
+				Code:    403,
+				Reason:  metav1.StatusReasonForbidden,
+				Message: `deployments.apps "foo" is forbidden: unable to create new content in namespace bar because it is being terminated`,
+				Details: &metav1.StatusDetails{
+					Name:  "foo",
+					Group: deployments.Group,
+					Kind:  deployments.Resource,
+					Causes: []metav1.StatusCause{{
+						Type:    corev1.NamespaceTerminatingCause,
+						Message: "namespace bar is being terminated",
+						Field:   "metadata.namespace",
+					}},
+				},
+			}},
+			expectedReason: event.NamespaceTerminatingHint,
+		},
+		"namespace terminating formatted by kubectl": {
+			err:            errors.New(`deployments.apps "foo" is forbidden: unable to create new content in namespace bar because it is being terminated`),
+			expectedReason: event.NamespaceTerminatingHint,
+		},
+		"webhook denied": {
+			err: apierrors.NewForbidden(deployments, "foo",
+				errors.New(`admission webhook "validate.example.com" denied the request: replicas must be odd`)),
+			expectedReason: event.WebhookDeniedHint,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			hint := HintFor(tc.err)
+			if tc.expectedReason == "" {
+				assert.Nil(t, hint)
+				return
+			}
+			if assert.NotNil(t, hint) {
+				assert.Equal(t, tc.expectedReason, hint.Reason)
+				assert.NotEmpty(t, hint.Message)
+			}
+		})
+	}
+}
+
+func TestHintFor_WebhookName(t *testing.T) {
+	hint := HintFor(errors.New(`admission webhook "validate.example.com" denied the request: no`))
+	if assert.NotNil(t, hint) {
+		assert.Contains(t, hint.Message, `"validate.example.com"`)
+	}
+}
@@ -6,10 +6,12 @@ package event
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/applymethod"
 )
 
 // Type determines the type of events that are available.
@@ -37,6 +39,13 @@ type Event struct {
 	// Type is the type of event.
 	Type Type
 
+	// SchemaVersion is the version of the schema of the event. Empty means
+	// CurrentSchemaVersion. See ConvertTo.
+	SchemaVersion SchemaVersion
+
+	// Timestamp is the time the event was sent.
+	Timestamp time.Time
+
 	// InitEvent contains information about which resources will
 	// be applied/pruned.
 	InitEvent InitEvent
@@ -98,6 +107,108 @@ func (e Event) String() string {
 	return sb.String()
 }
 
+// WithTiming returns a copy of the event with the Timing of the action group
+// or object event set, for an operation that started at start and ended now.
+// Other types of events are returned unchanged.
+func (e Event) WithTiming(start time.Time) Event {
+	timing := NewTiming(start, time.Now())
+	switch e.Type {
+	case ActionGroupType:
+		e.ActionGroupEvent.Timing = timing
+	case ApplyType:
+		e.ApplyEvent.Timing = timing
+	case PruneType:
+		e.PruneEvent.Timing = timing
+	case DeleteType:
+		e.DeleteEvent.Timing = timing
+	case WaitType:
+		e.WaitEvent.Timing = timing
+	}
+	return e
+}
+
+// Slim returns a copy of the event without the objects, keeping the
+// identifiers, statuses, and errors.
+func (e Event) Slim() Event {
+	e.ApplyEvent.Resource = nil
+	e.ApplyEvent.Diff = nil
+	e.StatusEvent.Resource = nil
+	e.StatusEvent.PollResourceInfo = slimResourceStatus(e.StatusEvent.PollResourceInfo)
+	e.PruneEvent.Object = nil
+	e.DeleteEvent.Object = nil
+	return e
+}
+
+// slimResourceStatus returns a copy of the ResourceStatus without the
+// objects of the resource and the generated resources.
+func slimResourceStatus(rs *pollevent.ResourceStatus) *pollevent.ResourceStatus {
+	if rs == nil {
+		return nil
+	}
+	slim := *rs
+	slim.Resource = nil
+	if len(rs.GeneratedResources) > 0 {
+		slim.GeneratedResources = make(pollevent.ResourceStatuses, len(rs.GeneratedResources))
+		for i, generated := range rs.GeneratedResources {
+			slim.GeneratedResources[i] = slimResourceStatus(generated)
+		}
+	}
+	return &slim
+}
+
+// Timing records when an operation started and ended.
+type Timing struct {
+	// StartTime is the time the operation started.
+	StartTime time.Time
+	// EndTime is the time the operation ended.
+	EndTime time.Time
+	// Duration is the time between StartTime and EndTime.
+	Duration time.Duration
+}
+
+// NewTiming returns the Timing of an operation that started at start and
+// ended at end.
+func NewTiming(start, end time.Time) Timing {
+	return Timing{
+		StartTime: start,
+		EndTime:   end,
+		Duration:  end.Sub(start),
+	}
+}
+
+// HintReason identifies a well-known class of failures.
+type HintReason string
+
+const (
+	// InventoryOverlapHint means the object is not owned by the inventory
+	// that is being applied or deleted.
+	InventoryOverlapHint HintReason = "InventoryOverlap"
+	// ImmutableFieldHint means the apply changed a field that can only be
+	// set when the object is created.
+	ImmutableFieldHint HintReason = "ImmutableField"
+	// MissingCRDHint means the server does not serve the kind of the
+	// object, usually because its CRD is not installed.
+	MissingCRDHint HintReason = "MissingCRD"
+	// NamespaceTerminatingHint means the namespace of the object is being
+	// deleted.
+	NamespaceTerminatingHint HintReason = "NamespaceTerminating"
+	// WebhookDeniedHint means an admission webhook rejected the object.
+	WebhookDeniedHint HintReason = "WebhookDenied"
+)
+
+// Hint describes the usual fix for a well-known class of failures, so it can
+// be shown to users instead of, or along with, the error message.
+type Hint struct {
+	// Reason identifies the class of the failure.
+	Reason HintReason
+	// Message describes how to fix the failure.
+	Message string
+}
+
+func (h Hint) String() string {
+	return fmt.Sprintf("%s: %s", h.Reason, h.Message)
+}
+
 type InitEvent struct {
 	ActionGroups ActionGroupList
 }
@@ -150,6 +261,9 @@ func (ag ActionGroup) String() string {
 
 type ErrorEvent struct {
 	Err error
+	// Hint describes the usual fix for the Error, if it is a well-known
+	// failure.
+	Hint *Hint
 }
 
 // String returns a string suitable for logging
@@ -172,6 +286,8 @@ type WaitEvent struct {
 	GroupName  string
 	Identifier object.ObjMetadata
 	Status     WaitEventStatus
+	// Timing is the time from the start of the wait to the event.
+	Timing
 }
 
 // String returns a string suitable for logging
@@ -192,6 +308,13 @@ type ActionGroupEvent struct {
 	GroupName string
 	Action    ResourceAction
 	Status    ActionGroupEventStatus
+	// Inventory is what happened to the inventory object, on the Finished
+	// event of the action group that updates or deletes it at the end of
+	// the run.
+	Inventory InventoryDisposition
+	// Timing is the time the action group ran. For Started events, only
+	// the StartTime is set.
+	Timing
 }
 
 // String returns a string suitable for logging
@@ -200,22 +323,130 @@ func (age ActionGroupEvent) String() string {
 		age.GroupName, age.Action, age.Status)
 }
 
+// InventoryDisposition is what happened to the inventory object at the end
+// of a run.
+//
+//go:generate stringer -type=InventoryDisposition -linecomment
+type InventoryDisposition int
+
+const (
+	// NoInventoryDisposition means the action group did not update or
+	// delete the inventory.
+	NoInventoryDisposition InventoryDisposition = iota // None
+	// InventoryUpdated means the inventory was updated with the objects
+	// still applied, like the objects that failed to be deleted.
+	InventoryUpdated // Updated
+	// InventoryDeleted means all the objects were deleted, and the
+	// inventory as well.
+	InventoryDeleted // Deleted
+	// InventoryRetained means all the objects were deleted, but the empty
+	// inventory was kept, because DestroyerOptions.KeepInventory is set.
+	InventoryRetained // Retained
+)
+
+// SkipReason identifies why an object was skipped, so consumers can branch
+// on the cause without parsing the error message. The Error of the event
+// describes the details.
+//
+//go:generate stringer -type=SkipReason -linecomment
+type SkipReason int
+
+const (
+	// NoSkipReason means the object was not skipped.
+	NoSkipReason SkipReason = iota // None
+	// UnknownSkipReason means the object was skipped by an unrecognized
+	// filter.
+	UnknownSkipReason // Unknown
+	// PolicyPreventedOwnershipChange means the inventory policy prevented
+	// actuating an object owned by another inventory, or not owned by any.
+	PolicyPreventedOwnershipChange // PolicyPreventedOwnershipChange
+	// AnnotationPreventedDeletion means the lifecycle annotation of the
+	// object prevented its deletion. The object is abandoned.
+	AnnotationPreventedDeletion // AnnotationPreventedDeletion
+	// ApplyPreventedDeletion means the object was not deleted, because it
+	// was applied by the same run.
+	ApplyPreventedDeletion // ApplyPreventedDeletion
+	// NamespaceInUse means the namespace was not deleted, because it
+	// contains objects that are still applied.
+	NamespaceInUse // NamespaceInUse
+	// DependencyFailed means a dependency or dependent of the object failed
+	// or was skipped, so the object was not actuated.
+	DependencyFailed // DependencyFailed
+	// DependencyMismatch means a dependency or dependent of the object is
+	// scheduled for the opposite actuation (apply or delete).
+	DependencyMismatch // DependencyMismatch
+	// KindPreventedDeletion means the kind of the object is kept by the
+	// Destroyer, like Namespaces and CRDs. The object is abandoned.
+	KindPreventedDeletion // KindPreventedDeletion
+	// StaleInventoryEntry means the object is in the inventory, but no
+	// longer exists in the cluster, or its type is not served anymore. The
+	// entry is removed from the inventory.
+	StaleInventoryEntry // StaleInventoryEntry
+	// ScopePreventedDeletion means the object is out of the scope of the
+	// prune, like its kind is not in the prune allowlist or its namespace
+	// is skipped. The object stays in the inventory.
+	ScopePreventedDeletion // ScopePreventedDeletion
+)
+
 //go:generate stringer -type=ApplyEventStatus -linecomment
 type ApplyEventStatus int
 
 const (
+	// ApplyPending is sent before the apply of an object is retried, with
+	// the Error of the failed attempt.
 	ApplyPending    ApplyEventStatus = iota // Pending
 	ApplySuccessful                         // Successful
 	ApplySkipped                            // Skipped
 	ApplyFailed                             // Failed
 )
 
+//go:generate stringer -type=ApplyOperation -linecomment
+type ApplyOperation int
+
+const (
+	// UnknownApplyOperation means the change made by the apply is not known,
+	// like for objects applied server-side without a diff.
+	UnknownApplyOperation ApplyOperation = iota // Unknown
+	// ApplyCreated means the object did not exist, and was created.
+	ApplyCreated // Created
+	// ApplyConfigured means the object existed, and was changed.
+	ApplyConfigured // Configured
+	// ApplyUnchanged means the object existed, and was not changed.
+	ApplyUnchanged // Unchanged
+)
+
 type ApplyEvent struct {
 	GroupName  string
 	Identifier object.ObjMetadata
 	Status     ApplyEventStatus
-	Resource   *unstructured.Unstructured
-	Error      error
+	// Resource is the object returned by the server, if the Status is
+	// ApplySuccessful, including the fields defaulted by the server and
+	// the UID. With a client-side dry-run, it is the local object. If the
+	// object was skipped, it is the local object.
+	Resource *unstructured.Unstructured
+	Error    error
+	// Warnings are the warnings sent by the server when the object was
+	// applied, like deprecation notices and admission warnings.
+	Warnings []string
+	// Diff lists the fields changed by the apply, compared to the object
+	// in the cluster before the apply. Only set if field diffs are enabled
+	// and the object already existed. Sensitive values are redacted.
+	Diff []object.FieldDiff
+	// Operation is the change made by the apply, if the Status is
+	// ApplySuccessful. With a dry-run, it is the change the apply would
+	// make.
+	Operation ApplyOperation
+	// SkipReason identifies why the object was skipped, if the Status is
+	// ApplySkipped.
+	SkipReason SkipReason
+	// Hint describes the usual fix for the Error, if it is a well-known
+	// failure.
+	Hint *Hint
+	// Method is the method the object was applied with, if the Status is
+	// ApplySuccessful, or if the apply failed.
+	Method applymethod.Method
+	// Timing is the time it took to process the object.
+	Timing
 }
 
 // String returns a string suitable for logging
@@ -269,6 +500,14 @@ type PruneEvent struct {
 	Status     PruneEventStatus
 	Object     *unstructured.Unstructured
 	Error      error
+	// SkipReason identifies why the object was skipped, if the Status is
+	// Skipped.
+	SkipReason SkipReason
+	// Hint describes the usual fix for the Error, if it is a well-known
+	// failure.
+	Hint *Hint
+	// Timing is the time it took to process the object.
+	Timing
 }
 
 // String returns a string suitable for logging
@@ -297,6 +536,14 @@ type DeleteEvent struct {
 	Status     DeleteEventStatus
 	Object     *unstructured.Unstructured
 	Error      error
+	// SkipReason identifies why the object was skipped, if the Status is
+	// Skipped.
+	SkipReason SkipReason
+	// Hint describes the usual fix for the Error, if it is a well-known
+	// failure.
+	Hint *Hint
+	// Timing is the time it took to process the object.
+	Timing
 }
 
 // String returns a string suitable for logging
@@ -312,13 +559,28 @@ func (de DeleteEvent) String() string {
 type ValidationEvent struct {
 	Identifiers object.ObjMetadataSet
 	Error       error
+	// Severity is WarningSeverity if the objects are valid, and are still
+	// applied or deleted.
+	Severity ValidationSeverity
 }
 
+//go:generate stringer -type=ValidationSeverity -linecomment
+type ValidationSeverity int
+
+const (
+	// ErrorSeverity means the objects are invalid, so they are not applied
+	// or deleted.
+	ErrorSeverity ValidationSeverity = iota // Error
+	// WarningSeverity means the objects are valid, but have a problem, like
+	// a deprecated API version or a warning of an admission rule.
+	WarningSeverity // Warning
+)
+
 // String returns a string suitable for logging
 func (ve ValidationEvent) String() string {
 	if ve.Error != nil {
-		return fmt.Sprintf("ValidationEvent{ Identifiers: %+v, Error: %q }",
-			ve.Identifiers, ve.Error)
+		return fmt.Sprintf("ValidationEvent{ Identifiers: %+v, Severity: %q, Error: %q }",
+			ve.Identifiers, ve.Severity, ve.Error)
 	}
 	return fmt.Sprintf("ValidationEvent{ Identifiers: %+v }",
 		ve.Identifiers)
@@ -0,0 +1,183 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestEventSlim(t *testing.T) {
+	deployment := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      "foo",
+				"namespace": "default",
+			},
+		},
+	}
+	replicaSet := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "ReplicaSet",
+			"metadata": map[string]interface{}{
+				"name":      "foo-123",
+				"namespace": "default",
+			},
+		},
+	}
+	id := object.UnstructuredToObjMetadata(deployment)
+	rsID := object.UnstructuredToObjMetadata(replicaSet)
+	resourceStatus := &pollevent.ResourceStatus{
+		Identifier: id,
+		Status:     status.CurrentStatus,
+		Resource:   deployment,
+		Message:    "Deployment is available",
+		GeneratedResources: pollevent.ResourceStatuses{
+			{
+				Identifier: rsID,
+				Status:     status.CurrentStatus,
+				Resource:   replicaSet,
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		event    Event
+		expected Event
+	}{
+		"apply event": {
+			event: Event{
+				Type:       ApplyType,
+				ApplyEvent: ApplyEvent{Identifier: id, Status: ApplySuccessful, Resource: deployment},
+			},
+			expected: Event{
+				Type:       ApplyType,
+				ApplyEvent: ApplyEvent{Identifier: id, Status: ApplySuccessful},
+			},
+		},
+		"status event": {
+			event: Event{
+				Type: StatusType,
+				StatusEvent: StatusEvent{
+					Identifier:       id,
+					PollResourceInfo: resourceStatus,
+					Resource:         deployment,
+				},
+			},
+			expected: Event{
+				Type: StatusType,
+				StatusEvent: StatusEvent{
+					Identifier: id,
+					PollResourceInfo: &pollevent.ResourceStatus{
+						Identifier: id,
+						Status:     status.CurrentStatus,
+						Message:    "Deployment is available",
+						GeneratedResources: pollevent.ResourceStatuses{
+							{
+								Identifier: rsID,
+								Status:     status.CurrentStatus,
+							},
+						},
+					},
+				},
+			},
+		},
+		"prune event": {
+			event: Event{
+				Type:       PruneType,
+				PruneEvent: PruneEvent{Identifier: id, Status: PruneSuccessful, Object: deployment},
+			},
+			expected: Event{
+				Type:       PruneType,
+				PruneEvent: PruneEvent{Identifier: id, Status: PruneSuccessful},
+			},
+		},
+		"delete event": {
+			event: Event{
+				Type:        DeleteType,
+				DeleteEvent: DeleteEvent{Identifier: id, Status: DeleteSuccessful, Object: deployment},
+			},
+			expected: Event{
+				Type:        DeleteType,
+				DeleteEvent: DeleteEvent{Identifier: id, Status: DeleteSuccessful},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.event.Slim())
+		})
+	}
+
+	// The original status must not be modified.
+	assert.Equal(t, deployment, resourceStatus.Resource)
+	assert.Equal(t, replicaSet, resourceStatus.GeneratedResources[0].Resource)
+}
+
+func TestEventWithTiming(t *testing.T) {
+	start := time.Now().Add(-time.Second)
+
+	testCases := map[string]struct {
+		event        Event
+		expectTiming func(Event) Timing
+	}{
+		"action group event": {
+			event:        Event{Type: ActionGroupType},
+			expectTiming: func(e Event) Timing { return e.ActionGroupEvent.Timing },
+		},
+		"apply event": {
+			event:        Event{Type: ApplyType},
+			expectTiming: func(e Event) Timing { return e.ApplyEvent.Timing },
+		},
+		"prune event": {
+			event:        Event{Type: PruneType},
+			expectTiming: func(e Event) Timing { return e.PruneEvent.Timing },
+		},
+		"delete event": {
+			event:        Event{Type: DeleteType},
+			expectTiming: func(e Event) Timing { return e.DeleteEvent.Timing },
+		},
+		"wait event": {
+			event:        Event{Type: WaitType},
+			expectTiming: func(e Event) Timing { return e.WaitEvent.Timing },
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			timing := tc.expectTiming(tc.event.WithTiming(start))
+			assert.Equal(t, start, timing.StartTime)
+			assert.True(t, timing.EndTime.After(start))
+			assert.Equal(t, timing.EndTime.Sub(start), timing.Duration)
+			assert.GreaterOrEqual(t, timing.Duration, time.Second)
+			// The original event is not modified.
+			assert.Equal(t, Timing{}, tc.expectTiming(tc.event))
+		})
+	}
+}
+
+func TestEventWithTiming_OtherTypes(t *testing.T) {
+	e := Event{Type: StatusType}
+	assert.Equal(t, e, e.WithTiming(time.Now()))
+}
+
+func TestNewTiming(t *testing.T) {
+	start := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	end := start.Add(1500 * time.Millisecond)
+	assert.Equal(t, Timing{
+		StartTime: start,
+		EndTime:   end,
+		Duration:  1500 * time.Millisecond,
+	}, NewTiming(start, end))
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"fmt"
+	"time"
+)
+
+// SchemaVersion is the version of the Event schema. Fields and enum values
+// will not be removed or renamed, and enum values will not be added to the
+// existing enums, without incrementing the version. Consumers that switch on
+// enum values can convert events to the version they were written for with
+// ConvertTo.
+type SchemaVersion string
+
+const (
+	// V1SchemaVersion is the schema of events before timestamps, timings,
+	// skip reasons, hints, warnings, diffs, and apply methods were added.
+	V1SchemaVersion SchemaVersion = "v1"
+	// V2SchemaVersion is the schema with timestamps, timings, skip
+	// reasons, hints, warnings, diffs, and apply methods.
+	V2SchemaVersion SchemaVersion = "v2"
+	// V3SchemaVersion is the schema with the KindPreventedDeletion and
+	// StaleInventoryEntry skip reasons, and inventory dispositions.
+	V3SchemaVersion SchemaVersion = "v3"
+	// V4SchemaVersion is the schema with the ScopePreventedDeletion skip
+	// reason, apply operations, and validation severities.
+	V4SchemaVersion SchemaVersion = "v4"
+
+	// CurrentSchemaVersion is the schema of the events sent by the Applier
+	// and Destroyer.
+	CurrentSchemaVersion = V4SchemaVersion
+)
+
+// schemaVersions lists the known versions, from oldest to newest.
+var schemaVersions = []SchemaVersion{
+	V1SchemaVersion,
+	V2SchemaVersion,
+	V3SchemaVersion,
+	V4SchemaVersion,
+}
+
+// schemaConversion converts events between a version and the next one.
+type schemaConversion struct {
+	up   func(Event) Event
+	down func(Event) Event
+}
+
+// schemaConversions maps each version, except the oldest, to the conversion
+// between it and the previous version.
+var schemaConversions = map[SchemaVersion]schemaConversion{
+	V2SchemaVersion: {up: upgradeToV2, down: downgradeToV1},
+	V3SchemaVersion: {up: upgradeToV3, down: downgradeToV2},
+	V4SchemaVersion: {up: upgradeToV4, down: downgradeToV3},
+}
+
+// Version returns the schema version of the event. Events with an empty
+// SchemaVersion, like the events sent by the Applier and Destroyer, are of
+// the CurrentSchemaVersion.
+func (e Event) Version() SchemaVersion {
+	if e.SchemaVersion == "" {
+		return CurrentSchemaVersion
+	}
+	return e.SchemaVersion
+}
+
+// ConvertTo returns a copy of the event converted to the schema version.
+// Fields that do not exist in an older version are cleared, and fields that
+// do not exist in a newer version are set to their closest value. Returns an
+// error if either version is unknown.
+func (e Event) ConvertTo(version SchemaVersion) (Event, error) {
+	from := indexOfSchemaVersion(e.Version())
+	if from < 0 {
+		return Event{}, fmt.Errorf("unknown event schema version: %q", e.Version())
+	}
+	to := indexOfSchemaVersion(version)
+	if to < 0 {
+		return Event{}, fmt.Errorf("unknown event schema version: %q", version)
+	}
+	for i := from; i < to; i++ {
+		e = schemaConversions[schemaVersions[i+1]].up(e)
+	}
+	for i := from; i > to; i-- {
+		e = schemaConversions[schemaVersions[i]].down(e)
+	}
+	e.SchemaVersion = version
+	return e, nil
+}
+
+func indexOfSchemaVersion(version SchemaVersion) int {
+	for i, v := range schemaVersions {
+		if v == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// upgradeToV2 sets the skip reason of skipped objects, which is unknown in
+// v1. The times and hints are left unset.
+func upgradeToV2(e Event) Event {
+	if e.ApplyEvent.Status == ApplySkipped && e.ApplyEvent.SkipReason == NoSkipReason {
+		e.ApplyEvent.SkipReason = UnknownSkipReason
+	}
+	if e.PruneEvent.Status == PruneSkipped && e.PruneEvent.SkipReason == NoSkipReason {
+		e.PruneEvent.SkipReason = UnknownSkipReason
+	}
+	if e.DeleteEvent.Status == DeleteSkipped && e.DeleteEvent.SkipReason == NoSkipReason {
+		e.DeleteEvent.SkipReason = UnknownSkipReason
+	}
+	return e
+}
+
+// downgradeToV1 clears the fields added in v2.
+func downgradeToV1(e Event) Event {
+	e.Timestamp = time.Time{}
+	e.ErrorEvent.Hint = nil
+	e.ActionGroupEvent.Timing = Timing{}
+	e.ApplyEvent.Warnings = nil
+	e.ApplyEvent.Diff = nil
+	e.ApplyEvent.Method = ""
+	e.ApplyEvent.SkipReason = NoSkipReason
+	e.ApplyEvent.Hint = nil
+	e.ApplyEvent.Timing = Timing{}
+	e.PruneEvent.SkipReason = NoSkipReason
+	e.PruneEvent.Hint = nil
+	e.PruneEvent.Timing = Timing{}
+	e.DeleteEvent.SkipReason = NoSkipReason
+	e.DeleteEvent.Hint = nil
+	e.DeleteEvent.Timing = Timing{}
+	e.WaitEvent.Timing = Timing{}
+	return e
+}
+
+// upgradeToV3 leaves the events unchanged, since v3 only adds skip reasons
+// and a field.
+func upgradeToV3(e Event) Event {
+	return e
+}
+
+// downgradeToV2 replaces the skip reasons added in v3 with
+// UnknownSkipReason, and clears the inventory disposition.
+func downgradeToV2(e Event) Event {
+	e.ActionGroupEvent.Inventory = NoInventoryDisposition
+	if isV3SkipReason(e.PruneEvent.SkipReason) {
+		e.PruneEvent.SkipReason = UnknownSkipReason
+	}
+	if isV3SkipReason(e.DeleteEvent.SkipReason) {
+		e.DeleteEvent.SkipReason = UnknownSkipReason
+	}
+	return e
+}
+
+func isV3SkipReason(reason SkipReason) bool {
+	return reason == KindPreventedDeletion || reason == StaleInventoryEntry
+}
+
+// upgradeToV4 leaves the events unchanged, since v4 only adds a skip reason
+// and fields.
+func upgradeToV4(e Event) Event {
+	return e
+}
+
+// downgradeToV3 replaces the skip reason added in v4 with
+// UnknownSkipReason, and clears the apply operation and the validation
+// severity. Validation warnings become validation errors, since all the
+// validation events of v3 are errors.
+func downgradeToV3(e Event) Event {
+	e.ApplyEvent.Operation = UnknownApplyOperation
+	if e.Type == ValidationType {
+		e.ValidationEvent.Severity = ErrorSeverity
+	}
+	if e.PruneEvent.SkipReason == ScopePreventedDeletion {
+		e.PruneEvent.SkipReason = UnknownSkipReason
+	}
+	return e
+}
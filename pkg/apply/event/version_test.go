@@ -0,0 +1,238 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestEventConvertTo(t *testing.T) {
+	id := object.ObjMetadata{Name: "foo", Namespace: "default"}
+	now := time.Now()
+	timing := NewTiming(now.Add(-time.Second), now)
+	applyErr := errors.New("skipped")
+
+	testCases := map[string]struct {
+		event       Event
+		version     SchemaVersion
+		expected    Event
+		expectedErr string
+	}{
+		"current to v1": {
+			event: Event{
+				Type:      ApplyType,
+				Timestamp: now,
+				ApplyEvent: ApplyEvent{
+					Identifier: id,
+					Status:     ApplySkipped,
+					Error:      applyErr,
+					Warnings:   []string{"deprecated"},
+					SkipReason: PolicyPreventedOwnershipChange,
+					Hint:       &Hint{Reason: InventoryOverlapHint},
+					Timing:     timing,
+				},
+			},
+			version: V1SchemaVersion,
+			expected: Event{
+				Type:          ApplyType,
+				SchemaVersion: V1SchemaVersion,
+				ApplyEvent: ApplyEvent{
+					Identifier: id,
+					Status:     ApplySkipped,
+					Error:      applyErr,
+				},
+			},
+		},
+		"v1 to v2": {
+			event: Event{
+				Type:          PruneType,
+				SchemaVersion: V1SchemaVersion,
+				PruneEvent: PruneEvent{
+					Identifier: id,
+					Status:     PruneSkipped,
+				},
+			},
+			version: V2SchemaVersion,
+			expected: Event{
+				Type:          PruneType,
+				SchemaVersion: V2SchemaVersion,
+				PruneEvent: PruneEvent{
+					Identifier: id,
+					Status:     PruneSkipped,
+					SkipReason: UnknownSkipReason,
+				},
+			},
+		},
+		"current stale inventory entry to v2": {
+			event: Event{
+				Type: PruneType,
+				PruneEvent: PruneEvent{
+					Identifier: id,
+					Status:     PruneSkipped,
+					SkipReason: StaleInventoryEntry,
+				},
+			},
+			version: V2SchemaVersion,
+			expected: Event{
+				Type:          PruneType,
+				SchemaVersion: V2SchemaVersion,
+				PruneEvent: PruneEvent{
+					Identifier: id,
+					Status:     PruneSkipped,
+					SkipReason: UnknownSkipReason,
+				},
+			},
+		},
+		"current scope prevented deletion to v3": {
+			event: Event{
+				Type: PruneType,
+				PruneEvent: PruneEvent{
+					Identifier: id,
+					Status:     PruneSkipped,
+					SkipReason: ScopePreventedDeletion,
+				},
+			},
+			version: V3SchemaVersion,
+			expected: Event{
+				Type:          PruneType,
+				SchemaVersion: V3SchemaVersion,
+				PruneEvent: PruneEvent{
+					Identifier: id,
+					Status:     PruneSkipped,
+					SkipReason: UnknownSkipReason,
+				},
+			},
+		},
+		"current apply operation to v3": {
+			event: Event{
+				Type: ApplyType,
+				ApplyEvent: ApplyEvent{
+					Identifier: id,
+					Status:     ApplySuccessful,
+					Diff:       []object.FieldDiff{{Path: ".spec.replicas", Old: int64(1), New: int64(3)}},
+					Operation:  ApplyConfigured,
+				},
+			},
+			version: V3SchemaVersion,
+			expected: Event{
+				Type:          ApplyType,
+				SchemaVersion: V3SchemaVersion,
+				ApplyEvent: ApplyEvent{
+					Identifier: id,
+					Status:     ApplySuccessful,
+					Diff:       []object.FieldDiff{{Path: ".spec.replicas", Old: int64(1), New: int64(3)}},
+				},
+			},
+		},
+		"current validation warning to v3": {
+			event: Event{
+				Type: ValidationType,
+				ValidationEvent: ValidationEvent{
+					Identifiers: object.ObjMetadataSet{id},
+					Error:       applyErr,
+					Severity:    WarningSeverity,
+				},
+			},
+			version: V3SchemaVersion,
+			expected: Event{
+				Type:          ValidationType,
+				SchemaVersion: V3SchemaVersion,
+				ValidationEvent: ValidationEvent{
+					Identifiers: object.ObjMetadataSet{id},
+					Error:       applyErr,
+				},
+			},
+		},
+		"current to v2": {
+			event: Event{
+				Type: DeleteType,
+				DeleteEvent: DeleteEvent{
+					Identifier: id,
+					Status:     DeleteSkipped,
+					SkipReason: KindPreventedDeletion,
+				},
+			},
+			version: V2SchemaVersion,
+			expected: Event{
+				Type:          DeleteType,
+				SchemaVersion: V2SchemaVersion,
+				DeleteEvent: DeleteEvent{
+					Identifier: id,
+					Status:     DeleteSkipped,
+					SkipReason: UnknownSkipReason,
+				},
+			},
+		},
+		"current inventory disposition to v2": {
+			event: Event{
+				Type: ActionGroupType,
+				ActionGroupEvent: ActionGroupEvent{
+					GroupName: "delete-inventory-0",
+					Action:    InventoryAction,
+					Status:    Finished,
+					Inventory: InventoryRetained,
+				},
+			},
+			version: V2SchemaVersion,
+			expected: Event{
+				Type:          ActionGroupType,
+				SchemaVersion: V2SchemaVersion,
+				ActionGroupEvent: ActionGroupEvent{
+					GroupName: "delete-inventory-0",
+					Action:    InventoryAction,
+					Status:    Finished,
+				},
+			},
+		},
+		"same version": {
+			event: Event{
+				Type: WaitType,
+				WaitEvent: WaitEvent{
+					Identifier: id,
+					Status:     ReconcileSuccessful,
+					Timing:     timing,
+				},
+			},
+			version: CurrentSchemaVersion,
+			expected: Event{
+				Type:          WaitType,
+				SchemaVersion: CurrentSchemaVersion,
+				WaitEvent: WaitEvent{
+					Identifier: id,
+					Status:     ReconcileSuccessful,
+					Timing:     timing,
+				},
+			},
+		},
+		"unknown target version": {
+			event:       Event{Type: InitType},
+			version:     "v0",
+			expectedErr: `unknown event schema version: "v0"`,
+		},
+		"unknown source version": {
+			event:       Event{Type: InitType, SchemaVersion: "v99"},
+			version:     V1SchemaVersion,
+			expectedErr: `unknown event schema version: "v99"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			converted, err := tc.event.ConvertTo(tc.version)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, converted)
+			assert.Equal(t, tc.version, converted.Version())
+		})
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"k8s.io/client-go/rest"
+	"k8s.io/kubectl/pkg/cmd/util"
+)
+
+// NewImpersonatingFactory returns a Factory whose clients impersonate the
+// specified user, groups, and UID, so the RBAC rules of that user are
+// enforced. Use it to create the inventory client of an Applier or
+// Destroyer built with impersonation, so the inventory is also read and
+// written as the impersonated user.
+func NewImpersonatingFactory(factory util.Factory, impersonate rest.ImpersonationConfig) util.Factory {
+	return util.NewFactory(&configGetter{
+		Factory: factory,
+		modify: func(config *rest.Config) {
+			config.Impersonate = impersonate
+		},
+	})
+}
+
+// withWarningHandler returns a Factory whose clients send the warnings of
+// the server to the handler.
+func withWarningHandler(factory util.Factory, handler rest.WarningHandler) util.Factory {
+	return util.NewFactory(&configGetter{
+		Factory: factory,
+		modify: func(config *rest.Config) {
+			config.WarningHandler = handler
+		},
+	})
+}
+
+// configGetter is a RESTClientGetter that modifies the REST config of the
+// wrapped Factory. Discovery and the RESTMapper are left unchanged, since
+// they don't depend on the modified settings.
+type configGetter struct {
+	util.Factory
+	modify func(*rest.Config)
+}
+
+// ToRESTConfig returns a modified copy of the REST config of the wrapped
+// Factory.
+func (g *configGetter) ToRESTConfig() (*rest.Config, error) {
+	config, err := g.Factory.ToRESTConfig()
+	if err != nil {
+		return nil, err
+	}
+	config = rest.CopyConfig(config)
+	g.modify(config)
+	return config, nil
+}
+
+// withImpersonation returns a copy of the REST config with impersonation.
+func withImpersonation(config *rest.Config, impersonate rest.ImpersonationConfig) *rest.Config {
+	config = rest.CopyConfig(config)
+	config.Impersonate = impersonate
+	return config
+}
+
+// isImpersonating returns true if the impersonation config impersonates
+// anyone.
+func isImpersonating(impersonate rest.ImpersonationConfig) bool {
+	return impersonate.UserName != "" || impersonate.UID != "" ||
+		len(impersonate.Groups) > 0 || len(impersonate.Extra) > 0
+}
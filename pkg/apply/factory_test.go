@@ -0,0 +1,152 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+func TestNewImpersonatingFactory(t *testing.T) {
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+
+	impersonate := rest.ImpersonationConfig{
+		UserName: "tenant",
+		UID:      "1234",
+		Groups:   []string{"tenants"},
+	}
+	factory := NewImpersonatingFactory(tf, impersonate)
+
+	config, err := factory.ToRESTConfig()
+	require.NoError(t, err)
+	assert.Equal(t, impersonate, config.Impersonate)
+
+	// the config of the wrapped factory must not be modified
+	original, err := tf.ToRESTConfig()
+	require.NoError(t, err)
+	assert.Equal(t, rest.ImpersonationConfig{}, original.Impersonate)
+}
+
+func TestApplierBuilder_WithImpersonation(t *testing.T) {
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+
+	impersonate := rest.ImpersonationConfig{
+		UserName: "tenant",
+	}
+	bx, err := NewApplierBuilder().
+		WithFactory(tf).
+		WithInventoryClient(inventory.NewFakeClient(nil)).
+		WithImpersonation(impersonate).
+		finalize()
+	require.NoError(t, err)
+	assert.Equal(t, impersonate, bx.restConfig.Impersonate)
+}
+
+func TestDestroyerBuilder_WithInventoryClientFactory(t *testing.T) {
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+
+	impersonate := rest.ImpersonationConfig{
+		UserName: "tenant",
+	}
+	invClientFactory := &recordingInvClientFactory{}
+	bx, err := NewDestroyerBuilder().
+		WithFactory(tf).
+		WithInventoryClientFactory(invClientFactory).
+		WithImpersonation(impersonate).
+		finalize()
+	require.NoError(t, err)
+	assert.Equal(t, invClientFactory.client, bx.invClient)
+
+	// the inventory client is created from the impersonating factory
+	require.NotNil(t, invClientFactory.factory)
+	config, err := invClientFactory.factory.ToRESTConfig()
+	require.NoError(t, err)
+	assert.Equal(t, impersonate, config.Impersonate)
+}
+
+// recordingInvClientFactory is an inventory.ClientFactory that records the
+// factory the client is created from.
+type recordingInvClientFactory struct {
+	factory util.Factory
+	client  inventory.Client
+}
+
+func (f *recordingInvClientFactory) NewClient(factory util.Factory) (inventory.Client, error) {
+	f.factory = factory
+	f.client = inventory.NewFakeClient(nil)
+	return f.client, nil
+}
+
+func TestApplier_NewInfoHelper_ConcurrentRuns(t *testing.T) {
+	// The server returns a warning naming the requested object.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := path.Base(r.URL.Path)
+		w.Header().Set("Warning", fmt.Sprintf(`299 - "warning for %s"`, name))
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":%q,"namespace":"default"}}`, name)
+	}))
+	defer server.Close()
+
+	tf := cmdtesting.NewTestFactory()
+	defer tf.Cleanup()
+	tf.ClientConfigVal = &rest.Config{Host: server.URL}
+
+	gvk := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(gvk, meta.RESTScopeNamespace)
+
+	bx, err := NewApplierBuilder().
+		WithFactory(tf).
+		WithInventoryClient(inventory.NewFakeClient(nil)).
+		WithRestMapper(mapper).
+		finalize()
+	require.NoError(t, err)
+	applier := &Applier{
+		mapper:        bx.mapper,
+		clientFactory: bx.clientFactory,
+	}
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"cm-a", "cm-b"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for i := 0; i < 10; i++ {
+				infoHelper, warnings := applier.newInfoHelper()
+				obj := &unstructured.Unstructured{}
+				obj.SetGroupVersionKind(gvk)
+				obj.SetName(name)
+				obj.SetNamespace("default")
+				info, err := infoHelper.BuildInfo(obj)
+				if !assert.NoError(t, err) {
+					return
+				}
+				_, err = resource.NewHelper(info.Client, info.Mapping).Get(info.Namespace, info.Name)
+				if !assert.NoError(t, err) {
+					return
+				}
+				assert.Equal(t, []string{"warning for " + name}, warnings.Flush())
+			}
+		}(name)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,207 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/transform"
+)
+
+var namespaceGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+
+// FanOutOptions defines the namespaces the objects of RunFanOut are copied
+// into, and their inventories.
+type FanOutOptions struct {
+	// Namespaces are the namespaces to copy the objects into.
+	Namespaces []string
+
+	// NamespaceSelector selects more namespaces in the cluster to copy the
+	// objects into. Optional.
+	NamespaceSelector labels.Selector
+
+	// InventoryPerNamespace returns the inventory of the copies in a
+	// namespace. If nil, all the copies are applied in one run, with one
+	// inventory.
+	InventoryPerNamespace func(namespace string) inventory.Info
+}
+
+// fanOutRun is one of the runs of RunFanOut.
+type fanOutRun struct {
+	invInfo inventory.Info
+	objs    object.UnstructuredSet
+}
+
+// RunFanOut applies copies of the namespaced objects in many namespaces,
+// like multi-tenant baseline objects, using a transform.NamespaceFanOut.
+//
+// By default, all the copies are applied in one run, with invInfo, so
+// removing a namespace from the list prunes the copies in it. With
+// FanOutOptions.InventoryPerNamespace, the cluster-scoped objects, including
+// the copies of the Namespace object, are applied first with invInfo. Then
+// the copies in each namespace are applied with the inventory of the
+// namespace, one run at a time, so removing a namespace from the list does
+// not prune its objects. A failed run does not stop the next runs.
+//
+// In both modes, the copies of the Namespace object are in invInfo, but are
+// annotated with the on-remove keep annotation, unless already annotated.
+// Removing a namespace from the list abandons the Namespace instead of
+// deleting it, with everything still in it.
+//
+// The events of all the runs are sent on the returned channel. The
+// Transformer of the options transforms the copies of each run.
+func (a *Applier) RunFanOut(ctx context.Context, invInfo inventory.Info, objects object.UnstructuredSet,
+	fanOut FanOutOptions, options ApplierOptions) <-chan event.Event {
+	eventChannel := make(chan event.Event, options.EventBufferSize)
+	go func() {
+		defer close(eventChannel)
+		namespaces, err := a.fanOutNamespaces(ctx, fanOut)
+		if err != nil {
+			handleError(eventChannel, err)
+			return
+		}
+		runs, err := fanOutRuns(a.mapper, invInfo, objects, namespaces, fanOut.InventoryPerNamespace)
+		if err != nil {
+			handleError(eventChannel, err)
+			return
+		}
+		logger := klog.FromContext(ctx)
+		for _, run := range runs {
+			logger.V(4).Info("fan-out run", "inventory", run.invInfo.ID(), "objects", len(run.objs))
+			for e := range a.Run(ctx, run.invInfo, run.objs, options) {
+				eventChannel <- e
+			}
+		}
+	}()
+	return eventChannel
+}
+
+// fanOutNamespaces returns the namespaces of the options, followed by the
+// namespaces selected in the cluster, without duplicates.
+func (a *Applier) fanOutNamespaces(ctx context.Context, fanOut FanOutOptions) ([]string, error) {
+	namespaces := append([]string{}, fanOut.Namespaces...)
+	if fanOut.NamespaceSelector != nil {
+		list, err := a.client.Resource(namespaceGVR).List(ctx, metav1.ListOptions{
+			LabelSelector: fanOut.NamespaceSelector.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the namespaces to fan out to: %w", err)
+		}
+		for _, ns := range list.Items {
+			namespaces = append(namespaces, ns.GetName())
+		}
+	}
+	seen := sets.New[string]()
+	unique := make([]string, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if !seen.Has(ns) {
+			seen.Insert(ns)
+			unique = append(unique, ns)
+		}
+	}
+	return unique, nil
+}
+
+// fanOutRuns returns the runs that apply the copies of the objects in the
+// namespaces. The scope of the objects is looked up with the RESTMapper.
+func fanOutRuns(mapper meta.RESTMapper, invInfo inventory.Info, objects object.UnstructuredSet, namespaces []string,
+	inventoryPerNamespace func(string) inventory.Info) ([]fanOutRun, error) {
+	clusterObjs, namespacedObjs, err := splitByScope(mapper, objects)
+	if err != nil {
+		return nil, err
+	}
+	if inventoryPerNamespace == nil {
+		objs, err := transform.NamespaceFanOut{Namespaces: namespaces}.Transform(objects)
+		if err != nil {
+			return nil, err
+		}
+		return []fanOutRun{{invInfo: invInfo, objs: keepNamespaces(objs, namespaces)}}, nil
+	}
+
+	template, err := transform.TemplateNamespace(objects)
+	if err != nil {
+		return nil, err
+	}
+	// The Namespace objects are copied with the cluster-scoped objects, so
+	// the namespaces exist before the inventories in them are created.
+	sharedObjs, err := transform.NamespaceFanOut{Namespaces: namespaces, Template: template}.Transform(clusterObjs)
+	if err != nil {
+		return nil, err
+	}
+	runs := []fanOutRun{{invInfo: invInfo, objs: keepNamespaces(sharedObjs, namespaces)}}
+	for _, ns := range namespaces {
+		objs, err := transform.NamespaceFanOut{Namespaces: []string{ns}, Template: template}.Transform(namespacedObjs)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, fanOutRun{invInfo: inventoryPerNamespace(ns), objs: objs})
+	}
+	return runs, nil
+}
+
+// splitByScope returns the cluster-scoped and the namespaced objects. The
+// scope is looked up with the RESTMapper, or with the CRDs in the objects,
+// so namespaced objects without a namespace are not mistaken for
+// cluster-scoped objects. Those objects can't be fanned out, so an error is
+// returned for them.
+func splitByScope(mapper meta.RESTMapper, objects object.UnstructuredSet) (object.UnstructuredSet, object.UnstructuredSet, error) {
+	var crds object.UnstructuredSet
+	for _, obj := range objects {
+		if object.IsCRD(obj) {
+			crds = append(crds, obj)
+		}
+	}
+	var clusterObjs, namespacedObjs object.UnstructuredSet
+	for _, obj := range objects {
+		scope, err := object.LookupResourceScope(obj, crds, mapper)
+		if err != nil {
+			return nil, nil, err
+		}
+		if scope.Name() == meta.RESTScopeNameRoot {
+			clusterObjs = append(clusterObjs, obj)
+			continue
+		}
+		if obj.GetNamespace() == "" {
+			return nil, nil, fmt.Errorf("namespaced object %s has no namespace to fan out from",
+				object.UnstructuredToObjMetadata(obj))
+		}
+		namespacedObjs = append(namespacedObjs, obj)
+	}
+	return clusterObjs, namespacedObjs, nil
+}
+
+// keepNamespaces annotates the copies of the Namespace object with the
+// on-remove keep annotation, unless they already have it, so they are not
+// deleted when their namespace is removed from the list.
+func keepNamespaces(objs object.UnstructuredSet, namespaces []string) object.UnstructuredSet {
+	fanOutNamespaces := sets.New(namespaces...)
+	for i, obj := range objs {
+		if !object.IsNamespace(obj) || !fanOutNamespaces.Has(obj.GetName()) {
+			continue
+		}
+		annotations := obj.GetAnnotations()
+		if _, found := annotations[common.OnRemoveAnnotation]; found {
+			continue
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[common.OnRemoveAnnotation] = common.OnRemoveKeep
+		obj = obj.DeepCopy()
+		obj.SetAnnotations(annotations)
+		objs[i] = obj
+	}
+	return objs
+}
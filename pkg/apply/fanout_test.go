@@ -0,0 +1,145 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestFanOutNamespaces(t *testing.T) {
+	newNamespace := func(name string, nsLabels map[string]string) runtime.Object {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("Namespace")
+		u.SetName(name)
+		u.SetLabels(nsLabels)
+		return u
+	}
+	applier := &Applier{
+		client: dynamicfake.NewSimpleDynamicClient(scheme.Scheme,
+			newNamespace("tenant-a", map[string]string{"tenant": "true"}),
+			newNamespace("tenant-b", map[string]string{"tenant": "true"}),
+			newNamespace("system", nil),
+		),
+	}
+
+	namespaces, err := applier.fanOutNamespaces(context.Background(), FanOutOptions{
+		Namespaces:        []string{"extra", "tenant-a"},
+		NamespaceSelector: labels.SelectorFromSet(labels.Set{"tenant": "true"}),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"extra", "tenant-a", "tenant-b"}, namespaces)
+}
+
+func TestFanOutRuns(t *testing.T) {
+	newObj := func(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		return u
+	}
+	objs := object.UnstructuredSet{
+		newObj("v1", "Namespace", "", "template"),
+		newObj("rbac.authorization.k8s.io/v1", "ClusterRole", "", "reader"),
+		newObj("v1", "ConfigMap", "template", "config"),
+	}
+	sharedInv := inventoryInfo{name: "shared", namespace: "default", id: "shared"}.toWrapped()
+	newInventory := func(namespace string) inventory.Info {
+		return inventoryInfo{name: "inventory", namespace: namespace, id: namespace}.toWrapped()
+	}
+
+	mapper := testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
+		scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+	testCases := map[string]struct {
+		objs                  object.UnstructuredSet
+		inventoryPerNamespace func(string) inventory.Info
+		expectedInventories   []string
+		expectedIds           [][]string
+		expectedError         string
+	}{
+		"one inventory": {
+			expectedInventories: []string{"shared"},
+			expectedIds: [][]string{
+				{
+					"_reader_rbac.authorization.k8s.io_ClusterRole",
+					"_a__Namespace",
+					"a_config__ConfigMap",
+					"_b__Namespace",
+					"b_config__ConfigMap",
+				},
+			},
+		},
+		"inventory per namespace": {
+			inventoryPerNamespace: newInventory,
+			expectedInventories:   []string{"shared", "a", "b"},
+			expectedIds: [][]string{
+				{
+					"_reader_rbac.authorization.k8s.io_ClusterRole",
+					"_a__Namespace",
+					"_b__Namespace",
+				},
+				{"a_config__ConfigMap"},
+				{"b_config__ConfigMap"},
+			},
+		},
+		"namespaced object without namespace": {
+			objs: object.UnstructuredSet{
+				newObj("v1", "Namespace", "", "template"),
+				newObj("v1", "ConfigMap", "", "config"),
+			},
+			inventoryPerNamespace: newInventory,
+			expectedError:         "namespaced object _config__ConfigMap has no namespace to fan out from",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			runObjs := objs
+			if tc.objs != nil {
+				runObjs = tc.objs
+			}
+			runs, err := fanOutRuns(mapper, sharedInv, runObjs, []string{"a", "b"}, tc.inventoryPerNamespace)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			var inventories []string
+			var ids [][]string
+			for _, run := range runs {
+				inventories = append(inventories, run.invInfo.ID())
+				var runIds []string
+				for _, obj := range run.objs {
+					runIds = append(runIds, object.UnstructuredToObjMetadata(obj).String())
+					// The copies of the Namespace are not pruned.
+					if object.IsNamespace(obj) {
+						assert.Equal(t, common.OnRemoveKeep, obj.GetAnnotations()[common.OnRemoveAnnotation])
+					}
+				}
+				ids = append(ids, runIds)
+			}
+			// The objects of the caller are not modified.
+			assert.Empty(t, objs[0].GetAnnotations())
+			assert.Equal(t, tc.expectedInventories, inventories)
+			assert.Equal(t, tc.expectedIds, ids)
+		})
+	}
+}
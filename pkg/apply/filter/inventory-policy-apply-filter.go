@@ -12,6 +12,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/client-go/dynamic"
+	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
@@ -24,6 +26,11 @@ type InventoryPolicyApplyFilter struct {
 	Mapper    meta.RESTMapper
 	Inv       inventory.Info
 	InvPolicy inventory.Policy
+	// InventoryIds are the objects in the inventory in the cluster. If set,
+	// Filter only retrieves the live objects that are not in the
+	// inventory yet. The owners of the others are verified from the result
+	// of their server-side apply, with VerifyBeforeApply and VerifyApplied.
+	InventoryIds map[object.ObjMetadata]struct{}
 }
 
 // Name returns a filter identifier for logging.
@@ -38,8 +45,60 @@ func (ipaf InventoryPolicyApplyFilter) Filter(obj *unstructured.Unstructured) er
 	if ipaf.InvPolicy == inventory.PolicyAdoptAll {
 		return nil
 	}
+	id := object.UnstructuredToObjMetadata(obj)
+	// optimization to avoid getting objects already owned by the inventory
+	if ipaf.Deferred(id) {
+		return nil
+	}
+	return ipaf.verifyLive(id)
+}
+
+// Deferred returns true if Filter doesn't verify the owner of the object,
+// because it is in the InventoryIds. Its owner is verified when it is
+// applied, with VerifyBeforeApply and VerifyApplied.
+func (ipaf InventoryPolicyApplyFilter) Deferred(id object.ObjMetadata) bool {
+	if ipaf.InvPolicy == inventory.PolicyAdoptAll {
+		return false
+	}
+	_, found := ipaf.InventoryIds[id]
+	return found
+}
+
+// VerifyBeforeApply verifies the owner of a Deferred object, if the result
+// of its apply can't: if it is not applied with server-side apply, or if it
+// is applied with ForceConflicts and PolicyMustMatch, since forcing takes
+// the owning inventory annotation from another inventory without a
+// conflict. Objects with PolicyAdoptIfNoInventory are assumed to still be
+// owned by the inventory, if the apply is forced.
+func (ipaf InventoryPolicyApplyFilter) VerifyBeforeApply(obj *unstructured.Unstructured, opts common.ServerSideOptions) error {
+	if opts.ServerSideApply && (!opts.ForceConflicts || ipaf.InvPolicy != inventory.PolicyMustMatch) {
+		return nil
+	}
+	return ipaf.verifyLive(object.UnstructuredToObjMetadata(obj))
+}
+
+// VerifyApplied verifies the owner of a Deferred object from the result of
+// its server-side apply. The owning inventory annotation of the applied
+// object is verified. If the apply failed with a conflict, the object may
+// be owned by another inventory, applied by another field manager, so the
+// live object is retrieved and verified. Returns nil if the owner is
+// verified, or if the apply failed for another reason.
+func (ipaf InventoryPolicyApplyFilter) VerifyApplied(applied *unstructured.Unstructured, applyErr error) error {
+	if applyErr == nil {
+		_, err := inventory.CanApply(ipaf.Inv, applied, ipaf.InvPolicy)
+		return err
+	}
+	if _, ok := applyerror.ParseConflictError(applyErr); ok {
+		return ipaf.verifyLive(object.UnstructuredToObjMetadata(applied))
+	}
+	return nil
+}
+
+// verifyLive retrieves the object from the cluster, and verifies that the
+// inventory policy allows to apply it.
+func (ipaf InventoryPolicyApplyFilter) verifyLive(id object.ObjMetadata) error {
 	// Object must be retrieved from the cluster to get the inventory id.
-	clusterObj, err := ipaf.getObject(object.UnstructuredToObjMetadata(obj))
+	clusterObj, err := ipaf.getObject(id)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// This simply means the object hasn't been created yet.
@@ -4,15 +4,20 @@
 package filter
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta/testrestmapper"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"k8s.io/kubectl/pkg/scheme"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
 
@@ -32,6 +37,7 @@ func TestInventoryPolicyApplyFilter(t *testing.T) {
 		inventoryID    string
 		objInventoryID string
 		policy         inventory.Policy
+		inventoryIds   object.ObjMetadataSet
 		expectedError  error
 	}{
 		"inventory and object ids match, not filtered": {
@@ -74,6 +80,37 @@ func TestInventoryPolicyApplyFilter(t *testing.T) {
 			objInventoryID: "",
 			policy:         inventory.PolicyAdoptAll,
 		},
+		"object in the inventory and adopt if no inventory, not retrieved and not filtered": {
+			inventoryID:    "foo",
+			objInventoryID: "bar",
+			policy:         inventory.PolicyAdoptIfNoInventory,
+			inventoryIds:   object.ObjMetadataSet{object.UnstructuredToObjMetadata(defaultObj)},
+		},
+		"object in the inventory and policy must match, not retrieved and not filtered": {
+			inventoryID:    "foo",
+			objInventoryID: "bar",
+			policy:         inventory.PolicyMustMatch,
+			inventoryIds:   object.ObjMetadataSet{object.UnstructuredToObjMetadata(defaultObj)},
+		},
+		"other object in the inventory, filtered and error": {
+			inventoryID:    "foo",
+			objInventoryID: "bar",
+			policy:         inventory.PolicyAdoptIfNoInventory,
+			inventoryIds: object.ObjMetadataSet{
+				testutil.ToIdentifier(t, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: other
+  namespace: default
+`),
+			},
+			expectedError: &inventory.PolicyPreventedActuationError{
+				Strategy: actuation.ActuationStrategyApply,
+				Policy:   inventory.PolicyAdoptIfNoInventory,
+				Status:   inventory.NoMatch,
+			},
+		},
 		"object id empty and policy must match, filtered and error": {
 			inventoryID:    "foo",
 			objInventoryID: "",
@@ -102,11 +139,123 @@ func TestInventoryPolicyApplyFilter(t *testing.T) {
 				Client: dynamicfake.NewSimpleDynamicClient(scheme.Scheme, obj),
 				Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
 					scheme.Scheme.PrioritizedVersionsAllGroups()...),
-				Inv:       inventory.WrapInventoryInfoObj(invObj),
-				InvPolicy: tc.policy,
+				Inv:          inventory.WrapInventoryInfoObj(invObj),
+				InvPolicy:    tc.policy,
+				InventoryIds: tc.inventoryIds.ToMap(),
 			}
 			err := filter.Filter(obj)
 			testutil.AssertEqual(t, tc.expectedError, err)
 		})
 	}
 }
+
+func TestInventoryPolicyApplyFilter_Verify(t *testing.T) {
+	conflictErr := apierrors.NewApplyConflict([]metav1.StatusCause{
+		{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "other-applier"`,
+			Field:   ".metadata.annotations.config.k8s.io/owning-inventory",
+		},
+	}, `Apply failed with 1 conflict: conflict with "other-applier": .metadata.annotations.config.k8s.io/owning-inventory`)
+	mustMatchErr := &inventory.PolicyPreventedActuationError{
+		Strategy: actuation.ActuationStrategyApply,
+		Policy:   inventory.PolicyMustMatch,
+		Status:   inventory.NoMatch,
+	}
+
+	tests := map[string]struct {
+		liveInventoryID    string
+		appliedInventoryID string
+		policy             inventory.Policy
+		serverSideOptions  common.ServerSideOptions
+		applyErr           error
+
+		expectedBeforeError  error
+		expectedAppliedError error
+	}{
+		"applied, owned by the inventory": {
+			liveInventoryID:    "bar",
+			appliedInventoryID: "foo",
+			policy:             inventory.PolicyMustMatch,
+			serverSideOptions:  common.ServerSideOptions{ServerSideApply: true},
+		},
+		"applied, owned by another inventory after the apply": {
+			liveInventoryID:      "bar",
+			appliedInventoryID:   "bar",
+			policy:               inventory.PolicyMustMatch,
+			serverSideOptions:    common.ServerSideOptions{ServerSideApply: true},
+			expectedAppliedError: mustMatchErr,
+		},
+		"conflict, owned by another inventory": {
+			liveInventoryID:      "bar",
+			policy:               inventory.PolicyMustMatch,
+			serverSideOptions:    common.ServerSideOptions{ServerSideApply: true},
+			applyErr:             conflictErr,
+			expectedAppliedError: mustMatchErr,
+		},
+		"conflict, owned by the inventory": {
+			liveInventoryID:   "foo",
+			policy:            inventory.PolicyMustMatch,
+			serverSideOptions: common.ServerSideOptions{ServerSideApply: true},
+			applyErr:          conflictErr,
+		},
+		"other error, not retrieved": {
+			liveInventoryID:   "bar",
+			policy:            inventory.PolicyMustMatch,
+			serverSideOptions: common.ServerSideOptions{ServerSideApply: true},
+			applyErr:          errors.New("connection refused"),
+		},
+		"forced apply and policy must match, retrieved before apply": {
+			liveInventoryID:     "bar",
+			appliedInventoryID:  "foo",
+			policy:              inventory.PolicyMustMatch,
+			serverSideOptions:   common.ServerSideOptions{ServerSideApply: true, ForceConflicts: true},
+			expectedBeforeError: mustMatchErr,
+		},
+		"forced apply and adopt if no inventory, not retrieved": {
+			liveInventoryID:    "bar",
+			appliedInventoryID: "foo",
+			policy:             inventory.PolicyAdoptIfNoInventory,
+			serverSideOptions:  common.ServerSideOptions{ServerSideApply: true, ForceConflicts: true},
+		},
+		"client-side apply, retrieved before apply": {
+			liveInventoryID:    "bar",
+			appliedInventoryID: "foo",
+			policy:             inventory.PolicyAdoptIfNoInventory,
+			expectedBeforeError: &inventory.PolicyPreventedActuationError{
+				Strategy: actuation.ActuationStrategyApply,
+				Policy:   inventory.PolicyAdoptIfNoInventory,
+				Status:   inventory.NoMatch,
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			live := defaultObj.DeepCopy()
+			live.SetAnnotations(map[string]string{inventory.OwningInventoryKey: tc.liveInventoryID})
+			applied := defaultObj.DeepCopy()
+			applied.SetAnnotations(map[string]string{inventory.OwningInventoryKey: tc.appliedInventoryID})
+			invObj := invObjTemplate.DeepCopy()
+			invObj.SetLabels(map[string]string{common.InventoryLabel: "foo"})
+			filter := InventoryPolicyApplyFilter{
+				Client: dynamicfake.NewSimpleDynamicClient(scheme.Scheme, live),
+				Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
+					scheme.Scheme.PrioritizedVersionsAllGroups()...),
+				Inv:          inventory.WrapInventoryInfoObj(invObj),
+				InvPolicy:    tc.policy,
+				InventoryIds: object.ObjMetadataSet{object.UnstructuredToObjMetadata(defaultObj)}.ToMap(),
+			}
+			require.True(t, filter.Deferred(object.UnstructuredToObjMetadata(applied)))
+			require.NoError(t, filter.Filter(applied))
+
+			err := filter.VerifyBeforeApply(applied, tc.serverSideOptions)
+			testutil.AssertEqual(t, tc.expectedBeforeError, err)
+			if err != nil {
+				return
+			}
+			err = filter.VerifyApplied(applied, tc.applyErr)
+			testutil.AssertEqual(t, tc.expectedAppliedError, err)
+		})
+	}
+}
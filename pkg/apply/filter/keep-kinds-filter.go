@@ -0,0 +1,55 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// KeepKindsFilter implements ValidationFilter interface to determine if an
+// object should not be deleted because of its kind, like the shared
+// Namespaces and CRDs of a destroyed environment.
+type KeepKindsFilter struct {
+	GroupKinds []schema.GroupKind
+}
+
+// Name returns the preferred name for the filter. Usually
+// used for logging.
+func (kkf KeepKindsFilter) Name() string {
+	return "KeepKindsFilter"
+}
+
+// Filter returns a KindPreventedDeletionError if the object is of one of
+// the kept kinds.
+func (kkf KeepKindsFilter) Filter(obj *unstructured.Unstructured) error {
+	gk := obj.GroupVersionKind().GroupKind()
+	for _, kept := range kkf.GroupKinds {
+		if gk == kept {
+			return &KindPreventedDeletionError{GroupKind: gk}
+		}
+	}
+	return nil
+}
+
+type KindPreventedDeletionError struct {
+	GroupKind schema.GroupKind
+}
+
+func (e *KindPreventedDeletionError) Error() string {
+	return fmt.Sprintf("objects of kind %q are kept", e.GroupKind)
+}
+
+func (e *KindPreventedDeletionError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*KindPreventedDeletionError)
+	if !ok {
+		return false
+	}
+	return e.GroupKind == tErr.GroupKind
+}
@@ -0,0 +1,52 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestKeepKindsFilter(t *testing.T) {
+	namespaceGK := schema.GroupKind{Kind: "Namespace"}
+	crdGK := schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+	tests := map[string]struct {
+		groupKinds    []schema.GroupKind
+		apiVersion    string
+		kind          string
+		expectedError error
+	}{
+		"no kept kinds": {
+			apiVersion: "v1",
+			kind:       "Namespace",
+		},
+		"kept kind": {
+			groupKinds: []schema.GroupKind{namespaceGK, crdGK},
+			apiVersion: "apiextensions.k8s.io/v1",
+			kind:       "CustomResourceDefinition",
+			expectedError: &KindPreventedDeletionError{
+				GroupKind: crdGK,
+			},
+		},
+		"other kind": {
+			groupKinds: []schema.GroupKind{namespaceGK, crdGK},
+			apiVersion: "v1",
+			kind:       "Pod",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			filter := KeepKindsFilter{GroupKinds: tc.groupKinds}
+			obj := defaultObj.DeepCopy()
+			obj.SetAPIVersion(tc.apiVersion)
+			obj.SetKind(tc.kind)
+			err := filter.Filter(obj)
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
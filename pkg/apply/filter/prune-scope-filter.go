@@ -0,0 +1,88 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// PruneScopeFilter implements ValidationFilter interface to determine if an
+// object should not be pruned because it is out of the scope of the prune:
+// its kind is not allowed, its namespace is skipped, or it is cluster-scoped
+// and cluster-scoped objects are not pruned. The skipped objects stay in the
+// inventory.
+type PruneScopeFilter struct {
+	// AllowGroupKinds are the kinds of the objects that can be pruned. If
+	// empty, objects of all kinds can be pruned.
+	AllowGroupKinds []schema.GroupKind
+	// SkipNamespaces are the namespaces of the objects that are not pruned.
+	SkipNamespaces []string
+	// SkipClusterScoped skips the prune of the cluster-scoped objects.
+	SkipClusterScoped bool
+}
+
+// Name returns the preferred name for the filter. Usually
+// used for logging.
+func (psf PruneScopeFilter) Name() string {
+	return "PruneScopeFilter"
+}
+
+// Filter returns a ScopePreventedDeletionError if the object is out of the
+// prune scope.
+func (psf PruneScopeFilter) Filter(obj *unstructured.Unstructured) error {
+	gk := obj.GroupVersionKind().GroupKind()
+	if len(psf.AllowGroupKinds) > 0 && !containsGroupKind(psf.AllowGroupKinds, gk) {
+		return &ScopePreventedDeletionError{
+			Reason: fmt.Sprintf("objects of kind %q are not in the prune allowlist", gk),
+		}
+	}
+	namespace := obj.GetNamespace()
+	if namespace == "" {
+		if psf.SkipClusterScoped {
+			return &ScopePreventedDeletionError{
+				Reason: "cluster-scoped objects are not pruned",
+			}
+		}
+		return nil
+	}
+	for _, skipped := range psf.SkipNamespaces {
+		if namespace == skipped {
+			return &ScopePreventedDeletionError{
+				Reason: fmt.Sprintf("objects in namespace %q are not pruned", namespace),
+			}
+		}
+	}
+	return nil
+}
+
+func containsGroupKind(groupKinds []schema.GroupKind, gk schema.GroupKind) bool {
+	for _, allowed := range groupKinds {
+		if gk == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+type ScopePreventedDeletionError struct {
+	Reason string
+}
+
+func (e *ScopePreventedDeletionError) Error() string {
+	return e.Reason
+}
+
+func (e *ScopePreventedDeletionError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*ScopePreventedDeletionError)
+	if !ok {
+		return false
+	}
+	return e.Reason == tErr.Reason
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestPruneScopeFilter(t *testing.T) {
+	deploymentGK := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+
+	tests := map[string]struct {
+		filter        PruneScopeFilter
+		apiVersion    string
+		kind          string
+		namespace     string
+		expectedError error
+	}{
+		"empty scope": {
+			apiVersion: "v1",
+			kind:       "Namespace",
+		},
+		"allowed kind": {
+			filter:     PruneScopeFilter{AllowGroupKinds: []schema.GroupKind{deploymentGK}},
+			apiVersion: "apps/v1",
+			kind:       "Deployment",
+			namespace:  "apps",
+		},
+		"kind not allowed": {
+			filter:     PruneScopeFilter{AllowGroupKinds: []schema.GroupKind{deploymentGK}},
+			apiVersion: "v1",
+			kind:       "ConfigMap",
+			namespace:  "apps",
+			expectedError: &ScopePreventedDeletionError{
+				Reason: `objects of kind "ConfigMap" are not in the prune allowlist`,
+			},
+		},
+		"skipped namespace": {
+			filter:     PruneScopeFilter{SkipNamespaces: []string{"kube-system", "apps"}},
+			apiVersion: "v1",
+			kind:       "ConfigMap",
+			namespace:  "apps",
+			expectedError: &ScopePreventedDeletionError{
+				Reason: `objects in namespace "apps" are not pruned`,
+			},
+		},
+		"other namespace": {
+			filter:     PruneScopeFilter{SkipNamespaces: []string{"kube-system"}},
+			apiVersion: "v1",
+			kind:       "ConfigMap",
+			namespace:  "apps",
+		},
+		"skipped cluster-scoped": {
+			filter:     PruneScopeFilter{SkipClusterScoped: true},
+			apiVersion: "v1",
+			kind:       "Namespace",
+			expectedError: &ScopePreventedDeletionError{
+				Reason: "cluster-scoped objects are not pruned",
+			},
+		},
+		"namespaced with skipped cluster-scoped": {
+			filter:     PruneScopeFilter{SkipClusterScoped: true},
+			apiVersion: "v1",
+			kind:       "ConfigMap",
+			namespace:  "apps",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := defaultObj.DeepCopy()
+			obj.SetAPIVersion(tc.apiVersion)
+			obj.SetKind(tc.kind)
+			obj.SetNamespace(tc.namespace)
+			err := tc.filter.Filter(obj)
+			testutil.AssertEqual(t, tc.expectedError, err)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"errors"
+
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+// SkipReasonFor returns the SkipReason for the error returned by a
+// ValidationFilter. Returns UnknownSkipReason if the error is not
+// recognized.
+func SkipReasonFor(err error) event.SkipReason {
+	var policyErr *inventory.PolicyPreventedActuationError
+	var depPreventedErr *DependencyPreventedActuationError
+	var depMismatchErr *DependencyActuationMismatchError
+	var annotationErr *AnnotationPreventedDeletionError
+	var kindErr *KindPreventedDeletionError
+	var applyPreventedErr *ApplyPreventedDeletionError
+	var namespaceErr *NamespaceInUseError
+	var staleErr *StaleInventoryEntryError
+	var scopeErr *ScopePreventedDeletionError
+	switch {
+	case err == nil:
+		return event.NoSkipReason
+	case errors.As(err, &policyErr):
+		return event.PolicyPreventedOwnershipChange
+	case errors.As(err, &depPreventedErr):
+		return event.DependencyFailed
+	case errors.As(err, &depMismatchErr):
+		return event.DependencyMismatch
+	case errors.As(err, &annotationErr):
+		return event.AnnotationPreventedDeletion
+	case errors.As(err, &kindErr):
+		return event.KindPreventedDeletion
+	case errors.As(err, &applyPreventedErr):
+		return event.ApplyPreventedDeletion
+	case errors.As(err, &namespaceErr):
+		return event.NamespaceInUse
+	case errors.As(err, &staleErr):
+		return event.StaleInventoryEntry
+	case errors.As(err, &scopeErr):
+		return event.ScopePreventedDeletion
+	default:
+		return event.UnknownSkipReason
+	}
+}
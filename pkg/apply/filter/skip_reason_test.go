@@ -0,0 +1,71 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+func TestSkipReasonFor(t *testing.T) {
+	tests := map[string]struct {
+		err            error
+		expectedReason event.SkipReason
+	}{
+		"no error": {
+			err:            nil,
+			expectedReason: event.NoSkipReason,
+		},
+		"unrecognized error": {
+			err:            errors.New("skipped"),
+			expectedReason: event.UnknownSkipReason,
+		},
+		"inventory policy": {
+			err:            &inventory.PolicyPreventedActuationError{},
+			expectedReason: event.PolicyPreventedOwnershipChange,
+		},
+		"dependency failed": {
+			err:            &DependencyPreventedActuationError{},
+			expectedReason: event.DependencyFailed,
+		},
+		"dependency mismatch": {
+			err:            &DependencyActuationMismatchError{},
+			expectedReason: event.DependencyMismatch,
+		},
+		"annotation prevented deletion": {
+			err:            &AnnotationPreventedDeletionError{},
+			expectedReason: event.AnnotationPreventedDeletion,
+		},
+		"kind prevented deletion": {
+			err:            &KindPreventedDeletionError{},
+			expectedReason: event.KindPreventedDeletion,
+		},
+		"apply prevented deletion": {
+			err:            &ApplyPreventedDeletionError{UID: "foo"},
+			expectedReason: event.ApplyPreventedDeletion,
+		},
+		"namespace in use": {
+			err:            &NamespaceInUseError{Namespace: "foo"},
+			expectedReason: event.NamespaceInUse,
+		},
+		"scope prevented deletion": {
+			err:            &ScopePreventedDeletionError{Reason: "cluster-scoped objects are not pruned"},
+			expectedReason: event.ScopePreventedDeletion,
+		},
+		"wrapped error": {
+			err:            fmt.Errorf("filtered: %w", &NamespaceInUseError{Namespace: "foo"}),
+			expectedReason: event.NamespaceInUse,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedReason, SkipReasonFor(tc.err))
+		})
+	}
+}
@@ -0,0 +1,38 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package filter
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// StaleInventoryEntryError means the object of an inventory entry no longer
+// exists in the cluster, or its type is not served anymore, so the entry is
+// removed from the inventory instead of being pruned.
+type StaleInventoryEntryError struct {
+	ID object.ObjMetadata
+	// Err is the NotFound or NoMatch error of the lookup of the object.
+	Err error
+}
+
+func (e *StaleInventoryEntryError) Error() string {
+	return fmt.Sprintf("removed from the inventory, since the object no longer exists: %v", e.Err)
+}
+
+func (e *StaleInventoryEntryError) Unwrap() error {
+	return e.Err
+}
+
+func (e *StaleInventoryEntryError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*StaleInventoryEntryError)
+	if !ok {
+		return false
+	}
+	return e.ID == tErr.ID
+}
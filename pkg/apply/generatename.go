@@ -0,0 +1,177 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	// maxNameLength and randomNameLength are the limits used by the
+	// apiserver to generate names.
+	maxNameLength    = 63
+	randomNameLength = 5
+)
+
+// generatedNameKey identifies an object with a generateName across runs.
+type generatedNameKey struct {
+	GroupKind schema.GroupKind
+	Namespace string
+	Value     string
+}
+
+// resolveGeneratedNames sets the names of the objects that have a
+// generateName instead of a name, so they can be planned, applied, and
+// recorded in the inventory like the other objects. An object created by an
+// earlier run, found in the inventory with the same GenerateNameAnnotation,
+// keeps its name. The others are created with their generateName, and get
+// the name generated by the apiserver. Objects with the same key as an
+// earlier object are left without a name, so they fail validation.
+//
+// Objects that can't be created yet, because their type or namespace is
+// applied later in the run, and objects of client-side dry runs get a name
+// generated locally, like the apiserver does.
+func (a *Applier) resolveGeneratedNames(ctx context.Context, invInfo inventory.Info, objs object.UnstructuredSet,
+	dryRun common.DryRunStrategy) error {
+	var generated object.UnstructuredSet
+	for _, obj := range objs {
+		if obj.GetName() == "" && obj.GetGenerateName() != "" {
+			generated = append(generated, obj)
+		}
+	}
+	if len(generated) == 0 || invInfo == nil {
+		return nil
+	}
+	inventoryIds, err := a.invClient.GetClusterObjs(ctx, invInfo)
+	if err != nil {
+		return err
+	}
+	logger := klog.FromContext(ctx)
+	seen := make(map[generatedNameKey]bool, len(generated))
+	for _, obj := range generated {
+		key := generatedNameKeyOf(obj)
+		if seen[key] {
+			logger.V(4).Info("duplicate generated name key", "object", object.UnstructuredToObjMetadata(obj), "key", key.Value)
+			continue
+		}
+		seen[key] = true
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[common.GenerateNameAnnotation] = key.Value
+		obj.SetAnnotations(annotations)
+		name, err := a.findGeneratedName(ctx, key, obj.GetGenerateName(), inventoryIds)
+		if err != nil {
+			return err
+		}
+		if name == "" && !dryRun.ClientDryRun() {
+			name, err = a.createGeneratedName(ctx, invInfo, obj, dryRun)
+			if err != nil {
+				return err
+			}
+		}
+		if name == "" {
+			name = generateName(obj.GetGenerateName())
+		}
+		logger.V(4).Info("resolved generated name", "object", object.UnstructuredToObjMetadata(obj), "name", name)
+		obj.SetName(name)
+	}
+	return nil
+}
+
+// findGeneratedName returns the name of the object in the inventory with the
+// key, or an empty string if there is none.
+func (a *Applier) findGeneratedName(ctx context.Context, key generatedNameKey, prefix string,
+	inventoryIds object.ObjMetadataSet) (string, error) {
+	for _, id := range inventoryIds {
+		if id.GroupKind != key.GroupKind || id.Namespace != key.Namespace || !strings.HasPrefix(id.Name, prefix) {
+			continue
+		}
+		mapping, err := a.mapper.RESTMapping(id.GroupKind)
+		if err != nil {
+			// The kind may be applied later in the run.
+			continue
+		}
+		obj, err := a.client.Resource(mapping.Resource).Namespace(id.Namespace).Get(ctx, id.Name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+		if obj.GetAnnotations()[common.GenerateNameAnnotation] == key.Value {
+			return id.Name, nil
+		}
+	}
+	return "", nil
+}
+
+// createGeneratedName creates the object with its generateName, and returns
+// the name generated by the apiserver. The object is owned by the inventory,
+// so the apply of the run can update it. An empty string is returned if the
+// type or the namespace of the object doesn't exist yet.
+func (a *Applier) createGeneratedName(ctx context.Context, invInfo inventory.Info, obj *unstructured.Unstructured,
+	dryRun common.DryRunStrategy) (string, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			// The type may be applied later in the run.
+			return "", nil
+		}
+		return "", err
+	}
+	generated := obj.DeepCopy()
+	inventory.AddInventoryIDAnnotation(generated, invInfo)
+	opts := metav1.CreateOptions{}
+	if dryRun.ServerDryRun() {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+	created, err := a.client.Resource(mapping.Resource).Namespace(obj.GetNamespace()).Create(ctx, generated, opts)
+	if apierrors.IsNotFound(err) {
+		// The namespace may be applied later in the run.
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to create %s with generateName %q: %w",
+			object.UnstructuredToObjMetadata(obj), obj.GetGenerateName(), err)
+	}
+	return created.GetName(), nil
+}
+
+// generatedNameKeyOf returns the key of the object, from the
+// GenerateNameAnnotation or, if it is not set, the generateName.
+func generatedNameKeyOf(obj *unstructured.Unstructured) generatedNameKey {
+	value, found := obj.GetAnnotations()[common.GenerateNameAnnotation]
+	if !found {
+		value = obj.GetGenerateName()
+	}
+	return generatedNameKey{
+		GroupKind: obj.GroupVersionKind().GroupKind(),
+		Namespace: obj.GetNamespace(),
+		Value:     value,
+	}
+}
+
+// generateName returns a random name with the prefix.
+func generateName(prefix string) string {
+	if len(prefix) > maxNameLength-randomNameLength {
+		prefix = prefix[:maxNameLength-randomNameLength]
+	}
+	return prefix + utilrand.String(randomNameLength)
+}
@@ -0,0 +1,162 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestResolveGeneratedNames(t *testing.T) {
+	configMap := func(name, generateName, key string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace("default")
+		u.SetName(name)
+		u.SetGenerateName(generateName)
+		if key != "" {
+			u.SetAnnotations(map[string]string{common.GenerateNameAnnotation: key})
+		}
+		return u
+	}
+	earlier := configMap("foo-abcde", "foo-", "foo-")
+	earlierID := object.UnstructuredToObjMetadata(earlier)
+
+	testCases := map[string]struct {
+		objs            object.UnstructuredSet
+		clusterObjs     []runtime.Object
+		inventoryIds    object.ObjMetadataSet
+		dryRun          common.DryRunStrategy
+		expectedNames   []string
+		expectedKeys    []string
+		expectedCreated []string
+	}{
+		"new object": {
+			objs:            object.UnstructuredSet{configMap("", "foo-", "")},
+			expectedNames:   []string{"foo-srv01"},
+			expectedKeys:    []string{"foo-"},
+			expectedCreated: []string{"foo-srv01"},
+		},
+		"new object, server dry run": {
+			objs:            object.UnstructuredSet{configMap("", "foo-", "")},
+			dryRun:          common.DryRunServer,
+			expectedNames:   []string{"foo-srv01"},
+			expectedKeys:    []string{"foo-"},
+			expectedCreated: []string{"foo-srv01"},
+		},
+		"new object, client dry run": {
+			objs:          object.UnstructuredSet{configMap("", "foo-", "")},
+			dryRun:        common.DryRunClient,
+			expectedNames: []string{"foo-*"},
+			expectedKeys:  []string{"foo-"},
+		},
+		"new object of a type applied later": {
+			objs: object.UnstructuredSet{func() *unstructured.Unstructured {
+				u := configMap("", "foo-", "")
+				u.SetAPIVersion("example.com/v1")
+				u.SetKind("Widget")
+				return u
+			}()},
+			expectedNames: []string{"foo-*"},
+			expectedKeys:  []string{"foo-"},
+		},
+		"object created by an earlier run": {
+			objs:          object.UnstructuredSet{configMap("", "foo-", "")},
+			clusterObjs:   []runtime.Object{earlier},
+			inventoryIds:  object.ObjMetadataSet{earlierID},
+			expectedNames: []string{"foo-abcde"},
+			expectedKeys:  []string{"foo-"},
+		},
+		"object created by an earlier run, not in the inventory": {
+			objs:            object.UnstructuredSet{configMap("", "foo-", "")},
+			clusterObjs:     []runtime.Object{earlier},
+			expectedNames:   []string{"foo-srv01"},
+			expectedKeys:    []string{"foo-"},
+			expectedCreated: []string{"foo-srv01"},
+		},
+		"object with another key": {
+			objs:            object.UnstructuredSet{configMap("", "foo-", "bar")},
+			clusterObjs:     []runtime.Object{earlier},
+			inventoryIds:    object.ObjMetadataSet{earlierID},
+			expectedNames:   []string{"foo-srv01"},
+			expectedKeys:    []string{"bar"},
+			expectedCreated: []string{"foo-srv01"},
+		},
+		"duplicate keys": {
+			objs: object.UnstructuredSet{
+				configMap("", "foo-", ""),
+				configMap("", "foo-", ""),
+			},
+			expectedNames:   []string{"foo-srv01", ""},
+			expectedKeys:    []string{"foo-", ""},
+			expectedCreated: []string{"foo-srv01"},
+		},
+		"named object": {
+			objs:          object.UnstructuredSet{configMap("foo", "foo-", "")},
+			expectedNames: []string{"foo"},
+			expectedKeys:  []string{""},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, tc.clusterObjs...)
+			// The fake client doesn't generate names, like the apiserver.
+			var created []string
+			client.PrependReactor("create", "configmaps", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				obj := action.(clienttesting.CreateAction).GetObject().(*unstructured.Unstructured).DeepCopy()
+				obj.SetName(obj.GetGenerateName() + "srv01")
+				assert.Equal(t, "test", obj.GetAnnotations()[inventory.OwningInventoryKey])
+				created = append(created, obj.GetName())
+				return true, obj, nil
+			})
+			applier := &Applier{
+				invClient: inventory.NewFakeClient(tc.inventoryIds),
+				client:    client,
+				mapper: testutil.NewFakeRESTMapper(schema.GroupVersionKind{
+					Version: "v1",
+					Kind:    "ConfigMap",
+				}),
+			}
+			invInfo := inventoryInfo{name: "inv", namespace: "default", id: "test"}
+			err := applier.resolveGeneratedNames(context.Background(), invInfo.toWrapped(), tc.objs, tc.dryRun)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedCreated, created)
+
+			for i, obj := range tc.objs {
+				if strings.HasSuffix(tc.expectedNames[i], "*") {
+					prefix := strings.TrimSuffix(tc.expectedNames[i], "*")
+					assert.True(t, strings.HasPrefix(obj.GetName(), prefix), obj.GetName())
+					assert.Len(t, obj.GetName(), len(prefix)+randomNameLength)
+					assert.NotEqual(t, earlier.GetName(), obj.GetName())
+				} else {
+					assert.Equal(t, tc.expectedNames[i], obj.GetName())
+				}
+				assert.Equal(t, tc.expectedKeys[i], obj.GetAnnotations()[common.GenerateNameAnnotation])
+			}
+		})
+	}
+}
+
+func TestGenerateName(t *testing.T) {
+	long := strings.Repeat("a", maxNameLength)
+	name := generateName(long)
+	assert.Len(t, name, maxNameLength)
+	assert.True(t, strings.HasPrefix(name, long[:maxNameLength-randomNameLength]))
+}
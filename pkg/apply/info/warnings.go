@@ -0,0 +1,50 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package info
+
+import (
+	"sync"
+
+	"k8s.io/client-go/rest"
+)
+
+// WarningRecorder is a rest.WarningHandler that records the warnings sent
+// by the server, like deprecation notices and admission warnings, so they
+// can be attached to the events of the objects that caused them.
+// WarningRecorder is safe for concurrent use.
+type WarningRecorder struct {
+	mu       sync.Mutex
+	warnings []string
+}
+
+var _ rest.WarningHandler = &WarningRecorder{}
+
+// HandleWarningHeader implements rest.WarningHandler.
+func (r *WarningRecorder) HandleWarningHeader(code int, _ string, text string) {
+	// Only 299 warnings are sent by the server
+	if code != 299 || text == "" {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, w := range r.warnings {
+		if w == text {
+			return
+		}
+	}
+	r.warnings = append(r.warnings, text)
+}
+
+// Flush returns the warnings recorded since the last Flush, and clears them.
+// A nil WarningRecorder has no warnings.
+func (r *WarningRecorder) Flush() []string {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	warnings := r.warnings
+	r.warnings = nil
+	return warnings
+}
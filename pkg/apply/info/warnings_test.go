@@ -0,0 +1,28 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package info
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWarningRecorder(t *testing.T) {
+	r := &WarningRecorder{}
+	r.HandleWarningHeader(299, "", "apps/v1beta1 Deployment is deprecated")
+	r.HandleWarningHeader(299, "", "apps/v1beta1 Deployment is deprecated")
+	r.HandleWarningHeader(299, "", "")
+	r.HandleWarningHeader(199, "", "miscellaneous warning")
+	r.HandleWarningHeader(299, "", "unknown field \"spec.foo\"")
+
+	assert.Equal(t, []string{
+		"apps/v1beta1 Deployment is deprecated",
+		"unknown field \"spec.foo\"",
+	}, r.Flush())
+	assert.Empty(t, r.Flush())
+
+	var nilRecorder *WarningRecorder
+	assert.Empty(t, nilRecorder.Flush())
+}
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -27,11 +28,13 @@ import (
 // apply-time-mutation annotation.
 // The optional ResourceCache will be used to speed up source object lookups,
 // if specified.
+// The optional Redactor redacts the objects logged as YAML.
 // Implements the Mutator interface
 type ApplyTimeMutator struct {
 	Client        dynamic.Interface
 	Mapper        meta.RESTMapper
 	ResourceCache cache.ResourceCache
+	Redactor      *object.Redactor
 }
 
 // Name returns a mutator identifier for logging.
@@ -43,6 +46,7 @@ func (atm *ApplyTimeMutator) Name() string {
 // substitutions, applying each of them to the supplied target object.
 // Returns true with a reason, if mutation was performed.
 func (atm *ApplyTimeMutator) Mutate(ctx context.Context, obj *unstructured.Unstructured) (bool, string, error) {
+	logger := klog.FromContext(ctx)
 	mutated := false
 	reason := ""
 
@@ -57,8 +61,8 @@ func (atm *ApplyTimeMutator) Mutate(ctx context.Context, obj *unstructured.Unstr
 		return mutated, reason, fmt.Errorf("failed to read annotation in object (%s): %w", targetRef, err)
 	}
 
-	klog.V(4).Infof("target object: %s", targetRef)
-	klog.V(7).Infof("target object YAML:\n%s", object.YamlStringer{O: obj})
+	logger.V(4).Info("target object", "object", targetRef)
+	logger.V(7).Info("target object YAML", "object", targetRef, "yaml", object.YamlStringer{O: obj, Redactor: atm.Redactor})
 
 	// validate no self-references
 	// Early validation to avoid GETs, but won't catch sources with implicit namespace.
@@ -94,8 +98,8 @@ func (atm *ApplyTimeMutator) Mutate(ctx context.Context, obj *unstructured.Unstr
 			return mutated, reason, fmt.Errorf("failed to get source object (%s): %w", sourceRef, err)
 		}
 
-		klog.V(4).Infof("source object: %s", sourceRef)
-		klog.V(7).Infof("source object YAML:\n%s", object.YamlStringer{O: sourceObj})
+		logger.V(4).Info("source object", "object", sourceRef)
+		logger.V(7).Info("source object YAML", "object", sourceRef, "yaml", object.YamlStringer{O: sourceObj, Redactor: atm.Redactor})
 
 		// lookup target field in target object
 		targetValue, _, err := readFieldValue(obj, sub.TargetPath)
@@ -133,8 +137,8 @@ func (atm *ApplyTimeMutator) Mutate(ctx context.Context, obj *unstructured.Unstr
 			newValue = strings.ReplaceAll(targetValueString, sub.Token, sourceValueString)
 		}
 
-		klog.V(5).Infof("substitution: targetRef=(%s), sourceRef=(%s): sourceValue=(%v), token=(%s), oldTargetValue=(%v), newTargetValue=(%v)",
-			targetRef, sourceRef, sourceValue, sub.Token, targetValue, newValue)
+		logger.V(5).Info("substitution", "object", targetRef, "source", sourceRef, "sourceValue", sourceValue,
+			"token", sub.Token, "oldTargetValue", targetValue, "newTargetValue", newValue)
 
 		// update target field in target object
 		err = writeFieldValue(obj, sub.TargetPath, newValue)
@@ -147,8 +151,8 @@ func (atm *ApplyTimeMutator) Mutate(ctx context.Context, obj *unstructured.Unstr
 	}
 
 	if mutated {
-		klog.V(4).Infof("mutated target object: %s", targetRef)
-		klog.V(7).Infof("mutated target object YAML:\n%s", object.YamlStringer{O: obj})
+		logger.V(4).Info("mutated target object", "object", targetRef)
+		logger.V(7).Info("mutated target object YAML", "object", targetRef, "yaml", object.YamlStringer{O: obj, Redactor: atm.Redactor})
 	}
 
 	return mutated, reason, nil
@@ -205,7 +209,7 @@ func (atm *ApplyTimeMutator) getObject(ctx context.Context, mapping *meta.RESTMa
 		// If it's not cached or not current, update the cache.
 		// This will add external objects to the cache,
 		// but the user won't get status events for them.
-		atm.ResourceCache.Put(id, computeStatus(obj))
+		atm.ResourceCache.Put(id, computeStatus(klog.FromContext(ctx), obj))
 	}
 
 	if err != nil {
@@ -217,7 +221,7 @@ func (atm *ApplyTimeMutator) getObject(ctx context.Context, mapping *meta.RESTMa
 }
 
 // computeStatus compares the spec to the status and returns the result.
-func computeStatus(obj *unstructured.Unstructured) cache.ResourceStatus {
+func computeStatus(logger logr.Logger, obj *unstructured.Unstructured) cache.ResourceStatus {
 	if obj == nil {
 		return cache.ResourceStatus{
 			Resource:      obj,
@@ -227,9 +231,9 @@ func computeStatus(obj *unstructured.Unstructured) cache.ResourceStatus {
 	}
 	result, err := status.Compute(obj)
 	if err != nil {
-		if klog.V(3).Enabled() {
+		if logger.V(3).Enabled() {
 			ref := mutation.ResourceReferenceFromUnstructured(obj)
-			klog.Info("failed to compute object status (%s): %d", ref, err)
+			logger.Info("failed to compute object status", "object", ref, "error", err)
 		}
 		return cache.ResourceStatus{
 			Resource: obj,
@@ -0,0 +1,93 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package mutator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// MetadataMutator adds common labels and annotations to every applied
+// object, like a revision or the SHA of a commit, overwriting the existing
+// values with the same keys. The injected keys are recorded in the
+// injected-metadata annotation, so they are ignored when the changes of the
+// fields are computed for the apply events.
+//
+// Like the other mutators, it modifies the object in place: the apply task
+// mutates the copy of the object it applies, so the objects of the caller
+// are not changed.
+// Implements the Mutator interface
+type MetadataMutator struct {
+	Labels      map[string]string
+	Annotations map[string]string
+}
+
+// InjectedMetadata lists the keys of the labels and annotations injected by
+// the MetadataMutator.
+type InjectedMetadata struct {
+	Labels      []string `json:"labels,omitempty"`
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// Name returns a mutator identifier for logging.
+func (mm *MetadataMutator) Name() string {
+	return "MetadataMutator"
+}
+
+// Mutate adds the labels and annotations to the object, and records their
+// keys. Returns true with a reason, if the object was changed.
+func (mm *MetadataMutator) Mutate(_ context.Context, obj *unstructured.Unstructured) (bool, string, error) {
+	if len(mm.Labels) == 0 && len(mm.Annotations) == 0 {
+		return false, "", nil
+	}
+	injected := InjectedMetadata{
+		Labels:      sortedKeys(mm.Labels),
+		Annotations: sortedKeys(mm.Annotations),
+	}
+	value, err := json.Marshal(injected)
+	if err != nil {
+		return false, "", fmt.Errorf("failed to record the injected metadata: %w", err)
+	}
+
+	mutated := object.AddLabels(obj, mm.Labels)
+	if object.AddAnnotations(obj, mm.Annotations) {
+		mutated = true
+	}
+	if object.AddAnnotations(obj, map[string]string{common.InjectedMetadataAnnotation: string(value)}) {
+		mutated = true
+	}
+	if !mutated {
+		return false, "", nil
+	}
+	return true, fmt.Sprintf("injected %d labels and %d annotations", len(mm.Labels), len(mm.Annotations)), nil
+}
+
+// ReadInjectedMetadata returns the keys of the labels and annotations
+// injected into the object by the MetadataMutator, if any.
+func ReadInjectedMetadata(obj *unstructured.Unstructured) (InjectedMetadata, error) {
+	var injected InjectedMetadata
+	value, found := obj.GetAnnotations()[common.InjectedMetadataAnnotation]
+	if !found {
+		return injected, nil
+	}
+	if err := json.Unmarshal([]byte(value), &injected); err != nil {
+		return injected, fmt.Errorf("failed to read the %s annotation: %w", common.InjectedMetadataAnnotation, err)
+	}
+	return injected, nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package mutator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/common"
+)
+
+func TestMetadataMutator(t *testing.T) {
+	newObj := func(labels, annotations map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetName("foo")
+		u.SetLabels(labels)
+		u.SetAnnotations(annotations)
+		return u
+	}
+
+	testCases := map[string]struct {
+		mutator             *MetadataMutator
+		obj                 *unstructured.Unstructured
+		expectedMutated     bool
+		expectedLabels      map[string]string
+		expectedAnnotations map[string]string
+	}{
+		"no metadata": {
+			mutator: &MetadataMutator{},
+			obj:     newObj(map[string]string{"app": "foo"}, nil),
+			expectedLabels: map[string]string{
+				"app": "foo",
+			},
+		},
+		"labels and annotations": {
+			mutator: &MetadataMutator{
+				Labels:      map[string]string{"team": "a", "revision": "2"},
+				Annotations: map[string]string{"example.com/sha": "abc"},
+			},
+			obj:             newObj(map[string]string{"app": "foo", "revision": "1"}, nil),
+			expectedMutated: true,
+			expectedLabels: map[string]string{
+				"app":      "foo",
+				"team":     "a",
+				"revision": "2",
+			},
+			expectedAnnotations: map[string]string{
+				"example.com/sha":                 "abc",
+				common.InjectedMetadataAnnotation: `{"labels":["revision","team"],"annotations":["example.com/sha"]}`,
+			},
+		},
+		"unchanged": {
+			mutator: &MetadataMutator{
+				Labels: map[string]string{"team": "a"},
+			},
+			obj: newObj(map[string]string{"team": "a"}, map[string]string{
+				common.InjectedMetadataAnnotation: `{"labels":["team"]}`,
+			}),
+			expectedLabels: map[string]string{
+				"team": "a",
+			},
+			expectedAnnotations: map[string]string{
+				common.InjectedMetadataAnnotation: `{"labels":["team"]}`,
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mutated, _, err := tc.mutator.Mutate(context.Background(), tc.obj)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedMutated, mutated)
+			assert.Equal(t, tc.expectedLabels, tc.obj.GetLabels())
+			assert.Equal(t, tc.expectedAnnotations, tc.obj.GetAnnotations())
+
+			injected, err := ReadInjectedMetadata(tc.obj)
+			require.NoError(t, err)
+			assert.Equal(t, sortedKeys(tc.mutator.Labels), emptyIfNil(injected.Labels))
+			assert.Equal(t, sortedKeys(tc.mutator.Annotations), emptyIfNil(injected.Annotations))
+		})
+	}
+}
+
+func emptyIfNil(keys []string) []string {
+	if keys == nil {
+		return []string{}
+	}
+	return keys
+}
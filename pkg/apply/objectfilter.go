@@ -0,0 +1,58 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// ObjectFilter selects a subset of the objects of a run, so the same set of
+// objects can be applied in parts, like the CRDs before the other objects.
+// An object is selected if it matches both the LabelSelector and the
+// GroupKinds.
+type ObjectFilter struct {
+	// LabelSelector selects the objects by their labels. If nil, all the
+	// labels match.
+	LabelSelector labels.Selector
+
+	// GroupKinds selects the objects by their kind. If empty, all the kinds
+	// match.
+	GroupKinds []schema.GroupKind
+}
+
+// Matches returns true if the object is selected by the filter.
+func (f *ObjectFilter) Matches(obj *unstructured.Unstructured) bool {
+	if f.LabelSelector != nil && !f.LabelSelector.Matches(labels.Set(obj.GetLabels())) {
+		return false
+	}
+	if len(f.GroupKinds) == 0 {
+		return true
+	}
+	gk := obj.GroupVersionKind().GroupKind()
+	for _, selected := range f.GroupKinds {
+		if gk == selected {
+			return true
+		}
+	}
+	return false
+}
+
+// Split returns the objects selected by the filter, and the others. If the
+// filter is nil, all the objects are selected.
+func (f *ObjectFilter) Split(objs object.UnstructuredSet) (selected, excluded object.UnstructuredSet) {
+	if f == nil {
+		return objs, nil
+	}
+	for _, obj := range objs {
+		if f.Matches(obj) {
+			selected = append(selected, obj)
+		} else {
+			excluded = append(excluded, obj)
+		}
+	}
+	return selected, excluded
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestObjectFilter_Split(t *testing.T) {
+	newObj := func(apiVersion, kind, name string, objLabels map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion(apiVersion)
+		u.SetKind(kind)
+		u.SetName(name)
+		u.SetLabels(objLabels)
+		return u
+	}
+	crd := newObj("apiextensions.k8s.io/v1", "CustomResourceDefinition", "crd", map[string]string{"tier": "bootstrap"})
+	deployment := newObj("apps/v1", "Deployment", "deployment", map[string]string{"tier": "app"})
+	configMap := newObj("v1", "ConfigMap", "config", nil)
+	objs := object.UnstructuredSet{crd, deployment, configMap}
+
+	testCases := map[string]struct {
+		filter           *ObjectFilter
+		expectedSelected object.UnstructuredSet
+		expectedExcluded object.UnstructuredSet
+	}{
+		"nil filter": {
+			expectedSelected: objs,
+		},
+		"empty filter": {
+			filter:           &ObjectFilter{},
+			expectedSelected: objs,
+		},
+		"label selector": {
+			filter: &ObjectFilter{
+				LabelSelector: labels.SelectorFromSet(labels.Set{"tier": "bootstrap"}),
+			},
+			expectedSelected: object.UnstructuredSet{crd},
+			expectedExcluded: object.UnstructuredSet{deployment, configMap},
+		},
+		"group kinds": {
+			filter: &ObjectFilter{
+				GroupKinds: []schema.GroupKind{
+					{Group: "apps", Kind: "Deployment"},
+					{Kind: "ConfigMap"},
+				},
+			},
+			expectedSelected: object.UnstructuredSet{deployment, configMap},
+			expectedExcluded: object.UnstructuredSet{crd},
+		},
+		"label selector and group kinds": {
+			filter: &ObjectFilter{
+				LabelSelector: labels.SelectorFromSet(labels.Set{"tier": "app"}),
+				GroupKinds:    []schema.GroupKind{{Kind: "ConfigMap"}},
+			},
+			expectedExcluded: objs,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			selected, excluded := tc.filter.Split(objs)
+			assert.Equal(t, tc.expectedSelected, selected)
+			assert.Equal(t, tc.expectedExcluded, excluded)
+		})
+	}
+}
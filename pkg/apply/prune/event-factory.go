@@ -6,7 +6,9 @@ package prune
 
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
 
@@ -60,6 +62,8 @@ func (pef PruneEventFactory) CreateSkippedEvent(obj *unstructured.Unstructured,
 			Object:     obj,
 			Identifier: object.UnstructuredToObjMetadata(obj),
 			Error:      err,
+			SkipReason: filter.SkipReasonFor(err),
+			Hint:       applyerror.HintFor(err),
 		},
 	}
 }
@@ -72,6 +76,7 @@ func (pef PruneEventFactory) CreateFailedEvent(id object.ObjMetadata, err error)
 			Status:     event.PruneFailed,
 			Identifier: id,
 			Error:      err,
+			Hint:       applyerror.HintFor(err),
 		},
 	}
 }
@@ -103,6 +108,8 @@ func (def DeleteEventFactory) CreateSkippedEvent(obj *unstructured.Unstructured,
 			Object:     obj,
 			Identifier: object.UnstructuredToObjMetadata(obj),
 			Error:      err,
+			SkipReason: filter.SkipReasonFor(err),
+			Hint:       applyerror.HintFor(err),
 		},
 	}
 }
@@ -115,6 +122,7 @@ func (def DeleteEventFactory) CreateFailedEvent(id object.ObjMetadata, err error
 			Status:     event.DeleteFailed,
 			Identifier: id,
 			Error:      err,
+			Hint:       applyerror.HintFor(err),
 		},
 	}
 }
@@ -14,7 +14,11 @@ package prune
 import (
 	"context"
 	"errors"
+	"sync"
+	"time"
 
+	"github.com/go-logr/logr"
+	"golang.org/x/time/rate"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -68,6 +72,15 @@ type Options struct {
 	// True if we are destroying, which deletes the inventory object
 	// as well (possibly) the inventory namespace.
 	Destroy bool
+
+	// DeleteConcurrency is the number of objects deleted in parallel.
+	// By default, the objects are deleted one at a time.
+	DeleteConcurrency int
+
+	// DeleteQPS limits the deletions per second of each of the
+	// DeleteConcurrency workers. By default, the deletions are only
+	// limited by the rate limiter of the client.
+	DeleteQPS float32
 }
 
 // Prune deletes the set of passed objects. A prune skip/failure is
@@ -93,22 +106,27 @@ func (p *Pruner) Prune(
 	taskName string,
 	opts Options,
 ) error {
+	logger := taskContext.Logger()
 	eventFactory := CreateEventFactory(opts.Destroy, taskName)
+	// Objects to delete by the worker pool, if deletions are concurrent.
+	concurrent := opts.DeleteConcurrency > 1 && !opts.DryRunStrategy.ClientOrServerDryRun()
+	var deletes object.UnstructuredSet
 	// Iterate through objects to prune (delete). If an object is not pruned
 	// and we need to keep it in the inventory, we must capture the prune failure.
 	for _, obj := range objs {
+		start := time.Now()
 		id := object.UnstructuredToObjMetadata(obj)
-		klog.V(5).Infof("evaluating prune filters (object: %q)", id)
+		logger.V(5).Info("evaluating prune filters", "object", id)
 
 		// UID will change if the object is deleted and re-created.
 		uid := obj.GetUID()
 		if uid == "" {
 			err := object.NotFound([]interface{}{"metadata", "uid"}, "")
-			if klog.V(4).Enabled() {
+			if logger.V(4).Enabled() {
 				// only log event emitted errors if the verbosity > 4
-				klog.Errorf("prune uid lookup errored (object: %s): %v", id, err)
+				logger.Error(err, "prune uid lookup errored", "object", id)
 			}
-			taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err))
+			taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err).WithTiming(start))
 			taskContext.InventoryManager().AddFailedDelete(id)
 			continue
 		}
@@ -116,34 +134,35 @@ func (p *Pruner) Prune(
 		// Check filters to see if we're prevented from pruning/deleting object.
 		var filterErr error
 		for _, pruneFilter := range pruneFilters {
-			klog.V(6).Infof("prune filter evaluating (filter: %s, object: %s)", pruneFilter.Name(), id)
+			logger.V(6).Info("prune filter evaluating", "filter", pruneFilter.Name(), "object", id)
 			filterErr = pruneFilter.Filter(obj)
 			if filterErr != nil {
 				var fatalErr *filter.FatalError
 				if errors.As(filterErr, &fatalErr) {
-					if klog.V(4).Enabled() {
+					if logger.V(4).Enabled() {
 						// only log event emitted errors if the verbosity > 4
-						klog.Errorf("prune filter errored (filter: %s, object: %s): %v", pruneFilter.Name(), id, fatalErr.Err)
+						logger.Error(fatalErr.Err, "prune filter errored", "filter", pruneFilter.Name(), "object", id)
 					}
-					taskContext.SendEvent(eventFactory.CreateFailedEvent(id, fatalErr.Err))
+					taskContext.SendEvent(eventFactory.CreateFailedEvent(id, fatalErr.Err).WithTiming(start))
 					taskContext.InventoryManager().AddFailedDelete(id)
 					break
 				}
-				klog.V(4).Infof("prune filtered (filter: %s, object: %s): %v", pruneFilter.Name(), id, filterErr)
+				logger.V(4).Info("prune filtered", "filter", pruneFilter.Name(), "object", id, "reason", filterErr)
 
 				// Remove the inventory annotation if deletion was prevented.
 				// This abandons the object so it won't be pruned by future applier runs.
 				var abandonErr *filter.AnnotationPreventedDeletionError
-				if errors.As(filterErr, &abandonErr) {
+				var keepKindErr *filter.KindPreventedDeletionError
+				if errors.As(filterErr, &abandonErr) || errors.As(filterErr, &keepKindErr) {
 					if !opts.DryRunStrategy.ClientOrServerDryRun() {
 						var err error
-						obj, err = p.removeInventoryAnnotation(obj)
+						obj, err = p.removeInventoryAnnotation(logger, obj)
 						if err != nil {
-							if klog.V(4).Enabled() {
+							if logger.V(4).Enabled() {
 								// only log event emitted errors if the verbosity > 4
-								klog.Errorf("error removing annotation (object: %q, annotation: %q): %v", id, inventory.OwningInventoryKey, err)
+								logger.Error(err, "error removing annotation", "object", id, "annotation", inventory.OwningInventoryKey)
 							}
-							taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err))
+							taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err).WithTiming(start))
 							taskContext.InventoryManager().AddFailedDelete(id)
 							break
 						}
@@ -165,7 +184,7 @@ func (p *Pruner) Prune(
 					}
 				}
 
-				taskContext.SendEvent(eventFactory.CreateSkippedEvent(obj, filterErr))
+				taskContext.SendEvent(eventFactory.CreateSkippedEvent(obj, filterErr).WithTiming(start))
 				taskContext.InventoryManager().AddSkippedDelete(id)
 				break
 			}
@@ -175,39 +194,117 @@ func (p *Pruner) Prune(
 		}
 
 		// Filters passed--actually delete object if not dry run.
+		if concurrent {
+			deletes = append(deletes, obj)
+			continue
+		}
+		var err error
 		if !opts.DryRunStrategy.ClientOrServerDryRun() {
-			klog.V(4).Infof("deleting object (object: %q)", id)
-			err := p.deleteObject(id, metav1.DeleteOptions{
-				// Only delete the resource if it hasn't already been deleted
-				// and recreated since the last GET. Otherwise error.
-				Preconditions: &metav1.Preconditions{
-					UID: &uid,
-				},
-				PropagationPolicy: &opts.PropagationPolicy,
-			})
-			if err != nil {
-				if apierrors.IsNotFound(err) {
-					klog.Warningf("error deleting object (object: %q): object not found: object may have been deleted asynchronously by another client", id)
-					// treat this as successful idempotent deletion
-				} else {
-					if klog.V(4).Enabled() {
-						// only log event emitted errors if the verbosity > 4
-						klog.Errorf("error deleting object (object: %q): %v", id, err)
+			err = p.delete(logger, obj, opts)
+		}
+		handleDeleteResult(logger, taskContext, eventFactory, obj, start, err)
+	}
+	if len(deletes) > 0 {
+		for result := range p.deleteConcurrently(taskContext.Context(), logger, deletes, opts) {
+			handleDeleteResult(logger, taskContext, eventFactory, result.obj, result.start, result.err)
+		}
+	}
+	return nil
+}
+
+// delete deletes the object, unless it has been deleted and recreated
+// since it was retrieved. An object that is not found is treated as
+// successfully deleted.
+func (p *Pruner) delete(logger logr.Logger, obj *unstructured.Unstructured, opts Options) error {
+	id := object.UnstructuredToObjMetadata(obj)
+	uid := obj.GetUID()
+	logger.V(4).Info("deleting object", "object", id)
+	err := p.deleteObject(id, metav1.DeleteOptions{
+		// Only delete the resource if it hasn't already been deleted
+		// and recreated since the last GET. Otherwise error.
+		Preconditions: &metav1.Preconditions{
+			UID: &uid,
+		},
+		PropagationPolicy: &opts.PropagationPolicy,
+	})
+	if apierrors.IsNotFound(err) {
+		logger.Info("error deleting object: object not found: object may have been deleted asynchronously by another client", "object", id)
+		// treat this as successful idempotent deletion
+		return nil
+	}
+	return err
+}
+
+// handleDeleteResult sends the event for the deletion of the object, which
+// started at start, and records the result in the inventory.
+func handleDeleteResult(logger logr.Logger, taskContext *taskrunner.TaskContext, eventFactory EventFactory, obj *unstructured.Unstructured, start time.Time, err error) {
+	id := object.UnstructuredToObjMetadata(obj)
+	if err != nil {
+		if logger.V(4).Enabled() {
+			// only log event emitted errors if the verbosity > 4
+			logger.Error(err, "error deleting object", "object", id)
+		}
+		taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err).WithTiming(start))
+		taskContext.InventoryManager().AddFailedDelete(id)
+		return
+	}
+	taskContext.InventoryManager().AddSuccessfulDelete(id, obj.GetUID())
+	taskContext.SendEvent(eventFactory.CreateSuccessEvent(obj).WithTiming(start))
+}
+
+// deleteResult is the result of the deletion of an object.
+type deleteResult struct {
+	obj   *unstructured.Unstructured
+	start time.Time
+	err   error
+}
+
+// deleteConcurrently deletes the objects with DeleteConcurrency workers,
+// each limited to DeleteQPS deletions per second. The results are sent to
+// the returned channel, which is closed once all the objects are deleted.
+// The results are handled by the caller, since the TaskContext is not safe
+// for concurrent use. Once the context is done, the remaining objects are
+// not deleted, and their results have the error of the context.
+func (p *Pruner) deleteConcurrently(ctx context.Context, logger logr.Logger, objs object.UnstructuredSet, opts Options) <-chan deleteResult {
+	objCh := make(chan *unstructured.Unstructured)
+	resultCh := make(chan deleteResult)
+	go func() {
+		defer close(objCh)
+		for _, obj := range objs {
+			objCh <- obj
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < opts.DeleteConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var limiter *rate.Limiter
+			if opts.DeleteQPS > 0 {
+				limiter = rate.NewLimiter(rate.Limit(opts.DeleteQPS), 1)
+			}
+			for obj := range objCh {
+				start := time.Now()
+				if limiter != nil {
+					if err := limiter.Wait(ctx); err != nil {
+						resultCh <- deleteResult{obj: obj, start: start, err: err}
+						continue
 					}
-					taskContext.SendEvent(eventFactory.CreateFailedEvent(id, err))
-					taskContext.InventoryManager().AddFailedDelete(id)
-					continue
 				}
+				resultCh <- deleteResult{obj: obj, start: start, err: p.delete(logger, obj, opts)}
 			}
-		}
-		taskContext.InventoryManager().AddSuccessfulDelete(id, obj.GetUID())
-		taskContext.SendEvent(eventFactory.CreateSuccessEvent(obj))
+		}()
 	}
-	return nil
+	go func() {
+		wg.Wait()
+		close(resultCh)
+	}()
+	return resultCh
 }
 
 // removeInventoryAnnotation removes the `config.k8s.io/owning-inventory` annotation from pruneObj.
-func (p *Pruner) removeInventoryAnnotation(obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+func (p *Pruner) removeInventoryAnnotation(logger logr.Logger, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
 	// Make a copy of the input object to avoid modifying the input.
 	// This prevents race conditions when writing to the underlying map.
 	obj = obj.DeepCopy()
@@ -215,7 +312,7 @@ func (p *Pruner) removeInventoryAnnotation(obj *unstructured.Unstructured) (*uns
 	annotations := obj.GetAnnotations()
 	if annotations != nil {
 		if _, ok := annotations[inventory.OwningInventoryKey]; ok {
-			klog.V(4).Infof("removing annotation (object: %q, annotation: %q)", id, inventory.OwningInventoryKey)
+			logger.V(4).Info("removing annotation", "object", id, "annotation", inventory.OwningInventoryKey)
 			delete(annotations, inventory.OwningInventoryKey)
 			obj.SetAnnotations(annotations)
 			namespacedClient, err := p.namespacedClient(id)
@@ -232,36 +329,71 @@ func (p *Pruner) removeInventoryAnnotation(obj *unstructured.Unstructured) (*uns
 // GetPruneObjs calculates the set of prune objects, and retrieves them
 // from the cluster. Set of prune objects equals the set of inventory
 // objects minus the set of currently applied objects. Returns an error
-// if one occurs.
+// if one occurs. Skipped objects are logged to the logger of the context.
 func (p *Pruner) GetPruneObjs(
+	ctx context.Context,
 	inv inventory.Info,
 	objs object.UnstructuredSet,
 	opts Options,
 ) (object.UnstructuredSet, error) {
+	pruneObjs, _, err := p.PlanPrune(ctx, inv, objs, opts)
+	return pruneObjs, err
+}
+
+// StaleEntry is an inventory entry whose object no longer exists in the
+// cluster, because it is not found, or because its type is not served
+// anymore.
+type StaleEntry struct {
+	ID object.ObjMetadata
+	// Err is the NotFound or NoMatch error of the lookup of the object.
+	Err error
+}
+
+// PlanPrune is like GetPruneObjs, but also returns the stale inventory
+// entries, which are not pruned, so they can be reported when they are
+// removed from the inventory.
+func (p *Pruner) PlanPrune(
+	ctx context.Context,
+	inv inventory.Info,
+	objs object.UnstructuredSet,
+	_ Options,
+) (object.UnstructuredSet, []StaleEntry, error) {
+	logger := klog.FromContext(ctx)
 	ids := object.UnstructuredSetToObjMetadataSet(objs)
-	invIDs, err := p.InvClient.GetClusterObjs(inv)
+	invIDs, err := p.InvClient.GetClusterObjs(ctx, inv)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// only return objects that were in the inventory but not in the object set
 	ids = invIDs.Diff(ids)
 	objs = object.UnstructuredSet{}
+	var stale []StaleEntry
+	mapperReset := false
 	for _, id := range ids {
 		pruneObj, err := p.getObject(id)
+		if meta.IsNoMatchError(err) && !mapperReset {
+			// The type may have been added since the RESTMapper was
+			// cached, so check again once before the entry is dropped.
+			meta.MaybeResetRESTMapper(p.Mapper)
+			mapperReset = true
+			pruneObj, err = p.getObject(id)
+		}
 		if err != nil {
 			if meta.IsNoMatchError(err) {
-				klog.V(4).Infof("skip pruning (object: %q): resource type not registered", id)
+				logger.V(4).Info("skip pruning: resource type not registered", "object", id)
+				stale = append(stale, StaleEntry{ID: id, Err: err})
 				continue
 			}
 			if apierrors.IsNotFound(err) {
-				klog.V(4).Infof("skip pruning (object: %q): resource not found", id)
+				logger.V(4).Info("skip pruning: resource not found", "object", id)
+				stale = append(stale, StaleEntry{ID: id, Err: err})
 				continue
 			}
-			return nil, err
+			return nil, nil, err
 		}
 		objs = append(objs, pruneObj)
 	}
-	return objs, nil
+	return objs, stale, nil
 }
 
 func (p *Pruner) getObject(id object.ObjMetadata) (*unstructured.Unstructured, error) {
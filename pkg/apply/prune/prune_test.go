@@ -9,6 +9,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-logr/logr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -342,6 +343,7 @@ func TestPrune(t *testing.T) {
 						Identifier: object.UnstructuredToObjMetadata(pod),
 						Status:     event.PruneSkipped,
 						Object:     pod,
+						SkipReason: event.ApplyPreventedDeletion,
 						Error: testutil.EqualError(&filter.ApplyPreventedDeletionError{
 							UID: "pod-uid",
 						}),
@@ -372,6 +374,7 @@ func TestPrune(t *testing.T) {
 						Identifier: object.UnstructuredToObjMetadata(pod),
 						Status:     event.PruneSkipped,
 						Object:     pod,
+						SkipReason: event.ApplyPreventedDeletion,
 						Error: testutil.EqualError(&filter.ApplyPreventedDeletionError{
 							UID: "pod-uid",
 						}),
@@ -412,6 +415,7 @@ func TestPrune(t *testing.T) {
 						Status:     event.PruneSkipped,
 						Object: testutil.Mutate(podDeletionPrevention.DeepCopy(),
 							testutil.DeleteOwningInv(t, testInventoryLabel)),
+						SkipReason: event.AnnotationPreventedDeletion,
 						Error: testutil.EqualError(&filter.AnnotationPreventedDeletionError{
 							Annotation: common.OnRemoveAnnotation,
 							Value:      common.OnRemoveKeep,
@@ -425,6 +429,7 @@ func TestPrune(t *testing.T) {
 						Status:     event.PruneSkipped,
 						Object: testutil.Unstructured(t, pdbDeletePreventionManifest,
 							testutil.DeleteOwningInv(t, testInventoryLabel)),
+						SkipReason: event.AnnotationPreventedDeletion,
 						Error: testutil.EqualError(&filter.AnnotationPreventedDeletionError{
 							Annotation: common.LifecycleDeleteAnnotation,
 							Value:      common.PreventDeletion,
@@ -460,6 +465,7 @@ func TestPrune(t *testing.T) {
 						Status:     event.DeleteSkipped,
 						Object: testutil.Mutate(podDeletionPrevention.DeepCopy(),
 							testutil.DeleteOwningInv(t, testInventoryLabel)),
+						SkipReason: event.AnnotationPreventedDeletion,
 						Error: testutil.EqualError(&filter.AnnotationPreventedDeletionError{
 							Annotation: common.OnRemoveAnnotation,
 							Value:      common.OnRemoveKeep,
@@ -473,6 +479,7 @@ func TestPrune(t *testing.T) {
 						Status:     event.DeleteSkipped,
 						Object: testutil.Unstructured(t, pdbDeletePreventionManifest,
 							testutil.DeleteOwningInv(t, testInventoryLabel)),
+						SkipReason: event.AnnotationPreventedDeletion,
 						Error: testutil.EqualError(&filter.AnnotationPreventedDeletionError{
 							Annotation: common.LifecycleDeleteAnnotation,
 							Value:      common.PreventDeletion,
@@ -502,6 +509,7 @@ func TestPrune(t *testing.T) {
 						Status:     event.PruneSkipped,
 						Object: testutil.Mutate(podDeletionPrevention.DeepCopy(),
 							testutil.DeleteOwningInv(t, testInventoryLabel)),
+						SkipReason: event.AnnotationPreventedDeletion,
 						Error: testutil.EqualError(&filter.AnnotationPreventedDeletionError{
 							Annotation: common.OnRemoveAnnotation,
 							Value:      common.OnRemoveKeep,
@@ -540,6 +548,7 @@ func TestPrune(t *testing.T) {
 						Identifier: object.UnstructuredToObjMetadata(namespace),
 						Status:     event.PruneSkipped,
 						Object:     namespace,
+						SkipReason: event.NamespaceInUse,
 						Error: testutil.EqualError(&filter.NamespaceInUseError{
 							Namespace: namespace.GetName(),
 						}),
@@ -644,6 +653,7 @@ func TestPrune(t *testing.T) {
 func TestPruneDeletionPrevention(t *testing.T) {
 	tests := map[string]struct {
 		pruneObj *unstructured.Unstructured
+		filters  []filter.ValidationFilter
 		options  Options
 	}{
 		"an object with the cli-utils.sigs.k8s.io/on-remove annotation (prune)": {
@@ -662,6 +672,13 @@ func TestPruneDeletionPrevention(t *testing.T) {
 			pruneObj: testutil.Unstructured(t, pdbDeletePreventionManifest),
 			options:  defaultOptionsDestroy,
 		},
+		"an object of a kept kind (destroy)": {
+			pruneObj: namespace,
+			filters: []filter.ValidationFilter{
+				filter.KeepKindsFilter{GroupKinds: []schema.GroupKind{{Kind: "Namespace"}}},
+			},
+			options: defaultOptionsDestroy,
+		},
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -677,10 +694,14 @@ func TestPruneDeletionPrevention(t *testing.T) {
 			eventChannel := make(chan event.Event, 2)
 			resourceCache := cache.NewResourceCacheMap()
 			taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
+			filters := tc.filters
+			if filters == nil {
+				filters = []filter.ValidationFilter{filter.PreventRemoveFilter{}}
+			}
 			err := func() error {
 				defer close(eventChannel)
 				// Run the prune and validate.
-				return po.Prune([]*unstructured.Unstructured{tc.pruneObj}, []filter.ValidationFilter{filter.PreventRemoveFilter{}}, taskContext, "test-0", tc.options)
+				return po.Prune([]*unstructured.Unstructured{tc.pruneObj}, filters, taskContext, "test-0", tc.options)
 			}()
 			require.NoError(t, err)
 
@@ -739,7 +760,7 @@ func TestPruneWithErrors(t *testing.T) {
 					PruneEvent: &testutil.ExpPruneEvent{
 						Identifier: object.UnstructuredToObjMetadata(pdbDeleteFailure),
 						Status:     event.PruneFailed,
-						Error:      fmt.Errorf("expected delete error"),
+						Error:      testutil.EqualErrorString("expected delete error"),
 					},
 				},
 			},
@@ -753,7 +774,7 @@ func TestPruneWithErrors(t *testing.T) {
 					DeleteEvent: &testutil.ExpDeleteEvent{
 						Identifier: object.UnstructuredToObjMetadata(pdbDeleteFailure),
 						Status:     event.DeleteFailed,
-						Error:      fmt.Errorf("expected delete error"),
+						Error:      testutil.EqualErrorString("expected delete error"),
 					},
 				},
 			},
@@ -860,7 +881,7 @@ func TestGetPruneObjs(t *testing.T) {
 					scheme.Scheme.PrioritizedVersionsAllGroups()...),
 			}
 			currentInventory := createInventoryInfo(tc.prevInventory...)
-			actualObjs, err := po.GetPruneObjs(currentInventory, tc.localObjs, Options{})
+			actualObjs, err := po.GetPruneObjs(context.TODO(), currentInventory, tc.localObjs, Options{})
 			if err != nil {
 				t.Fatalf("unexpected error %s returned", err)
 			}
@@ -876,6 +897,28 @@ func TestGetPruneObjs(t *testing.T) {
 	}
 }
 
+func TestPlanPrune_StaleEntries(t *testing.T) {
+	crontab := testutil.Unstructured(t, crontabCRManifest)
+	prevInventory := []*unstructured.Unstructured{crontab, pod, namespace}
+	po := Pruner{
+		InvClient: inventory.NewFakeClient(object.UnstructuredSetToObjMetadataSet(prevInventory)),
+		// The pod was deleted by another client.
+		Client: fake.NewSimpleDynamicClient(scheme.Scheme, namespace),
+		Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
+			scheme.Scheme.PrioritizedVersionsAllGroups()...),
+	}
+	pruneObjs, stale, err := po.PlanPrune(context.TODO(), createInventoryInfo(prevInventory...), nil, Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, object.ObjMetadataSet{object.UnstructuredToObjMetadata(namespace)},
+		object.UnstructuredSetToObjMetadataSet(pruneObjs))
+	require.Len(t, stale, 2)
+	assert.Equal(t, object.UnstructuredToObjMetadata(crontab), stale[0].ID)
+	assert.True(t, meta.IsNoMatchError(stale[0].Err), stale[0].Err)
+	assert.Equal(t, object.UnstructuredToObjMetadata(pod), stale[1].ID)
+	assert.True(t, apierrors.IsNotFound(stale[1].Err), stale[1].Err)
+}
+
 func TestGetObject_NoMatchError(t *testing.T) {
 	po := Pruner{
 		Client: fake.NewSimpleDynamicClient(scheme.Scheme, pod, namespace),
@@ -915,7 +958,7 @@ func TestHandleDeletePrevention(t *testing.T) {
 			scheme.Scheme.PrioritizedVersionsAllGroups()...),
 	}
 	var err error
-	obj, err = po.removeInventoryAnnotation(obj)
+	obj, err = po.removeInventoryAnnotation(logr.Discard(), obj)
 	if err != nil {
 		t.Fatalf("unexpected error %s returned", err)
 	}
@@ -989,6 +1032,47 @@ func TestPrune_PropagationPolicy(t *testing.T) {
 	}
 }
 
+func TestPrune_DeleteConcurrency(t *testing.T) {
+	pruneObjs := []*unstructured.Unstructured{pod, pdb, namespace}
+	clusterObjs := make([]runtime.Object, 0, len(pruneObjs))
+	for _, obj := range pruneObjs {
+		clusterObjs = append(clusterObjs, obj)
+	}
+	pruneIds := object.UnstructuredSetToObjMetadataSet(pruneObjs)
+	po := Pruner{
+		InvClient: inventory.NewFakeClient(pruneIds),
+		Client:    fake.NewSimpleDynamicClient(scheme.Scheme, clusterObjs...),
+		Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
+			scheme.Scheme.PrioritizedVersionsAllGroups()...),
+	}
+
+	eventChannel := make(chan event.Event, len(pruneObjs))
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
+	err := po.Prune(pruneObjs, []filter.ValidationFilter{}, taskContext, "test-0", Options{
+		PropagationPolicy: metav1.DeletePropagationBackground,
+		Destroy:           true,
+		DeleteConcurrency: 2,
+		DeleteQPS:         100,
+	})
+	require.NoError(t, err)
+	close(eventChannel)
+
+	// The deletions are concurrent, so the order of the events is not
+	// deterministic.
+	var deletedIds object.ObjMetadataSet
+	for e := range eventChannel {
+		assert.Equal(t, event.DeleteSuccessful, e.DeleteEvent.Status)
+		deletedIds = append(deletedIds, e.DeleteEvent.Identifier)
+	}
+	assert.True(t, pruneIds.Equal(deletedIds), "expected deleted objects %v, got %v", pruneIds, deletedIds)
+	assert.True(t, pruneIds.Equal(taskContext.InventoryManager().SuccessfulDeletes()))
+	for _, id := range pruneIds {
+		_, err := po.getObject(id)
+		assert.Truef(t, apierrors.IsNotFound(err), "expected object to be deleted: %s", id)
+	}
+}
+
 type fakeDynamicClient struct {
 	resourceInterface dynamic.ResourceInterface
 }
@@ -1010,3 +1094,48 @@ type fakeDynamicResourceClient struct {
 func (c *fakeDynamicResourceClient) Namespace(ns string) dynamic.ResourceInterface {
 	return c.resourceInterface
 }
+
+func TestPrune_DeleteConcurrency_ContextDone(t *testing.T) {
+	pruneObjs := []*unstructured.Unstructured{pod, pdb, namespace}
+	clusterObjs := make([]runtime.Object, 0, len(pruneObjs))
+	for _, obj := range pruneObjs {
+		clusterObjs = append(clusterObjs, obj)
+	}
+	pruneIds := object.UnstructuredSetToObjMetadataSet(pruneObjs)
+	po := Pruner{
+		InvClient: inventory.NewFakeClient(pruneIds),
+		Client:    fake.NewSimpleDynamicClient(scheme.Scheme, clusterObjs...),
+		Mapper: testrestmapper.TestOnlyStaticRESTMapper(scheme.Scheme,
+			scheme.Scheme.PrioritizedVersionsAllGroups()...),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	eventChannel := make(chan event.Event, len(pruneObjs))
+	resourceCache := cache.NewResourceCacheMap()
+	taskContext := taskrunner.NewTaskContext(eventChannel, resourceCache)
+	taskContext.SetContext(ctx)
+	err := po.Prune(pruneObjs, []filter.ValidationFilter{}, taskContext, "test-0", Options{
+		PropagationPolicy: metav1.DeletePropagationBackground,
+		Destroy:           true,
+		DeleteConcurrency: 2,
+		DeleteQPS:         100,
+	})
+	require.NoError(t, err)
+	close(eventChannel)
+
+	// No object is deleted once the run is cancelled, and they all stay in
+	// the inventory.
+	var failedIds object.ObjMetadataSet
+	for e := range eventChannel {
+		assert.Equal(t, event.DeleteFailed, e.DeleteEvent.Status)
+		assert.ErrorIs(t, e.DeleteEvent.Error, context.Canceled)
+		failedIds = append(failedIds, e.DeleteEvent.Identifier)
+	}
+	assert.True(t, pruneIds.Equal(failedIds), "expected failed objects %v, got %v", pruneIds, failedIds)
+	assert.True(t, pruneIds.Equal(taskContext.InventoryManager().FailedDeletes()))
+	for _, id := range pruneIds {
+		_, err := po.getObject(id)
+		assert.NoError(t, err)
+	}
+}
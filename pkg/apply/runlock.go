@@ -0,0 +1,81 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+// ConcurrentRunError is returned when an Applier or Destroyer run is started
+// for an inventory that another run in the same process is still using.
+type ConcurrentRunError struct {
+	// Inventory identifies the inventory with its namespace and name.
+	Inventory string
+}
+
+func (e *ConcurrentRunError) Error() string {
+	return fmt.Sprintf("another run is in progress for inventory %q", e.Inventory)
+}
+
+// runs tracks the inventories used by the runs of all the Appliers and
+// Destroyers of the process. The inventories are keyed by cluster, so
+// Appliers of different clusters don't block each other.
+var runs = &inventoryRuns{
+	running: make(map[string]chan struct{}),
+}
+
+// inventoryRuns makes sure only one run at a time uses each inventory, so
+// the task groups of concurrent runs don't interleave and corrupt the
+// inventory and the set of objects to prune.
+type inventoryRuns struct {
+	mu sync.Mutex
+	// running maps the inventories to a channel that is closed when the run
+	// using the inventory ends.
+	running map[string]chan struct{}
+}
+
+// start registers a run for the inventory in the cluster, and returns a
+// function to call when the run ends. If another run is using the
+// inventory, start returns a ConcurrentRunError, or waits until the other
+// run ends if wait is true.
+func (r *inventoryRuns) start(ctx context.Context, cluster string, invInfo inventory.Info, wait bool) (func(), error) {
+	inv := inventoryKey(invInfo)
+	key := cluster + " " + inv
+	for {
+		r.mu.Lock()
+		done, found := r.running[key]
+		if !found {
+			done = make(chan struct{})
+			r.running[key] = done
+			r.mu.Unlock()
+			return func() {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				delete(r.running, key)
+				close(done)
+			}, nil
+		}
+		r.mu.Unlock()
+
+		if !wait {
+			return nil, &ConcurrentRunError{Inventory: inv}
+		}
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// inventoryKey returns the namespace and name of the inventory. The ID is not
+// used, because the same inventory object may be referenced with or
+// without it.
+func inventoryKey(invInfo inventory.Info) string {
+	return fmt.Sprintf("%s/%s", invInfo.Namespace(), invInfo.Name())
+}
@@ -0,0 +1,84 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+)
+
+func TestInventoryRuns(t *testing.T) {
+	invInfo := inventory.WrapInventoryInfoObj(inventoryInfo{
+		name:      "abc-123",
+		namespace: "test",
+		id:        "test",
+	}.toUnstructured())
+	otherInvInfo := inventory.WrapInventoryInfoObj(inventoryInfo{
+		name:      "abc-456",
+		namespace: "test",
+		id:        "other",
+	}.toUnstructured())
+
+	// The same inventory, referenced without its ID.
+	noIDInvInfo := inventory.WrapInventoryInfoObj(inventoryInfo{
+		name:      "abc-123",
+		namespace: "test",
+	}.toUnstructured())
+
+	r := &inventoryRuns{running: make(map[string]chan struct{})}
+	ctx := context.Background()
+	cluster := "https://cluster-a"
+
+	endRun, err := r.start(ctx, cluster, invInfo, false)
+	require.NoError(t, err)
+
+	// A concurrent run for the same inventory fails fast.
+	_, err = r.start(ctx, cluster, invInfo, false)
+	var concurrentRunErr *ConcurrentRunError
+	require.True(t, errors.As(err, &concurrentRunErr))
+	assert.Equal(t, "test/abc-123", concurrentRunErr.Inventory)
+
+	// The inventory is identified by its namespace and name, with or
+	// without its ID.
+	_, err = r.start(ctx, cluster, noIDInvInfo, false)
+	require.True(t, errors.As(err, &concurrentRunErr))
+
+	// Runs for the same inventory in other clusters are not affected.
+	endOtherClusterRun, err := r.start(ctx, "https://cluster-b", invInfo, false)
+	require.NoError(t, err)
+	endOtherClusterRun()
+
+	// Runs for other inventories are not affected.
+	endOtherRun, err := r.start(ctx, cluster, otherInvInfo, false)
+	require.NoError(t, err)
+	endOtherRun()
+
+	// A waiting run stops waiting when the context is cancelled.
+	cancelCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = r.start(cancelCtx, cluster, invInfo, true)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	// A waiting run starts when the other run ends.
+	started := make(chan struct{})
+	go func() {
+		defer close(started)
+		endWaitingRun, err := r.start(ctx, cluster, invInfo, true)
+		assert.NoError(t, err)
+		endWaitingRun()
+	}()
+	endRun()
+	select {
+	case <-started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the run to start")
+	}
+	assert.Empty(t, r.running)
+}
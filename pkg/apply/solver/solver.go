@@ -15,14 +15,18 @@
 package solver
 
 import (
+	"context"
 	"fmt"
+	"strconv"
 	"time"
 
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
-	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
 	"sigs.k8s.io/cli-utils/pkg/apply/info"
@@ -34,9 +38,15 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/graph"
+	"sigs.k8s.io/cli-utils/pkg/object/hook"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
 )
 
+var (
+	jobGK = schema.GroupKind{Group: "batch", Kind: "Job"}
+	podGK = schema.GroupKind{Group: "", Kind: "Pod"}
+)
+
 type TaskQueueBuilder struct {
 	Pruner        *prune.Pruner
 	DynamicClient dynamic.Interface
@@ -44,6 +54,8 @@ type TaskQueueBuilder struct {
 	InfoHelper    info.Helper
 	Mapper        meta.RESTMapper
 	InvClient     inventory.Client
+	// Warnings records the warnings of the server for the applied objects.
+	Warnings *info.WarningRecorder
 	// Collector is used to collect validation errors and invalid objects.
 	// Invalid objects will be filtered and not be injected into tasks.
 	Collector     *validation.Collector
@@ -55,10 +67,13 @@ type TaskQueueBuilder struct {
 	applyCounter int
 	pruneCounter int
 	waitCounter  int
+	hookCounter  int
 
-	invInfo   inventory.Info
-	applyObjs object.UnstructuredSet
-	pruneObjs object.UnstructuredSet
+	invInfo      inventory.Info
+	applyObjs    object.UnstructuredSet
+	pruneObjs    object.UnstructuredSet
+	excludedObjs object.UnstructuredSet
+	stale        []prune.StaleEntry
 }
 
 type TaskQueue struct {
@@ -89,15 +104,34 @@ func (tq *TaskQueue) ToActionGroups() []event.ActionGroup {
 type Options struct {
 	ServerSideOptions common.ServerSideOptions
 	ReconcileTimeout  time.Duration
+	// WaitForJobCompletion waits for the applied Jobs to complete, unless
+	// their wait-for-completion annotation says otherwise.
+	WaitForJobCompletion bool
 	// True if we are destroying, which deletes the inventory object
 	// as well (possibly) the inventory namespace.
 	Destroy bool
+	// KeepInventory keeps the empty inventory object after a successful
+	// destroy.
+	KeepInventory bool
 	// True if we're deleting prune objects
 	Prune                  bool
 	DryRunStrategy         common.DryRunStrategy
 	PrunePropagationPolicy metav1.DeletionPropagation
 	PruneTimeout           time.Duration
 	InventoryPolicy        inventory.Policy
+	// Redactor redacts the sensitive values of the objects in the events.
+	Redactor *object.Redactor
+	// SkipUnchangedInventoryAdd skips the inventory update at the start of
+	// the run if no objects are added to the inventory.
+	SkipUnchangedInventoryAdd bool
+	// DeleteConcurrency is the number of objects deleted in parallel by
+	// each prune task.
+	DeleteConcurrency int
+	// DeleteQPS limits the deletions per second of each parallel worker.
+	DeleteQPS float32
+	// FieldDiffs attaches the changes of the fields of the applied objects
+	// to the apply events.
+	FieldDiffs bool
 }
 
 // WithInventory sets the inventory info and returns the builder for chaining.
@@ -118,26 +152,85 @@ func (t *TaskQueueBuilder) WithPruneObjects(pruneObjs object.UnstructuredSet) *T
 	return t
 }
 
+// WithStaleEntries sets the stale inventory entries, which are removed from
+// the inventory, and returns the builder for chaining.
+func (t *TaskQueueBuilder) WithStaleEntries(stale []prune.StaleEntry) *TaskQueueBuilder {
+	t.stale = stale
+	return t
+}
+
+// WithExcludedObjects sets the objects excluded from the run, which are
+// neither applied nor pruned but stay in the inventory, and returns the
+// builder for chaining.
+func (t *TaskQueueBuilder) WithExcludedObjects(excludedObjs object.UnstructuredSet) *TaskQueueBuilder {
+	t.excludedObjs = excludedObjs
+	return t
+}
+
+// objectExists returns true if the object exists in the cluster.
+func (t *TaskQueueBuilder) objectExists(ctx context.Context, id object.ObjMetadata) (bool, error) {
+	mapping, err := t.Mapper.RESTMapping(id.GroupKind)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	_, err = t.DynamicClient.Resource(mapping.Resource).Namespace(id.Namespace).
+		Get(ctx, id.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
 // Build returns the queue of tasks that have been created
 func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options) *TaskQueue {
+	logger := taskContext.Logger()
 	var tasks []taskrunner.Task
 
 	// reset counters
 	t.applyCounter = 0
 	t.pruneCounter = 0
 	t.waitCounter = 0
+	t.hookCounter = 0
 
 	// Filter objects that failed earlier validation
 	applyObjs := t.Collector.FilterInvalidObjects(t.applyObjs)
 	pruneObjs := t.Collector.FilterInvalidObjects(t.pruneObjs)
 
+	// Hooks are applied in their own phases, so they are not sorted with
+	// the other objects. Pre-prune hooks only run if objects are pruned.
+	// Otherwise, the ones applied by earlier runs are kept in the
+	// inventory, so they are not orphaned, and are pruned once they are
+	// removed from the set of objects to apply.
+	hooks, applyObjs := t.splitHooks(applyObjs)
+	var skippedHooks object.UnstructuredSet
+	if !o.Prune || len(pruneObjs) == 0 {
+		skippedHooks = hooks[hook.PrePrune]
+		delete(hooks, hook.PrePrune)
+	}
+	completionIds := t.completionIds(applyObjs, o)
+
 	// Merge applyObjs & pruneObjs and graph them together.
 	// This detects implicit and explicit dependencies.
 	// Invalid dependency annotations will be treated as validation errors.
 	allObjs := make(object.UnstructuredSet, 0, len(applyObjs)+len(pruneObjs))
 	allObjs = append(allObjs, applyObjs...)
 	allObjs = append(allObjs, pruneObjs...)
-	g, err := graph.DependencyGraph(allObjs)
+	// References to objects outside the set are valid if the objects
+	// exist in the cluster.
+	var exists graph.ExistsFunc
+	if t.DynamicClient != nil && t.Mapper != nil {
+		ctx := taskContext.Context()
+		exists = func(id object.ObjMetadata) (bool, error) {
+			return t.objectExists(ctx, id)
+		}
+	}
+	g, err := graph.DependencyGraphWithExternal(allObjs, exists)
 	if err != nil {
 		t.Collector.Collect(err)
 	}
@@ -154,18 +247,29 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 	applyObjs = t.Collector.FilterInvalidObjects(applyObjs)
 	pruneObjs = t.Collector.FilterInvalidObjects(pruneObjs)
 
+	// Hooks are applied, so they are added to the inventory like the other
+	// applied objects, and pruned if they are not deleted.
+	hookObjs := append(append(append(object.UnstructuredSet{},
+		hooks[hook.PreApply]...), hooks[hook.PostApply]...), hooks[hook.PrePrune]...)
+	for _, id := range object.UnstructuredSetToObjMetadataSet(hookObjs) {
+		taskContext.InventoryManager().AddPendingApply(id)
+	}
+
 	if !o.Destroy {
 		// InvAddTask creates the inventory and adds any objects being applied
-		klog.V(2).Infof("adding inventory add task (%d objects)", len(applyObjs))
+		logger.V(2).Info("adding inventory add task", "objects", len(applyObjs)+len(hookObjs))
 		tasks = append(tasks, &task.InvAddTask{
-			TaskName:  "inventory-add-0",
-			InvClient: t.InvClient,
-			InvInfo:   t.invInfo,
-			Objects:   applyObjs,
-			DryRun:    o.DryRunStrategy,
+			TaskName:      "inventory-add-0",
+			InvClient:     t.InvClient,
+			InvInfo:       t.invInfo,
+			Objects:       append(append(object.UnstructuredSet{}, applyObjs...), hookObjs...),
+			DryRun:        o.DryRunStrategy,
+			SkipUnchanged: o.SkipUnchangedInventoryAdd,
 		})
 	}
 
+	tasks = append(tasks, t.newHookTasks(logger, hook.PreApply, hooks[hook.PreApply], o)...)
+
 	if len(applyObjs) > 0 {
 		// Register actuation plan in the inventory
 		for _, id := range object.UnstructuredSetToObjMetadataSet(applyObjs) {
@@ -177,17 +281,22 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 
 		for _, applySet := range applySets {
 			tasks = append(tasks,
-				t.newApplyTask(applySet, t.ApplyFilters, t.ApplyMutators, o))
+				t.newApplyTask(logger, applySet, t.ApplyFilters, t.ApplyMutators, o))
 			// dry-run skips wait tasks
 			if !o.DryRunStrategy.ClientOrServerDryRun() {
 				applyIds := object.UnstructuredSetToObjMetadataSet(applySet)
-				tasks = append(tasks,
-					t.newWaitTask(applyIds, taskrunner.AllCurrent, o.ReconcileTimeout))
+				waitTask := t.newWaitTask(logger, applyIds, taskrunner.AllCurrent, o.ReconcileTimeout)
+				waitTask.CompletionIds = applyIds.Intersection(completionIds)
+				tasks = append(tasks, waitTask)
 			}
 		}
 	}
 
+	tasks = append(tasks, t.newHookTasks(logger, hook.PostApply, hooks[hook.PostApply], o)...)
+
 	if o.Prune && len(pruneObjs) > 0 {
+		tasks = append(tasks, t.newHookTasks(logger, hook.PrePrune, hooks[hook.PrePrune], o)...)
+
 		// Register actuation plan in the inventory
 		for _, id := range object.UnstructuredSetToObjMetadataSet(pruneObjs) {
 			taskContext.InventoryManager().AddPendingDelete(id)
@@ -201,18 +310,20 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 
 		for _, pruneSet := range pruneSets {
 			tasks = append(tasks,
-				t.newPruneTask(pruneSet, t.PruneFilters, o))
+				t.newPruneTask(logger, pruneSet, t.PruneFilters, o))
 			// dry-run skips wait tasks
 			if !o.DryRunStrategy.ClientOrServerDryRun() {
 				pruneIds := object.UnstructuredSetToObjMetadataSet(pruneSet)
 				tasks = append(tasks,
-					t.newWaitTask(pruneIds, taskrunner.AllNotFound, o.PruneTimeout))
+					t.newWaitTask(logger, pruneIds, taskrunner.AllNotFound, o.PruneTimeout))
 			}
 		}
 	}
 
-	prevInvIds, _ := t.InvClient.GetClusterObjs(t.invInfo)
-	klog.V(2).Infoln("adding delete/update inventory task")
+	prevInvIds, _ := t.InvClient.GetClusterObjs(taskContext.Context(), t.invInfo)
+	retained := object.UnstructuredSetToObjMetadataSet(t.excludedObjs)
+	retained = append(retained, object.UnstructuredSetToObjMetadataSet(skippedHooks)...)
+	logger.V(2).Info("adding delete/update inventory task")
 	var taskName string
 	if o.Destroy {
 		taskName = "inventory-delete-or-update-0"
@@ -224,21 +335,134 @@ func (t *TaskQueueBuilder) Build(taskContext *taskrunner.TaskContext, o Options)
 		InvClient:     t.InvClient,
 		InvInfo:       t.invInfo,
 		PrevInventory: prevInvIds,
+		Retained:      retained,
+		Stale:         t.stale,
 		DryRun:        o.DryRunStrategy,
 		Destroy:       o.Destroy,
+		KeepInventory: o.KeepInventory,
 	})
 
 	return &TaskQueue{tasks: tasks}
 }
 
+// completionIds returns the objects that must run to completion before they
+// are reconciled: the Jobs, if WaitForJobCompletion is true, and the Jobs and
+// Pods with the wait-for-completion annotation set to true. Invalid
+// annotations are collected as validation errors.
+func (t *TaskQueueBuilder) completionIds(objs object.UnstructuredSet, o Options) object.ObjMetadataSet {
+	var ids object.ObjMetadataSet
+	for _, obj := range objs {
+		gk := obj.GroupVersionKind().GroupKind()
+		if gk != jobGK && gk != podGK {
+			continue
+		}
+		wait := o.WaitForJobCompletion && gk == jobGK
+		if value, found := obj.GetAnnotations()[common.WaitForCompletionAnnotation]; found {
+			var err error
+			wait, err = strconv.ParseBool(value)
+			if err != nil {
+				id := object.UnstructuredToObjMetadata(obj)
+				t.Collector.Collect(validation.NewError(object.WithSource(obj, object.InvalidAnnotationError{
+					Annotation: common.WaitForCompletionAnnotation,
+					Cause:      err,
+				}), id))
+				continue
+			}
+		}
+		if wait {
+			ids = append(ids, object.UnstructuredToObjMetadata(obj))
+		}
+	}
+	return ids
+}
+
+// splitHooks returns the hooks, by phase, and the other objects. Hooks with
+// invalid annotations are collected as validation errors and dropped.
+func (t *TaskQueueBuilder) splitHooks(objs object.UnstructuredSet) (map[hook.Phase]object.UnstructuredSet, object.UnstructuredSet) {
+	hooks := make(map[hook.Phase]object.UnstructuredSet)
+	others := make(object.UnstructuredSet, 0, len(objs))
+	for _, obj := range objs {
+		if !hook.HasAnnotation(obj) {
+			others = append(others, obj)
+			continue
+		}
+		phase, err := hook.ReadAnnotation(obj)
+		if err == nil {
+			_, err = hook.DeleteOnSuccess(obj)
+		}
+		if err != nil {
+			id := object.UnstructuredToObjMetadata(obj)
+			t.Collector.Collect(validation.NewError(object.WithSource(obj, err), id))
+			continue
+		}
+		hooks[phase] = append(hooks[phase], obj)
+	}
+	return hooks, others
+}
+
+// newHookTasks returns the tasks that run the hooks of a phase: a task to
+// apply the hooks, a task to wait for them to complete, which stops the run
+// if any of them fails, and a task to delete the hooks that succeeded, if
+// their delete policy says so.
+func (t *TaskQueueBuilder) newHookTasks(logger logr.Logger, phase hook.Phase, hooks object.UnstructuredSet, o Options) []taskrunner.Task {
+	hooks = t.Collector.FilterInvalidObjects(hooks)
+	if len(hooks) == 0 {
+		return nil
+	}
+	logger.V(2).Info("adding hook tasks", "phase", phase, "objects", len(hooks))
+	prefix := fmt.Sprintf("%s-hooks", phase)
+	tasks := []taskrunner.Task{
+		t.applyTask(fmt.Sprintf("%s-apply-%d", prefix, t.hookCounter), hooks, t.ApplyFilters, t.ApplyMutators, o),
+	}
+	// dry-run skips wait and delete tasks
+	if !o.DryRunStrategy.ClientOrServerDryRun() {
+		waitTask := taskrunner.NewWaitTask(
+			fmt.Sprintf("%s-wait-%d", prefix, t.hookCounter),
+			object.UnstructuredSetToObjMetadataSet(hooks),
+			taskrunner.AllCompleted,
+			o.ReconcileTimeout,
+			t.Mapper,
+		)
+		waitTask.Required = true
+		tasks = append(tasks, waitTask)
+
+		var deletes object.UnstructuredSet
+		for _, obj := range hooks {
+			if deleteOnSuccess, _ := hook.DeleteOnSuccess(obj); deleteOnSuccess {
+				deletes = append(deletes, obj)
+			}
+		}
+		if len(deletes) > 0 {
+			tasks = append(tasks, &task.PruneTask{
+				TaskName:          fmt.Sprintf("%s-delete-%d", prefix, t.hookCounter),
+				Objects:           deletes,
+				Pruner:            t.Pruner,
+				PropagationPolicy: o.PrunePropagationPolicy,
+				DryRunStrategy:    o.DryRunStrategy,
+				Applied:           true,
+			})
+		}
+	}
+	t.hookCounter++
+	return tasks
+}
+
 // AppendApplyTask appends a task to the task queue to apply the passed objects
 // to the cluster. Returns a pointer to the Builder to chain function calls.
-func (t *TaskQueueBuilder) newApplyTask(applyObjs object.UnstructuredSet,
+func (t *TaskQueueBuilder) newApplyTask(logger logr.Logger, applyObjs object.UnstructuredSet,
 	applyFilters []filter.ValidationFilter, applyMutators []mutator.Interface, o Options) taskrunner.Task {
 	applyObjs = t.Collector.FilterInvalidObjects(applyObjs)
-	klog.V(2).Infof("adding apply task (%d objects)", len(applyObjs))
-	task := &task.ApplyTask{
-		TaskName:          fmt.Sprintf("apply-%d", t.applyCounter),
+	logger.V(2).Info("adding apply task", "objects", len(applyObjs))
+	task := t.applyTask(fmt.Sprintf("apply-%d", t.applyCounter), applyObjs, applyFilters, applyMutators, o)
+	t.applyCounter++
+	return task
+}
+
+// applyTask returns a task to apply the passed objects to the cluster.
+func (t *TaskQueueBuilder) applyTask(name string, applyObjs object.UnstructuredSet,
+	applyFilters []filter.ValidationFilter, applyMutators []mutator.Interface, o Options) *task.ApplyTask {
+	return &task.ApplyTask{
+		TaskName:          name,
 		Objects:           applyObjs,
 		Filters:           applyFilters,
 		Mutators:          applyMutators,
@@ -248,17 +472,18 @@ func (t *TaskQueueBuilder) newApplyTask(applyObjs object.UnstructuredSet,
 		OpenAPIGetter:     t.OpenAPIGetter,
 		InfoHelper:        t.InfoHelper,
 		Mapper:            t.Mapper,
+		Redactor:          o.Redactor,
+		Warnings:          t.Warnings,
+		FieldDiffs:        o.FieldDiffs,
 	}
-	t.applyCounter++
-	return task
 }
 
 // AppendWaitTask appends a task to wait on the passed objects to the task queue.
 // Returns a pointer to the Builder to chain function calls.
-func (t *TaskQueueBuilder) newWaitTask(waitIds object.ObjMetadataSet, condition taskrunner.Condition,
-	waitTimeout time.Duration) taskrunner.Task {
+func (t *TaskQueueBuilder) newWaitTask(logger logr.Logger, waitIds object.ObjMetadataSet, condition taskrunner.Condition,
+	waitTimeout time.Duration) *taskrunner.WaitTask {
 	waitIds = t.Collector.FilterInvalidIds(waitIds)
-	klog.V(2).Infoln("adding wait task")
+	logger.V(2).Info("adding wait task", "objects", len(waitIds))
 	task := taskrunner.NewWaitTask(
 		fmt.Sprintf("wait-%d", t.waitCounter),
 		waitIds,
@@ -272,10 +497,10 @@ func (t *TaskQueueBuilder) newWaitTask(waitIds object.ObjMetadataSet, condition
 
 // AppendPruneTask appends a task to delete objects from the cluster to the task queue.
 // Returns a pointer to the Builder to chain function calls.
-func (t *TaskQueueBuilder) newPruneTask(pruneObjs object.UnstructuredSet,
+func (t *TaskQueueBuilder) newPruneTask(logger logr.Logger, pruneObjs object.UnstructuredSet,
 	pruneFilters []filter.ValidationFilter, o Options) taskrunner.Task {
 	pruneObjs = t.Collector.FilterInvalidObjects(pruneObjs)
-	klog.V(2).Infof("adding prune task (%d objects)", len(pruneObjs))
+	logger.V(2).Info("adding prune task", "objects", len(pruneObjs))
 	task := &task.PruneTask{
 		TaskName:          fmt.Sprintf("prune-%d", t.pruneCounter),
 		Objects:           pruneObjs,
@@ -284,6 +509,8 @@ func (t *TaskQueueBuilder) newPruneTask(pruneObjs object.UnstructuredSet,
 		PropagationPolicy: o.PrunePropagationPolicy,
 		DryRunStrategy:    o.DryRunStrategy,
 		Destroy:           o.Destroy,
+		DeleteConcurrency: o.DeleteConcurrency,
+		DeleteQPS:         o.DeleteQPS,
 	}
 	t.pruneCounter++
 	return task
@@ -4,6 +4,7 @@
 package solver
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/kubectl/pkg/scheme"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/prune"
 	"sigs.k8s.io/cli-utils/pkg/apply/task"
@@ -1876,6 +1879,8 @@ func waitTaskComparer() cmp.Option {
 			x.Ids.Hash() == y.Ids.Hash() && // exact order match
 			x.Condition == y.Condition &&
 			x.Timeout == y.Timeout &&
+			x.Required == y.Required &&
+			x.CompletionIds.Hash() == y.CompletionIds.Hash() &&
 			cmp.Equal(x.Mapper, y.Mapper)
 	})
 }
@@ -1902,3 +1907,458 @@ func inventoryInfoComparer() cmp.Option {
 			x.Strategy() == y.Strategy()
 	})
 }
+
+func TestTaskQueueBuilder_HookBuild(t *testing.T) {
+	// Use a custom Asserter to customize the comparison options
+	asserter := testutil.NewAsserter(
+		cmpopts.EquateErrors(),
+		waitTaskComparer(),
+		fakeClientComparer(),
+		inventoryInfoComparer(),
+	)
+
+	invInfo := inventory.WrapInventoryInfoObj(newInvObject(
+		"abc-123", "default", "test"))
+
+	preApplyJob := `
+kind: Job
+apiVersion: batch/v1
+metadata:
+  name: migrate
+  namespace: test-namespace
+  annotations:
+    cli-utils.sigs.k8s.io/hook: pre-apply
+    cli-utils.sigs.k8s.io/hook-delete-policy: hook-succeeded
+`
+	postApplyPod := `
+kind: Pod
+apiVersion: v1
+metadata:
+  name: smoke-test
+  namespace: test-namespace
+  annotations:
+    cli-utils.sigs.k8s.io/hook: post-apply
+`
+	prePruneJob := `
+kind: Job
+apiVersion: batch/v1
+metadata:
+  name: backup
+  namespace: test-namespace
+  annotations:
+    cli-utils.sigs.k8s.io/hook: pre-prune
+`
+	invalidHook := `
+kind: Job
+apiVersion: batch/v1
+metadata:
+  name: invalid
+  namespace: test-namespace
+  annotations:
+    cli-utils.sigs.k8s.io/hook: post-delete
+`
+
+	testCases := map[string]struct {
+		applyObjs     object.UnstructuredSet
+		pruneObjs     object.UnstructuredSet
+		prevInventory object.ObjMetadataSet
+		options       Options
+		expectedTasks []taskrunner.Task
+		expectedError string
+	}{
+		"hooks in each phase": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, preApplyJob),
+				testutil.Unstructured(t, resources["deployment"]),
+				testutil.Unstructured(t, postApplyPod),
+				testutil.Unstructured(t, prePruneJob),
+			},
+			pruneObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["secret"]),
+			},
+			options: Options{Prune: true},
+			expectedTasks: []taskrunner.Task{
+				&task.InvAddTask{
+					TaskName:  "inventory-add-0",
+					InvClient: &inventory.FakeClient{},
+					InvInfo:   invInfo,
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, resources["deployment"]),
+						testutil.Unstructured(t, preApplyJob),
+						testutil.Unstructured(t, postApplyPod),
+						testutil.Unstructured(t, prePruneJob),
+					},
+				},
+				&task.ApplyTask{
+					TaskName: "pre-apply-hooks-apply-0",
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, preApplyJob),
+					},
+				},
+				&taskrunner.WaitTask{
+					TaskName: "pre-apply-hooks-wait-0",
+					Ids: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, preApplyJob),
+					},
+					Condition: taskrunner.AllCompleted,
+					Required:  true,
+				},
+				&task.PruneTask{
+					TaskName: "pre-apply-hooks-delete-0",
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, preApplyJob),
+					},
+					Applied: true,
+				},
+				&task.ApplyTask{
+					TaskName: "apply-0",
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, resources["deployment"]),
+					},
+				},
+				&taskrunner.WaitTask{
+					TaskName: "wait-0",
+					Ids: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["deployment"]),
+					},
+					Condition: taskrunner.AllCurrent,
+				},
+				&task.ApplyTask{
+					TaskName: "post-apply-hooks-apply-1",
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, postApplyPod),
+					},
+				},
+				&taskrunner.WaitTask{
+					TaskName: "post-apply-hooks-wait-1",
+					Ids: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, postApplyPod),
+					},
+					Condition: taskrunner.AllCompleted,
+					Required:  true,
+				},
+				&task.ApplyTask{
+					TaskName: "pre-prune-hooks-apply-2",
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, prePruneJob),
+					},
+				},
+				&taskrunner.WaitTask{
+					TaskName: "pre-prune-hooks-wait-2",
+					Ids: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, prePruneJob),
+					},
+					Condition: taskrunner.AllCompleted,
+					Required:  true,
+				},
+				&task.PruneTask{
+					TaskName: "prune-0",
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, resources["secret"]),
+					},
+				},
+				&taskrunner.WaitTask{
+					TaskName: "wait-1",
+					Ids: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["secret"]),
+					},
+					Condition: taskrunner.AllNotFound,
+				},
+				&task.DeleteOrUpdateInvTask{
+					TaskName:  "inventory-set-0",
+					InvClient: &inventory.FakeClient{},
+					InvInfo:   invInfo,
+					PrevInventory: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["secret"]),
+					},
+				},
+			},
+		},
+		"pre-prune hooks only run if objects are pruned": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, prePruneJob),
+			},
+			options: Options{Prune: true},
+			expectedTasks: []taskrunner.Task{
+				&task.InvAddTask{
+					TaskName:  "inventory-add-0",
+					InvClient: &inventory.FakeClient{},
+					InvInfo:   invInfo,
+					Objects:   object.UnstructuredSet{},
+				},
+				&task.DeleteOrUpdateInvTask{
+					TaskName:      "inventory-set-0",
+					InvClient:     &inventory.FakeClient{},
+					InvInfo:       invInfo,
+					PrevInventory: object.ObjMetadataSet{},
+					Retained: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, prePruneJob),
+					},
+				},
+			},
+		},
+		"pre-prune hooks of earlier runs stay in the inventory without prunes": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, resources["deployment"]),
+				testutil.Unstructured(t, prePruneJob),
+			},
+			prevInventory: object.ObjMetadataSet{
+				testutil.ToIdentifier(t, resources["deployment"]),
+				testutil.ToIdentifier(t, prePruneJob),
+			},
+			options: Options{Prune: true},
+			expectedTasks: []taskrunner.Task{
+				&task.InvAddTask{
+					TaskName:  "inventory-add-0",
+					InvClient: &inventory.FakeClient{},
+					InvInfo:   invInfo,
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, resources["deployment"]),
+					},
+				},
+				&task.ApplyTask{
+					TaskName: "apply-0",
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, resources["deployment"]),
+					},
+				},
+				&taskrunner.WaitTask{
+					TaskName: "wait-0",
+					Ids: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["deployment"]),
+					},
+					Condition: taskrunner.AllCurrent,
+				},
+				&task.DeleteOrUpdateInvTask{
+					TaskName:  "inventory-set-0",
+					InvClient: &inventory.FakeClient{},
+					InvInfo:   invInfo,
+					PrevInventory: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, resources["deployment"]),
+						testutil.ToIdentifier(t, prePruneJob),
+					},
+					Retained: object.ObjMetadataSet{
+						testutil.ToIdentifier(t, prePruneJob),
+					},
+				},
+			},
+		},
+		"dry-run skips waiting for and deleting hooks": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, preApplyJob),
+			},
+			options: Options{DryRunStrategy: common.DryRunClient},
+			expectedTasks: []taskrunner.Task{
+				&task.InvAddTask{
+					TaskName:  "inventory-add-0",
+					InvClient: &inventory.FakeClient{},
+					InvInfo:   invInfo,
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, preApplyJob),
+					},
+					DryRun: common.DryRunClient,
+				},
+				&task.ApplyTask{
+					TaskName: "pre-apply-hooks-apply-0",
+					Objects: object.UnstructuredSet{
+						testutil.Unstructured(t, preApplyJob),
+					},
+					DryRunStrategy: common.DryRunClient,
+				},
+				&task.DeleteOrUpdateInvTask{
+					TaskName:      "inventory-set-0",
+					InvClient:     &inventory.FakeClient{},
+					InvInfo:       invInfo,
+					PrevInventory: object.ObjMetadataSet{},
+					DryRun:        common.DryRunClient,
+				},
+			},
+		},
+		"invalid hook annotation": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, invalidHook),
+			},
+			expectedError: `invalid object: "test-namespace_invalid_batch_Job": ` +
+				`invalid "cli-utils.sigs.k8s.io/hook" annotation: ` +
+				`unknown phase "post-delete", must be one of "pre-apply", "post-apply", or "pre-prune"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mapper := testutil.NewFakeRESTMapper()
+			// inject mapper & pruner for equality comparison
+			for _, t := range tc.expectedTasks {
+				switch typedTask := t.(type) {
+				case *task.ApplyTask:
+					typedTask.Mapper = mapper
+				case *task.PruneTask:
+					typedTask.Pruner = &prune.Pruner{}
+				case *taskrunner.WaitTask:
+					typedTask.Mapper = mapper
+				}
+			}
+
+			prevInventory := object.UnstructuredSetToObjMetadataSet(tc.pruneObjs).Union(tc.prevInventory)
+			fakeInvClient := inventory.NewFakeClient(prevInventory)
+			vCollector := &validation.Collector{}
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    mapper,
+				InvClient: fakeInvClient,
+				Collector: vCollector,
+			}
+			taskContext := taskrunner.NewTaskContext(nil, nil)
+			tq := tqb.WithInventory(invInfo).
+				WithApplyObjects(tc.applyObjs).
+				WithPruneObjects(tc.pruneObjs).
+				Build(taskContext, tc.options)
+
+			err := vCollector.ToError()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			asserter.Equal(t, tc.expectedTasks, tq.tasks)
+		})
+	}
+}
+
+func TestTaskQueueBuilder_CompletionIds(t *testing.T) {
+	invInfo := inventory.WrapInventoryInfoObj(newInvObject(
+		"abc-123", "default", "test"))
+
+	job := `
+kind: Job
+apiVersion: batch/v1
+metadata:
+  name: job
+  namespace: test-namespace
+`
+	jobNoWait := `
+kind: Job
+apiVersion: batch/v1
+metadata:
+  name: job-no-wait
+  namespace: test-namespace
+  annotations:
+    cli-utils.sigs.k8s.io/wait-for-completion: "false"
+`
+	podWait := `
+kind: Pod
+apiVersion: v1
+metadata:
+  name: pod-wait
+  namespace: test-namespace
+  annotations:
+    cli-utils.sigs.k8s.io/wait-for-completion: "true"
+`
+	jobInvalid := `
+kind: Job
+apiVersion: batch/v1
+metadata:
+  name: job-invalid
+  namespace: test-namespace
+  annotations:
+    cli-utils.sigs.k8s.io/wait-for-completion: "maybe"
+`
+
+	testCases := map[string]struct {
+		applyObjs     object.UnstructuredSet
+		options       Options
+		expectedIds   object.ObjMetadataSet
+		expectedError string
+	}{
+		"no completion by default": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, job),
+				testutil.Unstructured(t, resources["pod"]),
+			},
+		},
+		"annotations": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, job),
+				testutil.Unstructured(t, podWait),
+			},
+			expectedIds: object.ObjMetadataSet{
+				testutil.ToIdentifier(t, podWait),
+			},
+		},
+		"wait for job completion": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, job),
+				testutil.Unstructured(t, jobNoWait),
+				testutil.Unstructured(t, resources["pod"]),
+				testutil.Unstructured(t, resources["deployment"]),
+			},
+			options: Options{WaitForJobCompletion: true},
+			expectedIds: object.ObjMetadataSet{
+				testutil.ToIdentifier(t, job),
+			},
+		},
+		"invalid annotation": {
+			applyObjs: object.UnstructuredSet{
+				testutil.Unstructured(t, jobInvalid),
+			},
+			expectedError: `invalid object: "test-namespace_job-invalid_batch_Job": ` +
+				`invalid "cli-utils.sigs.k8s.io/wait-for-completion" annotation: ` +
+				`strconv.ParseBool: parsing "maybe": invalid syntax`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			vCollector := &validation.Collector{}
+			tqb := TaskQueueBuilder{
+				Pruner:    pruner,
+				Mapper:    testutil.NewFakeRESTMapper(),
+				InvClient: inventory.NewFakeClient(nil),
+				Collector: vCollector,
+			}
+			taskContext := taskrunner.NewTaskContext(nil, nil)
+			tq := tqb.WithInventory(invInfo).
+				WithApplyObjects(tc.applyObjs).
+				Build(taskContext, tc.options)
+
+			err := vCollector.ToError()
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+
+			var completionIds object.ObjMetadataSet
+			for _, tsk := range tq.tasks {
+				if waitTask, ok := tsk.(*taskrunner.WaitTask); ok {
+					completionIds = append(completionIds, waitTask.CompletionIds...)
+				}
+			}
+			testutil.AssertEqual(t, tc.expectedIds.Hash(), completionIds.Hash())
+		})
+	}
+}
+
+func TestTaskQueueBuilder_ObjectExists(t *testing.T) {
+	secret := testutil.Unstructured(t, resources["secret"])
+	deployment := testutil.Unstructured(t, resources["deployment"])
+	tqb := TaskQueueBuilder{
+		Mapper: testutil.NewFakeRESTMapper(
+			secret.GroupVersionKind(),
+			deployment.GroupVersionKind(),
+		),
+		DynamicClient: dynamicfake.NewSimpleDynamicClient(scheme.Scheme, secret),
+	}
+
+	found, err := tqb.objectExists(context.Background(), testutil.ToIdentifier(t, resources["secret"]))
+	assert.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = tqb.objectExists(context.Background(), testutil.ToIdentifier(t, resources["deployment"]))
+	assert.NoError(t, err)
+	assert.False(t, found)
+
+	found, err = tqb.objectExists(context.Background(), testutil.ToIdentifier(t, resources["crontab1"]))
+	assert.NoError(t, err)
+	assert.False(t, found)
+}
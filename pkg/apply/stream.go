@@ -0,0 +1,177 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/openapi3"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+)
+
+// ObjectStream is a source of objects to apply, like a manifest reader.
+type ObjectStream interface {
+	// Next returns the next object, or io.EOF if there are no more objects.
+	Next() (*unstructured.Unstructured, error)
+}
+
+// ObjectStreamFunc is an adapter to allow the use of ordinary functions as
+// ObjectStreams.
+type ObjectStreamFunc func() (*unstructured.Unstructured, error)
+
+// Next calls f().
+func (f ObjectStreamFunc) Next() (*unstructured.Unstructured, error) {
+	return f()
+}
+
+// ChannelObjectStream returns an ObjectStream that reads the objects from
+// the channel, until the channel is closed.
+func ChannelObjectStream(objects <-chan *unstructured.Unstructured) ObjectStream {
+	return ObjectStreamFunc(func() (*unstructured.Unstructured, error) {
+		obj, ok := <-objects
+		if !ok {
+			return nil, io.EOF
+		}
+		return obj, nil
+	})
+}
+
+// RunStream performs the Apply step, like Run, for the objects read from the
+// stream. The objects are validated, planned, and applied only once the
+// stream is read to the end, since the objects to prune and the order of
+// the tasks depend on all the objects. While the stream is read, only the
+// server version and OpenAPI schema used by the validation are fetched, and
+// the resource mappings of the types are resolved. If the context is done
+// before the stream is read to the end, the run stops with the error of the
+// context, even if the stream is blocked.
+func (a *Applier) RunStream(ctx context.Context, invInfo inventory.Info, objects ObjectStream, options ApplierOptions) <-chan event.Event {
+	ctx = runContext(ctx, options.Logger, a.logger)
+	setDefaults(&options)
+	eventChannel := make(chan event.Event, options.EventBufferSize)
+	go func() {
+		defer close(eventChannel)
+		clusterInfo := &clusterInfo{applier: a}
+		clusterInfo.Prefetch(options)
+		objs, err := a.readStream(ctx, objects)
+		if err != nil {
+			handleError(eventChannel, err)
+			return
+		}
+		klog.FromContext(ctx).V(4).Info("apply run for streamed objects", "objects", len(objs))
+		a.run(ctx, invInfo, objs, options, eventChannel, clusterInfo)
+	}()
+	return eventChannel
+}
+
+// readStream reads all the objects from the stream, resolving the resource
+// mapping of each new type as they are read. Next may block, like when
+// reading from a channel, so the stream is read in the background, and
+// readStream returns as soon as the context is done. Next is not called
+// again once the context is done.
+func (a *Applier) readStream(ctx context.Context, objects ObjectStream) (object.UnstructuredSet, error) {
+	type result struct {
+		obj *unstructured.Unstructured
+		err error
+	}
+	results := make(chan result)
+	go func() {
+		for ctx.Err() == nil {
+			obj, err := objects.Next()
+			select {
+			case results <- result{obj: obj, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	var objs object.UnstructuredSet
+	mapped := make(map[schema.GroupKind]struct{})
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		var r result
+		select {
+		case r = <-results:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if errors.Is(r.err, io.EOF) {
+			return objs, nil
+		}
+		if r.err != nil {
+			return nil, fmt.Errorf("failed to read objects: %w", r.err)
+		}
+		obj := r.obj
+		objs = append(objs, obj)
+		gvk := obj.GroupVersionKind()
+		if _, found := mapped[gvk.GroupKind()]; found || gvk.Kind == "" {
+			continue
+		}
+		mapped[gvk.GroupKind()] = struct{}{}
+		// Errors are reported when the objects are validated.
+		if _, err := a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version); err != nil {
+			klog.FromContext(ctx).V(4).Info("failed to map type", "gvk", gvk, "error", err)
+		}
+	}
+}
+
+// clusterInfo fetches the cluster information needed to validate the
+// objects of a run at most once, so it can be fetched in the background
+// while the objects are read.
+type clusterInfo struct {
+	applier *Applier
+
+	versionOnce sync.Once
+	version     *version.Version
+	versionErr  error
+
+	openAPIOnce sync.Once
+	openAPI     openapi3.Root
+}
+
+// Prefetch starts fetching the cluster information required by the options
+// in the background.
+func (c *clusterInfo) Prefetch(options ApplierOptions) {
+	if options.DeprecatedAPIPolicy != validation.IgnoreDeprecatedAPIs {
+		go func() { _, _ = c.ServerVersion() }()
+	}
+	if options.ValidateSchema {
+		// The schemas of the GroupVersions are fetched when the objects
+		// are validated, but the list of the GroupVersions can be fetched
+		// early.
+		go func() { _, _ = c.OpenAPI().GroupVersions() }()
+	}
+}
+
+// ServerVersion returns the version of the cluster.
+func (c *clusterInfo) ServerVersion() (*version.Version, error) {
+	c.versionOnce.Do(func() {
+		c.version, c.versionErr = c.applier.getServerVersion()
+	})
+	return c.version, c.versionErr
+}
+
+// OpenAPI returns the OpenAPI v3 document of the cluster.
+func (c *clusterInfo) OpenAPI() openapi3.Root {
+	c.openAPIOnce.Do(func() {
+		c.openAPI = c.applier.getOpenAPIRoot()
+	})
+	return c.openAPI
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestReadStream(t *testing.T) {
+	deployment := testutil.Unstructured(t, resources["deployment"])
+	secret := testutil.Unstructured(t, resources["secret"])
+
+	testCases := map[string]struct {
+		objects     object.UnstructuredSet
+		streamErr   error
+		cancel      bool
+		expected    object.UnstructuredSet
+		expectedErr string
+	}{
+		"empty stream": {},
+		"objects are read in order": {
+			objects:  object.UnstructuredSet{deployment, secret},
+			expected: object.UnstructuredSet{deployment, secret},
+		},
+		"stream error": {
+			objects:     object.UnstructuredSet{deployment},
+			streamErr:   errors.New("invalid manifest"),
+			expectedErr: "failed to read objects: invalid manifest",
+		},
+		"cancelled context": {
+			objects:     object.UnstructuredSet{deployment},
+			cancel:      true,
+			expectedErr: context.Canceled.Error(),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ch := make(chan *unstructured.Unstructured, len(tc.objects))
+			for _, obj := range tc.objects {
+				ch <- obj
+			}
+			close(ch)
+			stream := ChannelObjectStream(ch)
+			if tc.streamErr != nil {
+				stream = ObjectStreamFunc(func() (*unstructured.Unstructured, error) {
+					if obj, ok := <-ch; ok {
+						return obj, nil
+					}
+					return nil, tc.streamErr
+				})
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tc.cancel {
+				cancel()
+			}
+
+			applier := &Applier{
+				mapper: testutil.NewFakeRESTMapper(deployment.GroupVersionKind(), secret.GroupVersionKind()),
+			}
+			objs, err := applier.readStream(ctx, stream)
+			if tc.expectedErr != "" {
+				require.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, objs)
+		})
+	}
+}
+
+func TestReadStream_BlockedStream(t *testing.T) {
+	deployment := testutil.Unstructured(t, resources["deployment"])
+
+	// The channel is never closed, so the stream blocks after the first
+	// object.
+	ch := make(chan *unstructured.Unstructured, 1)
+	ch <- deployment
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	applier := &Applier{
+		mapper: testutil.NewFakeRESTMapper(deployment.GroupVersionKind()),
+	}
+	_, err := applier.readStream(ctx, ChannelObjectStream(ch))
+	require.ErrorIs(t, err, context.Canceled)
+}
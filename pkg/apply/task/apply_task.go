@@ -9,9 +9,13 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
@@ -30,6 +34,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/applymethod"
 )
 
 // applyOptions defines the two key functions on the ApplyOptions
@@ -59,12 +64,33 @@ type ApplyTask struct {
 	Mutators          []mutator.Interface
 	DryRunStrategy    common.DryRunStrategy
 	ServerSideOptions common.ServerSideOptions
+	// Redactor redacts the sensitive values of the objects in the events.
+	Redactor *object.Redactor
+	// Warnings records the warnings of the server, which are attached to
+	// the apply events.
+	Warnings *info.WarningRecorder
+	// FieldDiffs attaches the changes of the fields of each object, compared
+	// to the object in the cluster before the apply, to the apply events.
+	FieldDiffs bool
 }
 
 // applyOptionsFactoryFunc is a factory function for creating a new
 // applyOptions implementation. Used to allow unit testing.
 var applyOptionsFactoryFunc = newApplyOptions
 
+var (
+	// noMatchRetries is the number of times the apply of an object is
+	// retried, when its kind is not served but is expected to be.
+	noMatchRetries = 3
+	// noMatchRetryInterval is the time between the retries.
+	noMatchRetryInterval = time.Second
+
+	crdGK         = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+	crdGVR        = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+	apiServiceGK  = schema.GroupKind{Group: "apiregistration.k8s.io", Kind: "APIService"}
+	apiServiceGVR = schema.GroupVersionResource{Group: "apiregistration.k8s.io", Version: "v1", Resource: "apiservices"}
+)
+
 func (a *ApplyTask) Name() string {
 	return a.TaskName
 }
@@ -87,51 +113,45 @@ func (a *ApplyTask) Identifiers() object.ObjMetadataSet {
 // the desired state of a resource is changed.
 func (a *ApplyTask) Start(taskContext *taskrunner.TaskContext) {
 	go func() {
-		// TODO: pipe Context through TaskContext
-		ctx := context.TODO()
+		logger := taskContext.Logger()
+		ctx := taskContext.Context()
 		objects := a.Objects
-		klog.V(2).Infof("apply task starting (name: %q, objects: %d)",
-			a.Name(), len(objects))
+		logger.V(2).Info("apply task starting", "task", a.Name(), "objects", len(objects))
 		for _, obj := range objects {
+			start := time.Now()
 			// Set the client and mapping fields on the provided
 			// info so they can be applied to the cluster.
-			info, err := a.InfoHelper.BuildInfo(obj)
+			info, err := a.buildInfo(ctx, taskContext, obj, start)
 			// BuildInfo strips path annotations.
+			// Keep the original object to add its source position to errors.
 			// Use modified object for filters, mutations, and events.
+			source := obj
 			obj = info.Object.(*unstructured.Unstructured)
 			id := object.UnstructuredToObjMetadata(obj)
 			if err != nil {
 				err = applyerror.NewUnknownTypeError(err)
-				if klog.V(4).Enabled() {
+				if logger.V(4).Enabled() {
 					// only log event emitted errors if the verbosity > 4
-					klog.Errorf("apply task errored (object: %s): unable to convert obj to info: %v", id, err)
+					logger.Error(err, "apply task errored: unable to convert obj to info", "object", id)
 				}
-				taskContext.SendEvent(a.createApplyFailedEvent(id, err))
+				taskContext.SendEvent(a.createApplyFailedEvent(id, object.WithSource(source, err)).WithTiming(start))
 				taskContext.InventoryManager().AddFailedApply(id)
 				continue
 			}
 
 			// Check filters to see if we're prevented from applying.
 			var filterErr error
+			var verifiers []ownerVerifier
 			for _, applyFilter := range a.Filters {
-				klog.V(6).Infof("apply filter evaluating (filter: %s, object: %s)", applyFilter.Name(), id)
+				logger.V(6).Info("apply filter evaluating", "filter", applyFilter.Name(), "object", id)
 				filterErr = applyFilter.Filter(obj)
 				if filterErr != nil {
-					var fatalErr *filter.FatalError
-					if errors.As(filterErr, &fatalErr) {
-						if klog.V(4).Enabled() {
-							// only log event emitted errors if the verbosity > 4
-							klog.Errorf("apply filter errored (filter: %s, object: %s): %v", applyFilter.Name(), id, fatalErr.Err)
-						}
-						taskContext.SendEvent(a.createApplyFailedEvent(id, fatalErr))
-						taskContext.InventoryManager().AddFailedApply(id)
-						break
-					}
-					klog.V(4).Infof("apply filtered (filter: %s, object: %s): %v", applyFilter.Name(), id, filterErr)
-					taskContext.SendEvent(a.createApplySkippedEvent(id, obj, filterErr))
-					taskContext.InventoryManager().AddSkippedApply(id)
+					a.sendFilteredEvent(taskContext, applyFilter.Name(), id, source, obj, filterErr, start)
 					break
 				}
+				if verifier, ok := applyFilter.(ownerVerifier); ok && verifier.Deferred(id) {
+					verifiers = append(verifiers, verifier)
+				}
 			}
 			if filterErr != nil {
 				continue
@@ -140,35 +160,118 @@ func (a *ApplyTask) Start(taskContext *taskrunner.TaskContext) {
 			// Execute mutators, if any apply
 			err = a.mutate(ctx, obj)
 			if err != nil {
-				if klog.V(4).Enabled() {
+				if logger.V(4).Enabled() {
 					// only log event emitted errors if the verbosity > 4
-					klog.Errorf("apply mutation errored (object: %s): %v", id, err)
+					logger.Error(err, "apply mutation errored", "object", id)
 				}
-				taskContext.SendEvent(a.createApplyFailedEvent(id, err))
+				taskContext.SendEvent(a.createApplyFailedEvent(id, object.WithSource(source, err)).WithTiming(start))
 				taskContext.InventoryManager().AddFailedApply(id)
 				continue
 			}
 
+			serverSideOptions, method, err := a.applyMethod(obj)
+			if err != nil {
+				taskContext.SendEvent(a.createApplyFailedEvent(id, object.WithSource(source, err)).WithTiming(start))
+				taskContext.InventoryManager().AddFailedApply(id)
+				continue
+			}
+
+			// Verify the owners deferred by the filters, if the result of
+			// the apply can't verify them.
+			for _, verifier := range verifiers {
+				if filterErr = verifier.VerifyBeforeApply(obj, serverSideOptions); filterErr != nil {
+					a.sendFilteredEvent(taskContext, verifier.Name(), id, source, obj, filterErr, start)
+					break
+				}
+			}
+			if filterErr != nil {
+				continue
+			}
+
+			printer := a.newPrinter(taskContext, start)
+			printer.method = method
+			if a.FieldDiffs {
+				live, err := a.getLiveObject(ctx, info)
+				if err != nil {
+					// The diff is optional, so don't fail the apply.
+					logger.V(4).Info("apply task failed to get the object to diff", "object", id, "error", err)
+				} else {
+					printer.live = live
+					printer.diffed = true
+					printer.localDiff = a.DryRunStrategy.ClientDryRun()
+				}
+			}
+
+			// Drop the warnings of earlier requests, so only the warnings
+			// caused by this object are attached to its event.
+			a.Warnings.Flush()
+
+			// Hold the events of the apply until the deferred owners are
+			// verified, so an object owned by another inventory is not
+			// reported as applied.
+			var held []event.Event
+			if len(verifiers) > 0 {
+				printer.sendEvent = func(e event.Event) {
+					held = append(held, e)
+				}
+			}
+
 			// Create a new instance of the applyOptions interface and use it
 			// to apply the objects.
-			ao := applyOptionsFactoryFunc(a.Name(), taskContext.EventChannel(),
-				a.ServerSideOptions, a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
+			ao := applyOptionsFactoryFunc(printer, serverSideOptions, a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
 			ao.SetObjects([]*resource.Info{info})
-			klog.V(5).Infof("applying object: %v", id)
+			logger.V(5).Info("applying object", "object", id, "method", method)
 			err = ao.Run()
-			if err != nil && a.ServerSideOptions.ServerSideApply && isAPIService(obj) && isStreamError(err) {
-				// Server-side Apply doesn't work with APIService before k8s 1.21
-				// https://github.com/kubernetes/kubernetes/issues/89264
-				// Thus APIService is handled specially using client-side apply.
-				err = a.clientSideApply(info, taskContext.EventChannel())
+			if err != nil && serverSideOptions.ServerSideApply && isServerSideApplyUnsupported(obj, err) {
+				// Old clusters and some aggregated APIs reject server-side
+				// apply, so the object is applied with a client-side
+				// three-way merge instead, with a warning on its event.
+				logger.V(2).Info("server-side apply unsupported, falling back to client-side apply", "object", id, "error", err)
+				printer.method = applymethod.ClientSide
+				printer.notes = append(printer.notes, fmt.Sprintf(
+					"server-side apply is not supported for %s, applied with client-side apply: %v",
+					obj.GroupVersionKind().GroupKind(), err))
+				err = a.clientSideApply(info, printer)
+			}
+			if err != nil && a.DryRunStrategy.ServerDryRun() && isServerDryRunUnsupported(err) {
+				// Admission webhooks with side effects reject dry-runs, so
+				// the object is previewed with a client-side dry-run
+				// instead, with a warning on its event.
+				logger.V(2).Info("server dry-run unsupported, falling back to client dry-run", "object", id, "error", err)
+				printer.method = applymethod.ClientSide
+				printer.localDiff = true
+				printer.notes = append(printer.notes, fmt.Sprintf(
+					"server dry-run is not supported for %s, previewed with client dry-run: %v",
+					obj.GroupVersionKind().GroupKind(), err))
+				err = a.clientDryRun(info, printer)
+			}
+			if verifyErr := a.verifyApplied(verifiers, obj, info, err); verifyErr != nil {
+				if err != nil {
+					// The object was not applied, so it is skipped.
+					a.sendFilteredEvent(taskContext, "owner verification", id, source, obj, verifyErr, start)
+					continue
+				}
+				failedEvent := a.createApplyFailedEvent(id, object.WithSource(source, verifyErr))
+				failedEvent.ApplyEvent.Method = printer.method
+				taskContext.SendEvent(failedEvent.WithTiming(start))
+				taskContext.InventoryManager().AddFailedApply(id)
+				continue
+			}
+			for _, e := range held {
+				taskContext.SendEvent(e)
 			}
 			if err != nil {
+				if conflictErr, ok := applyerror.ParseConflictError(err); ok {
+					err = conflictErr
+				}
 				err = applyerror.NewApplyRunError(err)
-				if klog.V(4).Enabled() {
+				if logger.V(4).Enabled() {
 					// only log event emitted errors if the verbosity > 4
-					klog.Errorf("apply errored (object: %s): %v", id, err)
+					logger.Error(err, "apply errored", "object", id)
 				}
-				taskContext.SendEvent(a.createApplyFailedEvent(id, err))
+				failedEvent := a.createApplyFailedEvent(id, object.WithSource(source, err))
+				failedEvent.ApplyEvent.Method = printer.method
+				taskContext.SendEvent(failedEvent.WithTiming(start))
 				taskContext.InventoryManager().AddFailedApply(id)
 			} else if info.Object != nil {
 				acc, err := meta.Accessor(info.Object)
@@ -183,7 +286,163 @@ func (a *ApplyTask) Start(taskContext *taskrunner.TaskContext) {
 	}()
 }
 
-func newApplyOptions(taskName string, eventChannel chan<- event.Event, serverSideOptions common.ServerSideOptions,
+// ownerVerifier is implemented by the filters that defer the verification
+// of the owners of some objects to their apply, like the
+// InventoryPolicyApplyFilter.
+type ownerVerifier interface {
+	Name() string
+	Deferred(id object.ObjMetadata) bool
+	VerifyBeforeApply(obj *unstructured.Unstructured, opts common.ServerSideOptions) error
+	VerifyApplied(applied *unstructured.Unstructured, applyErr error) error
+}
+
+var _ ownerVerifier = filter.InventoryPolicyApplyFilter{}
+
+// sendFilteredEvent sends the event of an object the filter prevented from
+// being applied: a failed event for a filter.FatalError, or a skipped event.
+func (a *ApplyTask) sendFilteredEvent(taskContext *taskrunner.TaskContext, filterName string, id object.ObjMetadata,
+	source, obj *unstructured.Unstructured, filterErr error, start time.Time) {
+	logger := taskContext.Logger()
+	var fatalErr *filter.FatalError
+	if errors.As(filterErr, &fatalErr) {
+		if logger.V(4).Enabled() {
+			// only log event emitted errors if the verbosity > 4
+			logger.Error(fatalErr.Err, "apply filter errored", "filter", filterName, "object", id)
+		}
+		taskContext.SendEvent(a.createApplyFailedEvent(id, object.WithSource(source, fatalErr)).WithTiming(start))
+		taskContext.InventoryManager().AddFailedApply(id)
+		return
+	}
+	logger.V(4).Info("apply filtered", "filter", filterName, "object", id, "reason", filterErr)
+	taskContext.SendEvent(a.createApplySkippedEvent(id, obj, filterErr).WithTiming(start))
+	taskContext.InventoryManager().AddSkippedApply(id)
+}
+
+// verifyApplied verifies the owners deferred by the filters from the result
+// of the apply: the applied object, or the error of the apply.
+func (a *ApplyTask) verifyApplied(verifiers []ownerVerifier, obj *unstructured.Unstructured, info *resource.Info, applyErr error) error {
+	applied := obj
+	if u, ok := info.Object.(*unstructured.Unstructured); ok && applyErr == nil {
+		applied = u
+	}
+	for _, verifier := range verifiers {
+		if err := verifier.VerifyApplied(applied, applyErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildInfo builds the info of the object. If the kind of the object is not
+// served, but its CRD or APIService was applied earlier in the run or exists
+// in the cluster, the RESTMapper is probably stale, so the mapper is reset and
+// the object retried a few times, with a pending apply event for each retry.
+func (a *ApplyTask) buildInfo(ctx context.Context, taskContext *taskrunner.TaskContext, obj *unstructured.Unstructured, start time.Time) (*resource.Info, error) {
+	info, err := a.InfoHelper.BuildInfo(obj)
+	if err == nil || !meta.IsNoMatchError(err) {
+		return info, err
+	}
+	logger := klog.FromContext(ctx)
+	gvk := obj.GroupVersionKind()
+	if !a.isKindExpected(ctx, taskContext, gvk) {
+		return info, err
+	}
+	id := object.UnstructuredToObjMetadata(obj)
+	for i := 0; i < noMatchRetries && meta.IsNoMatchError(err); i++ {
+		logger.V(3).Info("Resetting RESTMapper to retry the apply of an unmapped kind", "object", id, "attempt", i+1)
+		taskContext.SendEvent(a.createApplyRetryEvent(id, err).WithTiming(start))
+		select {
+		case <-ctx.Done():
+			logger.V(3).Info("Stopped retrying the apply of an unmapped kind", "object", id, "error", ctx.Err())
+			return info, err
+		case <-time.After(noMatchRetryInterval):
+		}
+		meta.MaybeResetRESTMapper(a.Mapper)
+		info, err = a.InfoHelper.BuildInfo(obj)
+	}
+	return info, err
+}
+
+// isKindExpected returns true if the CRD or APIService that serves the kind
+// was applied earlier in the run, or exists in the cluster.
+func (a *ApplyTask) isKindExpected(ctx context.Context, taskContext *taskrunner.TaskContext, gvk schema.GroupVersionKind) bool {
+	for _, id := range taskContext.InventoryManager().SuccessfulApplies() {
+		// CRDs are named <plural>.<group>, and APIServices <version>.<group>.
+		if id.GroupKind != crdGK && id.GroupKind != apiServiceGK {
+			continue
+		}
+		if _, group, found := strings.Cut(id.Name, "."); found && group == gvk.Group {
+			return true
+		}
+	}
+	if a.DynamicClient == nil {
+		return false
+	}
+	// The kinds are looked up in the cluster once per run, since every
+	// object of an unknown kind would repeat the lookup.
+	gk := gvk.GroupKind()
+	if served, found := taskContext.ServedKind(gk); found {
+		return served
+	}
+	served, err := a.isKindServed(ctx, gvk)
+	if err != nil {
+		klog.FromContext(ctx).V(4).Info("apply task failed to list the CRDs", "error", err)
+		return false
+	}
+	taskContext.SetServedKind(gk, served)
+	return served
+}
+
+// isKindServed returns true if an APIService or a CRD in the cluster serves
+// the kind.
+func (a *ApplyTask) isKindServed(ctx context.Context, gvk schema.GroupVersionKind) (bool, error) {
+	logger := klog.FromContext(ctx)
+	_, err := a.DynamicClient.Resource(apiServiceGVR).
+		Get(ctx, fmt.Sprintf("%s.%s", gvk.Version, gvk.Group), metav1.GetOptions{})
+	if err == nil {
+		return true, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		logger.V(4).Info("apply task failed to get the APIService of the kind", "kind", gvk, "error", err)
+	}
+	crds, err := a.DynamicClient.Resource(crdGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return false, err
+	}
+	for i := range crds.Items {
+		if gk, found := object.GetCRDGroupKind(&crds.Items[i]); found && gk == gvk.GroupKind() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// applyMethod returns the server-side options to apply the object with, and
+// the method they apply it with. The apply-method annotation of the object
+// overrides the ServerSideOptions of the task. A server-side dry-run always
+// uses server-side apply.
+func (a *ApplyTask) applyMethod(obj *unstructured.Unstructured) (common.ServerSideOptions, applymethod.Method, error) {
+	serverSideOptions := a.ServerSideOptions
+	method, err := applymethod.ReadAnnotation(obj)
+	if err != nil {
+		return serverSideOptions, "", err
+	}
+	switch method {
+	case applymethod.ServerSide:
+		serverSideOptions.ServerSideApply = true
+		if serverSideOptions.FieldManager == "" {
+			serverSideOptions.FieldManager = common.DefaultFieldManager
+		}
+	case applymethod.ClientSide:
+		serverSideOptions.ServerSideApply = false
+	}
+	if serverSideOptions.ServerSideApply || a.DryRunStrategy.ServerDryRun() {
+		return serverSideOptions, applymethod.ServerSide, nil
+	}
+	return serverSideOptions, applymethod.ClientSide, nil
+}
+
+func newApplyOptions(printer *KubectlPrinterAdapter, serverSideOptions common.ServerSideOptions,
 	strategy common.DryRunStrategy, dynamicClient dynamic.Interface,
 	openAPIGetter discovery.OpenAPISchemaInterface) applyOptions {
 	emptyString := ""
@@ -210,16 +469,40 @@ func newApplyOptions(taskName string, eventChannel chan<- event.Event, serverSid
 		ForceConflicts:  serverSideOptions.ForceConflicts,
 		FieldManager:    serverSideOptions.FieldManager,
 		DryRunStrategy:  strategy.Strategy(),
-		ToPrinter: (&KubectlPrinterAdapter{
-			ch:        eventChannel,
-			groupName: taskName,
-		}).toPrinterFunc(),
-		DynamicClient: dynamicClient,
+		ToPrinter:       printer.toPrinterFunc(),
+		DynamicClient:   dynamicClient,
+	}
+}
+
+// newPrinter returns a KubectlPrinterAdapter that sends the apply events of
+// the task, for an object processed since start, through the TaskContext.
+func (a *ApplyTask) newPrinter(taskContext *taskrunner.TaskContext, start time.Time) *KubectlPrinterAdapter {
+	return &KubectlPrinterAdapter{
+		sendEvent: taskContext.SendEvent,
+		start:     start,
+		groupName: a.Name(),
+		redactor:  a.Redactor,
+		warnings:  a.Warnings,
+	}
+}
+
+// getLiveObject returns the object in the cluster, or nil if it does not
+// exist yet.
+func (a *ApplyTask) getLiveObject(ctx context.Context, info *resource.Info) (*unstructured.Unstructured, error) {
+	if info.Mapping == nil {
+		return nil, fmt.Errorf("missing resource mapping")
+	}
+	live, err := a.DynamicClient.Resource(info.Mapping.Resource).
+		Namespace(info.Namespace).
+		Get(ctx, info.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
 	}
+	return live, err
 }
 
 func (a *ApplyTask) sendTaskResult(taskContext *taskrunner.TaskContext) {
-	klog.V(2).Infof("apply task completing (name: %q)", a.Name())
+	taskContext.Logger().V(2).Info("apply task completing", "task", a.Name())
 	taskContext.TaskChannel() <- taskrunner.TaskResult{}
 }
 
@@ -231,15 +514,16 @@ func (a *ApplyTask) StatusUpdate(_ *taskrunner.TaskContext, _ object.ObjMetadata
 
 // mutate loops through the mutator list and executes them on the object.
 func (a *ApplyTask) mutate(ctx context.Context, obj *unstructured.Unstructured) error {
+	logger := klog.FromContext(ctx)
 	id := object.UnstructuredToObjMetadata(obj)
 	for _, mutator := range a.Mutators {
-		klog.V(6).Infof("apply mutator %s: %s", mutator.Name(), id)
+		logger.V(6).Info("apply mutator", "mutator", mutator.Name(), "object", id)
 		mutated, reason, err := mutator.Mutate(ctx, obj)
 		if err != nil {
 			return fmt.Errorf("failed to mutate %q with %q: %w", id, mutator.Name(), err)
 		}
 		if mutated {
-			klog.V(4).Infof("resource mutated (mutator: %q, resource: %q, reason: %q)", mutator.Name(), id, reason)
+			logger.V(4).Info("resource mutated", "mutator", mutator.Name(), "object", id, "reason", reason)
 		}
 	}
 	return nil
@@ -253,6 +537,20 @@ func (a *ApplyTask) createApplyFailedEvent(id object.ObjMetadata, err error) eve
 			Identifier: id,
 			Status:     event.ApplyFailed,
 			Error:      err,
+			Hint:       applyerror.HintFor(err),
+			Warnings:   a.Warnings.Flush(),
+		},
+	}
+}
+
+func (a *ApplyTask) createApplyRetryEvent(id object.ObjMetadata, err error) event.Event {
+	return event.Event{
+		Type: event.ApplyType,
+		ApplyEvent: event.ApplyEvent{
+			GroupName:  a.Name(),
+			Identifier: id,
+			Status:     event.ApplyPending,
+			Error:      err,
 		},
 	}
 }
@@ -264,15 +562,28 @@ func (a *ApplyTask) createApplySkippedEvent(id object.ObjMetadata, resource *uns
 			GroupName:  a.Name(),
 			Identifier: id,
 			Status:     event.ApplySkipped,
-			Resource:   resource,
+			Resource:   a.Redactor.Redact(resource),
 			Error:      err,
+			SkipReason: filter.SkipReasonFor(err),
+			Hint:       applyerror.HintFor(err),
 		},
 	}
 }
 
 func isAPIService(obj *unstructured.Unstructured) bool {
-	gk := obj.GroupVersionKind().GroupKind()
-	return gk.Group == "apiregistration.k8s.io" && gk.Kind == "APIService"
+	return obj.GroupVersionKind().GroupKind() == apiServiceGK
+}
+
+// isServerSideApplyUnsupported returns true if the server-side apply of the
+// object failed because the server does not support it: the apply patch
+// type is unknown to servers before k8s 1.16 and to some aggregated APIs.
+func isServerSideApplyUnsupported(obj *unstructured.Unstructured, err error) bool {
+	if apierrors.IsUnsupportedMediaType(err) || apierrors.IsMethodNotSupported(err) {
+		return true
+	}
+	// Server-side Apply doesn't work with APIService before k8s 1.21
+	// https://github.com/kubernetes/kubernetes/issues/89264
+	return isAPIService(obj) && isStreamError(err)
 }
 
 // isStreamError checks if the error is a StreamError. Since kubectl wraps the actual StreamError,
@@ -281,8 +592,20 @@ func isStreamError(err error) bool {
 	return strings.Contains(err.Error(), "stream error: stream ID ")
 }
 
-func (a *ApplyTask) clientSideApply(info *resource.Info, eventChannel chan<- event.Event) error {
-	ao := applyOptionsFactoryFunc(a.Name(), eventChannel, common.ServerSideOptions{ServerSideApply: false}, a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
+// isServerDryRunUnsupported returns true if the server rejected the
+// dry-run of the object, like an admission webhook with side effects does.
+func isServerDryRunUnsupported(err error) bool {
+	return apierrors.IsBadRequest(err) && strings.Contains(err.Error(), "does not support dry run")
+}
+
+func (a *ApplyTask) clientDryRun(info *resource.Info, printer *KubectlPrinterAdapter) error {
+	ao := applyOptionsFactoryFunc(printer, common.ServerSideOptions{ServerSideApply: false}, common.DryRunClient, a.DynamicClient, a.OpenAPIGetter)
+	ao.SetObjects([]*resource.Info{info})
+	return ao.Run()
+}
+
+func (a *ApplyTask) clientSideApply(info *resource.Info, printer *KubectlPrinterAdapter) error {
+	ao := applyOptionsFactoryFunc(printer, common.ServerSideOptions{ServerSideApply: false}, a.DryRunStrategy, a.DynamicClient, a.OpenAPIGetter)
 	ao.SetObjects([]*resource.Info{info})
 	return ao.Run()
 }
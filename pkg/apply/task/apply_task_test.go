@@ -4,23 +4,36 @@
 package task
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/resource"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/info"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/applymethod"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
 
@@ -86,7 +99,7 @@ func TestApplyTask_BasicAppliedObjects(t *testing.T) {
 			objs := toUnstructureds(tc.applied)
 
 			oldAO := applyOptionsFactoryFunc
-			applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+			applyOptionsFactoryFunc = func(*KubectlPrinterAdapter, common.ServerSideOptions, common.DryRunStrategy,
 				dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
 				return &fakeApplyOptions{}
 			}
@@ -178,7 +191,7 @@ func TestApplyTask_FetchGeneration(t *testing.T) {
 			objs := toUnstructureds(tc.rss)
 
 			oldAO := applyOptionsFactoryFunc
-			applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+			applyOptionsFactoryFunc = func(*KubectlPrinterAdapter, common.ServerSideOptions, common.DryRunStrategy,
 				dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
 				return &fakeApplyOptions{}
 			}
@@ -300,7 +313,7 @@ func TestApplyTask_DryRun(t *testing.T) {
 
 				ao := &fakeApplyOptions{}
 				oldAO := applyOptionsFactoryFunc
-				applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+				applyOptionsFactoryFunc = func(*KubectlPrinterAdapter, common.ServerSideOptions, common.DryRunStrategy,
 					dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
 					return ao
 				}
@@ -449,7 +462,7 @@ func TestApplyTaskWithError(t *testing.T) {
 
 			ao := &fakeApplyOptions{}
 			oldAO := applyOptionsFactoryFunc
-			applyOptionsFactoryFunc = func(string, chan<- event.Event, common.ServerSideOptions, common.DryRunStrategy,
+			applyOptionsFactoryFunc = func(*KubectlPrinterAdapter, common.ServerSideOptions, common.DryRunStrategy,
 				dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
 				return ao
 			}
@@ -577,3 +590,599 @@ func (f *fakeInfoHelper) BuildInfos(objs []*unstructured.Unstructured) ([]*resou
 func (f *fakeInfoHelper) BuildInfo(obj *unstructured.Unstructured) (*resource.Info, error) {
 	return object.UnstructuredToInfo(obj)
 }
+
+// resettableRESTMapper serves all kinds after it has been reset servedAfter
+// times, like a RESTMapper with stale discovery documents.
+type resettableRESTMapper struct {
+	meta.RESTMapper
+	resets      int
+	servedAfter int
+}
+
+func (m *resettableRESTMapper) Reset() {
+	m.resets++
+}
+
+func (m *resettableRESTMapper) RESTMapping(gk schema.GroupKind, versions ...string) (*meta.RESTMapping, error) {
+	if m.resets < m.servedAfter {
+		return nil, &meta.NoKindMatchError{GroupKind: gk, SearchedVersions: versions}
+	}
+	return &meta.RESTMapping{
+		Resource: schema.GroupVersionResource{Group: gk.Group, Version: versions[0], Resource: "anothercustoms"},
+		Scope:    meta.RESTScopeNamespace,
+	}, nil
+}
+
+func TestApplyTask_NoKindMatchRetry(t *testing.T) {
+	defer func(interval time.Duration) { noMatchRetryInterval = interval }(noMatchRetryInterval)
+	noMatchRetryInterval = time.Millisecond
+
+	crd := toUnstructured(map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata": map[string]interface{}{
+			"name": "anothercustoms.anothercustom.io",
+		},
+		"spec": map[string]interface{}{
+			"group": "anothercustom.io",
+			"names": map[string]interface{}{
+				"kind": "AnotherCustom",
+			},
+		},
+	})
+	cr := toUnstructured(map[string]interface{}{
+		"apiVersion": "anothercustom.io/v2",
+		"kind":       "AnotherCustom",
+		"metadata": map[string]interface{}{
+			"name":      "bar",
+			"namespace": "barbar",
+		},
+	})
+	crdID := object.UnstructuredToObjMetadata(crd)
+	crID := object.UnstructuredToObjMetadata(cr)
+
+	testCases := map[string]struct {
+		appliedCRD     bool
+		clusterObjs    []runtime.Object
+		servedAfter    int
+		expectedEvents []event.ApplyEventStatus
+		expectedResets int
+		expectedFailed bool
+		cancelled      bool
+	}{
+		"CRD applied earlier, served after a reset": {
+			appliedCRD:     true,
+			servedAfter:    1,
+			expectedEvents: []event.ApplyEventStatus{event.ApplyPending},
+			expectedResets: 1,
+		},
+		"CRD applied earlier, never served": {
+			appliedCRD:  true,
+			servedAfter: 10,
+			expectedEvents: []event.ApplyEventStatus{
+				event.ApplyPending, event.ApplyPending, event.ApplyPending, event.ApplyFailed,
+			},
+			expectedResets: 3,
+			expectedFailed: true,
+		},
+		"CRD in the cluster, served after a reset": {
+			clusterObjs:    []runtime.Object{crd},
+			servedAfter:    1,
+			expectedEvents: []event.ApplyEventStatus{event.ApplyPending},
+			expectedResets: 1,
+		},
+		"unknown CRD": {
+			servedAfter:    1,
+			expectedEvents: []event.ApplyEventStatus{event.ApplyFailed},
+			expectedFailed: true,
+		},
+		"cancelled run stops retrying": {
+			appliedCRD:     true,
+			cancelled:      true,
+			servedAfter:    10,
+			expectedEvents: []event.ApplyEventStatus{event.ApplyPending, event.ApplyFailed},
+			expectedFailed: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event)
+			taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+			if tc.appliedCRD {
+				taskContext.InventoryManager().AddSuccessfulApply(crdID, "crd-uid", 0)
+			}
+			if tc.cancelled {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				taskContext.SetContext(ctx)
+			}
+
+			ao := &fakeApplyOptions{}
+			oldAO := applyOptionsFactoryFunc
+			applyOptionsFactoryFunc = func(*KubectlPrinterAdapter, common.ServerSideOptions, common.DryRunStrategy,
+				dynamic.Interface, discovery.OpenAPISchemaInterface) applyOptions {
+				return ao
+			}
+			defer func() { applyOptionsFactoryFunc = oldAO }()
+
+			mapper := &resettableRESTMapper{servedAfter: tc.servedAfter}
+			dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+				map[schema.GroupVersionResource]string{
+					crdGVR:        "CustomResourceDefinitionList",
+					apiServiceGVR: "APIServiceList",
+				}, tc.clusterObjs...)
+			applyTask := &ApplyTask{
+				Objects:       object.UnstructuredSet{cr},
+				DynamicClient: dynamicClient,
+				InfoHelper: info.NewHelper(mapper, func(*meta.RESTMapping) (resource.RESTClient, error) {
+					return nil, nil
+				}),
+				Mapper: mapper,
+			}
+
+			var events []event.ApplyEventStatus
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for msg := range eventChannel {
+					assert.Equal(t, crID, msg.ApplyEvent.Identifier)
+					events = append(events, msg.ApplyEvent.Status)
+				}
+			}()
+
+			applyTask.Start(taskContext)
+			<-taskContext.TaskChannel()
+			close(eventChannel)
+			wg.Wait()
+
+			assert.Equal(t, tc.expectedEvents, events)
+			assert.Equal(t, tc.expectedResets, mapper.resets)
+			assert.Equal(t, tc.expectedFailed, taskContext.InventoryManager().IsFailedApply(crID))
+			if !tc.expectedFailed {
+				assert.Len(t, ao.passedObjects, 1)
+			}
+		})
+	}
+}
+
+func TestApplyTask_NoKindMatchLookupOnce(t *testing.T) {
+	newCR := func(name string) *unstructured.Unstructured {
+		return toUnstructured(map[string]interface{}{
+			"apiVersion": "anothercustom.io/v2",
+			"kind":       "AnotherCustom",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "barbar",
+			},
+		})
+	}
+	eventChannel := make(chan event.Event)
+	taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+
+	mapper := &resettableRESTMapper{servedAfter: 1}
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			crdGVR:        "CustomResourceDefinitionList",
+			apiServiceGVR: "APIServiceList",
+		})
+	applyTask := &ApplyTask{
+		Objects:       object.UnstructuredSet{newCR("foo"), newCR("bar")},
+		DynamicClient: dynamicClient,
+		InfoHelper: info.NewHelper(mapper, func(*meta.RESTMapping) (resource.RESTClient, error) {
+			return nil, nil
+		}),
+		Mapper: mapper,
+	}
+
+	var events []event.ApplyEventStatus
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for msg := range eventChannel {
+			events = append(events, msg.ApplyEvent.Status)
+		}
+	}()
+	applyTask.Start(taskContext)
+	<-taskContext.TaskChannel()
+	close(eventChannel)
+	wg.Wait()
+
+	assert.Equal(t, []event.ApplyEventStatus{event.ApplyFailed, event.ApplyFailed}, events)
+	// The APIService and the CRDs are only looked up for the first object.
+	assert.Len(t, dynamicClient.Actions(), 2)
+}
+
+func TestApplyTask_ApplyMethod(t *testing.T) {
+	newObject := func(method string) *unstructured.Unstructured {
+		u := toUnstructured(map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "foo",
+				"namespace": "default",
+			},
+		})
+		if method != "" {
+			u.SetAnnotations(map[string]string{applymethod.Annotation: method})
+		}
+		return u
+	}
+
+	testCases := map[string]struct {
+		obj               *unstructured.Unstructured
+		serverSideOptions common.ServerSideOptions
+		dryRunStrategy    common.DryRunStrategy
+		expectedOptions   common.ServerSideOptions
+		expectedMethod    applymethod.Method
+		expectedError     string
+	}{
+		"run default, client-side": {
+			obj:            newObject(""),
+			expectedMethod: applymethod.ClientSide,
+		},
+		"run default, server-side": {
+			obj:               newObject(""),
+			serverSideOptions: common.ServerSideOptions{ServerSideApply: true, FieldManager: "test"},
+			expectedOptions:   common.ServerSideOptions{ServerSideApply: true, FieldManager: "test"},
+			expectedMethod:    applymethod.ServerSide,
+		},
+		"server-side object in a client-side run": {
+			obj:             newObject("server-side"),
+			expectedOptions: common.ServerSideOptions{ServerSideApply: true, FieldManager: common.DefaultFieldManager},
+			expectedMethod:  applymethod.ServerSide,
+		},
+		"client-side object in a server-side run": {
+			obj:               newObject("client-side"),
+			serverSideOptions: common.ServerSideOptions{ServerSideApply: true, FieldManager: "test"},
+			expectedOptions:   common.ServerSideOptions{FieldManager: "test"},
+			expectedMethod:    applymethod.ClientSide,
+		},
+		"client-side object in a server-side dry-run": {
+			obj:            newObject("client-side"),
+			dryRunStrategy: common.DryRunServer,
+			expectedMethod: applymethod.ServerSide,
+		},
+		"unknown method": {
+			obj: newObject("replace"),
+			expectedError: `invalid "cli-utils.sigs.k8s.io/apply-method" annotation: ` +
+				`unknown apply method "replace", must be "client-side" or "server-side"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			applyTask := &ApplyTask{
+				ServerSideOptions: tc.serverSideOptions,
+				DryRunStrategy:    tc.dryRunStrategy,
+			}
+			options, method, err := applyTask.applyMethod(tc.obj)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOptions, options)
+			assert.Equal(t, tc.expectedMethod, method)
+		})
+	}
+}
+
+// ssaRejectingApplyOptions fails the server-side requests with the error,
+// like a server that does not support server-side apply or dry-runs.
+type ssaRejectingApplyOptions struct {
+	printer    *KubectlPrinterAdapter
+	serverSide bool
+	err        error
+	objects    []*resource.Info
+}
+
+func (f *ssaRejectingApplyOptions) Run() error {
+	if f.serverSide {
+		return f.err
+	}
+	printer, err := f.printer.toPrinterFunc()("configured")
+	if err != nil {
+		return err
+	}
+	for _, obj := range f.objects {
+		if err := printer.PrintObj(obj.Object, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *ssaRejectingApplyOptions) SetObjects(objects []*resource.Info) {
+	f.objects = objects
+}
+
+func TestApplyTask_ServerSideApplyFallback(t *testing.T) {
+	obj := toUnstructured(map[string]interface{}{
+		"apiVersion": "custom.io/v1",
+		"kind":       "Custom",
+		"metadata": map[string]interface{}{
+			"name":      "foo",
+			"namespace": "default",
+		},
+	})
+
+	testCases := map[string]struct {
+		err              error
+		expectedStatus   event.ApplyEventStatus
+		expectedMethod   applymethod.Method
+		expectedWarnings []string
+	}{
+		"unsupported media type": {
+			err: &apierrors.StatusError{ErrStatus: metav1.Status{
+				Status:  metav1.StatusFailure,
+				Code:    http.StatusUnsupportedMediaType,
+				Reason:  metav1.StatusReasonUnsupportedMediaType,
+				Message: "the body of the request was in an unknown format",
+			}},
+			expectedStatus: event.ApplySuccessful,
+			expectedMethod: applymethod.ClientSide,
+			expectedWarnings: []string{
+				"server-side apply is not supported for Custom.custom.io, applied with client-side apply: " +
+					"the body of the request was in an unknown format",
+			},
+		},
+		"other error": {
+			err:            apierrors.NewForbidden(schema.GroupResource{}, "foo", errors.New("denied")),
+			expectedStatus: event.ApplyFailed,
+			expectedMethod: applymethod.ServerSide,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event)
+			taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+
+			oldAO := applyOptionsFactoryFunc
+			applyOptionsFactoryFunc = func(printer *KubectlPrinterAdapter, serverSideOptions common.ServerSideOptions,
+				_ common.DryRunStrategy, _ dynamic.Interface, _ discovery.OpenAPISchemaInterface) applyOptions {
+				return &ssaRejectingApplyOptions{
+					printer:    printer,
+					serverSide: serverSideOptions.ServerSideApply,
+					err:        tc.err,
+				}
+			}
+			defer func() { applyOptionsFactoryFunc = oldAO }()
+
+			applyTask := &ApplyTask{
+				Objects:           object.UnstructuredSet{obj},
+				InfoHelper:        &fakeInfoHelper{},
+				ServerSideOptions: common.ServerSideOptions{ServerSideApply: true},
+			}
+
+			var events []event.Event
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for msg := range eventChannel {
+					events = append(events, msg)
+				}
+			}()
+			applyTask.Start(taskContext)
+			<-taskContext.TaskChannel()
+			close(eventChannel)
+			wg.Wait()
+
+			require.Len(t, events, 1)
+			assert.Equal(t, tc.expectedStatus, events[0].ApplyEvent.Status)
+			assert.Equal(t, tc.expectedMethod, events[0].ApplyEvent.Method)
+			assert.Equal(t, tc.expectedWarnings, events[0].ApplyEvent.Warnings)
+		})
+	}
+}
+
+func TestApplyTask_ServerDryRunFallback(t *testing.T) {
+	obj := toUnstructured(map[string]interface{}{
+		"apiVersion": "custom.io/v1",
+		"kind":       "Custom",
+		"metadata": map[string]interface{}{
+			"name":      "foo",
+			"namespace": "default",
+		},
+	})
+
+	testCases := map[string]struct {
+		err              error
+		expectedStatus   event.ApplyEventStatus
+		expectedMethod   applymethod.Method
+		expectedWarnings []string
+	}{
+		"webhook with side effects": {
+			err:            apierrors.NewBadRequest(`admission webhook "audit.example.com" does not support dry run`),
+			expectedStatus: event.ApplySuccessful,
+			expectedMethod: applymethod.ClientSide,
+			expectedWarnings: []string{
+				"server dry-run is not supported for Custom.custom.io, previewed with client dry-run: " +
+					`admission webhook "audit.example.com" does not support dry run`,
+			},
+		},
+		"other error": {
+			err:            apierrors.NewBadRequest("invalid object"),
+			expectedStatus: event.ApplyFailed,
+			expectedMethod: applymethod.ServerSide,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event)
+			taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+
+			oldAO := applyOptionsFactoryFunc
+			applyOptionsFactoryFunc = func(printer *KubectlPrinterAdapter, _ common.ServerSideOptions,
+				strategy common.DryRunStrategy, _ dynamic.Interface, _ discovery.OpenAPISchemaInterface) applyOptions {
+				return &ssaRejectingApplyOptions{
+					printer:    printer,
+					serverSide: strategy.ServerDryRun(),
+					err:        tc.err,
+				}
+			}
+			defer func() { applyOptionsFactoryFunc = oldAO }()
+
+			applyTask := &ApplyTask{
+				Objects:        object.UnstructuredSet{obj},
+				InfoHelper:     &fakeInfoHelper{},
+				DryRunStrategy: common.DryRunServer,
+			}
+
+			var events []event.Event
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for msg := range eventChannel {
+					events = append(events, msg)
+				}
+			}()
+			applyTask.Start(taskContext)
+			<-taskContext.TaskChannel()
+			close(eventChannel)
+			wg.Wait()
+
+			require.Len(t, events, 1)
+			assert.Equal(t, tc.expectedStatus, events[0].ApplyEvent.Status)
+			assert.Equal(t, tc.expectedMethod, events[0].ApplyEvent.Method)
+			assert.Equal(t, tc.expectedWarnings, events[0].ApplyEvent.Warnings)
+		})
+	}
+}
+
+func TestApplyTask_DeferredOwnerVerification(t *testing.T) {
+	obj := toUnstructured(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "foo",
+			"namespace": "default",
+		},
+	})
+	conflictErr := errors.New(`Apply failed with 1 conflict: conflict with "other-applier": .metadata.annotations.config.k8s.io/owning-inventory`)
+	preventedErr := errors.New("owned by another inventory")
+
+	testCases := map[string]struct {
+		deferred     bool
+		applyErr     error
+		beforeErr    error
+		appliedErr   error
+		expectedRuns int
+
+		expectedStatus event.ApplyEventStatus
+	}{
+		"verified by the apply": {
+			deferred:       true,
+			expectedRuns:   1,
+			expectedStatus: event.ApplySuccessful,
+		},
+		"verified before the apply": {
+			deferred:       true,
+			beforeErr:      preventedErr,
+			expectedStatus: event.ApplySkipped,
+		},
+		"conflict with another inventory": {
+			deferred:       true,
+			applyErr:       conflictErr,
+			appliedErr:     preventedErr,
+			expectedRuns:   1,
+			expectedStatus: event.ApplySkipped,
+		},
+		"another inventory after the apply": {
+			deferred:       true,
+			appliedErr:     preventedErr,
+			expectedRuns:   1,
+			expectedStatus: event.ApplyFailed,
+		},
+		"not deferred": {
+			beforeErr:      preventedErr,
+			appliedErr:     preventedErr,
+			expectedRuns:   1,
+			expectedStatus: event.ApplySuccessful,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event)
+			taskContext := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+
+			runs := 0
+			oldAO := applyOptionsFactoryFunc
+			applyOptionsFactoryFunc = func(printer *KubectlPrinterAdapter, serverSideOptions common.ServerSideOptions,
+				_ common.DryRunStrategy, _ dynamic.Interface, _ discovery.OpenAPISchemaInterface) applyOptions {
+				runs++
+				return &ssaRejectingApplyOptions{
+					printer:    printer,
+					serverSide: tc.applyErr != nil,
+					err:        tc.applyErr,
+				}
+			}
+			defer func() { applyOptionsFactoryFunc = oldAO }()
+
+			verifier := &fakeOwnerVerifier{
+				deferred:   tc.deferred,
+				beforeErr:  tc.beforeErr,
+				appliedErr: tc.appliedErr,
+			}
+			applyTask := &ApplyTask{
+				Objects:           object.UnstructuredSet{obj},
+				InfoHelper:        &fakeInfoHelper{},
+				Filters:           []filter.ValidationFilter{verifier},
+				ServerSideOptions: common.ServerSideOptions{ServerSideApply: true},
+			}
+
+			var events []event.Event
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for msg := range eventChannel {
+					events = append(events, msg)
+				}
+			}()
+			applyTask.Start(taskContext)
+			<-taskContext.TaskChannel()
+			close(eventChannel)
+			wg.Wait()
+
+			assert.Equal(t, tc.expectedRuns, runs)
+			require.Len(t, events, 1)
+			assert.Equal(t, tc.expectedStatus, events[0].ApplyEvent.Status)
+		})
+	}
+}
+
+// fakeOwnerVerifier is a filter that defers the verification of the owner
+// of the objects to their apply.
+type fakeOwnerVerifier struct {
+	deferred   bool
+	beforeErr  error
+	appliedErr error
+}
+
+func (f *fakeOwnerVerifier) Name() string {
+	return "fakeOwnerVerifier"
+}
+
+func (f *fakeOwnerVerifier) Filter(*unstructured.Unstructured) error {
+	return nil
+}
+
+func (f *fakeOwnerVerifier) Deferred(object.ObjMetadata) bool {
+	return f.deferred
+}
+
+func (f *fakeOwnerVerifier) VerifyBeforeApply(*unstructured.Unstructured, common.ServerSideOptions) error {
+	return f.beforeErr
+}
+
+func (f *fakeOwnerVerifier) VerifyApplied(*unstructured.Unstructured, error) error {
+	return f.appliedErr
+}
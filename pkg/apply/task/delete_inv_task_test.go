@@ -125,7 +125,7 @@ func TestDeleteInvTask(t *testing.T) {
 					t.Errorf("unexpected error running DeleteOrUpdateInvTask: %s", result.Err)
 				}
 			}
-			actual, _ := client.GetClusterObjs(nil)
+			actual, _ := client.GetClusterObjs(context.Context(), nil)
 			testutil.AssertEqual(t, tc.expectedObjs, actual,
 				"Actual cluster objects (%d) do not match expected cluster objects (%d)",
 				len(actual), len(tc.expectedObjs))
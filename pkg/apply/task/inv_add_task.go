@@ -6,7 +6,6 @@ package task
 import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -27,6 +26,11 @@ type InvAddTask struct {
 	InvInfo   inventory.Info
 	Objects   object.UnstructuredSet
 	DryRun    common.DryRunStrategy
+	// SkipUnchanged skips the update of the inventory if all the objects are
+	// already in the inventory, so only the final inventory update of the
+	// run changes the inventory object. The objects are then not recorded
+	// with a pending status before they are applied.
+	SkipUnchanged bool
 }
 
 func (i *InvAddTask) Name() string {
@@ -45,22 +49,36 @@ func (i *InvAddTask) Identifiers() object.ObjMetadataSet {
 // into the current inventory.
 func (i *InvAddTask) Start(taskContext *taskrunner.TaskContext) {
 	go func() {
-		klog.V(2).Infof("inventory add task starting (name: %q)", i.Name())
+		ctx := taskContext.Context()
+		logger := taskContext.Logger()
+		logger.V(2).Info("inventory add task starting", "task", i.Name())
 		if err := inventory.ValidateNoInventory(i.Objects); err != nil {
 			i.sendTaskResult(taskContext, err)
 			return
 		}
 		// Ensures the namespace exists before applying the inventory object into it.
 		if invNamespace := inventoryNamespaceInSet(i.InvInfo, i.Objects); invNamespace != nil {
-			klog.V(4).Infof("applying inventory namespace %s", invNamespace.GetName())
-			if err := i.InvClient.ApplyInventoryNamespace(invNamespace, i.DryRun); err != nil {
+			logger.V(4).Info("applying inventory namespace", "namespace", invNamespace.GetName())
+			if err := i.InvClient.ApplyInventoryNamespace(ctx, invNamespace, i.DryRun); err != nil {
 				i.sendTaskResult(taskContext, err)
 				return
 			}
 		}
-		klog.V(4).Infof("merging %d local objects into inventory", len(i.Objects))
 		currentObjs := object.UnstructuredSetToObjMetadataSet(i.Objects)
-		_, err := i.InvClient.Merge(i.InvInfo, currentObjs, i.DryRun)
+		if i.SkipUnchanged && len(currentObjs) > 0 {
+			clusterObjs, err := i.InvClient.GetClusterObjs(ctx, i.InvInfo)
+			if err != nil {
+				i.sendTaskResult(taskContext, err)
+				return
+			}
+			if len(currentObjs.Diff(clusterObjs)) == 0 {
+				logger.V(4).Info("skipping inventory merge: all local objects are in the inventory", "objects", len(currentObjs))
+				i.sendTaskResult(taskContext, nil)
+				return
+			}
+		}
+		logger.V(4).Info("merging local objects into inventory", "objects", len(i.Objects))
+		_, err := i.InvClient.Merge(ctx, i.InvInfo, currentObjs, i.DryRun)
 		i.sendTaskResult(taskContext, err)
 	}()
 }
@@ -91,7 +109,7 @@ func inventoryNamespaceInSet(inv inventory.Info, objs object.UnstructuredSet) *u
 }
 
 func (i *InvAddTask) sendTaskResult(taskContext *taskrunner.TaskContext, err error) {
-	klog.V(2).Infof("inventory add task completing (name: %q)", i.Name())
+	taskContext.Logger().V(2).Info("inventory add task completing", "task", i.Name())
 	taskContext.TaskChannel() <- taskrunner.TaskResult{
 		Err: err,
 	}
@@ -4,6 +4,7 @@
 package task
 
 import (
+	"context"
 	"testing"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -130,7 +131,7 @@ func TestInvAddTask(t *testing.T) {
 			if result.Err != nil {
 				t.Errorf("unexpected error running InvAddTask: %s", result.Err)
 			}
-			actual, _ := client.GetClusterObjs(nil)
+			actual, _ := client.GetClusterObjs(context.Context(), nil)
 			if !tc.expectedObjs.Equal(actual) {
 				t.Errorf("expected merged inventory (%s), got (%s)", tc.expectedObjs, actual)
 			}
@@ -194,3 +195,40 @@ func createNamespace(ns string) *unstructured.Unstructured {
 		},
 	}
 }
+
+// mergeFailingClient fails the test if the inventory is merged.
+type mergeFailingClient struct {
+	*inventory.FakeClient
+	t *testing.T
+}
+
+func (c *mergeFailingClient) Merge(context.Context, inventory.Info, object.ObjMetadataSet, common.DryRunStrategy) (object.ObjMetadataSet, error) {
+	c.t.Errorf("unexpected inventory merge")
+	return object.ObjMetadataSet{}, nil
+}
+
+func TestInvAddTask_SkipUnchanged(t *testing.T) {
+	id1 := object.UnstructuredToObjMetadata(obj1)
+	id2 := object.UnstructuredToObjMetadata(obj2)
+
+	client := &mergeFailingClient{
+		FakeClient: inventory.NewFakeClient(object.ObjMetadataSet{id1, id2}),
+		t:          t,
+	}
+	eventChannel := make(chan event.Event)
+	resourceCache := cache.NewResourceCacheMap()
+	context := taskrunner.NewTaskContext(eventChannel, resourceCache)
+
+	task := InvAddTask{
+		TaskName:      taskName,
+		InvClient:     client,
+		InvInfo:       nil,
+		Objects:       []*unstructured.Unstructured{obj1, obj2},
+		SkipUnchanged: true,
+	}
+	task.Start(context)
+	result := <-context.TaskChannel()
+	if result.Err != nil {
+		t.Errorf("unexpected error running InvAddTask: %s", result.Err)
+	}
+}
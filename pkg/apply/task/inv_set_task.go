@@ -5,8 +5,9 @@ package task
 
 import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/prune"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
@@ -20,9 +21,20 @@ type DeleteOrUpdateInvTask struct {
 	InvClient     inventory.Client
 	InvInfo       inventory.Info
 	PrevInventory object.ObjMetadataSet
-	DryRun        common.DryRunStrategy
+	// Retained are the objects that stay in the inventory, if they were in
+	// the PrevInventory, although they are neither applied nor pruned, like
+	// the objects excluded from the run by a filter.
+	Retained object.ObjMetadataSet
+	// Stale are the entries of the PrevInventory whose objects no longer
+	// exist in the cluster. They are removed from the inventory, with a
+	// skipped prune or delete event each.
+	Stale  []prune.StaleEntry
+	DryRun common.DryRunStrategy
 	// if Destroy is set, the inventory will be deleted if all objects were successfully pruned
 	Destroy bool
+	// KeepInventory keeps the empty inventory, instead of deleting it, after
+	// a successful Destroy.
+	KeepInventory bool
 }
 
 func (i *DeleteOrUpdateInvTask) Name() string {
@@ -45,18 +57,60 @@ func (i *DeleteOrUpdateInvTask) Identifiers() object.ObjMetadataSet {
 // prunes were failed or skipped, the inventory will be updated.
 //
 // If Destroy is false, the inventory will be updated.
+//
+// The TaskResult reports what happened to the inventory.
 func (i *DeleteOrUpdateInvTask) Start(taskContext *taskrunner.TaskContext) {
 	go func() {
+		i.sendStaleEvents(taskContext)
 		var err error
-		if i.Destroy && i.destroySuccessful(taskContext) {
-			err = i.deleteInventory()
-		} else {
+		disposition := event.InventoryUpdated
+		switch {
+		case i.Destroy && i.destroySuccessful(taskContext) && !i.KeepInventory:
+			err = i.deleteInventory(taskContext)
+			disposition = event.InventoryDeleted
+		case i.Destroy && i.destroySuccessful(taskContext):
+			err = i.updateInventory(taskContext)
+			disposition = event.InventoryRetained
+		default:
 			err = i.updateInventory(taskContext)
 		}
-		taskContext.TaskChannel() <- taskrunner.TaskResult{Err: err}
+		if err != nil {
+			disposition = event.NoInventoryDisposition
+		}
+		taskContext.TaskChannel() <- taskrunner.TaskResult{Err: err, Inventory: disposition}
 	}()
 }
 
+// sendStaleEvents reports the stale entries removed from the inventory.
+func (i *DeleteOrUpdateInvTask) sendStaleEvents(taskContext *taskrunner.TaskContext) {
+	for _, entry := range i.Stale {
+		err := &filter.StaleInventoryEntryError{ID: entry.ID, Err: entry.Err}
+		if i.Destroy {
+			taskContext.SendEvent(event.Event{
+				Type: event.DeleteType,
+				DeleteEvent: event.DeleteEvent{
+					GroupName:  i.TaskName,
+					Identifier: entry.ID,
+					Status:     event.DeleteSkipped,
+					Error:      err,
+					SkipReason: event.StaleInventoryEntry,
+				},
+			})
+			continue
+		}
+		taskContext.SendEvent(event.Event{
+			Type: event.PruneType,
+			PruneEvent: event.PruneEvent{
+				GroupName:  i.TaskName,
+				Identifier: entry.ID,
+				Status:     event.PruneSkipped,
+				Error:      err,
+				SkipReason: event.StaleInventoryEntry,
+			},
+		})
+	}
+}
+
 // Cancel is not supported by the DeleteOrUpdateInvTask.
 func (i *DeleteOrUpdateInvTask) Cancel(_ *taskrunner.TaskContext) {}
 
@@ -74,6 +128,7 @@ func (i *DeleteOrUpdateInvTask) StatusUpdate(_ *taskrunner.TaskContext, _ object
 // - Applied resources (successful)
 //
 // Retained objects:
+// - Resources excluded from the run
 // - Applied resources (filtered/skipped)
 // - Applied resources (failed)
 // - Deleted resources (filtered/skipped) that were not abandoned
@@ -83,8 +138,10 @@ func (i *DeleteOrUpdateInvTask) StatusUpdate(_ *taskrunner.TaskContext, _ object
 // Removed objects:
 // - Deleted resources (successful)
 // - Abandoned resources (successful)
+// - Stale entries, whose objects no longer exist
 func (i *DeleteOrUpdateInvTask) updateInventory(taskContext *taskrunner.TaskContext) error {
-	klog.V(2).Infof("inventory set task starting (name: %q)", i.TaskName)
+	logger := taskContext.Logger()
+	logger.V(2).Info("inventory set task starting", "task", i.TaskName)
 	invObjs := object.ObjMetadataSet{}
 
 	// TODO: Just use InventoryManager.Store()
@@ -92,7 +149,7 @@ func (i *DeleteOrUpdateInvTask) updateInventory(taskContext *taskrunner.TaskCont
 
 	// If an object applied successfully, keep or add it to the inventory.
 	appliedObjs := im.SuccessfulApplies()
-	klog.V(4).Infof("set inventory %d successful applies", len(appliedObjs))
+	logger.V(4).Info("set inventory successful applies", "objects", len(appliedObjs))
 	invObjs = invObjs.Union(appliedObjs)
 
 	// If an object failed to apply and was previously stored in the inventory,
@@ -101,7 +158,7 @@ func (i *DeleteOrUpdateInvTask) updateInventory(taskContext *taskrunner.TaskCont
 	// because even tho they were added by InvAddTask, the PrevInventory
 	// represents the inventory before the pipeline has run.
 	applyFailures := i.PrevInventory.Intersection(im.FailedApplies())
-	klog.V(4).Infof("keep in inventory %d failed applies", len(applyFailures))
+	logger.V(4).Info("keep in inventory failed applies", "objects", len(applyFailures))
 	invObjs = invObjs.Union(applyFailures)
 
 	// If an object skipped apply and was previously stored in the inventory,
@@ -110,7 +167,7 @@ func (i *DeleteOrUpdateInvTask) updateInventory(taskContext *taskrunner.TaskCont
 	// because the apply filters all currently depend on cluster state,
 	// but we're doing the intersection anyway just to be sure.
 	applySkips := i.PrevInventory.Intersection(im.SkippedApplies())
-	klog.V(4).Infof("keep in inventory %d skipped applies", len(applySkips))
+	logger.V(4).Info("keep in inventory skipped applies", "objects", len(applySkips))
 	invObjs = invObjs.Union(applySkips)
 
 	// If an object failed to delete and was previously stored in the inventory,
@@ -119,7 +176,7 @@ func (i *DeleteOrUpdateInvTask) updateInventory(taskContext *taskrunner.TaskCont
 	// because the set of resources to prune comes from the inventory,
 	// but we're doing the intersection anyway just to be sure.
 	pruneFailures := i.PrevInventory.Intersection(im.FailedDeletes())
-	klog.V(4).Infof("set inventory %d failed prunes", len(pruneFailures))
+	logger.V(4).Info("set inventory failed prunes", "objects", len(pruneFailures))
 	invObjs = invObjs.Union(pruneFailures)
 
 	// If an object skipped delete and was previously stored in the inventory,
@@ -128,51 +185,59 @@ func (i *DeleteOrUpdateInvTask) updateInventory(taskContext *taskrunner.TaskCont
 	// because the set of resources to prune comes from the inventory,
 	// but we're doing the intersection anyway just to be sure.
 	pruneSkips := i.PrevInventory.Intersection(im.SkippedDeletes())
-	klog.V(4).Infof("keep in inventory %d skipped prunes", len(pruneSkips))
+	logger.V(4).Info("keep in inventory skipped prunes", "objects", len(pruneSkips))
 	invObjs = invObjs.Union(pruneSkips)
 
 	// If an object failed to reconcile and was previously stored in the inventory,
 	// then keep it in the inventory so it can be waited on next time.
 	reconcileFailures := i.PrevInventory.Intersection(im.FailedReconciles())
-	klog.V(4).Infof("set inventory %d failed reconciles", len(reconcileFailures))
+	logger.V(4).Info("set inventory failed reconciles", "objects", len(reconcileFailures))
 	invObjs = invObjs.Union(reconcileFailures)
 
 	// If an object timed out reconciling and was previously stored in the inventory,
 	// then keep it in the inventory so it can be waited on next time.
 	reconcileTimeouts := i.PrevInventory.Intersection(im.TimeoutReconciles())
-	klog.V(4).Infof("keep in inventory %d timeout reconciles", len(reconcileTimeouts))
+	logger.V(4).Info("keep in inventory timeout reconciles", "objects", len(reconcileTimeouts))
 	invObjs = invObjs.Union(reconcileTimeouts)
 
 	// If an object is abandoned, then remove it from the inventory.
 	abandonedObjects := taskContext.AbandonedObjects()
-	klog.V(4).Infof("remove from inventory %d abandoned objects", len(abandonedObjects))
+	logger.V(4).Info("remove from inventory abandoned objects", "objects", len(abandonedObjects))
 	invObjs = invObjs.Diff(abandonedObjects)
 
 	// If an object is invalid and was previously stored in the inventory,
 	// then keep it in the inventory so it can be applied/pruned next time.
 	invalidObjects := i.PrevInventory.Intersection(taskContext.InvalidObjects())
-	klog.V(4).Infof("keep in inventory %d invalid objects", len(invalidObjects))
+	logger.V(4).Info("keep in inventory invalid objects", "objects", len(invalidObjects))
 	invObjs = invObjs.Union(invalidObjects)
 
-	klog.V(4).Infof("get the apply status for %d objects", len(invObjs))
+	// If an object is excluded from the run and was previously stored in the
+	// inventory, then keep it in the inventory so it can be applied/pruned
+	// next time.
+	retained := i.PrevInventory.Intersection(i.Retained)
+	logger.V(4).Info("keep in inventory excluded objects", "objects", len(retained))
+	invObjs = invObjs.Union(retained)
+
+	logger.V(4).Info("get the apply status for objects", "objects", len(invObjs))
 	objStatus := taskContext.InventoryManager().Inventory().Status.Objects
 
-	klog.V(4).Infof("set inventory %d total objects", len(invObjs))
-	err := i.InvClient.Replace(i.InvInfo, invObjs, objStatus, i.DryRun)
+	logger.V(4).Info("set inventory total objects", "objects", len(invObjs))
+	err := i.InvClient.Replace(taskContext.Context(), i.InvInfo, invObjs, objStatus, i.DryRun)
 
-	klog.V(2).Infof("inventory set task completing (name: %q)", i.TaskName)
+	logger.V(2).Info("inventory set task completing", "task", i.TaskName)
 	return err
 }
 
 // deleteInventory deletes the inventory object from the cluster.
-func (i *DeleteOrUpdateInvTask) deleteInventory() error {
-	klog.V(2).Infof("delete inventory task starting (name: %q)", i.Name())
-	err := i.InvClient.DeleteInventoryObj(i.InvInfo, i.DryRun)
+func (i *DeleteOrUpdateInvTask) deleteInventory(taskContext *taskrunner.TaskContext) error {
+	logger := taskContext.Logger()
+	logger.V(2).Info("delete inventory task starting", "task", i.Name())
+	err := i.InvClient.DeleteInventoryObj(taskContext.Context(), i.InvInfo, i.DryRun)
 	// Not found is not error, since this means it was already deleted.
 	if apierrors.IsNotFound(err) {
 		err = nil
 	}
-	klog.V(2).Infof("delete inventory task completing (name: %q)", i.Name())
+	logger.V(2).Info("delete inventory task completing", "task", i.Name())
 	return err
 }
 
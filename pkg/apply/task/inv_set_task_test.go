@@ -6,9 +6,13 @@ package task
 import (
 	"testing"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/apply/prune"
 	"sigs.k8s.io/cli-utils/pkg/apply/taskrunner"
 	"sigs.k8s.io/cli-utils/pkg/inventory"
 	"sigs.k8s.io/cli-utils/pkg/object"
@@ -40,6 +44,7 @@ func TestInvSetTask(t *testing.T) {
 		timeoutReconciles object.ObjMetadataSet
 		abandonedObjs     object.ObjMetadataSet
 		invalidObjs       object.ObjMetadataSet
+		retainedObjs      object.ObjMetadataSet
 		expectedObjs      object.ObjMetadataSet
 	}{
 		"no apply objs, no prune failures; no inventory": {
@@ -187,6 +192,12 @@ func TestInvSetTask(t *testing.T) {
 			timeoutReconciles: object.ObjMetadataSet{id3},
 			expectedObjs:      object.ObjMetadataSet{id3},
 		},
+		"retained objects, one in prev inventory": {
+			prevInventory: object.ObjMetadataSet{id2},
+			appliedObjs:   object.ObjMetadataSet{id1},
+			retainedObjs:  object.ObjMetadataSet{id2, id3},
+			expectedObjs:  object.ObjMetadataSet{id1, id2},
+		},
 	}
 
 	for name, tc := range tests {
@@ -201,6 +212,7 @@ func TestInvSetTask(t *testing.T) {
 				InvClient:     client,
 				InvInfo:       nil,
 				PrevInventory: tc.prevInventory,
+				Retained:      tc.retainedObjs,
 				Destroy:       false,
 			}
 			im := context.InventoryManager()
@@ -246,10 +258,141 @@ func TestInvSetTask(t *testing.T) {
 			if result.Err != nil {
 				t.Errorf("unexpected error running InvAddTask: %s", result.Err)
 			}
-			actual, _ := client.GetClusterObjs(nil)
+			actual, _ := client.GetClusterObjs(context.Context(), nil)
 			testutil.AssertEqual(t, tc.expectedObjs, actual,
 				"Actual cluster objects (%d) do not match expected cluster objects (%d)",
 				len(actual), len(tc.expectedObjs))
 		})
 	}
 }
+
+func TestInvSetTask_Disposition(t *testing.T) {
+	id1 := object.UnstructuredToObjMetadata(obj1)
+
+	tests := map[string]struct {
+		destroy             bool
+		keepInventory       bool
+		deletedObjs         object.ObjMetadataSet
+		failedDeletes       object.ObjMetadataSet
+		expectedDisposition event.InventoryDisposition
+		expectedObjs        object.ObjMetadataSet
+	}{
+		"apply": {
+			expectedDisposition: event.InventoryUpdated,
+			expectedObjs:        object.ObjMetadataSet{},
+		},
+		"successful destroy": {
+			destroy:             true,
+			deletedObjs:         object.ObjMetadataSet{id1},
+			expectedDisposition: event.InventoryDeleted,
+			expectedObjs:        object.ObjMetadataSet{id1},
+		},
+		"successful destroy, inventory kept": {
+			destroy:             true,
+			keepInventory:       true,
+			deletedObjs:         object.ObjMetadataSet{id1},
+			expectedDisposition: event.InventoryRetained,
+			expectedObjs:        object.ObjMetadataSet{},
+		},
+		"failed destroy": {
+			destroy:             true,
+			failedDeletes:       object.ObjMetadataSet{id1},
+			expectedDisposition: event.InventoryUpdated,
+			expectedObjs:        object.ObjMetadataSet{id1},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// The fake client keeps the objects when the inventory is deleted.
+			client := inventory.NewFakeClient(object.ObjMetadataSet{id1})
+			context := taskrunner.NewTaskContext(make(chan event.Event), cache.NewResourceCacheMap())
+			im := context.InventoryManager()
+			for _, id := range tc.deletedObjs {
+				im.AddSuccessfulDelete(id, "unused-uid")
+			}
+			for _, id := range tc.failedDeletes {
+				im.AddFailedDelete(id)
+			}
+
+			task := DeleteOrUpdateInvTask{
+				TaskName:      taskName,
+				InvClient:     client,
+				PrevInventory: object.ObjMetadataSet{id1},
+				Destroy:       tc.destroy,
+				KeepInventory: tc.keepInventory,
+			}
+			task.Start(context)
+			result := <-context.TaskChannel()
+			if result.Err != nil {
+				t.Fatalf("unexpected error running DeleteOrUpdateInvTask: %s", result.Err)
+			}
+			testutil.AssertEqual(t, tc.expectedDisposition, result.Inventory)
+			actual, _ := client.GetClusterObjs(context.Context(), nil)
+			testutil.AssertEqual(t, tc.expectedObjs, actual)
+		})
+	}
+}
+
+func TestInvSetTask_StaleEntries(t *testing.T) {
+	id1 := object.UnstructuredToObjMetadata(obj1)
+	id2 := object.UnstructuredToObjMetadata(obj2)
+	notFound := apierrors.NewNotFound(schema.GroupResource{Resource: "pods"}, id2.Name)
+
+	tests := map[string]struct {
+		destroy       bool
+		expectedEvent event.Event
+	}{
+		"apply": {
+			expectedEvent: event.Event{
+				Type: event.PruneType,
+				PruneEvent: event.PruneEvent{
+					GroupName:  taskName,
+					Identifier: id2,
+					Status:     event.PruneSkipped,
+					Error:      &filter.StaleInventoryEntryError{ID: id2, Err: notFound},
+					SkipReason: event.StaleInventoryEntry,
+				},
+			},
+		},
+		"destroy": {
+			destroy: true,
+			expectedEvent: event.Event{
+				Type: event.DeleteType,
+				DeleteEvent: event.DeleteEvent{
+					GroupName:  taskName,
+					Identifier: id2,
+					Status:     event.DeleteSkipped,
+					Error:      &filter.StaleInventoryEntryError{ID: id2, Err: notFound},
+					SkipReason: event.StaleInventoryEntry,
+				},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			client := inventory.NewFakeClient(object.ObjMetadataSet{id1, id2})
+			eventChannel := make(chan event.Event, 1)
+			context := taskrunner.NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+			context.InventoryManager().AddSuccessfulApply(id1, "unused-uid", int64(0))
+
+			task := DeleteOrUpdateInvTask{
+				TaskName:      taskName,
+				InvClient:     client,
+				PrevInventory: object.ObjMetadataSet{id1, id2},
+				Stale:         []prune.StaleEntry{{ID: id2, Err: notFound}},
+				Destroy:       tc.destroy,
+				KeepInventory: true,
+			}
+			task.Start(context)
+			result := <-context.TaskChannel()
+			if result.Err != nil {
+				t.Fatalf("unexpected error running DeleteOrUpdateInvTask: %s", result.Err)
+			}
+			testutil.AssertEqual(t, tc.expectedEvent, <-eventChannel)
+			actual, _ := client.GetClusterObjs(context.Context(), nil)
+			testutil.AssertEqual(t, object.ObjMetadataSet{id1}, actual)
+		})
+	}
+}
@@ -6,12 +6,18 @@ package task
 import (
 	"fmt"
 	"io"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/cli-runtime/pkg/printers"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/info"
+	"sigs.k8s.io/cli-utils/pkg/apply/mutator"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/applymethod"
 )
 
 // KubectlPrinterAdapter is a workaround for capturing progress from
@@ -20,16 +26,45 @@ import (
 // plugs into ApplyOptions as a ToPrinter function, but instead of
 // printing the info, it emits it as an event on the provided channel.
 type KubectlPrinterAdapter struct {
-	ch        chan<- event.Event
+	sendEvent func(event.Event)
 	groupName string
+	redactor  *object.Redactor
+	warnings  *info.WarningRecorder
+	// live is the object in the cluster before the apply. If set, the
+	// changes of the fields are attached to the events.
+	live *unstructured.Unstructured
+	// diffed is true if the object in the cluster was looked up, so a nil
+	// live object means the object is created.
+	diffed bool
+	// localDiff only diffs the fields set in the local object, for
+	// client-side dry-runs, where the object is not merged with the object
+	// in the cluster.
+	localDiff bool
+	// start is the time the task started processing the object.
+	start time.Time
+	// method is the method the object is applied with.
+	method applymethod.Method
+	// notes are the warnings of the client about the apply, like a fallback
+	// to client-side apply, attached to the event before the warnings of
+	// the server.
+	notes []string
 }
 
 // resourcePrinterImpl implements the ResourcePrinter interface. But
 // instead of printing, it emits information on the provided channel.
 type resourcePrinterImpl struct {
 	applyStatus event.ApplyEventStatus
-	ch          chan<- event.Event
+	operation   event.ApplyOperation
+	sendEvent   func(event.Event)
 	groupName   string
+	redactor    *object.Redactor
+	warnings    *info.WarningRecorder
+	live        *unstructured.Unstructured
+	diffed      bool
+	localDiff   bool
+	start       time.Time
+	method      applymethod.Method
+	notes       []string
 }
 
 // PrintObj takes the provided object and operation and emits
@@ -39,18 +74,102 @@ func (r *resourcePrinterImpl) PrintObj(obj runtime.Object, _ io.Writer) error {
 	if err != nil {
 		return err
 	}
-	r.ch <- event.Event{
+	u := obj.(*unstructured.Unstructured)
+	var diff []object.FieldDiff
+	if r.live != nil {
+		injected := injectedMetadata(r.live, u)
+		diff = object.DiffFields(diffContent(r.live, injected), diffContent(u, injected))
+		if r.localDiff {
+			diff = localFieldDiffs(diff)
+		}
+		diff = r.redactor.RedactDiffs(u, diff)
+	}
+	operation := r.operation
+	if r.diffed {
+		switch {
+		case r.live == nil:
+			operation = event.ApplyCreated
+		case len(diff) == 0:
+			operation = event.ApplyUnchanged
+		default:
+			operation = event.ApplyConfigured
+		}
+	}
+	warnings := r.warnings.Flush()
+	if len(r.notes) > 0 {
+		warnings = append(append([]string{}, r.notes...), warnings...)
+	}
+	r.sendEvent(event.Event{
 		Type: event.ApplyType,
 		ApplyEvent: event.ApplyEvent{
 			GroupName:  r.groupName,
 			Identifier: id,
 			Status:     r.applyStatus,
-			Resource:   obj.(*unstructured.Unstructured),
+			Resource:   r.redactor.Redact(u),
+			Warnings:   warnings,
+			Diff:       diff,
+			Operation:  operation,
+			Method:     r.method,
 		},
-	}
+	}.WithTiming(r.start))
 	return nil
 }
 
+// diffContent returns a copy of the content of the object without the
+// fields that change on every write, or that the apply does not change, and
+// without the injected labels and annotations.
+func diffContent(u *unstructured.Unstructured, injected mutator.InjectedMetadata) map[string]interface{} {
+	u = u.DeepCopy()
+	unstructured.RemoveNestedField(u.Object, "metadata", "managedFields")
+	unstructured.RemoveNestedField(u.Object, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(u.Object, "metadata", "generation")
+	unstructured.RemoveNestedField(u.Object, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+	unstructured.RemoveNestedField(u.Object, "metadata", "annotations", common.InjectedMetadataAnnotation)
+	for _, k := range injected.Labels {
+		unstructured.RemoveNestedField(u.Object, "metadata", "labels", k)
+	}
+	for _, k := range injected.Annotations {
+		unstructured.RemoveNestedField(u.Object, "metadata", "annotations", k)
+	}
+	unstructured.RemoveNestedField(u.Object, "status")
+	if len(u.GetLabels()) == 0 {
+		unstructured.RemoveNestedField(u.Object, "metadata", "labels")
+	}
+	if len(u.GetAnnotations()) == 0 {
+		unstructured.RemoveNestedField(u.Object, "metadata", "annotations")
+	}
+	return u.Object
+}
+
+// localFieldDiffs returns the diffs of the fields set in the local object.
+// The fields only set in the cluster, like the defaulted fields, are kept by
+// the apply.
+func localFieldDiffs(diffs []object.FieldDiff) []object.FieldDiff {
+	var local []object.FieldDiff
+	for _, d := range diffs {
+		if d.New != nil {
+			local = append(local, d)
+		}
+	}
+	return local
+}
+
+// injectedMetadata returns the keys of the labels and annotations injected
+// into any of the objects. Invalid records are ignored, so the keys are
+// diffed.
+func injectedMetadata(objs ...*unstructured.Unstructured) mutator.InjectedMetadata {
+	var all mutator.InjectedMetadata
+	for _, obj := range objs {
+		injected, err := mutator.ReadInjectedMetadata(obj)
+		if err != nil {
+			continue
+		}
+		all.Labels = append(all.Labels, injected.Labels...)
+		all.Annotations = append(all.Annotations, injected.Annotations...)
+	}
+	return all
+}
+
 type toPrinterFunc func(string) (printers.ResourcePrinter, error)
 
 // toPrinterFunc returns a function of type toPrinterFunc. This
@@ -59,9 +178,18 @@ func (p *KubectlPrinterAdapter) toPrinterFunc() toPrinterFunc {
 	return func(operation string) (printers.ResourcePrinter, error) {
 		applyStatus, err := kubectlOperationToApplyStatus(operation)
 		return &resourcePrinterImpl{
-			ch:          p.ch,
+			sendEvent:   p.sendEvent,
 			applyStatus: applyStatus,
+			operation:   kubectlOperationToApplyOperation(operation),
 			groupName:   p.groupName,
+			redactor:    p.redactor,
+			warnings:    p.warnings,
+			live:        p.live,
+			diffed:      p.diffed,
+			localDiff:   p.localDiff,
+			start:       p.start,
+			method:      p.method,
+			notes:       p.notes,
 		}, err
 	}
 }
@@ -80,3 +208,18 @@ func kubectlOperationToApplyStatus(operation string) (event.ApplyEventStatus, er
 		return event.ApplyEventStatus(0), fmt.Errorf("unknown operation %s", operation)
 	}
 }
+
+// kubectlOperationToApplyOperation returns the change reported by kubectl.
+// Server-side applies don't report whether the object changed.
+func kubectlOperationToApplyOperation(operation string) event.ApplyOperation {
+	switch operation {
+	case "created":
+		return event.ApplyCreated
+	case "configured":
+		return event.ApplyConfigured
+	case "unchanged":
+		return event.ApplyUnchanged
+	default:
+		return event.UnknownApplyOperation
+	}
+}
@@ -5,12 +5,17 @@ package task
 
 import (
 	"bytes"
+	"context"
 	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/mutator"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/applymethod"
 )
 
 func TestKubectlPrinterAdapter(t *testing.T) {
@@ -19,8 +24,9 @@ func TestKubectlPrinterAdapter(t *testing.T) {
 	operation := "serverside-applied"
 
 	adapter := KubectlPrinterAdapter{
-		ch:        ch,
+		sendEvent: func(e event.Event) { ch <- e },
 		groupName: "test-0",
+		method:    applymethod.ServerSide,
 	}
 
 	toPrinterFunc := adapter.toPrinterFunc()
@@ -52,4 +58,253 @@ func TestKubectlPrinterAdapter(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, event.ApplySuccessful, msg.ApplyEvent.Status)
 	assert.Equal(t, deployment, msg.ApplyEvent.Resource)
+	assert.Equal(t, applymethod.ServerSide, msg.ApplyEvent.Method)
+}
+
+func TestKubectlPrinterAdapter_RedactsSecrets(t *testing.T) {
+	ch := make(chan event.Event)
+	buffer := bytes.Buffer{}
+
+	adapter := KubectlPrinterAdapter{
+		sendEvent: func(e event.Event) { ch <- e },
+		groupName: "test-0",
+	}
+
+	resourcePrinter, err := adapter.toPrinterFunc()("serverside-applied")
+	assert.NoError(t, err)
+
+	secret := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata": map[string]interface{}{
+				"name":      "name",
+				"namespace": "namespace",
+			},
+			"data": map[string]interface{}{
+				"password": "cGFzcw==",
+			},
+		},
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err = resourcePrinter.PrintObj(secret, &buffer)
+	}()
+	msg := <-ch
+	wg.Wait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"password": object.RedactedValue}, msg.ApplyEvent.Resource.Object["data"])
+	// the applied object must not be modified
+	assert.Equal(t, "cGFzcw==", secret.Object["data"].(map[string]interface{})["password"])
+}
+
+func TestKubectlPrinterAdapter_Diff(t *testing.T) {
+	newSecret := func(password string, resourceVersion string) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata": map[string]interface{}{
+					"name":            "name",
+					"namespace":       "namespace",
+					"resourceVersion": resourceVersion,
+					"labels": map[string]interface{}{
+						"app": password,
+					},
+				},
+				"data": map[string]interface{}{
+					"password": password,
+				},
+			},
+		}
+	}
+	ch := make(chan event.Event)
+	adapter := KubectlPrinterAdapter{
+		sendEvent: func(e event.Event) { ch <- e },
+		groupName: "test-0",
+		live:      newSecret("b2xk", "1"),
+	}
+
+	resourcePrinter, err := adapter.toPrinterFunc()("serverside-applied")
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err = resourcePrinter.PrintObj(newSecret("bmV3", "2"), &bytes.Buffer{})
+	}()
+	msg := <-ch
+	wg.Wait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []object.FieldDiff{
+		{Path: ".data.password", Old: object.RedactedValue, New: object.RedactedValue},
+		{Path: ".metadata.labels.app", Old: "b2xk", New: "bmV3"},
+	}, msg.ApplyEvent.Diff)
+}
+
+func TestKubectlPrinterAdapter_DiffIgnoresInjectedMetadata(t *testing.T) {
+	newConfigMap := func(revision, value string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"metadata": map[string]interface{}{
+					"name":      "name",
+					"namespace": "namespace",
+				},
+				"data": map[string]interface{}{
+					"key": value,
+				},
+			},
+		}
+		_, _, err := (&mutator.MetadataMutator{
+			Labels:      map[string]string{"revision": revision},
+			Annotations: map[string]string{"example.com/sha": revision},
+		}).Mutate(context.Background(), u)
+		require.NoError(t, err)
+		return u
+	}
+	ch := make(chan event.Event)
+	adapter := KubectlPrinterAdapter{
+		sendEvent: func(e event.Event) { ch <- e },
+		groupName: "test-0",
+		live:      newConfigMap("1", "old"),
+	}
+
+	resourcePrinter, err := adapter.toPrinterFunc()("serverside-applied")
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err = resourcePrinter.PrintObj(newConfigMap("2", "new"), &bytes.Buffer{})
+	}()
+	msg := <-ch
+	wg.Wait()
+
+	assert.NoError(t, err)
+	assert.Equal(t, []object.FieldDiff{
+		{Path: ".data.key", Old: "old", New: "new"},
+	}, msg.ApplyEvent.Diff)
+}
+
+func TestKubectlPrinterAdapter_Operation(t *testing.T) {
+	newDeployment := func(replicas int64, strategy string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"metadata": map[string]interface{}{
+					"name":      "name",
+					"namespace": "namespace",
+				},
+				"spec": map[string]interface{}{
+					"replicas": replicas,
+				},
+			},
+		}
+		if strategy != "" {
+			require.NoError(t, unstructured.SetNestedField(u.Object, strategy, "spec", "strategy", "type"))
+		}
+		return u
+	}
+
+	testCases := map[string]struct {
+		operation         string
+		live              *unstructured.Unstructured
+		diffed            bool
+		localDiff         bool
+		obj               *unstructured.Unstructured
+		expectedOperation event.ApplyOperation
+		expectedDiff      []object.FieldDiff
+	}{
+		"created by kubectl": {
+			operation:         "created",
+			obj:               newDeployment(1, ""),
+			expectedOperation: event.ApplyCreated,
+		},
+		"server-side apply without diff": {
+			operation:         "serverside-applied",
+			obj:               newDeployment(1, ""),
+			expectedOperation: event.UnknownApplyOperation,
+		},
+		"diffed object not found": {
+			operation:         "serverside-applied",
+			diffed:            true,
+			obj:               newDeployment(1, ""),
+			expectedOperation: event.ApplyCreated,
+		},
+		"diffed object unchanged": {
+			operation:         "serverside-applied",
+			live:              newDeployment(1, "RollingUpdate"),
+			diffed:            true,
+			obj:               newDeployment(1, "RollingUpdate"),
+			expectedOperation: event.ApplyUnchanged,
+		},
+		"diffed object configured": {
+			operation:         "serverside-applied",
+			live:              newDeployment(1, "RollingUpdate"),
+			diffed:            true,
+			obj:               newDeployment(3, "RollingUpdate"),
+			expectedOperation: event.ApplyConfigured,
+			expectedDiff: []object.FieldDiff{
+				{Path: ".spec.replicas", Old: int64(1), New: int64(3)},
+			},
+		},
+		"client dry-run ignores the fields only set in the cluster": {
+			operation:         "configured",
+			live:              newDeployment(1, "RollingUpdate"),
+			diffed:            true,
+			localDiff:         true,
+			obj:               newDeployment(1, ""),
+			expectedOperation: event.ApplyUnchanged,
+		},
+		"client dry-run diffs the local fields": {
+			operation:         "configured",
+			live:              newDeployment(1, "RollingUpdate"),
+			diffed:            true,
+			localDiff:         true,
+			obj:               newDeployment(3, ""),
+			expectedOperation: event.ApplyConfigured,
+			expectedDiff: []object.FieldDiff{
+				{Path: ".spec.replicas", Old: int64(1), New: int64(3)},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ch := make(chan event.Event)
+			adapter := KubectlPrinterAdapter{
+				sendEvent: func(e event.Event) { ch <- e },
+				groupName: "test-0",
+				live:      tc.live,
+				diffed:    tc.diffed,
+				localDiff: tc.localDiff,
+			}
+
+			resourcePrinter, err := adapter.toPrinterFunc()(tc.operation)
+			require.NoError(t, err)
+
+			var wg sync.WaitGroup
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err = resourcePrinter.PrintObj(tc.obj, &bytes.Buffer{})
+			}()
+			msg := <-ch
+			wg.Wait()
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedOperation, msg.ApplyEvent.Operation)
+			assert.Equal(t, tc.expectedDiff, msg.ApplyEvent.Diff)
+		})
+	}
 }
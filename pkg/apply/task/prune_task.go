@@ -5,7 +5,6 @@ package task
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/apply/filter"
 	"sigs.k8s.io/cli-utils/pkg/apply/prune"
@@ -28,6 +27,14 @@ type PruneTask struct {
 	// True if we are destroying, which deletes the inventory object
 	// as well (possibly) the inventory namespace.
 	Destroy bool
+	// DeleteConcurrency is the number of objects deleted in parallel.
+	DeleteConcurrency int
+	// DeleteQPS limits the deletions per second of each parallel worker.
+	DeleteQPS float32
+	// Applied means the Objects were applied earlier in the run, like
+	// hooks deleted after they succeed. The UIDs of the objects are set
+	// from the apply, and objects applied by the run can be deleted.
+	Applied bool
 }
 
 func (p *PruneTask) Name() string {
@@ -52,16 +59,21 @@ func (p *PruneTask) Identifiers() object.ObjMetadataSet {
 // to signal to the taskrunner that the task has completed (or failed).
 func (p *PruneTask) Start(taskContext *taskrunner.TaskContext) {
 	go func() {
-		klog.V(2).Infof("prune task starting (name: %q, objects: %d)",
-			p.Name(), len(p.Objects))
-		// Create filter to prevent deletion of currently applied
-		// objects. Must be done here to wait for applied UIDs.
-		uidFilter := filter.CurrentUIDFilter{
-			CurrentUIDs: taskContext.InventoryManager().AppliedResourceUIDs(),
+		logger := taskContext.Logger()
+		logger.V(2).Info("prune task starting", "task", p.Name(), "objects", len(p.Objects))
+		objs := p.Objects
+		if p.Applied {
+			objs = p.appliedObjects(taskContext)
+		} else {
+			// Create filter to prevent deletion of currently applied
+			// objects. Must be done here to wait for applied UIDs.
+			uidFilter := filter.CurrentUIDFilter{
+				CurrentUIDs: taskContext.InventoryManager().AppliedResourceUIDs(),
+			}
+			p.Filters = append(p.Filters, uidFilter)
 		}
-		p.Filters = append(p.Filters, uidFilter)
 		err := p.Pruner.Prune(
-			p.Objects,
+			objs,
 			p.Filters,
 			taskContext,
 			p.Name(),
@@ -69,15 +81,31 @@ func (p *PruneTask) Start(taskContext *taskrunner.TaskContext) {
 				DryRunStrategy:    p.DryRunStrategy,
 				PropagationPolicy: p.PropagationPolicy,
 				Destroy:           p.Destroy,
+				DeleteConcurrency: p.DeleteConcurrency,
+				DeleteQPS:         p.DeleteQPS,
 			},
 		)
-		klog.V(2).Infof("prune task completing (name: %q)", p.Name())
+		logger.V(2).Info("prune task completing", "task", p.Name())
 		taskContext.TaskChannel() <- taskrunner.TaskResult{
 			Err: err,
 		}
 	}()
 }
 
+// appliedObjects returns copies of the Objects, with the UIDs they were
+// given when applied.
+func (p *PruneTask) appliedObjects(taskContext *taskrunner.TaskContext) object.UnstructuredSet {
+	objs := make(object.UnstructuredSet, 0, len(p.Objects))
+	for _, obj := range p.Objects {
+		obj = obj.DeepCopy()
+		if uid, found := taskContext.InventoryManager().AppliedResourceUID(object.UnstructuredToObjMetadata(obj)); found {
+			obj.SetUID(uid)
+		}
+		objs = append(objs, obj)
+	}
+	return objs
+}
+
 // Cancel is not supported by the PruneTask.
 func (p *PruneTask) Cancel(_ *taskrunner.TaskContext) {}
 
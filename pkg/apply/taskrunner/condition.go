@@ -4,6 +4,9 @@
 package taskrunner
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
@@ -21,13 +24,25 @@ const (
 	// has reached the NotFound status, i.e. they are all deleted
 	// from the cluster.
 	AllNotFound Condition = "AllNotFound"
+
+	// AllCompleted Condition means all the provided resources
+	// have run to completion: Jobs have the Complete condition and
+	// Pods have succeeded. Other resources must be Current.
+	// Jobs with the Failed condition and failed Pods are failed.
+	AllCompleted Condition = "AllCompleted"
+)
+
+var (
+	jobGK = schema.GroupKind{Group: "batch", Kind: "Job"}
+	podGK = schema.GroupKind{Group: "", Kind: "Pod"}
 )
 
 // Meets returns true if the provided status meets the condition and
-// false if it does not.
+// false if it does not. For AllCompleted, the Current status is required,
+// but not sufficient, since Jobs and Pods are Current while running.
 func (c Condition) Meets(s status.Status) bool {
 	switch c {
-	case AllCurrent:
+	case AllCurrent, AllCompleted:
 		return s == status.CurrentStatus
 	case AllNotFound:
 		return s == status.NotFoundStatus
@@ -45,6 +60,8 @@ func conditionMet(taskContext *TaskContext, ids object.ObjMetadataSet, c Conditi
 		return allMatchStatus(taskContext, ids, status.CurrentStatus)
 	case AllNotFound:
 		return allMatchStatus(taskContext, ids, status.NotFoundStatus)
+	case AllCompleted:
+		return allCompleted(taskContext, ids)
 	default:
 		return noneMatchStatus(taskContext, ids, status.UnknownStatus)
 	}
@@ -72,6 +89,66 @@ func allMatchStatus(taskContext *TaskContext, ids object.ObjMetadataSet, s statu
 	return true
 }
 
+// allCompleted checks whether all of the resources provided have run to
+// completion. Resources with older generations are considered non-matching.
+func allCompleted(taskContext *TaskContext, ids object.ObjMetadataSet) bool {
+	for _, id := range ids {
+		cached := taskContext.ResourceCache().Get(id)
+		if cached.Resource == nil {
+			return false
+		}
+		if completed, failed, ok := completion(cached.Resource); ok {
+			if !completed || failed {
+				return false
+			}
+		} else if cached.Status != status.CurrentStatus {
+			return false
+		}
+
+		applyGen, _ := taskContext.InventoryManager().AppliedGeneration(id) // generation at apply time
+		if cached.Resource.GetGeneration() < applyGen {
+			// cache too old
+			return false
+		}
+	}
+	return true
+}
+
+// completion returns whether the Job or Pod has run to completion, and
+// whether it failed. The last return value is false for other resources.
+func completion(u *unstructured.Unstructured) (completed bool, failed bool, ok bool) {
+	switch u.GroupVersionKind().GroupKind() {
+	case jobGK:
+		objc, err := status.GetObjectWithConditions(u.UnstructuredContent())
+		if err != nil {
+			return false, false, true
+		}
+		for _, c := range objc.Status.Conditions {
+			if c.Status != corev1.ConditionTrue {
+				continue
+			}
+			switch c.Type {
+			case "Complete":
+				return true, false, true
+			case "Failed":
+				return true, true, true
+			}
+		}
+		return false, false, true
+	case podGK:
+		phase, _, _ := unstructured.NestedString(u.Object, "status", "phase")
+		switch corev1.PodPhase(phase) {
+		case corev1.PodSucceeded:
+			return true, false, true
+		case corev1.PodFailed:
+			return true, true, true
+		}
+		return false, false, true
+	default:
+		return false, false, false
+	}
+}
+
 // allMatchStatus checks whether none of the resources provided have the provided status.
 // Resources with older generations are considered matching.
 func noneMatchStatus(taskContext *TaskContext, ids object.ObjMetadataSet, s status.Status) bool {
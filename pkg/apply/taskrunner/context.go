@@ -4,6 +4,11 @@
 package taskrunner
 
 import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
@@ -21,7 +26,10 @@ func NewTaskContext(eventChannel chan event.Event, resourceCache cache.ResourceC
 		inventoryManager: inventory.NewManager(),
 		abandonedObjects: make(map[object.ObjMetadata]struct{}),
 		invalidObjects:   make(map[object.ObjMetadata]struct{}),
+		servedKinds:      make(map[schema.GroupKind]bool),
 		graph:            graph.New(),
+		logger:           klog.Background(),
+		ctx:              context.Background(),
 	}
 }
 
@@ -35,6 +43,16 @@ type TaskContext struct {
 	abandonedObjects map[object.ObjMetadata]struct{}
 	invalidObjects   map[object.ObjMetadata]struct{}
 	graph            *graph.Graph
+	slimEvents       bool
+	logger           logr.Logger
+	ctx              context.Context
+	// keepAppliedObjects keeps the objects of successful apply events, even
+	// if slimEvents is true.
+	keepAppliedObjects bool
+	redactor           *object.Redactor
+	// servedKinds caches the lookups of the CRDs and APIServices of the
+	// unmapped kinds in the cluster, so they are only looked up once per run.
+	servedKinds map[schema.GroupKind]bool
 }
 
 func (tc *TaskContext) TaskChannel() chan TaskResult {
@@ -61,9 +79,79 @@ func (tc *TaskContext) SetGraph(g *graph.Graph) {
 	tc.graph = g
 }
 
-// SendEvent sends an event on the event channel
+// Logger returns the logger of the run. Tasks should log with it, instead
+// of the global klog logger.
+func (tc *TaskContext) Logger() logr.Logger {
+	return tc.logger
+}
+
+// SetLogger sets the logger of the run.
+func (tc *TaskContext) SetLogger(logger logr.Logger) {
+	tc.logger = logger
+}
+
+// Context returns the context of the run, with the logger of the run.
+// Tasks should pass it to the inventory client, so the requests are
+// cancelled with the run.
+func (tc *TaskContext) Context() context.Context {
+	return klog.NewContext(tc.ctx, tc.logger)
+}
+
+// SetContext sets the context of the run.
+func (tc *TaskContext) SetContext(ctx context.Context) {
+	tc.ctx = ctx
+}
+
+// SetSlimEvents sets whether the objects are omitted from the events sent on
+// the event channel.
+func (tc *TaskContext) SetSlimEvents(slim bool) {
+	tc.slimEvents = slim
+}
+
+// SetKeepAppliedObjects sets whether the objects returned by the server are
+// kept on successful apply events, even if the events are slim.
+func (tc *TaskContext) SetKeepAppliedObjects(keep bool) {
+	tc.keepAppliedObjects = keep
+}
+
+// SetRedactor sets the Redactor used to redact the objects of the events.
+// The values of Secrets are redacted, even if it is not set.
+func (tc *TaskContext) SetRedactor(redactor *object.Redactor) {
+	tc.redactor = redactor
+}
+
+// redactEvent returns a copy of the event with the sensitive values of its
+// objects redacted.
+func (tc *TaskContext) redactEvent(e event.Event) event.Event {
+	e.ApplyEvent.Resource = tc.redactor.Redact(e.ApplyEvent.Resource)
+	e.StatusEvent.Resource = tc.redactor.Redact(e.StatusEvent.Resource)
+	if info := e.StatusEvent.PollResourceInfo; info != nil && info.Resource != nil {
+		redacted := *info
+		redacted.Resource = tc.redactor.Redact(info.Resource)
+		e.StatusEvent.PollResourceInfo = &redacted
+	}
+	e.PruneEvent.Object = tc.redactor.Redact(e.PruneEvent.Object)
+	e.DeleteEvent.Object = tc.redactor.Redact(e.DeleteEvent.Object)
+	return e
+}
+
+// SendEvent sends an event on the event channel. The Timestamp of the event
+// is set to the current time, if not already set. The objects of the event
+// are redacted.
 func (tc *TaskContext) SendEvent(e event.Event) {
-	klog.V(3).Infof("Sending event: %v", e)
+	if e.Timestamp.IsZero() {
+		e.Timestamp = time.Now()
+	}
+	e = tc.redactEvent(e)
+	if tc.slimEvents {
+		applied := e.ApplyEvent.Resource
+		e = e.Slim()
+		if tc.keepAppliedObjects && e.Type == event.ApplyType && e.ApplyEvent.Status == event.ApplySuccessful {
+			e.ApplyEvent.Resource = applied
+		}
+	}
+	// Only the identifiers and statuses are logged, not the objects.
+	tc.logger.V(3).Info("Sending event", "event", e.String())
 	tc.eventChannel <- e
 }
 
@@ -98,3 +186,16 @@ func (tc *TaskContext) AddInvalidObject(id object.ObjMetadata) {
 func (tc *TaskContext) InvalidObjects() object.ObjMetadataSet {
 	return object.ObjMetadataSetFromMap(tc.invalidObjects)
 }
+
+// ServedKind returns true if the kind was looked up in the cluster, and
+// whether a CRD or APIService serves it.
+func (tc *TaskContext) ServedKind(gk schema.GroupKind) (served bool, found bool) {
+	served, found = tc.servedKinds[gk]
+	return served, found
+}
+
+// SetServedKind records whether a CRD or APIService in the cluster serves
+// the kind.
+func (tc *TaskContext) SetServedKind(gk schema.GroupKind, served bool) {
+	tc.servedKinds[gk] = served
+}
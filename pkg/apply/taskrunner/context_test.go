@@ -0,0 +1,140 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package taskrunner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/apply/cache"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	testlog "sigs.k8s.io/cli-utils/pkg/testutil/log"
+)
+
+func TestTaskContext_Context(t *testing.T) {
+	taskContext := NewTaskContext(make(chan event.Event), cache.NewResourceCacheMap())
+	assert.NoError(t, taskContext.Context().Err())
+
+	sink := testlog.NewSink(0)
+	taskContext.SetLogger(sink.Logger())
+	ctx, cancel := context.WithCancel(context.Background())
+	taskContext.SetContext(ctx)
+
+	// The context carries the logger of the run.
+	klog.FromContext(taskContext.Context()).Info("from context")
+	sink.AssertLogged(t, 0, "from context")
+
+	// The context is cancelled with the run.
+	cancel()
+	assert.ErrorIs(t, taskContext.Context().Err(), context.Canceled)
+}
+
+func TestTaskContext_SendEvent_KeepAppliedObjects(t *testing.T) {
+	obj := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "foo",
+				"namespace": "default",
+				"uid":       "123",
+			},
+		},
+	}
+
+	testCases := map[string]struct {
+		keep     bool
+		status   event.ApplyEventStatus
+		expected *unstructured.Unstructured
+	}{
+		"slim": {
+			status: event.ApplySuccessful,
+		},
+		"slim, keep applied objects": {
+			keep:     true,
+			status:   event.ApplySuccessful,
+			expected: obj,
+		},
+		"slim, keep applied objects, skipped": {
+			keep:   true,
+			status: event.ApplySkipped,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			eventChannel := make(chan event.Event, 1)
+			taskContext := NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+			taskContext.SetSlimEvents(true)
+			taskContext.SetKeepAppliedObjects(tc.keep)
+
+			taskContext.SendEvent(event.Event{
+				Type: event.ApplyType,
+				ApplyEvent: event.ApplyEvent{
+					Status:   tc.status,
+					Resource: obj,
+				},
+			})
+			e := <-eventChannel
+			assert.Equal(t, tc.expected, e.ApplyEvent.Resource)
+		})
+	}
+}
+
+func TestTaskContext_SendEvent_Redact(t *testing.T) {
+	secret := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata": map[string]interface{}{
+				"name":      "foo",
+				"namespace": "default",
+			},
+			"data": map[string]interface{}{
+				"password": "cGFzcw==",
+			},
+		},
+	}
+	original := secret.DeepCopy()
+
+	eventChannel := make(chan event.Event, 1)
+	taskContext := NewTaskContext(eventChannel, cache.NewResourceCacheMap())
+	sink := testlog.NewSink(3)
+	taskContext.SetLogger(sink.Logger())
+
+	taskContext.SendEvent(event.Event{
+		Type: event.StatusType,
+		StatusEvent: event.StatusEvent{
+			Identifier: object.UnstructuredToObjMetadata(secret),
+			PollResourceInfo: &pollevent.ResourceStatus{
+				Identifier: object.UnstructuredToObjMetadata(secret),
+				Status:     status.CurrentStatus,
+				Resource:   secret,
+			},
+			Resource: secret,
+		},
+	})
+	e := <-eventChannel
+
+	password, _, _ := unstructured.NestedString(e.StatusEvent.Resource.Object, "data", "password")
+	assert.Equal(t, object.RedactedValue, password)
+	password, _, _ = unstructured.NestedString(e.StatusEvent.PollResourceInfo.Resource.Object, "data", "password")
+	assert.Equal(t, object.RedactedValue, password)
+	assert.Equal(t, status.CurrentStatus, e.StatusEvent.PollResourceInfo.Status)
+	// the objects of the sender must not be modified
+	assert.Equal(t, original, secret)
+
+	// Only the identifiers are logged.
+	entries := sink.Find(3, "Sending event")
+	if assert.Len(t, entries, 1) {
+		assert.NotContains(t, entries[0].String(), "cGFzcw==")
+		assert.Contains(t, entries[0].String(), "foo")
+	}
+}
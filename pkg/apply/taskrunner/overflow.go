@@ -0,0 +1,121 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package taskrunner
+
+import (
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// OverflowPolicy defines what the runner does with status events when the
+// consumer of the event channel can't keep up.
+//
+//go:generate stringer -type=OverflowPolicy
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the runner until the status event has been
+	// received. This is the default.
+	OverflowBlock OverflowPolicy = iota
+
+	// OverflowDropStatusEvents drops status events if the event channel is
+	// full. Other events are never dropped. Use together with a buffered
+	// event channel, otherwise status events are dropped whenever the
+	// consumer is not waiting for the next event.
+	OverflowDropStatusEvents
+
+	// OverflowCoalesceStatusEvents holds back status events if the event
+	// channel is full, and only keeps the latest status event for each
+	// object. The pending events are sent as soon as the consumer catches up.
+	OverflowCoalesceStatusEvents
+)
+
+// statusEventSender sends status events to the event channel, applying the
+// OverflowPolicy.
+//
+// statusEventSender is not thread-safe. It must only be used by the runner
+// goroutine.
+type statusEventSender struct {
+	policy       OverflowPolicy
+	eventChannel chan event.Event
+	logger       logr.Logger
+	// pending holds the coalesced status events, in the order the objects
+	// were first queued.
+	pending map[object.ObjMetadata]event.Event
+	order   []object.ObjMetadata
+}
+
+func newStatusEventSender(eventChannel chan event.Event, policy OverflowPolicy, logger logr.Logger) *statusEventSender {
+	return &statusEventSender{
+		policy:       policy,
+		eventChannel: eventChannel,
+		logger:       logger,
+		pending:      make(map[object.ObjMetadata]event.Event),
+	}
+}
+
+// Send sends the status event according to the OverflowPolicy.
+func (s *statusEventSender) Send(e event.Event) {
+	switch s.policy {
+	case OverflowDropStatusEvents:
+		select {
+		case s.eventChannel <- e:
+			s.logger.V(3).Info("Sending event", "event", e)
+		default:
+			s.logger.V(4).Info("Event channel full: dropped status event", "object", e.StatusEvent.Identifier)
+		}
+	case OverflowCoalesceStatusEvents:
+		if len(s.order) == 0 {
+			select {
+			case s.eventChannel <- e:
+				s.logger.V(3).Info("Sending event", "event", e)
+				return
+			default:
+			}
+		}
+		id := e.StatusEvent.Identifier
+		if _, found := s.pending[id]; found {
+			s.logger.V(4).Info("Event channel full: coalesced status event", "object", id)
+		} else {
+			s.order = append(s.order, id)
+		}
+		s.pending[id] = e
+	default:
+		s.logger.V(3).Info("Sending event", "event", e)
+		s.eventChannel <- e
+	}
+}
+
+// Next returns the event channel and the next pending status event, if there
+// is one. Otherwise it returns a nil channel, which blocks forever when used
+// in a select statement.
+func (s *statusEventSender) Next() (chan event.Event, event.Event) {
+	if len(s.order) == 0 {
+		return nil, event.Event{}
+	}
+	return s.eventChannel, s.pending[s.order[0]]
+}
+
+// Sent removes the event returned by Next from the pending events. Call this
+// after the event has been sent.
+func (s *statusEventSender) Sent() {
+	if len(s.order) == 0 {
+		return
+	}
+	delete(s.pending, s.order[0])
+	s.order = s.order[1:]
+}
+
+// Flush sends all the pending status events, blocking until they have
+// been received.
+func (s *statusEventSender) Flush() {
+	for _, id := range s.order {
+		e := s.pending[id]
+		s.logger.V(3).Info("Sending event", "event", e)
+		s.eventChannel <- e
+	}
+	s.pending = make(map[object.ObjMetadata]event.Event)
+	s.order = nil
+}
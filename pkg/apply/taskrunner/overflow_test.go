@@ -0,0 +1,78 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package taskrunner
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func statusEvent(id object.ObjMetadata, s status.Status) event.Event {
+	return event.Event{
+		Type: event.StatusType,
+		StatusEvent: event.StatusEvent{
+			Identifier: id,
+			PollResourceInfo: &pollevent.ResourceStatus{
+				Identifier: id,
+				Status:     s,
+			},
+		},
+	}
+}
+
+func receiveStatuses(ch chan event.Event) []status.Status {
+	var statuses []status.Status
+	for {
+		select {
+		case e := <-ch:
+			statuses = append(statuses, e.StatusEvent.PollResourceInfo.Status)
+		default:
+			return statuses
+		}
+	}
+}
+
+func TestStatusEventSender_Drop(t *testing.T) {
+	ch := make(chan event.Event, 1)
+	sender := newStatusEventSender(ch, OverflowDropStatusEvents, logr.Discard())
+
+	sender.Send(statusEvent(depID, status.InProgressStatus))
+	sender.Send(statusEvent(depID, status.CurrentStatus))
+
+	pendingCh, _ := sender.Next()
+	assert.Nil(t, pendingCh)
+	assert.Equal(t, []status.Status{status.InProgressStatus}, receiveStatuses(ch))
+}
+
+func TestStatusEventSender_Coalesce(t *testing.T) {
+	ch := make(chan event.Event, 1)
+	sender := newStatusEventSender(ch, OverflowCoalesceStatusEvents, logr.Discard())
+
+	sender.Send(statusEvent(depID, status.InProgressStatus))
+	// The channel is full, so these are held back and coalesced.
+	sender.Send(statusEvent(cmID, status.InProgressStatus))
+	sender.Send(statusEvent(depID, status.CurrentStatus))
+	sender.Send(statusEvent(cmID, status.CurrentStatus))
+
+	assert.Equal(t, []status.Status{status.InProgressStatus}, receiveStatuses(ch))
+
+	pendingCh, e := sender.Next()
+	assert.NotNil(t, pendingCh)
+	assert.Equal(t, cmID, e.StatusEvent.Identifier)
+	assert.Equal(t, status.CurrentStatus, e.StatusEvent.PollResourceInfo.Status)
+	pendingCh <- e
+	sender.Sent()
+	assert.Equal(t, []status.Status{status.CurrentStatus}, receiveStatuses(ch))
+
+	sender.Flush()
+	pendingCh, _ = sender.Next()
+	assert.Nil(t, pendingCh)
+	assert.Equal(t, []status.Status{status.CurrentStatus}, receiveStatuses(ch))
+}
@@ -6,6 +6,7 @@ package taskrunner
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
@@ -38,6 +39,9 @@ type Options struct {
 	// RESTScopeStrategy specifies which strategy to use when listing and
 	// watching resources. By default, the strategy is selected automatically.
 	WatcherRESTScopeStrategy watcher.RESTScopeStrategy
+	// StatusEventOverflowPolicy specifies what to do with status events
+	// when the event channel is full. By default, the runner blocks.
+	StatusEventOverflowPolicy OverflowPolicy
 }
 
 // Run executes the tasks in the taskqueue, with the statusPoller running in the
@@ -59,27 +63,34 @@ func (tsr *TaskStatusRunner) Run(
 	// Give the poller its own context and run it in the background.
 	// If taskStatusRunner.Run is cancelled, baseRunner.run will exit early,
 	// causing the poller to be cancelled.
-	statusCtx, cancelFunc := context.WithCancel(context.Background())
+	// The status watcher logs with the logger of the run.
+	logger := taskContext.Logger()
+	statusCtx, cancelFunc := context.WithCancel(klog.NewContext(context.Background(), logger))
 	statusChannel := tsr.StatusWatcher.Watch(statusCtx, tsr.Identifiers, watcher.Options{
 		RESTScopeStrategy: opts.WatcherRESTScopeStrategy,
 	})
 
-	// complete stops the statusPoller, drains the statusChannel, and returns
-	// the provided error.
+	statusEvents := newStatusEventSender(taskContext.EventChannel(), opts.StatusEventOverflowPolicy, logger)
+
+	// complete stops the statusPoller, drains the statusChannel, sends the
+	// pending status events, and returns the provided error.
 	// Run this before returning!
 	// Avoid using defer, otherwise the statusPoller will hang. It needs to be
 	// drained synchronously before return, instead of asynchronously after.
 	complete := func(err error) error {
-		klog.V(7).Info("Runner cancelled status watcher")
+		logger.V(7).Info("Runner cancelled status watcher")
 		cancelFunc()
 		for statusEvent := range statusChannel {
-			klog.V(7).Infof("Runner ignored status event: %v", statusEvent)
+			logger.V(7).Info("Runner ignored status event", "event", statusEvent)
 		}
+		statusEvents.Flush()
 		return err
 	}
 
 	// Wait until the StatusWatcher is sychronized to start the first task.
 	var currentTask Task
+	// taskStart is the time the current task started.
+	var taskStart time.Time
 	done := false
 
 	// abort is used to signal that something has failed, and
@@ -95,7 +106,15 @@ func (tsr *TaskStatusRunner) Run(
 	doneCh := ctx.Done()
 
 	for {
+		// pendingCh is nil, unless there are coalesced status events
+		// waiting to be sent.
+		pendingCh, pendingEvent := statusEvents.Next()
+
 		select {
+		// Coalesced status events are sent as soon as the consumer of the
+		// eventChannel catches up.
+		case pendingCh <- pendingEvent:
+			statusEvents.Sent()
 		// This processes status events from a channel, most likely
 		// driven by the StatusPoller. All normal resource status update
 		// events are passed through to the eventChannel. This means
@@ -112,10 +131,10 @@ func (tsr *TaskStatusRunner) Run(
 			}
 
 			if abort {
-				klog.V(7).Infof("Runner ignored status event: %v", statusEvent)
+				logger.V(7).Info("Runner ignored status event", "event", statusEvent)
 				continue
 			}
-			klog.V(7).Infof("Runner received status event: %v", statusEvent)
+			logger.V(7).Info("Runner received status event", "event", statusEvent)
 
 			// An error event on the statusChannel means the StatusWatcher
 			// has encountered a problem so it can't continue. This means
@@ -137,7 +156,7 @@ func (tsr *TaskStatusRunner) Run(
 			// Tasks may commence!
 			if statusEvent.Type == pollevent.SyncEvent {
 				// Find and start the first task in the queue.
-				currentTask, done = nextTask(taskQueue, taskContext)
+				currentTask, taskStart, done = nextTask(taskQueue, taskContext)
 				if done {
 					return complete(nil)
 				}
@@ -146,15 +165,21 @@ func (tsr *TaskStatusRunner) Run(
 
 			if opts.EmitStatusEvents {
 				// Forward all normal events to the eventChannel
-				taskContext.SendEvent(event.Event{
-					Type: event.StatusType,
+				e := event.Event{
+					Type:      event.StatusType,
+					Timestamp: time.Now(),
 					StatusEvent: event.StatusEvent{
 						Identifier:       statusEvent.Resource.Identifier,
 						PollResourceInfo: statusEvent.Resource,
 						Resource:         statusEvent.Resource.Resource,
 						Error:            statusEvent.Error,
 					},
-				})
+				}
+				e = taskContext.redactEvent(e)
+				if taskContext.slimEvents {
+					e = e.Slim()
+				}
+				statusEvents.Send(e)
 			}
 
 			id := statusEvent.Resource.Identifier
@@ -189,8 +214,9 @@ func (tsr *TaskStatusRunner) Run(
 					GroupName: currentTask.Name(),
 					Action:    currentTask.Action(),
 					Status:    event.Finished,
+					Inventory: msg.Inventory,
 				},
-			})
+			}.WithTiming(taskStart))
 			if msg.Err != nil {
 				return complete(
 					fmt.Errorf("task failed (action: %q, name: %q): %w",
@@ -199,7 +225,7 @@ func (tsr *TaskStatusRunner) Run(
 			if abort {
 				return complete(abortReason)
 			}
-			currentTask, done = nextTask(taskQueue, taskContext)
+			currentTask, taskStart, done = nextTask(taskQueue, taskContext)
 			// If there are no more tasks, we are done. So just
 			// return.
 			if done {
@@ -212,7 +238,7 @@ func (tsr *TaskStatusRunner) Run(
 			doneCh = nil // Set doneCh to nil so we don't enter a busy loop.
 			abort = true
 			abortReason = ctx.Err() // always non-nil when doneCh is closed
-			klog.V(7).Infof("Runner aborting: %v", abortReason)
+			logger.V(7).Info("Runner aborting", "reason", abortReason)
 			if currentTask != nil {
 				currentTask.Cancel(taskContext)
 			} else {
@@ -224,9 +250,9 @@ func (tsr *TaskStatusRunner) Run(
 }
 
 // nextTask fetches the latest task from the taskQueue and
-// starts it. If the taskQueue is empty, it the second
-// return value will be true.
-func nextTask(taskQueue chan Task, taskContext *TaskContext) (Task, bool) {
+// starts it, returning the task and the time it started.
+// If the taskQueue is empty, the last return value will be true.
+func nextTask(taskQueue chan Task, taskContext *TaskContext) (Task, time.Time, bool) {
 	var tsk Task
 	select {
 	// If there is any tasks left in the queue, this
@@ -235,21 +261,23 @@ func nextTask(taskQueue chan Task, taskContext *TaskContext) (Task, bool) {
 		tsk = t
 	default:
 		// Only happens when the channel is empty.
-		return nil, true
+		return nil, time.Time{}, true
 	}
 
+	start := time.Now()
 	taskContext.SendEvent(event.Event{
 		Type: event.ActionGroupType,
 		ActionGroupEvent: event.ActionGroupEvent{
 			GroupName: tsk.Name(),
 			Action:    tsk.Action(),
 			Status:    event.Started,
+			Timing:    event.Timing{StartTime: start},
 		},
 	})
 
 	tsk.Start(taskContext)
 
-	return tsk, false
+	return tsk, start, false
 }
 
 // TaskResult is the type returned from tasks once they have completed
@@ -257,4 +285,7 @@ func nextTask(taskQueue chan Task, taskContext *TaskContext) (Task, bool) {
 // set.
 type TaskResult struct {
 	Err error
+	// Inventory is what the task did with the inventory object, if it
+	// updates or deletes it at the end of the run.
+	Inventory event.InventoryDisposition
 }
@@ -16,7 +16,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
-	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+	kstatustesting "sigs.k8s.io/cli-utils/pkg/kstatus/testing"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
@@ -325,7 +325,16 @@ func TestBaseRunner(t *testing.T) {
 					t.Errorf("expected event type %s, but got %s",
 						want, got)
 				}
+				assert.False(t, e.Timestamp.IsZero(), "event timestamp not set: %v", e)
+				if e.Type == event.ActionGroupType && e.ActionGroupEvent.Status == event.Finished {
+					assert.False(t, e.ActionGroupEvent.StartTime.IsZero())
+					assert.False(t, e.ActionGroupEvent.EndTime.Before(e.ActionGroupEvent.StartTime))
+					assert.Equal(t, e.ActionGroupEvent.EndTime.Sub(e.ActionGroupEvent.StartTime), e.ActionGroupEvent.Duration)
+				}
 				if e.Type == event.WaitType {
+					assert.False(t, e.WaitEvent.StartTime.IsZero())
+					// Ignore the timing, which varies by run.
+					e.WaitEvent.Timing = event.Timing{}
 					waitEvents = append(waitEvents, e.WaitEvent)
 				}
 			}
@@ -540,36 +549,9 @@ func (f *fakeApplyTask) Cancel(_ *TaskContext) {}
 
 func (f *fakeApplyTask) StatusUpdate(_ *TaskContext, _ object.ObjMetadata) {}
 
-type fakeWatcher struct {
-	start  chan struct{}
-	events []pollevent.Event
-}
-
-func newFakeWatcher(statusEvents []pollevent.Event) *fakeWatcher {
-	return &fakeWatcher{
-		events: statusEvents,
-		start:  make(chan struct{}),
+func newFakeWatcher(statusEvents []pollevent.Event) *kstatustesting.FakeStatusWatcher {
+	return &kstatustesting.FakeStatusWatcher{
+		Steps:        kstatustesting.Script(statusEvents...),
+		WaitForStart: true,
 	}
 }
-
-// Start events being sent on the status channel
-func (f *fakeWatcher) Start() {
-	close(f.start)
-}
-
-func (f *fakeWatcher) Watch(ctx context.Context, _ object.ObjMetadataSet, _ watcher.Options) <-chan pollevent.Event {
-	eventChannel := make(chan pollevent.Event)
-	go func() {
-		defer close(eventChannel)
-		// send sync event immediately
-		eventChannel <- pollevent.Event{Type: pollevent.SyncEvent}
-		// wait until started to send the events
-		<-f.start
-		for _, f := range f.events {
-			eventChannel <- f
-		}
-		// wait until cancelled to close the event channel and exit
-		<-ctx.Done()
-	}()
-	return eventChannel
-}
@@ -12,7 +12,6 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/klog/v2"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
@@ -20,6 +19,12 @@ import (
 
 var (
 	crdGK = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+
+	// crdMappingRetries and crdMappingInterval bound how long to wait for
+	// the RESTMapper to serve the kinds of established CRDs, since the
+	// discovery documents are updated after the CRDs are established.
+	crdMappingRetries  = 10
+	crdMappingInterval = 500 * time.Millisecond
 )
 
 // Task is the interface that must be implemented by
@@ -64,6 +69,12 @@ type WaitTask struct {
 	Timeout time.Duration
 	// Mapper is the RESTMapper to update after CRDs have been reconciled
 	Mapper meta.RESTMapper
+	// CompletionIds are the Jobs and Pods that must run to completion, like
+	// with the AllCompleted Condition, when the Condition is AllCurrent.
+	CompletionIds object.ObjMetadataSet
+	// Required makes the task fail if any of the resources failed, timed
+	// out, or was skipped, which stops the remaining tasks.
+	Required bool
 	// cancelFunc is a function that will cancel the timeout timer
 	// on the task.
 	cancelFunc context.CancelFunc
@@ -72,6 +83,10 @@ type WaitTask struct {
 	// failed is the set of resources that we are waiting for, but is considered
 	// failed, i.e. unlikely to successfully reconcile.
 	failed object.ObjMetadataSet
+	// startTime is the time the task started.
+	startTime time.Time
+	// cancelled is true if the task was cancelled by the task runner.
+	cancelled bool
 	// mu protects the pending ObjMetadataSet
 	mu sync.RWMutex
 }
@@ -91,8 +106,8 @@ func (w *WaitTask) Identifiers() object.ObjMetadataSet {
 // Start kicks off the task. For the wait task, this just means
 // setting up the timeout timer.
 func (w *WaitTask) Start(taskContext *TaskContext) {
-	klog.V(2).Infof("wait task starting (name: %q, objects: %d)",
-		w.Name(), len(w.Ids))
+	taskContext.Logger().V(2).Info("wait task starting", "task", w.Name(), "objects", len(w.Ids))
+	w.startTime = time.Now()
 
 	// TODO: inherit context from task runner, passed through the TaskContext
 	ctx := context.Background()
@@ -113,7 +128,7 @@ func (w *WaitTask) Start(taskContext *TaskContext) {
 		// Err is always non-nil when Done channel is closed.
 		err := ctx.Err()
 
-		klog.V(2).Infof("wait task completing (name: %q,): %v", w.TaskName, err)
+		taskContext.Logger().V(2).Info("wait task completing", "task", w.TaskName, "reason", err)
 
 		switch err {
 		case context.Canceled:
@@ -127,7 +142,7 @@ func (w *WaitTask) Start(taskContext *TaskContext) {
 		w.updateRESTMapper(taskContext)
 
 		// Done here. signal completion to the task runner
-		taskContext.TaskChannel() <- TaskResult{}
+		taskContext.TaskChannel() <- TaskResult{Err: w.requiredErr(taskContext)}
 	}()
 }
 
@@ -139,7 +154,7 @@ func (w *WaitTask) sendEvent(taskContext *TaskContext, id object.ObjMetadata, st
 			Identifier: id,
 			Status:     status,
 		},
-	})
+	}.WithTiming(w.startTime))
 }
 
 // startInner sends initial pending, skipped, an reconciled events.
@@ -149,7 +164,7 @@ func (w *WaitTask) startInner(taskContext *TaskContext) {
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	klog.V(3).Infof("wait task progress: %d/%d", 0, len(w.Ids))
+	taskContext.Logger().V(3).Info("wait task progress", "task", w.TaskName, "reconciled", 0, "objects", len(w.Ids))
 
 	pending := object.ObjMetadataSet{}
 	for _, id := range w.Ids {
@@ -158,7 +173,7 @@ func (w *WaitTask) startInner(taskContext *TaskContext) {
 			err := taskContext.InventoryManager().SetSkippedReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as skipped reconcile: %v", err)
+				taskContext.Logger().Error(err, "Failed to mark object as skipped reconcile", "object", id)
 			}
 			w.sendEvent(taskContext, id, event.ReconcileSkipped)
 		case w.changedUID(taskContext, id):
@@ -168,14 +183,14 @@ func (w *WaitTask) startInner(taskContext *TaskContext) {
 			err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as successful reconcile: %v", err)
+				taskContext.Logger().Error(err, "Failed to mark object as successful reconcile", "object", id)
 			}
 			w.sendEvent(taskContext, id, event.ReconcileSuccessful)
 		default:
 			err := taskContext.InventoryManager().SetPendingReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as pending reconcile: %v", err)
+				taskContext.Logger().Error(err, "Failed to mark object as pending reconcile", "object", id)
 			}
 			pending = append(pending, id)
 			w.sendEvent(taskContext, id, event.ReconcilePending)
@@ -183,11 +198,11 @@ func (w *WaitTask) startInner(taskContext *TaskContext) {
 	}
 	w.pending = pending
 
-	klog.V(3).Infof("wait task progress: %d/%d", len(w.Ids)-len(w.pending), len(w.Ids))
+	taskContext.Logger().V(3).Info("wait task progress", "task", w.TaskName, "reconciled", len(w.Ids)-len(w.pending), "objects", len(w.Ids))
 
 	if len(pending) == 0 {
 		// all reconciled - clear pending and exit
-		klog.V(3).Infof("all objects reconciled or skipped (name: %q)", w.TaskName)
+		taskContext.Logger().V(3).Info("all objects reconciled or skipped", "task", w.TaskName)
 		w.cancelFunc()
 	}
 }
@@ -202,7 +217,7 @@ func (w *WaitTask) sendTimeoutEvents(taskContext *TaskContext) {
 		err := taskContext.InventoryManager().SetTimeoutReconcile(id)
 		if err != nil {
 			// Object never applied or deleted!
-			klog.Errorf("Failed to mark object as pending reconcile: %v", err)
+			taskContext.Logger().Error(err, "Failed to mark object as pending reconcile", "object", id)
 		}
 		w.sendEvent(taskContext, id, event.ReconcileTimeout)
 	}
@@ -211,7 +226,15 @@ func (w *WaitTask) sendTimeoutEvents(taskContext *TaskContext) {
 // reconciledByID checks whether the condition set in the task is currently met
 // for the specified object given the status of resource in the cache.
 func (w *WaitTask) reconciledByID(taskContext *TaskContext, id object.ObjMetadata) bool {
-	return conditionMet(taskContext, object.ObjMetadataSet{id}, w.Condition)
+	return conditionMet(taskContext, object.ObjMetadataSet{id}, w.conditionOf(id))
+}
+
+// conditionOf returns the condition the object must meet.
+func (w *WaitTask) conditionOf(id object.ObjMetadata) Condition {
+	if w.Condition == AllCurrent && w.CompletionIds.Contains(id) {
+		return AllCompleted
+	}
+	return w.Condition
 }
 
 // skipped returns true if the object failed or was skipped by a preceding
@@ -226,15 +249,46 @@ func (w *WaitTask) skipped(taskContext *TaskContext, id object.ObjMetadata) bool
 		im.IsFailedDelete(id) || im.IsSkippedDelete(id) {
 		return true
 	}
+	if w.Condition == AllCompleted &&
+		(im.IsFailedApply(id) || im.IsSkippedApply(id)) {
+		return true
+	}
 	return false
 }
 
 // failedByID returns true if the resource is failed.
 func (w *WaitTask) failedByID(taskContext *TaskContext, id object.ObjMetadata) bool {
 	cached := taskContext.ResourceCache().Get(id)
+	if w.conditionOf(id) == AllCompleted && cached.Resource != nil {
+		if _, failed, ok := completion(cached.Resource); ok {
+			return failed
+		}
+	}
 	return cached.Status == status.FailedStatus
 }
 
+// requiredErr returns an error if the task is Required and any of the
+// resources did not reconcile successfully. Returns nil if the task was
+// cancelled, since the task runner reports why.
+func (w *WaitTask) requiredErr(taskContext *TaskContext) error {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if !w.Required || w.cancelled {
+		return nil
+	}
+	var unreconciled object.ObjMetadataSet
+	for _, id := range w.Ids {
+		if !taskContext.InventoryManager().IsSuccessfulReconcile(id) {
+			unreconciled = append(unreconciled, id)
+		}
+	}
+	if len(unreconciled) == 0 {
+		return nil
+	}
+	return fmt.Errorf("required objects did not reconcile: %s", unreconciled)
+}
+
 // changedUID returns true if the UID of the object has changed since it was
 // applied or deleted. This indicates that the object was deleted and recreated.
 func (w *WaitTask) changedUID(taskContext *TaskContext, id object.ObjMetadata) bool {
@@ -243,13 +297,13 @@ func (w *WaitTask) changedUID(taskContext *TaskContext, id object.ObjMetadata) b
 	// Get the uid from the ApplyTask/PruneTask
 	taskObj, found := taskContext.InventoryManager().ObjectStatus(id)
 	if !found {
-		klog.Errorf("Unknown object UID from InventoryManager: %v", id)
+		taskContext.Logger().Error(nil, "Unknown object UID from InventoryManager", "object", id)
 		return false
 	}
 	oldUID = taskObj.UID
 	if oldUID == "" {
 		// All objects should have been given a UID by the apiserver
-		klog.Errorf("Empty object UID from InventoryManager: %v", id)
+		taskContext.Logger().Error(nil, "Empty object UID from InventoryManager", "object", id)
 		return false
 	}
 
@@ -264,14 +318,14 @@ func (w *WaitTask) changedUID(taskContext *TaskContext, id object.ObjMetadata) b
 			// K8s DELETE API doesn't always return an object.
 		default:
 			// For all other statuses, nil Resource is probably a bug.
-			klog.Errorf("Unknown object UID from ResourceCache (status: %v): %v", pollerObj.Status, id)
+			taskContext.Logger().Error(nil, "Unknown object UID from ResourceCache", "object", id, "status", pollerObj.Status)
 		}
 		return false
 	}
 	newUID = pollerObj.Resource.GetUID()
 	if newUID == "" {
 		// All objects should have been given a UID by the apiserver
-		klog.Errorf("Empty object UID from ResourceCache (status: %v): %v", pollerObj.Status, id)
+		taskContext.Logger().Error(nil, "Empty object UID from ResourceCache", "object", id, "status", pollerObj.Status)
 		return false
 	}
 
@@ -284,21 +338,21 @@ func (w *WaitTask) handleChangedUID(taskContext *TaskContext, id object.ObjMetad
 	case AllNotFound:
 		// Object recreated by another actor after deletion.
 		// Treat as success.
-		klog.Infof("UID change detected: deleted object have been recreated: marking reconcile successful: %v", id)
+		taskContext.Logger().Info("UID change detected: deleted object have been recreated: marking reconcile successful", "object", id)
 		err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
 		if err != nil {
 			// Object never applied or deleted!
-			klog.Errorf("Failed to mark object as successful reconcile: %v", err)
+			taskContext.Logger().Error(err, "Failed to mark object as successful reconcile", "object", id)
 		}
 		w.sendEvent(taskContext, id, event.ReconcileSuccessful)
-	case AllCurrent:
+	case AllCurrent, AllCompleted:
 		// Object deleted and recreated by another actor after apply.
 		// Treat as failure (unverifiable).
-		klog.Infof("UID change detected: applied object has been deleted and recreated: marking reconcile failed: %v", id)
+		taskContext.Logger().Info("UID change detected: applied object has been deleted and recreated: marking reconcile failed", "object", id)
 		err := taskContext.InventoryManager().SetFailedReconcile(id)
 		if err != nil {
 			// Object never applied or deleted!
-			klog.Errorf("Failed to mark object as failed reconcile: %v", err)
+			taskContext.Logger().Error(err, "Failed to mark object as failed reconcile", "object", id)
 		}
 		w.sendEvent(taskContext, id, event.ReconcileFailed)
 	default:
@@ -308,6 +362,9 @@ func (w *WaitTask) handleChangedUID(taskContext *TaskContext, id object.ObjMetad
 
 // Cancel exits early with a timeout error
 func (w *WaitTask) Cancel(_ *TaskContext) {
+	w.mu.Lock()
+	w.cancelled = true
+	w.mu.Unlock()
 	w.cancelFunc()
 }
 
@@ -318,9 +375,9 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 	w.mu.Lock()
 	defer w.mu.Unlock()
 
-	if klog.V(5).Enabled() {
+	if logger := taskContext.Logger().V(5); logger.Enabled() {
 		status := taskContext.ResourceCache().Get(id).Status
-		klog.Infof("status update (object: %q, status: %q)", id, status)
+		logger.Info("status update", "object", id, "status", status)
 	}
 
 	switch {
@@ -335,7 +392,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as successful reconcile: %v", err)
+				taskContext.Logger().Error(err, "Failed to mark object as successful reconcile", "object", id)
 			}
 			w.pending = w.pending.Remove(id)
 			w.sendEvent(taskContext, id, event.ReconcileSuccessful)
@@ -344,7 +401,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			err := taskContext.InventoryManager().SetFailedReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as failed reconcile: %v", err)
+				taskContext.Logger().Error(err, "Failed to mark object as failed reconcile", "object", id)
 			}
 			w.pending = w.pending.Remove(id)
 			w.failed = append(w.failed, id)
@@ -366,7 +423,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			err := taskContext.InventoryManager().SetSuccessfulReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as successful reconcile: %v", err)
+				taskContext.Logger().Error(err, "Failed to mark object as successful reconcile", "object", id)
 			}
 			w.failed = w.failed.Remove(id)
 			w.sendEvent(taskContext, id, event.ReconcileSuccessful)
@@ -376,7 +433,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			err := taskContext.InventoryManager().SetPendingReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as pending reconcile: %v", err)
+				taskContext.Logger().Error(err, "Failed to mark object as pending reconcile", "object", id)
 			}
 			w.failed = w.failed.Remove(id)
 			w.pending = append(w.pending, id)
@@ -390,7 +447,7 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 			err := taskContext.InventoryManager().SetPendingReconcile(id)
 			if err != nil {
 				// Object never applied or deleted!
-				klog.Errorf("Failed to mark object as pending reconcile: %v", err)
+				taskContext.Logger().Error(err, "Failed to mark object as pending reconcile", "object", id)
 			}
 			w.pending = append(w.pending, id)
 			w.sendEvent(taskContext, id, event.ReconcilePending)
@@ -398,27 +455,35 @@ func (w *WaitTask) StatusUpdate(taskContext *TaskContext, id object.ObjMetadata)
 		// else - still reconciled
 	}
 
-	klog.V(3).Infof("wait task progress: %d/%d", len(w.Ids)-len(w.pending), len(w.Ids))
+	taskContext.Logger().V(3).Info("wait task progress", "task", w.TaskName, "reconciled", len(w.Ids)-len(w.pending), "objects", len(w.Ids))
 
 	// If we no longer have any pending resources, the WaitTask
 	// can be completed.
 	if len(w.pending) == 0 {
 		// all reconciled, so exit
-		klog.V(3).Infof("all objects reconciled or skipped (name: %q)", w.TaskName)
+		taskContext.Logger().V(3).Info("all objects reconciled or skipped", "task", w.TaskName)
 		w.cancelFunc()
 	}
 }
 
 // updateRESTMapper resets the RESTMapper if CRDs were applied, so that new
-// resource types can be applied by subsequent tasks.
+// resource types can be applied by subsequent tasks. If the CRDs are
+// established, it waits for the RESTMapper to serve their kinds, resetting it
+// again until they are served, the retries run out, or the run is cancelled.
 // TODO: find a way to add/remove mappers without resetting the entire mapper
 // Resetting the mapper requires all CRDs to be queried again.
 func (w *WaitTask) updateRESTMapper(taskContext *TaskContext) {
 	foundCRD := false
+	var kinds []schema.GroupKind
 	for _, id := range w.Ids {
 		if id.GroupKind == crdGK && !w.skipped(taskContext, id) {
 			foundCRD = true
-			break
+			if !taskContext.InventoryManager().IsSuccessfulReconcile(id) {
+				continue
+			}
+			if gk, found := object.GetCRDGroupKind(taskContext.ResourceCache().Get(id).Resource); found {
+				kinds = append(kinds, gk)
+			}
 		}
 	}
 	if !foundCRD {
@@ -426,6 +491,36 @@ func (w *WaitTask) updateRESTMapper(taskContext *TaskContext) {
 		return
 	}
 
-	klog.V(3).Infof("Resetting RESTMapper")
+	logger := taskContext.Logger()
+	logger.V(3).Info("Resetting RESTMapper")
 	meta.MaybeResetRESTMapper(w.Mapper)
+
+	for i := 0; i < crdMappingRetries; i++ {
+		missing := w.unmappedKinds(kinds)
+		if len(missing) == 0 {
+			return
+		}
+		logger.V(3).Info("Waiting for the RESTMapper to serve the kinds of established CRDs", "kinds", missing)
+		select {
+		case <-taskContext.Context().Done():
+			// The run is cancelled, so the kinds will not be applied.
+			return
+		case <-time.After(crdMappingInterval):
+		}
+		meta.MaybeResetRESTMapper(w.Mapper)
+	}
+	if missing := w.unmappedKinds(kinds); len(missing) > 0 {
+		logger.Info("RESTMapper does not serve the kinds of established CRDs", "kinds", missing)
+	}
+}
+
+// unmappedKinds returns the kinds that the RESTMapper does not serve.
+func (w *WaitTask) unmappedKinds(kinds []schema.GroupKind) []schema.GroupKind {
+	var missing []schema.GroupKind
+	for _, gk := range kinds {
+		if _, err := w.Mapper.RESTMapping(gk); err != nil {
+			missing = append(missing, gk)
+		}
+	}
+	return missing
 }
@@ -4,10 +4,15 @@
 package taskrunner
 
 import (
+	"context"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/apply/cache"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
@@ -1403,3 +1408,222 @@ func TestWaitTask_UIDChanged(t *testing.T) {
 		})
 	}
 }
+
+var testJobYAML = `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: migrate
+  namespace: default
+  uid: job-uid
+  generation: 1
+`
+
+func TestWaitTask_RequiredCompletion(t *testing.T) {
+	testJobID := testutil.ToIdentifier(t, testJobYAML)
+
+	withJobCondition := func(conditionType string) *unstructured.Unstructured {
+		job := testutil.Unstructured(t, testJobYAML)
+		err := unstructured.SetNestedSlice(job.Object, []interface{}{
+			map[string]interface{}{
+				"type":   conditionType,
+				"status": "True",
+			},
+		}, "status", "conditions")
+		require.NoError(t, err)
+		return job
+	}
+
+	testCases := map[string]struct {
+		job    *unstructured.Unstructured
+		status status.Status
+		cancel bool
+		// completionIds waits with AllCurrent and CompletionIds, instead
+		// of AllCompleted.
+		completionIds  bool
+		expectedStatus event.WaitEventStatus
+		expectedErr    bool
+	}{
+		"completed job": {
+			job:            withJobCondition("Complete"),
+			status:         status.CurrentStatus,
+			expectedStatus: event.ReconcileSuccessful,
+		},
+		"failed job": {
+			job:            withJobCondition("Failed"),
+			status:         status.FailedStatus,
+			expectedStatus: event.ReconcileFailed,
+			expectedErr:    true,
+		},
+		"running job is not completed": {
+			job:            testutil.Unstructured(t, testJobYAML),
+			status:         status.CurrentStatus,
+			expectedStatus: event.ReconcileTimeout,
+			expectedErr:    true,
+		},
+		"completion ids, completed job": {
+			job:            withJobCondition("Complete"),
+			status:         status.CurrentStatus,
+			completionIds:  true,
+			expectedStatus: event.ReconcileSuccessful,
+		},
+		"completion ids, running job is not completed": {
+			job:            testutil.Unstructured(t, testJobYAML),
+			status:         status.CurrentStatus,
+			completionIds:  true,
+			expectedStatus: event.ReconcileTimeout,
+			expectedErr:    true,
+		},
+		"cancelled": {
+			job:            testutil.Unstructured(t, testJobYAML),
+			status:         status.CurrentStatus,
+			cancel:         true,
+			expectedStatus: event.ReconcilePending,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			task := NewWaitTask("wait-0", object.ObjMetadataSet{testJobID}, AllCompleted,
+				time.Second, testutil.NewFakeRESTMapper())
+			if tc.completionIds {
+				task.Condition = AllCurrent
+				task.CompletionIds = object.ObjMetadataSet{testJobID}
+			}
+			task.Required = true
+
+			eventChannel := make(chan event.Event, 10)
+			resourceCache := cache.NewResourceCacheMap()
+			taskContext := NewTaskContext(eventChannel, resourceCache)
+			taskContext.InventoryManager().AddSuccessfulApply(testJobID, "job-uid", 1)
+
+			task.Start(taskContext)
+			resourceCache.Put(testJobID, cache.ResourceStatus{
+				Resource: tc.job,
+				Status:   tc.status,
+			})
+			task.StatusUpdate(taskContext, testJobID)
+			if tc.cancel {
+				task.Cancel(taskContext)
+			}
+
+			var result TaskResult
+			select {
+			case result = <-taskContext.TaskChannel():
+			case <-time.After(5 * time.Second):
+				t.Fatal("timed out waiting for the task result")
+			}
+			close(eventChannel)
+			var lastStatus event.WaitEventStatus
+			for e := range eventChannel {
+				lastStatus = e.WaitEvent.Status
+			}
+			assert.Equal(t, tc.expectedStatus, lastStatus)
+			if tc.expectedErr {
+				assert.Error(t, result.Err)
+			} else {
+				assert.NoError(t, result.Err)
+			}
+		})
+	}
+}
+
+var testCRDYAML = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: crontabs.stable.example.com
+  uid: crd-uid
+spec:
+  group: stable.example.com
+  names:
+    kind: CronTab
+    plural: crontabs
+  scope: Namespaced
+`
+
+// lateRESTMapper serves all kinds after it has been reset servedAfter times,
+// like a RESTMapper whose discovery documents are updated after CRDs are
+// established.
+type lateRESTMapper struct {
+	meta.RESTMapper
+	resets      int
+	servedAfter int
+}
+
+func (m *lateRESTMapper) Reset() {
+	m.resets++
+}
+
+func (m *lateRESTMapper) RESTMapping(gk schema.GroupKind, _ ...string) (*meta.RESTMapping, error) {
+	if m.resets < m.servedAfter {
+		return nil, &meta.NoKindMatchError{GroupKind: gk}
+	}
+	return &meta.RESTMapping{Scope: meta.RESTScopeNamespace}, nil
+}
+
+func TestWaitTask_UpdateRESTMapper(t *testing.T) {
+	defer func(interval time.Duration) { crdMappingInterval = interval }(crdMappingInterval)
+	crdMappingInterval = time.Millisecond
+
+	testCRDID := testutil.ToIdentifier(t, testCRDYAML)
+
+	testCases := map[string]struct {
+		reconciled     bool
+		cancelled      bool
+		servedAfter    int
+		expectedResets int
+	}{
+		"served after the first reset": {
+			reconciled:     true,
+			servedAfter:    1,
+			expectedResets: 1,
+		},
+		"served after more resets": {
+			reconciled:     true,
+			servedAfter:    3,
+			expectedResets: 3,
+		},
+		"never served": {
+			reconciled:     true,
+			servedAfter:    100,
+			expectedResets: 1 + crdMappingRetries,
+		},
+		"not reconciled": {
+			servedAfter:    100,
+			expectedResets: 1,
+		},
+		"cancelled run": {
+			reconciled:     true,
+			cancelled:      true,
+			servedAfter:    100,
+			expectedResets: 1,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mapper := &lateRESTMapper{servedAfter: tc.servedAfter}
+			task := NewWaitTask("wait-0", object.ObjMetadataSet{testCRDID}, AllCurrent, 0, mapper)
+
+			resourceCache := cache.NewResourceCacheMap()
+			taskContext := NewTaskContext(make(chan event.Event), resourceCache)
+			taskContext.InventoryManager().AddSuccessfulApply(testCRDID, "crd-uid", 0)
+			resourceCache.Put(testCRDID, cache.ResourceStatus{
+				Resource: testutil.Unstructured(t, testCRDYAML),
+				Status:   status.CurrentStatus,
+			})
+			if tc.reconciled {
+				require.NoError(t, taskContext.InventoryManager().SetSuccessfulReconcile(testCRDID))
+			}
+			if tc.cancelled {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				taskContext.SetContext(ctx)
+			}
+
+			task.updateRESTMapper(taskContext)
+			assert.Equal(t, tc.expectedResets, mapper.resets)
+		})
+	}
+}
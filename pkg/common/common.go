@@ -41,6 +41,33 @@ const (
 	// PreventDeletion is the value used with LifecycleDeletionAnnotation
 	// to prevent deleting a resource.
 	PreventDeletion = "detach"
+
+	// WaitForCompletionAnnotation defines whether the applier waits for a
+	// Job or Pod to run to completion, instead of only being Current. The
+	// value is "true" or "false", and overrides
+	// ApplierOptions.WaitForJobCompletion.
+	WaitForCompletionAnnotation = "cli-utils.sigs.k8s.io/wait-for-completion"
+
+	// GenerateNameAnnotation tracks the objects that have a generateName
+	// instead of a name, so they are matched with the objects created by
+	// earlier runs instead of being created again. The value defaults to
+	// the generateName, and must be set to tell apart the objects with the
+	// same kind, namespace, and generateName.
+	GenerateNameAnnotation = "cli-utils.sigs.k8s.io/generate-name"
+
+	// InjectedMetadataAnnotation records the keys of the common labels and
+	// annotations injected into an object by the applier, so they are not
+	// reported as changes of the object on every apply.
+	InjectedMetadataAnnotation = "cli-utils.sigs.k8s.io/injected-metadata"
+
+	// ChildInventoriesAnnotation lists the comma-separated IDs of the child
+	// inventories of a parent inventory object.
+	ChildInventoriesAnnotation = "cli-utils.sigs.k8s.io/child-inventories"
+
+	// ParentInventoryLabel is the label of a child inventory object with the
+	// ID of its parent inventory. Children labeled with the ID of a parent
+	// that doesn't list them are orphaned, and can be pruned.
+	ParentInventoryLabel = "cli-utils.sigs.k8s.io/parent-inventory-id"
 )
 
 // RandomStr returns an eight-digit (with leading zeros) string of a
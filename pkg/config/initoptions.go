@@ -5,13 +5,17 @@ package config
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/klog/v2"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
@@ -24,6 +28,10 @@ import (
 
 const (
 	manifestFilename = "inventory-template.yaml"
+
+	// StdoutOutput is the Output that writes the inventory object
+	// template to the output stream, instead of a file.
+	StdoutOutput = "-"
 )
 
 // InitOptions contains the fields necessary to generate a
@@ -40,6 +48,13 @@ type InitOptions struct {
 	Namespace string
 	// Inventory object label value; must be a valid k8s label value.
 	InventoryID string
+	// Labels are added to the labels of the inventory object, next to
+	// the inventory-id label.
+	Labels map[string]string
+	// Output is the path of the file the inventory object template is
+	// written to, or StdoutOutput. By default, the template is written to
+	// the inventory-template.yaml file in the package directory.
+	Output string
 }
 
 func NewInitOptions(f cmdutil.Factory, ioStreams genericclioptions.IOStreams) *InitOptions {
@@ -81,11 +96,23 @@ func (i *InitOptions) Complete(args []string) error {
 	if !validateInventoryID(i.InventoryID) {
 		return fmt.Errorf("invalid group name: %s", i.InventoryID)
 	}
+	if err := validateLabels(i.Labels); err != nil {
+		return err
+	}
 	// Output the calculated namespace used for inventory object.
-	fmt.Fprintf(i.ioStreams.Out, "namespace: %s is used for inventory object\n", i.Namespace)
+	fmt.Fprintf(i.messageOut(), "namespace: %s is used for inventory object\n", i.Namespace)
 	return nil
 }
 
+// messageOut returns the stream for the messages of the command, which is
+// the error stream if the template is written to the output stream.
+func (i *InitOptions) messageOut() io.Writer {
+	if i.Output == StdoutOutput {
+		return i.ioStreams.ErrOut
+	}
+	return i.ioStreams.Out
+}
+
 type namespaceLoader interface {
 	Namespace() (string, bool, error)
 }
@@ -204,6 +231,23 @@ func validateInventoryID(inventoryID string) bool {
 	return re.MatchString(inventoryID)
 }
 
+// validateLabels returns an error if the extra labels of the inventory
+// object are not valid, or override the inventory-id label.
+func validateLabels(labels map[string]string) error {
+	for key, value := range labels {
+		if key == common.InventoryLabel {
+			return fmt.Errorf("label %q is set by the inventory-id", key)
+		}
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid label key %q: %s", key, strings.Join(errs, "; "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid label value %q: %s", value, strings.Join(errs, "; "))
+		}
+	}
+	return nil
+}
+
 // fileExists returns true if a file at path already exists;
 // false otherwise.
 func fileExists(path string) bool {
@@ -227,11 +271,49 @@ func (i *InitOptions) fillInValues() string {
 	manifestStr = strings.ReplaceAll(manifestStr, "<NAMESPACE>", i.Namespace)
 	manifestStr = strings.ReplaceAll(manifestStr, "<RANDOMSUFFIX>", randomSuffix)
 	manifestStr = strings.ReplaceAll(manifestStr, "<INVENTORYID>", i.InventoryID)
-	return manifestStr
+	return addLabels(manifestStr, i.Labels)
+}
+
+// addLabels adds the labels, sorted by key, after the inventory-id label of
+// the template, with the same indentation. The template is unchanged if it
+// doesn't have the inventory-id label.
+func addLabels(manifestStr string, labels map[string]string) string {
+	if len(labels) == 0 {
+		return manifestStr
+	}
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	lines := strings.Split(manifestStr, "\n")
+	result := make([]string, 0, len(lines)+len(keys))
+	for _, line := range lines {
+		result = append(result, line)
+		trimmed := strings.TrimLeft(line, " ")
+		if !strings.HasPrefix(trimmed, common.InventoryLabel+":") {
+			continue
+		}
+		indent := line[:len(line)-len(trimmed)]
+		for _, key := range keys {
+			result = append(result, fmt.Sprintf("%s%s: %s", indent, key, strconv.Quote(labels[key])))
+		}
+	}
+	return strings.Join(result, "\n")
 }
 
 func (i *InitOptions) Run() error {
-	manifestFilePath := filepath.Join(i.Dir, manifestFilename)
+	if i.Output == StdoutOutput {
+		if _, err := fmt.Fprint(i.ioStreams.Out, i.fillInValues()); err != nil {
+			return fmt.Errorf("unable to write inventory object template: %w", err)
+		}
+		return nil
+	}
+	manifestFilePath := i.Output
+	if manifestFilePath == "" {
+		manifestFilePath = filepath.Join(i.Dir, manifestFilename)
+	}
 	if fileExists(manifestFilePath) {
 		return fmt.Errorf("inventory object template file already exists: %s", manifestFilePath)
 	}
@@ -371,3 +371,114 @@ func TestFillInValues(t *testing.T) {
 		})
 	}
 }
+
+func TestFillInValuesLabels(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("foo")
+	defer tf.Cleanup()
+	ioStreams, _, _, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+	io := NewInitOptions(tf, ioStreams)
+	io.Namespace = "foo"
+	io.InventoryID = "bar"
+	io.Labels = map[string]string{
+		"team":                   "shop",
+		"example.com/managed-by": "platform",
+	}
+	actual := io.fillInValues()
+	expectedLabels := `    cli-utils.sigs.k8s.io/inventory-id: bar
+    example.com/managed-by: "platform"
+    team: "shop"
+`
+	assert.Contains(t, actual, expectedLabels)
+}
+
+func TestValidateLabels(t *testing.T) {
+	tests := map[string]struct {
+		labels             map[string]string
+		expectedErrMessage string
+	}{
+		"No labels": {},
+		"Valid labels": {
+			labels: map[string]string{"team": "shop", "example.com/env": ""},
+		},
+		"Inventory-id label": {
+			labels:             map[string]string{"cli-utils.sigs.k8s.io/inventory-id": "bar"},
+			expectedErrMessage: `label "cli-utils.sigs.k8s.io/inventory-id" is set by the inventory-id`,
+		},
+		"Invalid key": {
+			labels:             map[string]string{"team shop": "shop"},
+			expectedErrMessage: `invalid label key "team shop"`,
+		},
+		"Invalid value": {
+			labels:             map[string]string{"team": "shop/web"},
+			expectedErrMessage: `invalid label value "shop/web"`,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := validateLabels(tc.labels)
+			if tc.expectedErrMessage == "" {
+				assert.NoError(t, err)
+				return
+			}
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tc.expectedErrMessage)
+			}
+		})
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := map[string]struct {
+		output       string
+		expectedFile string
+	}{
+		"Default output": {
+			expectedFile: manifestFilename,
+		},
+		"File output": {
+			output:       "inventory.yaml",
+			expectedFile: "inventory.yaml",
+		},
+		"Stdout output": {
+			output: StdoutOutput,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			tf := cmdtesting.NewTestFactory().WithNamespace("foo")
+			defer tf.Cleanup()
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			io := NewInitOptions(tf, ioStreams)
+			io.Dir = dir
+			io.Namespace = "foo"
+			io.InventoryID = "bar"
+			io.Output = tc.output
+			if tc.output != "" && tc.output != StdoutOutput {
+				io.Output = filepath.Join(dir, tc.output)
+			}
+			if !assert.NoError(t, io.Run()) {
+				return
+			}
+
+			if tc.expectedFile == "" {
+				assert.Contains(t, out.String(), "cli-utils.sigs.k8s.io/inventory-id: bar")
+				entries, err := os.ReadDir(dir)
+				assert.NoError(t, err)
+				assert.Empty(t, entries)
+				return
+			}
+			data, err := os.ReadFile(filepath.Join(dir, tc.expectedFile))
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Contains(t, string(data), "cli-utils.sigs.k8s.io/inventory-id: bar")
+			assert.Contains(t, out.String(), "Initialized: ")
+			// The template file isn't overwritten.
+			assert.EqualError(t, io.Run(), "inventory object template file already exists: "+
+				filepath.Join(dir, tc.expectedFile))
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package features defines the feature gates of experimental behaviors, so
+// they can ship disabled and be enabled by each consumer.
+//
+// The features of the process are read from the CLI_UTILS_FEATURE_GATES
+// environment variable, with the same syntax as the --feature-gates flag of
+// Kubernetes components:
+//
+//	CLI_UTILS_FEATURE_GATES=WatchStatus=true,ServerSideApplyFastPath=true
+//
+// Consumers can also set the features with DefaultMutableFeatureGate, or
+// pass their own feature gate, created with NewFeatureGate, in the options
+// of the Applier.
+package features
+
+import (
+	"os"
+
+	"k8s.io/component-base/featuregate"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// WatchStatus makes the status command watch the objects for status
+	// changes by default, instead of polling them.
+	WatchStatus featuregate.Feature = "WatchStatus"
+
+	// ServerSideApplyFastPath skips getting the objects already in the
+	// inventory before they are applied with server-side apply, like
+	// ApplierOptions.SkipInventoryPolicyGet.
+	ServerSideApplyFastPath featuregate.Feature = "ServerSideApplyFastPath"
+)
+
+// EnvVar is the environment variable the features of the process are read
+// from.
+const EnvVar = "CLI_UTILS_FEATURE_GATES"
+
+var defaultFeatures = map[featuregate.Feature]featuregate.FeatureSpec{
+	WatchStatus:             {Default: false, PreRelease: featuregate.Alpha},
+	ServerSideApplyFastPath: {Default: false, PreRelease: featuregate.Alpha},
+}
+
+// DefaultMutableFeatureGate is the feature gate of the process. Its
+// features are set from the EnvVar environment variable, when the package
+// is initialized.
+var DefaultMutableFeatureGate = NewFeatureGate()
+
+// DefaultFeatureGate is the read-only view of DefaultMutableFeatureGate,
+// used when no feature gate is provided.
+var DefaultFeatureGate featuregate.FeatureGate = DefaultMutableFeatureGate
+
+func init() {
+	if err := SetFromEnv(DefaultMutableFeatureGate); err != nil {
+		klog.ErrorS(err, "Ignoring invalid feature gates", "env", EnvVar)
+	}
+}
+
+// NewFeatureGate returns a new feature gate with the known features, at
+// their default values.
+func NewFeatureGate() featuregate.MutableFeatureGate {
+	fg := featuregate.NewFeatureGate()
+	if err := fg.Add(defaultFeatures); err != nil {
+		// The known features are static, so this never happens.
+		panic(err)
+	}
+	return fg
+}
+
+// SetFromEnv sets the features of the feature gate from the EnvVar
+// environment variable, if it is set.
+func SetFromEnv(fg featuregate.MutableFeatureGate) error {
+	value, found := os.LookupEnv(EnvVar)
+	if !found {
+		return nil
+	}
+	return fg.Set(value)
+}
@@ -0,0 +1,68 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package features
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/component-base/featuregate"
+)
+
+func TestSetFromEnv(t *testing.T) {
+	testCases := map[string]struct {
+		env         string
+		unset       bool
+		expected    map[featuregate.Feature]bool
+		expectedErr bool
+	}{
+		"unset": {
+			unset: true,
+			expected: map[featuregate.Feature]bool{
+				WatchStatus:             false,
+				ServerSideApplyFastPath: false,
+			},
+		},
+		"one feature": {
+			env: "WatchStatus=true",
+			expected: map[featuregate.Feature]bool{
+				WatchStatus:             true,
+				ServerSideApplyFastPath: false,
+			},
+		},
+		"all alpha features": {
+			env: "AllAlpha=true",
+			expected: map[featuregate.Feature]bool{
+				WatchStatus:             true,
+				ServerSideApplyFastPath: true,
+			},
+		},
+		"unknown feature": {
+			env:         "Unknown=true",
+			expectedErr: true,
+		},
+		"invalid value": {
+			env:         "WatchStatus=maybe",
+			expectedErr: true,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			if !tc.unset {
+				t.Setenv(EnvVar, tc.env)
+			}
+			fg := NewFeatureGate()
+			err := SetFromEnv(fg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			for feature, enabled := range tc.expected {
+				assert.Equal(t, enabled, fg.Enabled(feature), feature)
+			}
+		})
+	}
+}
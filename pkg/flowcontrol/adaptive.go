@@ -0,0 +1,208 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package flowcontrol
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	flowcontrolapi "k8s.io/api/flowcontrol/v1beta2"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultThrottledQPS is the QPS used after the first throttled request,
+	// if the AdaptiveRateLimiter has no maximum QPS.
+	DefaultThrottledQPS = 50
+
+	// DefaultMinQPS is the lowest QPS the AdaptiveRateLimiter throttles to.
+	DefaultMinQPS = 1
+
+	// maxRetryAfter caps the pause requested by the server with the
+	// Retry-After header.
+	maxRetryAfter = time.Minute
+)
+
+// AdaptiveRateLimiter is a client-side rate limiter that adapts its QPS to
+// the feedback of the server.
+//
+// When the server rejects a request with 429 Too Many Requests, for example
+// because the API Priority and Fairness (APF) queues of the flow schema are
+// full, the QPS is halved and all requests are paused for the duration of
+// the Retry-After header. As requests succeed again, the QPS is increased
+// by about one request per second, every second, up to the maximum QPS.
+//
+// This keeps large runs from tripping a storm of server-side rejections,
+// while still using the capacity the server has available.
+type AdaptiveRateLimiter struct {
+	mu          sync.Mutex
+	limiter     *rate.Limiter
+	maxQPS      float64
+	minQPS      float64
+	pausedUntil time.Time
+	// now is replaced in tests.
+	now func() time.Time
+}
+
+// NewAdaptiveRateLimiter returns a new AdaptiveRateLimiter with the
+// specified maximum QPS and burst. If maxQPS is not positive, the requests
+// are not throttled until the server rejects a request.
+func NewAdaptiveRateLimiter(maxQPS float32, burst int) *AdaptiveRateLimiter {
+	limit := rate.Inf
+	if maxQPS > 0 {
+		limit = rate.Limit(maxQPS)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &AdaptiveRateLimiter{
+		limiter: rate.NewLimiter(limit, burst),
+		maxQPS:  float64(maxQPS),
+		minQPS:  DefaultMinQPS,
+		now:     time.Now,
+	}
+}
+
+// Configure makes the clients built from the REST config use the rate
+// limiter, and send the responses of the server to the rate limiter.
+// The QPS and Burst of the config are ignored.
+func (l *AdaptiveRateLimiter) Configure(config *rest.Config) {
+	config.RateLimiter = l
+	config.WrapTransport = transport.Wrappers(config.WrapTransport, l.WrapTransport)
+}
+
+// WrapTransport returns a RoundTripper that sends the responses of the
+// server to the rate limiter.
+func (l *AdaptiveRateLimiter) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		resp, err := rt.RoundTrip(req)
+		if err == nil {
+			l.Observe(resp)
+		}
+		return resp, err
+	})
+}
+
+// Observe adapts the QPS to the response of the server.
+func (l *AdaptiveRateLimiter) Observe(resp *http.Response) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if resp.StatusCode != http.StatusTooManyRequests {
+		if resp.StatusCode < http.StatusBadRequest {
+			l.increase()
+		}
+		return
+	}
+
+	var limit float64
+	if l.limiter.Limit() == rate.Inf {
+		// Not throttled yet.
+		limit = DefaultThrottledQPS
+	} else {
+		limit = math.Max(float64(l.limiter.Limit())/2, l.minQPS)
+	}
+	l.limiter.SetLimit(rate.Limit(limit))
+
+	if retryAfter, ok := retryAfter(resp); ok {
+		if until := l.now().Add(retryAfter); until.After(l.pausedUntil) {
+			l.pausedUntil = until
+		}
+	}
+	klog.V(3).Infof("Client-side throttling: server rejected request (flow schema: %q, priority level: %q): reduced QPS to %.1f",
+		resp.Header.Get(flowcontrolapi.ResponseHeaderMatchedFlowSchemaUID),
+		resp.Header.Get(flowcontrolapi.ResponseHeaderMatchedPriorityLevelConfigurationUID),
+		limit)
+}
+
+// increase increases the QPS after a successful request, by 1/QPS, so the
+// QPS grows by about one each second.
+func (l *AdaptiveRateLimiter) increase() {
+	if l.limiter.Limit() == rate.Inf {
+		return
+	}
+	limit := float64(l.limiter.Limit())
+	limit += 1 / limit
+	if l.maxQPS > 0 && limit > l.maxQPS {
+		limit = l.maxQPS
+	}
+	l.limiter.SetLimit(rate.Limit(limit))
+}
+
+// TryAccept returns true if a token is taken immediately. Otherwise,
+// it returns false.
+func (l *AdaptiveRateLimiter) TryAccept() bool {
+	if l.pause() > 0 {
+		return false
+	}
+	return l.limiter.Allow()
+}
+
+// Accept returns once a token becomes available.
+func (l *AdaptiveRateLimiter) Accept() {
+	_ = l.Wait(context.Background())
+}
+
+// Wait returns nil if a token is taken before the Context is done.
+func (l *AdaptiveRateLimiter) Wait(ctx context.Context) error {
+	if pause := l.pause(); pause > 0 {
+		timer := time.NewTimer(pause)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return l.limiter.Wait(ctx)
+}
+
+// Stop stops the rate limiter. It's a no-op.
+func (l *AdaptiveRateLimiter) Stop() {}
+
+// QPS returns the current QPS of the rate limiter.
+func (l *AdaptiveRateLimiter) QPS() float32 {
+	limit := l.limiter.Limit()
+	if limit == rate.Inf {
+		return -1
+	}
+	return float32(limit)
+}
+
+// pause returns how long the requests are still paused for.
+func (l *AdaptiveRateLimiter) pause() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.pausedUntil.Sub(l.now())
+}
+
+// retryAfter returns the duration of the Retry-After header, in seconds.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(value)
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+	d := time.Duration(seconds) * time.Second
+	if d > maxRetryAfter {
+		d = maxRetryAfter
+	}
+	return d, true
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package flowcontrol
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+)
+
+func throttledResponse(retryAfter string) *http.Response {
+	headers := http.Header{}
+	if retryAfter != "" {
+		headers.Set("Retry-After", retryAfter)
+	}
+	return &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Header:     headers,
+	}
+}
+
+func okResponse() *http.Response {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+	}
+}
+
+func TestAdaptiveRateLimiter(t *testing.T) {
+	testCases := map[string]struct {
+		maxQPS      float32
+		responses   []*http.Response
+		expectedQPS float32
+	}{
+		"unlimited until throttled": {
+			maxQPS:      -1,
+			responses:   []*http.Response{okResponse(), okResponse()},
+			expectedQPS: -1,
+		},
+		"unlimited throttled": {
+			maxQPS:      -1,
+			responses:   []*http.Response{throttledResponse("")},
+			expectedQPS: DefaultThrottledQPS,
+		},
+		"throttled halves the QPS": {
+			maxQPS:      20,
+			responses:   []*http.Response{throttledResponse(""), throttledResponse("")},
+			expectedQPS: 5,
+		},
+		"throttled to the min QPS": {
+			maxQPS: 2,
+			responses: []*http.Response{
+				throttledResponse(""), throttledResponse(""), throttledResponse(""),
+			},
+			expectedQPS: DefaultMinQPS,
+		},
+		"successful requests increase the QPS": {
+			maxQPS: 20,
+			responses: []*http.Response{
+				throttledResponse(""), throttledResponse(""),
+				okResponse(), okResponse(), okResponse(), okResponse(), okResponse(),
+			},
+			// 5 + 1/5 + 1/5.2 + ...
+			expectedQPS: 5.93,
+		},
+		"successful requests increase the QPS up to the max QPS": {
+			maxQPS: 2,
+			responses: []*http.Response{
+				throttledResponse(""), okResponse(), okResponse(), okResponse(),
+			},
+			expectedQPS: 2,
+		},
+		"failed requests don't increase the QPS": {
+			maxQPS: 20,
+			responses: []*http.Response{
+				throttledResponse(""),
+				{StatusCode: http.StatusInternalServerError, Header: http.Header{}},
+			},
+			expectedQPS: 10,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			limiter := NewAdaptiveRateLimiter(tc.maxQPS, 10)
+			for _, resp := range tc.responses {
+				limiter.Observe(resp)
+			}
+			assert.InDelta(t, tc.expectedQPS, limiter.QPS(), 0.01)
+		})
+	}
+}
+
+func TestAdaptiveRateLimiterRetryAfter(t *testing.T) {
+	now := time.Now()
+	limiter := NewAdaptiveRateLimiter(-1, 10)
+	limiter.now = func() time.Time { return now }
+
+	assert.True(t, limiter.TryAccept())
+
+	limiter.Observe(throttledResponse("3"))
+	assert.False(t, limiter.TryAccept())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, limiter.Wait(ctx))
+
+	// A shorter Retry-After doesn't shorten the pause.
+	limiter.Observe(throttledResponse("1"))
+	now = now.Add(2 * time.Second)
+	assert.False(t, limiter.TryAccept())
+
+	now = now.Add(time.Second)
+	assert.True(t, limiter.TryAccept())
+}
+
+func TestAdaptiveRateLimiterConfigure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	limiter := NewAdaptiveRateLimiter(-1, 10)
+	config := &rest.Config{Host: server.URL}
+	limiter.Configure(config)
+	assert.Equal(t, limiter, config.RateLimiter)
+
+	rt, err := rest.TransportFor(config)
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := rt.RoundTrip(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.InDelta(t, DefaultThrottledQPS, limiter.QPS(), 0.01)
+}
@@ -0,0 +1,89 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"sync"
+
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+type cacheKey struct{}
+
+// WithCache returns a context in which the ClusterClient caches the
+// inventory objects it reads, so the tasks of a run don't each get the same
+// inventory object from the cluster. The cached inventory object is dropped
+// when the ClusterClient writes or deletes it. Use a new context for each
+// run, so the changes made by other clients between runs are seen.
+func WithCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, cacheKey{}, &invCache{objs: map[cacheEntry]object.UnstructuredSet{}})
+}
+
+// CacheStats counts the reads of inventory objects served from the cache
+// of WithCache, and the ones that got the inventory object from the cluster.
+type CacheStats struct {
+	Hits   int64
+	Misses int64
+}
+
+// invCache holds the inventory objects read in a run.
+type invCache struct {
+	mu   sync.Mutex
+	objs map[cacheEntry]object.UnstructuredSet
+}
+
+// cacheEntry identifies an inventory in the cache.
+type cacheEntry struct {
+	strategy  Strategy
+	namespace string
+	name      string
+	id        string
+}
+
+func cacheFromContext(ctx context.Context) *invCache {
+	c, _ := ctx.Value(cacheKey{}).(*invCache)
+	return c
+}
+
+func cacheEntryFor(inv Info) cacheEntry {
+	return cacheEntry{
+		strategy:  inv.Strategy(),
+		namespace: inv.Namespace(),
+		name:      inv.Name(),
+		id:        inv.ID(),
+	}
+}
+
+// get returns copies of the cached inventory objects, so callers can change
+// them without changing the cache.
+func (c *invCache) get(inv Info) (object.UnstructuredSet, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	objs, found := c.objs[cacheEntryFor(inv)]
+	if !found {
+		return nil, false
+	}
+	return deepCopyObjs(objs), true
+}
+
+func (c *invCache) set(inv Info, objs object.UnstructuredSet) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.objs[cacheEntryFor(inv)] = deepCopyObjs(objs)
+}
+
+func (c *invCache) invalidate(inv Info) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.objs, cacheEntryFor(inv))
+}
+
+func deepCopyObjs(objs object.UnstructuredSet) object.UnstructuredSet {
+	copies := make(object.UnstructuredSet, 0, len(objs))
+	for _, obj := range objs {
+		copies = append(copies, obj.DeepCopy())
+	}
+	return copies
+}
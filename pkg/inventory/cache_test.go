@@ -0,0 +1,85 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestClusterClient_Cache(t *testing.T) {
+	invObj := &unstructured.Unstructured{}
+	invObj.SetAPIVersion("v1")
+	invObj.SetKind("ConfigMap")
+	invObj.SetNamespace("default")
+	invObj.SetName("inv")
+	invObj.SetLabels(map[string]string{common.InventoryLabel: "test-id"})
+	inv := WrapInventoryInfoObj(invObj)
+	pod1 := ignoreErrInfoToObjMeta(pod1Info)
+	pod2 := ignoreErrInfoToObjMeta(pod2Info)
+
+	testCases := map[string]struct {
+		cached        bool
+		expectedLists int
+		expectedStats CacheStats
+	}{
+		"without cache": {
+			expectedLists: 4,
+		},
+		"with cache": {
+			cached:        true,
+			expectedLists: 2,
+			expectedStats: CacheStats{Hits: 2, Misses: 2},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			stored := invObj.DeepCopy()
+			require.NoError(t, unstructured.SetNestedStringMap(stored.Object, podDataNoStatus("pod-1"), "data"))
+			dc := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, stored)
+			cic := &ClusterClient{
+				dc:                    dc,
+				mapper:                testutil.NewFakeRESTMapper(ConfigMapGVK),
+				InventoryFactoryFunc:  WrapInventoryObj,
+				invToUnstructuredFunc: InvInfoToConfigMap,
+				statusPolicy:          StatusPolicyNone,
+				gvk:                   ConfigMapGVK,
+			}
+			ctx := context.Background()
+			if tc.cached {
+				ctx = WithCache(ctx)
+			}
+
+			objs, err := cic.GetClusterObjs(ctx, inv)
+			require.NoError(t, err)
+			assert.Equal(t, object.ObjMetadataSet{pod1}, objs)
+			// Merge reads the inventory twice, then writes it, which
+			// drops it from the cache.
+			_, err = cic.Merge(ctx, inv, object.ObjMetadataSet{pod2}, common.DryRunNone)
+			require.NoError(t, err)
+			objs, err = cic.GetClusterObjs(ctx, inv)
+			require.NoError(t, err)
+			assert.True(t, object.ObjMetadataSet{pod1, pod2}.Equal(objs), objs)
+
+			lists := 0
+			for _, action := range dc.Actions() {
+				if action.GetVerb() == "list" {
+					lists++
+				}
+			}
+			assert.Equal(t, tc.expectedLists, lists)
+			assert.Equal(t, tc.expectedStats, cic.CacheStats())
+		})
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/common"
+)
+
+var configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+// InventoryNotFoundError is returned by FindByID when no inventory object
+// has the inventory-id label.
+type InventoryNotFoundError struct {
+	ID string
+}
+
+func (e *InventoryNotFoundError) Error() string {
+	return fmt.Sprintf("no inventory object found with %s=%s", common.InventoryLabel, e.ID)
+}
+
+// Is returns true if the specified error is equal to this error.
+// Use errors.Is(error) to recursively check if an error wraps this error.
+func (e *InventoryNotFoundError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*InventoryNotFoundError)
+	if !ok {
+		return false
+	}
+	return e.ID == tErr.ID
+}
+
+// AmbiguousInventoryError is returned by FindByID when more than one
+// inventory object has the inventory-id label.
+type AmbiguousInventoryError struct {
+	ID string
+	// Objects are the matching inventory objects, as sorted
+	// "namespace/name" strings.
+	Objects []string
+}
+
+func (e *AmbiguousInventoryError) Error() string {
+	return fmt.Sprintf("%d inventory objects found with %s=%s: %s",
+		len(e.Objects), common.InventoryLabel, e.ID, strings.Join(e.Objects, ", "))
+}
+
+// Is returns true if the specified error is equal to this error.
+// Use errors.Is(error) to recursively check if an error wraps this error.
+func (e *AmbiguousInventoryError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*AmbiguousInventoryError)
+	if !ok {
+		return false
+	}
+	return e.ID == tErr.ID && strings.Join(e.Objects, ",") == strings.Join(tErr.Objects, ",")
+}
+
+// FindByID looks up the ConfigMap inventory object with the inventory-id
+// label in all the namespaces, so callers that only store the ID of an
+// inventory can get its Info. Returns an InventoryNotFoundError if there is
+// no such object, or an AmbiguousInventoryError if there is more than one.
+func FindByID(ctx context.Context, client dynamic.Interface, inventoryID string) (Info, error) {
+	if inventoryID == "" {
+		return nil, fmt.Errorf("inventory ID must be specified")
+	}
+	labelSelector := fmt.Sprintf("%s=%s", common.InventoryLabel, inventoryID)
+	klog.FromContext(ctx).V(4).Info("inventory object find by ID", "selector", labelSelector)
+
+	uList, err := client.Resource(configMapGVR).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory objects: %w", err)
+	}
+	switch len(uList.Items) {
+	case 0:
+		return nil, &InventoryNotFoundError{ID: inventoryID}
+	case 1:
+		return WrapInventoryInfoObj(&uList.Items[0]), nil
+	default:
+		objs := make([]string, 0, len(uList.Items))
+		for _, item := range uList.Items {
+			objs = append(objs, item.GetNamespace()+"/"+item.GetName())
+		}
+		sort.Strings(objs)
+		return nil, &AmbiguousInventoryError{ID: inventoryID, Objects: objs}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/common"
+)
+
+func TestFindByID(t *testing.T) {
+	inventoryObj := func(namespace, name, id string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace(namespace)
+		u.SetName(name)
+		u.SetLabels(map[string]string{common.InventoryLabel: id})
+		return u
+	}
+
+	testCases := map[string]struct {
+		clusterObjs       []runtime.Object
+		id                string
+		expectedNamespace string
+		expectedName      string
+		expectedErr       error
+	}{
+		"found": {
+			clusterObjs: []runtime.Object{
+				inventoryObj("foo", "inv", "test-id"),
+				inventoryObj("bar", "inv", "other-id"),
+			},
+			id:                "test-id",
+			expectedNamespace: "foo",
+			expectedName:      "inv",
+		},
+		"not found": {
+			clusterObjs: []runtime.Object{
+				inventoryObj("bar", "inv", "other-id"),
+			},
+			id:          "test-id",
+			expectedErr: &InventoryNotFoundError{ID: "test-id"},
+		},
+		"ambiguous": {
+			clusterObjs: []runtime.Object{
+				inventoryObj("foo", "inv", "test-id"),
+				inventoryObj("bar", "inv-copy", "test-id"),
+			},
+			id: "test-id",
+			expectedErr: &AmbiguousInventoryError{
+				ID:      "test-id",
+				Objects: []string{"bar/inv-copy", "foo/inv"},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			client := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, tc.clusterObjs...)
+			inv, err := FindByID(context.Background(), client, tc.id)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedNamespace, inv.Namespace())
+			assert.Equal(t, tc.expectedName, inv.Name())
+			assert.Equal(t, tc.id, inv.ID())
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// A parent inventory lists the IDs of its child inventories in the
+// child-inventories annotation of its inventory object, and the child
+// inventory objects have the parent-inventory-id label. This lets platforms
+// that split an environment into layers, each applied with its own
+// inventory, get all the objects the environment owns from the parent.
+// The hierarchy functions work with ConfigMap inventories.
+
+// LinkChild adds the child inventory to the children of the parent
+// inventory. Both inventory objects must exist.
+func LinkChild(ctx context.Context, dc dynamic.Interface, parent, child Info) error {
+	if parent.ID() == "" || child.ID() == "" {
+		return fmt.Errorf("linked inventories must have an inventory ID")
+	}
+	if parent.ID() == child.ID() {
+		return fmt.Errorf("inventory %q can't be its own child", parent.ID())
+	}
+	childObj, err := getInventoryObj(ctx, dc, child)
+	if err != nil {
+		return err
+	}
+	if childObj.GetLabels()[common.ParentInventoryLabel] != parent.ID() {
+		labels := childObj.GetLabels()
+		if labels == nil {
+			labels = map[string]string{}
+		}
+		labels[common.ParentInventoryLabel] = parent.ID()
+		childObj.SetLabels(labels)
+		if err := updateInventoryObj(ctx, dc, childObj); err != nil {
+			return err
+		}
+	}
+	return updateChildIDs(ctx, dc, parent, func(ids sets.Set[string]) {
+		ids.Insert(child.ID())
+	})
+}
+
+// UnlinkChild removes the child inventory from the children of the parent
+// inventory. The child inventory object keeps the parent-inventory-id
+// label, so it is returned by OrphanedChildren until it is pruned.
+func UnlinkChild(ctx context.Context, dc dynamic.Interface, parent, child Info) error {
+	return updateChildIDs(ctx, dc, parent, func(ids sets.Set[string]) {
+		ids.Delete(child.ID())
+	})
+}
+
+// ChildInventories returns the child inventories listed by the parent
+// inventory object. The children without an inventory object in the
+// cluster are skipped.
+func ChildInventories(ctx context.Context, dc dynamic.Interface, parent Info) ([]Info, error) {
+	parentObj, err := getInventoryObj(ctx, dc, parent)
+	if err != nil {
+		return nil, err
+	}
+	var children []Info
+	for _, id := range sets.List(childIDs(parentObj)) {
+		child, err := FindByID(ctx, dc, id)
+		if errors.Is(err, &InventoryNotFoundError{ID: id}) {
+			klog.FromContext(ctx).V(4).Info("child inventory not found", "parent", parent.ID(), "child", id)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, child)
+	}
+	return children, nil
+}
+
+// OrphanedChildren returns the child inventories labeled with the ID of the
+// parent inventory that the parent doesn't list anymore. They can be pruned
+// with the Destroyer.
+func OrphanedChildren(ctx context.Context, dc dynamic.Interface, parent Info) ([]Info, error) {
+	parentObj, err := getInventoryObj(ctx, dc, parent)
+	if err != nil {
+		return nil, err
+	}
+	ids := childIDs(parentObj)
+	children, err := LabeledChildren(ctx, dc, parent)
+	if err != nil {
+		return nil, err
+	}
+	var orphans []Info
+	for _, child := range children {
+		if !ids.Has(child.ID()) {
+			orphans = append(orphans, child)
+		}
+	}
+	return orphans, nil
+}
+
+// LabeledChildren returns the child inventories labeled with the ID of the
+// parent inventory, whether the parent lists them or not. The parent
+// inventory object doesn't need to exist.
+func LabeledChildren(ctx context.Context, dc dynamic.Interface, parent Info) ([]Info, error) {
+	uList, err := dc.Resource(configMapGVR).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", common.ParentInventoryLabel, parent.ID()),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the child inventories: %w", err)
+	}
+	var children []Info
+	for i := range uList.Items {
+		children = append(children, WrapInventoryInfoObj(&uList.Items[i]))
+	}
+	return children, nil
+}
+
+// AggregateObjects returns the objects of the parent inventory and of all
+// its descendant inventories.
+func AggregateObjects(ctx context.Context, invClient Client, dc dynamic.Interface, parent Info) (object.ObjMetadataSet, error) {
+	return aggregateObjects(ctx, invClient, dc, parent, sets.New[string]())
+}
+
+func aggregateObjects(ctx context.Context, invClient Client, dc dynamic.Interface, inv Info,
+	visited sets.Set[string]) (object.ObjMetadataSet, error) {
+	// Guard against cycles in the hierarchy.
+	if visited.Has(inv.ID()) {
+		return nil, nil
+	}
+	visited.Insert(inv.ID())
+	objs, err := invClient.GetClusterObjs(ctx, inv)
+	if err != nil {
+		return nil, err
+	}
+	children, err := ChildInventories(ctx, dc, inv)
+	if err != nil {
+		return nil, err
+	}
+	for _, child := range children {
+		childObjs, err := aggregateObjects(ctx, invClient, dc, child, visited)
+		if err != nil {
+			return nil, err
+		}
+		objs = objs.Union(childObjs)
+	}
+	return objs, nil
+}
+
+// childIDs returns the IDs in the child-inventories annotation.
+func childIDs(parentObj *unstructured.Unstructured) sets.Set[string] {
+	ids := sets.New[string]()
+	for _, id := range strings.Split(parentObj.GetAnnotations()[common.ChildInventoriesAnnotation], ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			ids.Insert(id)
+		}
+	}
+	return ids
+}
+
+// updateChildIDs changes the child-inventories annotation of the parent
+// inventory object, if the IDs are changed.
+func updateChildIDs(ctx context.Context, dc dynamic.Interface, parent Info, update func(sets.Set[string])) error {
+	parentObj, err := getInventoryObj(ctx, dc, parent)
+	if err != nil {
+		return err
+	}
+	ids := childIDs(parentObj)
+	updated := ids.Clone()
+	update(updated)
+	if updated.Equal(ids) {
+		return nil
+	}
+	list := sets.List(updated)
+	annotations := parentObj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if len(list) == 0 {
+		delete(annotations, common.ChildInventoriesAnnotation)
+	} else {
+		annotations[common.ChildInventoriesAnnotation] = strings.Join(list, ",")
+	}
+	parentObj.SetAnnotations(annotations)
+	return updateInventoryObj(ctx, dc, parentObj)
+}
+
+func getInventoryObj(ctx context.Context, dc dynamic.Interface, inv Info) (*unstructured.Unstructured, error) {
+	obj, err := dc.Resource(configMapGVR).Namespace(inv.Namespace()).Get(ctx, inv.Name(), metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inventory object %s/%s: %w", inv.Namespace(), inv.Name(), err)
+	}
+	return obj, nil
+}
+
+func updateInventoryObj(ctx context.Context, dc dynamic.Interface, obj *unstructured.Unstructured) error {
+	klog.FromContext(ctx).V(4).Info("updating inventory hierarchy", "inventory", klog.KObj(obj))
+	_, err := dc.Resource(configMapGVR).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to update inventory object %s: %w", klog.KObj(obj), err)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestInventoryHierarchy(t *testing.T) {
+	invObj := func(namespace, id string, data map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace(namespace)
+		u.SetName("inv")
+		u.SetLabels(map[string]string{common.InventoryLabel: id})
+		if data != nil {
+			_ = unstructured.SetNestedStringMap(u.Object, data, "data")
+		}
+		return u
+	}
+	parentObj := invObj("env", "env-id", podDataNoStatus("pod-1"))
+	networkObj := invObj("network", "network-id", podDataNoStatus("pod-2"))
+	appsObj := invObj("apps", "apps-id", podDataNoStatus("pod-3"))
+	parent := WrapInventoryInfoObj(parentObj)
+	network := WrapInventoryInfoObj(networkObj)
+	apps := WrapInventoryInfoObj(appsObj)
+
+	dc := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, parentObj, networkObj, appsObj)
+	invClient := &ClusterClient{
+		dc:                    dc,
+		mapper:                testutil.NewFakeRESTMapper(ConfigMapGVK),
+		InventoryFactoryFunc:  WrapInventoryObj,
+		invToUnstructuredFunc: InvInfoToConfigMap,
+		statusPolicy:          StatusPolicyNone,
+		gvk:                   ConfigMapGVK,
+	}
+	ctx := context.Background()
+
+	require.NoError(t, LinkChild(ctx, dc, parent, network))
+	require.NoError(t, LinkChild(ctx, dc, parent, apps))
+	// The children can be linked again.
+	require.NoError(t, LinkChild(ctx, dc, parent, apps))
+
+	children, err := ChildInventories(ctx, dc, parent)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apps-id", "network-id"}, infoIDs(children))
+	objs, err := AggregateObjects(ctx, invClient, dc, parent)
+	require.NoError(t, err)
+	assert.True(t, object.ObjMetadataSet{
+		ignoreErrInfoToObjMeta(pod1Info),
+		ignoreErrInfoToObjMeta(pod2Info),
+		ignoreErrInfoToObjMeta(pod3Info),
+	}.Equal(objs), objs)
+	orphans, err := OrphanedChildren(ctx, dc, parent)
+	require.NoError(t, err)
+	assert.Empty(t, orphans)
+
+	require.NoError(t, UnlinkChild(ctx, dc, parent, apps))
+
+	children, err = ChildInventories(ctx, dc, parent)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"network-id"}, infoIDs(children))
+	objs, err = AggregateObjects(ctx, invClient, dc, parent)
+	require.NoError(t, err)
+	assert.True(t, object.ObjMetadataSet{
+		ignoreErrInfoToObjMeta(pod1Info),
+		ignoreErrInfoToObjMeta(pod2Info),
+	}.Equal(objs), objs)
+	orphans, err = OrphanedChildren(ctx, dc, parent)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apps-id"}, infoIDs(orphans))
+	labeled, err := LabeledChildren(ctx, dc, parent)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"apps-id", "network-id"}, infoIDs(labeled))
+}
+
+func TestLinkChild_Itself(t *testing.T) {
+	inv := &fakeInventoryInfo{id: "test-id"}
+	err := LinkChild(context.Background(), nil, inv, inv)
+	assert.EqualError(t, err, `inventory "test-id" can't be its own child`)
+}
+
+func infoIDs(infos []Info) []string {
+	var ids []string
+	for _, inv := range infos {
+		ids = append(ids, inv.ID())
+	}
+	return ids
+}
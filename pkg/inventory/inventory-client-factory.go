@@ -19,8 +19,15 @@ type ClientFactory interface {
 // ClusterClientFactory is a factory that creates instances of ClusterClient inventory client.
 type ClusterClientFactory struct {
 	StatusPolicy StatusPolicy
+	// Compress stores the objects of the inventory ConfigMaps in the
+	// compressed format. See WrapCompressedInventoryObj.
+	Compress bool
 }
 
 func (ccf ClusterClientFactory) NewClient(factory cmdutil.Factory) (Client, error) {
-	return NewClient(factory, WrapInventoryObj, InvInfoToConfigMap, ccf.StatusPolicy, ConfigMapGVK)
+	invFunc := WrapInventoryObj
+	if ccf.Compress {
+		invFunc = WrapCompressedInventoryObj
+	}
+	return NewClient(factory, invFunc, InvInfoToConfigMap, ccf.StatusPolicy, ConfigMapGVK)
 }
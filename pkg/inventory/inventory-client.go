@@ -6,6 +6,7 @@ package inventory
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -28,23 +29,23 @@ type Client interface {
 	// GetClusterObjs returns the set of previously applied objects as ObjMetadata,
 	// or an error if one occurred. This set of previously applied object references
 	// is stored in the inventory objects living in the cluster.
-	GetClusterObjs(inv Info) (object.ObjMetadataSet, error)
+	GetClusterObjs(ctx context.Context, inv Info) (object.ObjMetadataSet, error)
 	// Merge applies the union of the passed objects with the currently
 	// stored objects in the inventory object. Returns the set of
 	// objects which are not in the passed objects (objects to be pruned).
 	// Otherwise, returns an error if one happened.
-	Merge(inv Info, objs object.ObjMetadataSet, dryRun common.DryRunStrategy) (object.ObjMetadataSet, error)
+	Merge(ctx context.Context, inv Info, objs object.ObjMetadataSet, dryRun common.DryRunStrategy) (object.ObjMetadataSet, error)
 	// Replace replaces the set of objects stored in the inventory
 	// object with the passed set of objects, or an error if one occurs.
-	Replace(inv Info, objs object.ObjMetadataSet, status []actuation.ObjectStatus, dryRun common.DryRunStrategy) error
+	Replace(ctx context.Context, inv Info, objs object.ObjMetadataSet, status []actuation.ObjectStatus, dryRun common.DryRunStrategy) error
 	// DeleteInventoryObj deletes the passed inventory object from the APIServer.
-	DeleteInventoryObj(inv Info, dryRun common.DryRunStrategy) error
+	DeleteInventoryObj(ctx context.Context, inv Info, dryRun common.DryRunStrategy) error
 	// ApplyInventoryNamespace applies the Namespace that the inventory object should be in.
-	ApplyInventoryNamespace(invNamespace *unstructured.Unstructured, dryRun common.DryRunStrategy) error
+	ApplyInventoryNamespace(ctx context.Context, invNamespace *unstructured.Unstructured, dryRun common.DryRunStrategy) error
 	// GetClusterInventoryInfo returns the cluster inventory object.
-	GetClusterInventoryInfo(inv Info) (*unstructured.Unstructured, error)
+	GetClusterInventoryInfo(ctx context.Context, inv Info) (*unstructured.Unstructured, error)
 	// GetClusterInventoryObjs looks up the inventory objects from the cluster.
-	GetClusterInventoryObjs(inv Info) (object.UnstructuredSet, error)
+	GetClusterInventoryObjs(ctx context.Context, inv Info) (object.UnstructuredSet, error)
 	// ListClusterInventoryObjs returns a map mapping from inventory name to a list of cluster inventory objects
 	ListClusterInventoryObjs(ctx context.Context) (map[string]object.ObjMetadataSet, error)
 }
@@ -59,6 +60,10 @@ type ClusterClient struct {
 	invToUnstructuredFunc ToUnstructuredFunc
 	statusPolicy          StatusPolicy
 	gvk                   schema.GroupVersionKind
+	// cacheHits and cacheMisses count the reads of inventory objects in
+	// the contexts of WithCache.
+	cacheHits   int64
+	cacheMisses int64
 }
 
 var _ Client = &ClusterClient{}
@@ -102,10 +107,11 @@ func NewClient(factory cmdutil.Factory,
 // to prune. Creates the initial cluster inventory object storing the passed
 // objects if an inventory object does not exist. Returns an error if one
 // occurred.
-func (cic *ClusterClient) Merge(localInv Info, objs object.ObjMetadataSet, dryRun common.DryRunStrategy) (object.ObjMetadataSet, error) {
+func (cic *ClusterClient) Merge(ctx context.Context, localInv Info, objs object.ObjMetadataSet, dryRun common.DryRunStrategy) (object.ObjMetadataSet, error) {
+	defer cic.invalidateCache(ctx, localInv)
 	pruneIds := object.ObjMetadataSet{}
 	invObj := cic.invToUnstructuredFunc(localInv)
-	clusterInv, err := cic.GetClusterInventoryInfo(localInv)
+	clusterInv, err := cic.GetClusterInventoryInfo(ctx, localInv)
 	if err != nil {
 		return pruneIds, err
 	}
@@ -121,19 +127,19 @@ func (cic *ClusterClient) Merge(localInv Info, objs object.ObjMetadataSet, dryRu
 		if err := inv.Store(objs, status); err != nil {
 			return nil, err
 		}
-		klog.V(4).Infof("creating initial inventory object with %d objects", len(objs))
+		klog.FromContext(ctx).V(4).Info("creating initial inventory object", "objects", len(objs))
 
 		if dryRun.ClientOrServerDryRun() {
-			klog.V(4).Infof("dry-run create inventory object: not created")
+			klog.FromContext(ctx).V(4).Info("dry-run create inventory object: not created")
 			return nil, nil
 		}
 
-		err = inv.Apply(cic.dc, cic.mapper, cic.statusPolicy)
+		err = inv.Apply(ctx, cic.dc, cic.mapper, cic.statusPolicy)
 		return nil, err
 	}
 
 	// Update existing cluster inventory with merged union of objects
-	clusterObjs, err := cic.GetClusterObjs(localInv)
+	clusterObjs, err := cic.GetClusterObjs(ctx, localInv)
 	if err != nil {
 		return pruneIds, err
 	}
@@ -143,8 +149,7 @@ func (cic *ClusterClient) Merge(localInv Info, objs object.ObjMetadataSet, dryRu
 	if cic.statusPolicy == StatusPolicyAll {
 		status = getObjStatus(pruneIds, unionObjs)
 	}
-	klog.V(4).Infof("num objects to prune: %d", len(pruneIds))
-	klog.V(4).Infof("num merged objects to store in inventory: %d", len(unionObjs))
+	klog.FromContext(ctx).V(4).Info("merged objects to store in inventory", "pruneObjects", len(pruneIds), "objects", len(unionObjs))
 	wrappedInv := cic.InventoryFactoryFunc(clusterInv)
 	if err = wrappedInv.Store(unionObjs, status); err != nil {
 		return pruneIds, err
@@ -158,28 +163,29 @@ func (cic *ClusterClient) Merge(localInv Info, objs object.ObjMetadataSet, dryRu
 	}
 
 	if dryRun.ClientOrServerDryRun() {
-		klog.V(4).Infof("dry-run create inventory object: not created")
+		klog.FromContext(ctx).V(4).Info("dry-run create inventory object: not created")
 		return pruneIds, nil
 	}
-	err = wrappedInv.Apply(cic.dc, cic.mapper, cic.statusPolicy)
+	err = wrappedInv.Apply(ctx, cic.dc, cic.mapper, cic.statusPolicy)
 	return pruneIds, err
 }
 
 // Replace stores the passed objects in the cluster inventory object, or
 // an error if one occurred.
-func (cic *ClusterClient) Replace(localInv Info, objs object.ObjMetadataSet, status []actuation.ObjectStatus,
+func (cic *ClusterClient) Replace(ctx context.Context, localInv Info, objs object.ObjMetadataSet, status []actuation.ObjectStatus,
 	dryRun common.DryRunStrategy) error {
 	// Skip entire function for dry-run.
 	if dryRun.ClientOrServerDryRun() {
-		klog.V(4).Infoln("dry-run replace inventory object: not applied")
+		klog.FromContext(ctx).V(4).Info("dry-run replace inventory object: not applied")
 		return nil
 	}
-	clusterInv, err := cic.GetClusterInventoryInfo(localInv)
+	defer cic.invalidateCache(ctx, localInv)
+	clusterInv, err := cic.GetClusterInventoryInfo(ctx, localInv)
 	if err != nil {
 		return fmt.Errorf("failed to read inventory from cluster: %w", err)
 	}
 
-	clusterObjs, err := cic.GetClusterObjs(localInv)
+	clusterObjs, err := cic.GetClusterObjs(ctx, localInv)
 	if err != nil {
 		return fmt.Errorf("failed to read inventory objects from cluster: %w", err)
 	}
@@ -196,10 +202,9 @@ func (cic *ClusterClient) Replace(localInv Info, objs object.ObjMetadataSet, sta
 		return nil
 	}
 
-	klog.V(4).Infof("replace cluster inventory: %s/%s", clusterInv.GetNamespace(), clusterInv.GetName())
-	klog.V(4).Infof("replace cluster inventory %d objects", len(objs))
+	klog.FromContext(ctx).V(4).Info("replace cluster inventory", "inventory", klog.KObj(clusterInv), "objects", len(objs))
 
-	if err := wrappedInv.ApplyWithPrune(cic.dc, cic.mapper, cic.statusPolicy, objs); err != nil {
+	if err := wrappedInv.ApplyWithPrune(ctx, cic.dc, cic.mapper, cic.statusPolicy, objs); err != nil {
 		return fmt.Errorf("failed to write updated inventory to cluster: %w", err)
 	}
 
@@ -225,27 +230,28 @@ func (cic *ClusterClient) replaceInventory(inv *unstructured.Unstructured, objs
 }
 
 // DeleteInventoryObj deletes the inventory object from the cluster.
-func (cic *ClusterClient) DeleteInventoryObj(localInv Info, dryRun common.DryRunStrategy) error {
+func (cic *ClusterClient) DeleteInventoryObj(ctx context.Context, localInv Info, dryRun common.DryRunStrategy) error {
 	if localInv == nil {
 		return fmt.Errorf("retrieving cluster inventory object with nil local inventory")
 	}
+	defer cic.invalidateCache(ctx, localInv)
 	switch localInv.Strategy() {
 	case NameStrategy:
-		return cic.deleteInventoryObjByName(cic.invToUnstructuredFunc(localInv), dryRun)
+		return cic.deleteInventoryObjByName(ctx, cic.invToUnstructuredFunc(localInv), dryRun)
 	case LabelStrategy:
-		return cic.deleteInventoryObjsByLabel(localInv, dryRun)
+		return cic.deleteInventoryObjsByLabel(ctx, localInv, dryRun)
 	default:
 		panic(fmt.Errorf("unknown inventory strategy: %s", localInv.Strategy()))
 	}
 }
 
-func (cic *ClusterClient) deleteInventoryObjsByLabel(inv Info, dryRun common.DryRunStrategy) error {
-	clusterInvObjs, err := cic.getClusterInventoryObjsByLabel(inv)
+func (cic *ClusterClient) deleteInventoryObjsByLabel(ctx context.Context, inv Info, dryRun common.DryRunStrategy) error {
+	clusterInvObjs, err := cic.getClusterInventoryObjsByLabel(ctx, inv)
 	if err != nil {
 		return err
 	}
 	for _, invObj := range clusterInvObjs {
-		if err := cic.deleteInventoryObjByName(invObj, dryRun); err != nil {
+		if err := cic.deleteInventoryObjByName(ctx, invObj, dryRun); err != nil {
 			return err
 		}
 	}
@@ -254,9 +260,9 @@ func (cic *ClusterClient) deleteInventoryObjsByLabel(inv Info, dryRun common.Dry
 
 // GetClusterObjs returns the objects stored in the cluster inventory object, or
 // an error if one occurred.
-func (cic *ClusterClient) GetClusterObjs(localInv Info) (object.ObjMetadataSet, error) {
+func (cic *ClusterClient) GetClusterObjs(ctx context.Context, localInv Info) (object.ObjMetadataSet, error) {
 	var objs object.ObjMetadataSet
-	clusterInv, err := cic.GetClusterInventoryInfo(localInv)
+	clusterInv, err := cic.GetClusterInventoryInfo(ctx, localInv)
 	if err != nil {
 		return objs, fmt.Errorf("failed to read inventory from cluster: %w", err)
 	}
@@ -277,8 +283,8 @@ func (cic *ClusterClient) GetClusterObjs(localInv Info) (object.ObjMetadataSet,
 //
 // TODO(seans3): Remove the special case code to merge multiple cluster inventory
 // objects once we've determined that this case is no longer possible.
-func (cic *ClusterClient) GetClusterInventoryInfo(inv Info) (*unstructured.Unstructured, error) {
-	clusterInvObjects, err := cic.GetClusterInventoryObjs(inv)
+func (cic *ClusterClient) GetClusterInventoryInfo(ctx context.Context, inv Info) (*unstructured.Unstructured, error) {
+	clusterInvObjects, err := cic.GetClusterInventoryObjs(ctx, inv)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read inventory objects from cluster: %w", err)
 	}
@@ -292,7 +298,7 @@ func (cic *ClusterClient) GetClusterInventoryInfo(inv Info) (*unstructured.Unstr
 	return clusterInv, nil
 }
 
-func (cic *ClusterClient) getClusterInventoryObjsByLabel(inv Info) (object.UnstructuredSet, error) {
+func (cic *ClusterClient) getClusterInventoryObjsByLabel(ctx context.Context, inv Info) (object.UnstructuredSet, error) {
 	localInv := cic.invToUnstructuredFunc(inv)
 	if localInv == nil {
 		return nil, fmt.Errorf("retrieving cluster inventory object with nil local inventory")
@@ -309,9 +315,9 @@ func (cic *ClusterClient) getClusterInventoryObjsByLabel(inv Info) (object.Unstr
 		return nil, err
 	}
 	labelSelector := fmt.Sprintf("%s=%s", common.InventoryLabel, label)
-	klog.V(4).Infof("inventory object fetch by label (group: %q, namespace: %q, selector: %q)", groupResource, namespace, labelSelector)
+	klog.FromContext(ctx).V(4).Info("inventory object fetch by label", "group", groupResource, "namespace", namespace, "selector", labelSelector)
 
-	uList, err := cic.dc.Resource(mapping.Resource).Namespace(namespace).List(context.TODO(), metav1.ListOptions{
+	uList, err := cic.dc.Resource(mapping.Resource).Namespace(namespace).List(ctx, metav1.ListOptions{
 		LabelSelector: labelSelector,
 	})
 	if err != nil {
@@ -324,7 +330,7 @@ func (cic *ClusterClient) getClusterInventoryObjsByLabel(inv Info) (object.Unstr
 	return invList, nil
 }
 
-func (cic *ClusterClient) getClusterInventoryObjsByName(inv Info) (object.UnstructuredSet, error) {
+func (cic *ClusterClient) getClusterInventoryObjsByName(ctx context.Context, inv Info) (object.UnstructuredSet, error) {
 	localInv := cic.invToUnstructuredFunc(inv)
 	if localInv == nil {
 		return nil, fmt.Errorf("retrieving cluster inventory object with nil local inventory")
@@ -335,9 +341,9 @@ func (cic *ClusterClient) getClusterInventoryObjsByName(inv Info) (object.Unstru
 		return nil, err
 	}
 
-	klog.V(4).Infof("inventory object fetch by name (namespace: %q, name: %q)", inv.Namespace(), inv.Name())
+	klog.FromContext(ctx).V(4).Info("inventory object fetch by name", "namespace", inv.Namespace(), "name", inv.Name())
 	clusterInv, err := cic.dc.Resource(mapping.Resource).Namespace(inv.Namespace()).
-		Get(context.TODO(), inv.Name(), metav1.GetOptions{})
+		Get(ctx, inv.Name(), metav1.GetOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
 		return nil, err
 	}
@@ -347,24 +353,53 @@ func (cic *ClusterClient) getClusterInventoryObjsByName(inv Info) (object.Unstru
 	return object.UnstructuredSet{clusterInv}, nil
 }
 
-func (cic *ClusterClient) GetClusterInventoryObjs(inv Info) (object.UnstructuredSet, error) {
+func (cic *ClusterClient) GetClusterInventoryObjs(ctx context.Context, inv Info) (object.UnstructuredSet, error) {
 	if inv == nil {
 		return nil, fmt.Errorf("inventoryInfo must be specified")
 	}
+	c := cacheFromContext(ctx)
+	if c != nil {
+		if clusterInvObjects, found := c.get(inv); found {
+			atomic.AddInt64(&cic.cacheHits, 1)
+			return clusterInvObjects, nil
+		}
+		atomic.AddInt64(&cic.cacheMisses, 1)
+	}
 
 	var clusterInvObjects object.UnstructuredSet
 	var err error
 	switch inv.Strategy() {
 	case NameStrategy:
-		clusterInvObjects, err = cic.getClusterInventoryObjsByName(inv)
+		clusterInvObjects, err = cic.getClusterInventoryObjsByName(ctx, inv)
 	case LabelStrategy:
-		clusterInvObjects, err = cic.getClusterInventoryObjsByLabel(inv)
+		clusterInvObjects, err = cic.getClusterInventoryObjsByLabel(ctx, inv)
 	default:
 		panic(fmt.Errorf("unknown inventory strategy: %s", inv.Strategy()))
 	}
+	if err == nil && c != nil {
+		c.set(inv, clusterInvObjects)
+	}
 	return clusterInvObjects, err
 }
 
+// CacheStats returns the number of reads of inventory objects served from
+// the cache of WithCache, and the number of reads that got them from the
+// cluster, since the ClusterClient was created.
+func (cic *ClusterClient) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadInt64(&cic.cacheHits),
+		Misses: atomic.LoadInt64(&cic.cacheMisses),
+	}
+}
+
+// invalidateCache drops the cached inventory objects of the inventory, after
+// they were changed.
+func (cic *ClusterClient) invalidateCache(ctx context.Context, inv Info) {
+	if c := cacheFromContext(ctx); c != nil {
+		c.invalidate(inv)
+	}
+}
+
 func (cic *ClusterClient) ListClusterInventoryObjs(ctx context.Context) (map[string]object.ObjMetadataSet, error) {
 	// Define the mapping
 	mapping, err := cic.mapper.RESTMapping(cic.gvk.GroupKind(), cic.gvk.Version)
@@ -397,9 +432,9 @@ func (cic *ClusterClient) ListClusterInventoryObjs(ctx context.Context) (map[str
 }
 
 // createInventoryObj creates the passed inventory object on the APIServer.
-func (cic *ClusterClient) createInventoryObj(obj *unstructured.Unstructured, dryRun common.DryRunStrategy) (*unstructured.Unstructured, error) {
+func (cic *ClusterClient) createInventoryObj(ctx context.Context, obj *unstructured.Unstructured, dryRun common.DryRunStrategy) (*unstructured.Unstructured, error) {
 	if dryRun.ClientOrServerDryRun() {
-		klog.V(4).Infof("dry-run create inventory object: not created")
+		klog.FromContext(ctx).V(4).Info("dry-run create inventory object: not created")
 		return obj.DeepCopy(), nil
 	}
 	if obj == nil {
@@ -417,16 +452,16 @@ func (cic *ClusterClient) createInventoryObj(obj *unstructured.Unstructured, dry
 		return nil, err
 	}
 
-	klog.V(4).Infof("creating inventory object: %s/%s", obj.GetNamespace(), obj.GetName())
+	klog.FromContext(ctx).V(4).Info("creating inventory object", "inventory", klog.KObj(obj))
 	return cic.dc.Resource(mapping.Resource).Namespace(obj.GetNamespace()).
-		Create(context.TODO(), obj, metav1.CreateOptions{})
+		Create(ctx, obj, metav1.CreateOptions{})
 }
 
 // deleteInventoryObjByName deletes the passed inventory object from the APIServer, or
 // an error if one occurs.
-func (cic *ClusterClient) deleteInventoryObjByName(obj *unstructured.Unstructured, dryRun common.DryRunStrategy) error {
+func (cic *ClusterClient) deleteInventoryObjByName(ctx context.Context, obj *unstructured.Unstructured, dryRun common.DryRunStrategy) error {
 	if dryRun.ClientOrServerDryRun() {
-		klog.V(4).Infof("dry-run delete inventory object: not deleted")
+		klog.FromContext(ctx).V(4).Info("dry-run delete inventory object: not deleted")
 		return nil
 	}
 	if obj == nil {
@@ -438,21 +473,21 @@ func (cic *ClusterClient) deleteInventoryObjByName(obj *unstructured.Unstructure
 		return err
 	}
 
-	klog.V(4).Infof("deleting inventory object: %s/%s", obj.GetNamespace(), obj.GetName())
+	klog.FromContext(ctx).V(4).Info("deleting inventory object", "inventory", klog.KObj(obj))
 	return cic.dc.Resource(mapping.Resource).Namespace(obj.GetNamespace()).
-		Delete(context.TODO(), obj.GetName(), metav1.DeleteOptions{})
+		Delete(ctx, obj.GetName(), metav1.DeleteOptions{})
 }
 
 // ApplyInventoryNamespace creates the passed namespace if it does not already
 // exist, or returns an error if one happened. NOTE: No error if already exists.
-func (cic *ClusterClient) ApplyInventoryNamespace(obj *unstructured.Unstructured, dryRun common.DryRunStrategy) error {
+func (cic *ClusterClient) ApplyInventoryNamespace(ctx context.Context, obj *unstructured.Unstructured, dryRun common.DryRunStrategy) error {
 	if dryRun.ClientOrServerDryRun() {
-		klog.V(4).Infof("dry-run apply inventory namespace (%s): not applied", obj.GetName())
+		klog.FromContext(ctx).V(4).Info("dry-run apply inventory namespace: not applied", "namespace", obj.GetName())
 		return nil
 	}
 
 	invNamespace := obj.DeepCopy()
-	klog.V(4).Infof("applying inventory namespace: %s", obj.GetName())
+	klog.FromContext(ctx).V(4).Info("applying inventory namespace", "namespace", obj.GetName())
 	object.StripKyamlAnnotations(invNamespace)
 	if err := util.CreateApplyAnnotation(invNamespace, unstructured.UnstructuredJSONScheme); err != nil {
 		return err
@@ -463,7 +498,7 @@ func (cic *ClusterClient) ApplyInventoryNamespace(obj *unstructured.Unstructured
 		return err
 	}
 
-	_, err = cic.dc.Resource(mapping.Resource).Create(context.TODO(), invNamespace, metav1.CreateOptions{})
+	_, err = cic.dc.Resource(mapping.Resource).Create(ctx, invNamespace, metav1.CreateOptions{})
 	if apierrors.IsAlreadyExists(err) {
 		return nil
 	}
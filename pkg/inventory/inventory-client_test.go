@@ -4,6 +4,7 @@
 package inventory
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -97,7 +98,7 @@ func TestGetClusterInventoryInfo(t *testing.T) {
 			if tc.inv != nil {
 				inv = storeObjsInInventory(tc.inv, tc.localObjs, tc.objStatus)
 			}
-			clusterInv, err := invClient.GetClusterInventoryInfo(WrapInventoryInfoObj(inv))
+			clusterInv, err := invClient.GetClusterInventoryInfo(context.TODO(), WrapInventoryInfoObj(inv))
 			if tc.isError {
 				if err == nil {
 					t.Fatalf("expected error but received none")
@@ -189,6 +190,34 @@ func TestMerge(t *testing.T) {
 			statusPolicy: StatusPolicyAll,
 			isError:      false,
 		},
+		"Parent object inventory by name: prune obj": {
+			localInv: parentObjectInventory(NameStrategy),
+			localObjs: object.ObjMetadataSet{
+				ignoreErrInfoToObjMeta(pod1Info),
+			},
+			clusterObjs: object.ObjMetadataSet{
+				ignoreErrInfoToObjMeta(pod1Info),
+				ignoreErrInfoToObjMeta(pod3Info),
+			},
+			pruneObjs: object.ObjMetadataSet{
+				ignoreErrInfoToObjMeta(pod3Info),
+			},
+			statusPolicy: StatusPolicyAll,
+		},
+		"Parent object inventory by label: prune obj": {
+			localInv: parentObjectInventory(LabelStrategy),
+			localObjs: object.ObjMetadataSet{
+				ignoreErrInfoToObjMeta(pod1Info),
+			},
+			clusterObjs: object.ObjMetadataSet{
+				ignoreErrInfoToObjMeta(pod1Info),
+				ignoreErrInfoToObjMeta(pod3Info),
+			},
+			pruneObjs: object.ObjMetadataSet{
+				ignoreErrInfoToObjMeta(pod3Info),
+			},
+			statusPolicy: StatusPolicyAll,
+		},
 	}
 
 	for name, tc := range tests {
@@ -199,13 +228,14 @@ func TestMerge(t *testing.T) {
 				defer tf.Cleanup()
 
 				tf.FakeDynamicClient.PrependReactor("list", "configmaps", toReactionFunc(tc.clusterObjs))
+				tf.FakeDynamicClient.PrependReactor("get", "configmaps", toGetReactionFunc(tc.clusterObjs))
 				// Create the local inventory object storing "tc.localObjs"
 				invClient, err := NewClient(tf,
 					WrapInventoryObj, InvInfoToConfigMap, tc.statusPolicy, ConfigMapGVK)
 				require.NoError(t, err)
 
 				// Call "Merge" to create the union of clusterObjs and localObjs.
-				pruneObjs, err := invClient.Merge(tc.localInv, tc.localObjs, drs)
+				pruneObjs, err := invClient.Merge(context.TODO(), tc.localInv, tc.localObjs, drs)
 				if tc.isError {
 					if err == nil {
 						t.Fatalf("expected error but received none")
@@ -280,7 +310,7 @@ func TestCreateInventory(t *testing.T) {
 			if inv != nil {
 				inv = storeObjsInInventory(tc.inv, tc.localObjs, tc.objStatus)
 			}
-			_, err = invClient.createInventoryObj(inv, common.DryRunNone)
+			_, err = invClient.createInventoryObj(context.TODO(), inv, common.DryRunNone)
 			if tc.error != "" {
 				assert.EqualError(t, err, tc.error)
 			} else {
@@ -369,11 +399,11 @@ func TestReplace(t *testing.T) {
 	invClient, err := NewClient(tf,
 		WrapInventoryObj, InvInfoToConfigMap, StatusPolicyAll, ConfigMapGVK)
 	require.NoError(t, err)
-	err = invClient.Replace(copyInventory(), object.ObjMetadataSet{}, nil, common.DryRunClient)
+	err = invClient.Replace(context.TODO(), copyInventory(), object.ObjMetadataSet{}, nil, common.DryRunClient)
 	if err != nil {
 		t.Fatalf("unexpected error received: %s", err)
 	}
-	err = invClient.Replace(copyInventory(), object.ObjMetadataSet{}, nil, common.DryRunServer)
+	err = invClient.Replace(context.TODO(), copyInventory(), object.ObjMetadataSet{}, nil, common.DryRunServer)
 	if err != nil {
 		t.Fatalf("unexpected error received: %s", err)
 	}
@@ -444,6 +474,14 @@ func TestGetClusterObjs(t *testing.T) {
 			clusterObjs: object.ObjMetadataSet{ignoreErrInfoToObjMeta(pod1Info), ignoreErrInfoToObjMeta(pod3Info)},
 			isError:     false,
 		},
+		"Parent object inventory by name": {
+			localInv:    parentObjectInventory(NameStrategy),
+			clusterObjs: object.ObjMetadataSet{ignoreErrInfoToObjMeta(pod1Info), ignoreErrInfoToObjMeta(pod3Info)},
+		},
+		"Parent object inventory by label": {
+			localInv:    parentObjectInventory(LabelStrategy),
+			clusterObjs: object.ObjMetadataSet{ignoreErrInfoToObjMeta(pod1Info), ignoreErrInfoToObjMeta(pod3Info)},
+		},
 	}
 
 	for name, tc := range tests {
@@ -451,11 +489,12 @@ func TestGetClusterObjs(t *testing.T) {
 			tf := cmdtesting.NewTestFactory().WithNamespace(testNamespace)
 			defer tf.Cleanup()
 			tf.FakeDynamicClient.PrependReactor("list", "configmaps", toReactionFunc(tc.clusterObjs))
+			tf.FakeDynamicClient.PrependReactor("get", "configmaps", toGetReactionFunc(tc.clusterObjs))
 
 			invClient, err := NewClient(tf,
 				WrapInventoryObj, InvInfoToConfigMap, tc.statusPolicy, ConfigMapGVK)
 			require.NoError(t, err)
-			clusterObjs, err := invClient.GetClusterObjs(tc.localInv)
+			clusterObjs, err := invClient.GetClusterObjs(context.TODO(), tc.localInv)
 			if tc.isError {
 				if err == nil {
 					t.Fatalf("expected error but received none")
@@ -522,7 +561,7 @@ func TestDeleteInventoryObj(t *testing.T) {
 				if inv != nil {
 					inv = storeObjsInInventory(tc.inv, tc.localObjs, tc.objStatus)
 				}
-				err = invClient.deleteInventoryObjByName(inv, drs)
+				err = invClient.deleteInventoryObjByName(context.TODO(), inv, drs)
 				if err != nil {
 					t.Fatalf("unexpected error received: %s", err)
 				}
@@ -565,7 +604,7 @@ func TestApplyInventoryNamespace(t *testing.T) {
 			invClient, err := NewClient(tf,
 				WrapInventoryObj, InvInfoToConfigMap, tc.statusPolicy, ConfigMapGVK)
 			require.NoError(t, err)
-			err = invClient.ApplyInventoryNamespace(tc.namespace, tc.dryRunStrategy)
+			err = invClient.ApplyInventoryNamespace(context.TODO(), tc.namespace, tc.dryRunStrategy)
 			assert.NoError(t, err)
 		})
 	}
@@ -589,6 +628,32 @@ func toReactionFunc(objs object.ObjMetadataSet) clienttesting.ReactionFunc {
 	}
 }
 
+// toGetReactionFunc returns the inventory object storing the objects, like
+// toReactionFunc, for the inventories looked up by name.
+func toGetReactionFunc(objs object.ObjMetadataSet) clienttesting.ReactionFunc {
+	return func(action clienttesting.Action) (bool, runtime.Object, error) {
+		u := copyInventoryInfo()
+		err := unstructured.SetNestedStringMap(u.Object, objs.ToStringMap(), "data")
+		return true, u, err
+	}
+}
+
+// parentObjectInventory returns the Info of the inventory of a parent
+// object, with the name, namespace, and ID of the inventory object.
+func parentObjectInventory(strategy Strategy) Info {
+	parent := &unstructured.Unstructured{}
+	parent.SetAPIVersion("example.com/v1")
+	parent.SetKind("Application")
+	parent.SetNamespace(testNamespace)
+	parent.SetName(inventoryObjName)
+	parent.SetLabels(map[string]string{common.InventoryLabel: testInventoryLabel})
+	inv, err := InfoFromObject(parent, InfoOptions{Strategy: strategy})
+	if err != nil {
+		panic(err)
+	}
+	return inv
+}
+
 func storeObjsInInventory(info Info, objs object.ObjMetadataSet, status []actuation.ObjectStatus) *unstructured.Unstructured {
 	wrapped := WrapInventoryObj(InvInfoToConfigMap(info))
 	_ = wrapped.Store(objs, status)
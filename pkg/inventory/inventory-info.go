@@ -3,6 +3,16 @@
 
 package inventory
 
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/common"
+)
+
 type Strategy string
 
 const (
@@ -31,3 +41,94 @@ type Info interface {
 
 	Strategy() Strategy
 }
+
+// InfoOptions configures InfoFromObject.
+type InfoOptions struct {
+	// Strategy is the strategy of the Info. Defaults to NameStrategy.
+	Strategy Strategy
+
+	// IDLabel is the label of the object with the inventory ID. Defaults to
+	// the inventory-id label.
+	IDLabel string
+
+	// UIDAsID uses the UID of the object as the inventory ID, if the object
+	// doesn't have the IDLabel.
+	UIDAsID bool
+
+	// RequiredLabels are the labels the object must have.
+	RequiredLabels []string
+}
+
+// InfoFromObject returns the Info of the inventory identified by a parent
+// object, like the custom resource of an application managed by a
+// controller, with the name and namespace of the object. The Client must
+// know how to store the inventory of such an Info.
+//
+// Returns an error if the object misses one of the RequiredLabels, or if
+// the inventory ID is missing with the Label strategy, or is not a valid
+// label value.
+func InfoFromObject(obj *unstructured.Unstructured, opts InfoOptions) (Info, error) {
+	if obj == nil {
+		return nil, fmt.Errorf("the inventory parent object can't be nil")
+	}
+	if obj.GetName() == "" {
+		return nil, fmt.Errorf("the inventory parent object must have a name")
+	}
+	ref := klog.KObj(obj)
+	labels := obj.GetLabels()
+	for _, label := range opts.RequiredLabels {
+		if labels[label] == "" {
+			return nil, fmt.Errorf("the inventory parent object %s must have the label %q", ref, label)
+		}
+	}
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = NameStrategy
+	}
+	idLabel := opts.IDLabel
+	if idLabel == "" {
+		idLabel = common.InventoryLabel
+	}
+	id := labels[idLabel]
+	if id == "" && opts.UIDAsID {
+		id = string(obj.GetUID())
+	}
+	if id == "" && strategy == LabelStrategy {
+		return nil, fmt.Errorf("the inventory parent object %s must have an inventory ID with the %s strategy", ref, strategy)
+	}
+	if errs := validation.IsValidLabelValue(id); len(errs) > 0 {
+		return nil, fmt.Errorf("invalid inventory ID %q of the inventory parent object %s: %s", id, ref, strings.Join(errs, "; "))
+	}
+	return &objectInfo{
+		namespace: obj.GetNamespace(),
+		name:      obj.GetName(),
+		id:        id,
+		strategy:  strategy,
+	}, nil
+}
+
+// objectInfo is the Info of InfoFromObject.
+type objectInfo struct {
+	namespace string
+	name      string
+	id        string
+	strategy  Strategy
+}
+
+var _ Info = &objectInfo{}
+
+func (i *objectInfo) Namespace() string {
+	return i.namespace
+}
+
+func (i *objectInfo) Name() string {
+	return i.name
+}
+
+func (i *objectInfo) ID() string {
+	return i.id
+}
+
+func (i *objectInfo) Strategy() Strategy {
+	return i.strategy
+}
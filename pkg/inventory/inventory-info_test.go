@@ -0,0 +1,88 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/cli-utils/pkg/common"
+)
+
+func TestInfoFromObject(t *testing.T) {
+	parent := func(labels map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("example.com/v1")
+		u.SetKind("Application")
+		u.SetNamespace("apps")
+		u.SetName("shop")
+		u.SetUID(types.UID("7b0e51b4-1f4c-4b8e-9a64-3f1d2b1c0a55"))
+		u.SetLabels(labels)
+		return u
+	}
+
+	testCases := map[string]struct {
+		obj              *unstructured.Unstructured
+		opts             InfoOptions
+		expectedID       string
+		expectedStrategy Strategy
+		expectedErr      string
+	}{
+		"inventory-id label": {
+			obj:              parent(map[string]string{common.InventoryLabel: "shop-id"}),
+			expectedID:       "shop-id",
+			expectedStrategy: NameStrategy,
+		},
+		"custom ID label with the label strategy": {
+			obj: parent(map[string]string{"example.com/app-id": "shop-id"}),
+			opts: InfoOptions{
+				Strategy: LabelStrategy,
+				IDLabel:  "example.com/app-id",
+			},
+			expectedID:       "shop-id",
+			expectedStrategy: LabelStrategy,
+		},
+		"UID as ID": {
+			obj:              parent(nil),
+			opts:             InfoOptions{UIDAsID: true},
+			expectedID:       "7b0e51b4-1f4c-4b8e-9a64-3f1d2b1c0a55",
+			expectedStrategy: NameStrategy,
+		},
+		"no ID with the name strategy": {
+			obj:              parent(nil),
+			expectedStrategy: NameStrategy,
+		},
+		"no ID with the label strategy": {
+			obj:         parent(nil),
+			opts:        InfoOptions{Strategy: LabelStrategy},
+			expectedErr: "the inventory parent object apps/shop must have an inventory ID with the label strategy",
+		},
+		"missing required label": {
+			obj:         parent(map[string]string{common.InventoryLabel: "shop-id"}),
+			opts:        InfoOptions{RequiredLabels: []string{"example.com/team"}},
+			expectedErr: `the inventory parent object apps/shop must have the label "example.com/team"`,
+		},
+		"nil object": {
+			expectedErr: "the inventory parent object can't be nil",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			inv, err := InfoFromObject(tc.obj, tc.opts)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "apps", inv.Namespace())
+			assert.Equal(t, "shop", inv.Name())
+			assert.Equal(t, tc.expectedID, inv.ID())
+			assert.Equal(t, tc.expectedStrategy, inv.Strategy())
+		})
+	}
+}
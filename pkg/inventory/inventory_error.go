@@ -7,7 +7,9 @@ package inventory
 
 import (
 	"fmt"
+	"time"
 
+	"k8s.io/apimachinery/pkg/util/duration"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
@@ -87,3 +89,41 @@ func (e *PolicyPreventedActuationError) Is(err error) bool {
 		e.Policy == tErr.Policy &&
 		e.Status == tErr.Status
 }
+
+// IDMismatchError is returned when the ID of the inventory differs from the
+// inventory-id label of the inventory object in the cluster with the same
+// name and namespace.
+type IDMismatchError struct {
+	Namespace  string
+	Name       string
+	ProvidedID string
+	ClusterID  string
+	// Created is the creation time of the inventory object in the cluster.
+	Created time.Time
+}
+
+func (e *IDMismatchError) Error() string {
+	return fmt.Sprintf("inventory-id of inventory object in cluster doesn't match provided id %q: "+
+		"the inventory object %s/%s, created %s ago, has inventory-id %q. "+
+		"The inventory object was probably created by another package or tool using the same name and namespace, "+
+		"or the inventory ID of this package was changed. "+
+		"Use the inventory ID of the existing inventory object, or another inventory name",
+		e.ProvidedID, e.Namespace, e.Name, duration.HumanDuration(time.Since(e.Created)), e.ClusterID)
+}
+
+// Is returns true if the specified error is equal to this error.
+// Use errors.Is(error) to recursively check if an error wraps this error.
+func (e *IDMismatchError) Is(err error) bool {
+	if err == nil {
+		return false
+	}
+	tErr, ok := err.(*IDMismatchError)
+	if !ok {
+		return false
+	}
+	return e.Namespace == tErr.Namespace &&
+		e.Name == tErr.Name &&
+		e.ProvidedID == tErr.ProvidedID &&
+		e.ClusterID == tErr.ClusterID &&
+		e.Created.Equal(tErr.Created)
+}
@@ -4,10 +4,14 @@
 package inventory
 
 import (
+	"errors"
 	"regexp"
+	"strings"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/cli-runtime/pkg/resource"
 	"sigs.k8s.io/cli-utils/pkg/common"
@@ -249,6 +253,51 @@ func TestRetrieveInventoryLabel(t *testing.T) {
 	}
 }
 
+func TestValidateInventoryID(t *testing.T) {
+	created := time.Now().Add(-3 * time.Hour).Truncate(time.Second)
+	clusterInv := object.InfoToUnstructured(invInfo)
+	clusterInv.SetCreationTimestamp(metav1.NewTime(created))
+
+	tests := map[string]struct {
+		id          string
+		expectedErr error
+	}{
+		"matching ID": {
+			id: testInventoryLabel,
+		},
+		"different ID": {
+			id: "other-id",
+			expectedErr: &IDMismatchError{
+				Namespace:  clusterInv.GetNamespace(),
+				Name:       clusterInv.GetName(),
+				ProvidedID: "other-id",
+				ClusterID:  testInventoryLabel,
+				Created:    created,
+			},
+		},
+	}
+
+	for tn, tc := range tests {
+		t.Run(tn, func(t *testing.T) {
+			localInv := clusterInv.DeepCopy()
+			localInv.SetLabels(map[string]string{common.InventoryLabel: tc.id})
+			err := ValidateInventoryID(clusterInv, WrapInventoryInfoObj(localInv))
+			if tc.expectedErr == nil {
+				if err != nil {
+					t.Fatalf("Received unexpected error: %s\n", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Expected error %v, got %v\n", tc.expectedErr, err)
+			}
+			if !strings.Contains(err.Error(), "created 3h ago") {
+				t.Errorf("Expected the age of the inventory object in the error, got %q\n", err)
+			}
+		})
+	}
+}
+
 func TestSplitUnstructureds(t *testing.T) {
 	tests := map[string]struct {
 		allObjs      []*unstructured.Unstructured
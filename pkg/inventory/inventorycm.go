@@ -9,9 +9,13 @@
 package inventory
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -31,6 +35,22 @@ var ConfigMapGVK = schema.GroupVersionKind{
 	Version: "v1",
 }
 
+const (
+	// ConfigMapFormatVersionKey is the key of the ConfigMap data that holds
+	// the format version of the inventory. ConfigMaps without it use the
+	// legacy format, with one key per object.
+	ConfigMapFormatVersionKey = "format-version"
+
+	// ConfigMapFormatCompressed is the format version of inventories that
+	// store the objects as gzip-compressed, base64-encoded JSON in the
+	// ConfigMapObjectsKey of the ConfigMap data.
+	ConfigMapFormatCompressed = "2"
+
+	// ConfigMapObjectsKey is the key of the ConfigMap data that holds the
+	// objects of compressed inventories.
+	ConfigMapObjectsKey = "objects"
+)
+
 // WrapInventoryObj takes a passed ConfigMap (as a resource.Info),
 // wraps it with the ConfigMap and upcasts the wrapper as
 // an the Inventory interface.
@@ -45,12 +65,33 @@ func WrapInventoryInfoObj(inv *unstructured.Unstructured) Info {
 	return &ConfigMap{inv: inv}
 }
 
+// WrapCompressedInventoryObj is like WrapInventoryObj, but stores the
+// objects compressed, so about three times as many objects fit in the
+// ConfigMap. Inventories in the legacy format are still read, and are
+// converted when they are stored. Older versions can't read compressed
+// inventories.
+func WrapCompressedInventoryObj(inv *unstructured.Unstructured) Storage {
+	return &ConfigMap{inv: inv, compress: true}
+}
+
+// InvInfoToConfigMap returns the inventory ConfigMap of the Info. Infos that
+// don't wrap a ConfigMap, like the Infos of InfoFromObject, get a new
+// ConfigMap with their name, namespace, and ID label.
 func InvInfoToConfigMap(inv Info) *unstructured.Unstructured {
-	icm, ok := inv.(*ConfigMap)
-	if ok {
+	if inv == nil {
+		return nil
+	}
+	if icm, ok := inv.(*ConfigMap); ok {
 		return icm.inv
 	}
-	return nil
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(ConfigMapGVK)
+	u.SetNamespace(inv.Namespace())
+	u.SetName(inv.Name())
+	if id := inv.ID(); id != "" {
+		u.SetLabels(map[string]string{common.InventoryLabel: id})
+	}
+	return u
 }
 
 // ConfigMap wraps a ConfigMap resource and implements
@@ -60,6 +101,8 @@ type ConfigMap struct {
 	inv       *unstructured.Unstructured
 	objMetas  object.ObjMetadataSet
 	objStatus []actuation.ObjectStatus
+	// compress stores the objects in the compressed format.
+	compress bool
 }
 
 var _ Info = &ConfigMap{}
@@ -96,6 +139,14 @@ func (icm *ConfigMap) Load() (object.ObjMetadataSet, error) {
 		return objs, err
 	}
 	if exists {
+		if objMap[ConfigMapFormatVersionKey] == ConfigMapFormatCompressed {
+			objMap, err = decompressObjMap(objMap[ConfigMapObjectsKey])
+			if err != nil {
+				return objs, fmt.Errorf("error decompressing object metadata from inventory object: %w", err)
+			}
+		} else if version, found := objMap[ConfigMapFormatVersionKey]; found {
+			return objs, fmt.Errorf("unsupported inventory format version: %q", version)
+		}
 		for objStr := range objMap {
 			obj, err := object.ParseObjMetadata(objStr)
 			if err != nil {
@@ -121,6 +172,16 @@ func (icm *ConfigMap) Store(objMetas object.ObjMetadataSet, status []actuation.O
 func (icm *ConfigMap) GetObject() (*unstructured.Unstructured, error) {
 	// Create the objMap of all the resources, and compute the hash.
 	objMap := buildObjMap(icm.objMetas, icm.objStatus)
+	if icm.compress {
+		data, err := compressObjMap(objMap)
+		if err != nil {
+			return nil, err
+		}
+		objMap = map[string]string{
+			ConfigMapFormatVersionKey: ConfigMapFormatCompressed,
+			ConfigMapObjectsKey:       data,
+		}
+	}
 	// Create the inventory object by copying the template.
 	invCopy := icm.inv.DeepCopy()
 	// Adds the inventory map to the ConfigMap "data" section.
@@ -134,42 +195,42 @@ func (icm *ConfigMap) GetObject() (*unstructured.Unstructured, error) {
 
 // Apply is an Storage interface function implemented to apply the inventory
 // object. StatusPolicy is not needed since ConfigMaps do not have a status subresource.
-func (icm *ConfigMap) Apply(dc dynamic.Interface, mapper meta.RESTMapper, _ StatusPolicy) error {
+func (icm *ConfigMap) Apply(ctx context.Context, dc dynamic.Interface, mapper meta.RESTMapper, _ StatusPolicy) error {
 	invInfo, namespacedClient, err := icm.getNamespacedClient(dc, mapper)
 	if err != nil {
 		return err
 	}
 
 	// Get cluster object, if exsists.
-	clusterObj, err := namespacedClient.Get(context.TODO(), invInfo.GetName(), metav1.GetOptions{})
+	clusterObj, err := namespacedClient.Get(ctx, invInfo.GetName(), metav1.GetOptions{})
 	if err != nil && !apierrors.IsNotFound(err) {
 		return err
 	}
 
 	// Create cluster inventory object, if it does not exist on cluster.
 	if clusterObj == nil {
-		klog.V(4).Infof("creating inventory object: %s/%s", invInfo.GetNamespace(), invInfo.GetName())
-		_, err = namespacedClient.Create(context.TODO(), invInfo, metav1.CreateOptions{})
+		klog.FromContext(ctx).V(4).Info("creating inventory object", "inventory", klog.KObj(invInfo))
+		_, err = namespacedClient.Create(ctx, invInfo, metav1.CreateOptions{})
 		return err
 	}
 
 	// Update the cluster inventory object instead.
-	klog.V(4).Infof("updating inventory object: %s/%s", invInfo.GetNamespace(), invInfo.GetName())
-	_, err = namespacedClient.Update(context.TODO(), invInfo, metav1.UpdateOptions{})
+	klog.FromContext(ctx).V(4).Info("updating inventory object", "inventory", klog.KObj(invInfo))
+	_, err = namespacedClient.Update(ctx, invInfo, metav1.UpdateOptions{})
 	return err
 }
 
 // ApplyWithPrune is a Storage interface function implemented to apply the inventory object with a list of objects
 // to be pruned. StatusPolicy is not needed since ConfigMaps do not have a status subresource.
-func (icm *ConfigMap) ApplyWithPrune(dc dynamic.Interface, mapper meta.RESTMapper, _ StatusPolicy, _ object.ObjMetadataSet) error {
+func (icm *ConfigMap) ApplyWithPrune(ctx context.Context, dc dynamic.Interface, mapper meta.RESTMapper, _ StatusPolicy, _ object.ObjMetadataSet) error {
 	invInfo, namespacedClient, err := icm.getNamespacedClient(dc, mapper)
 	if err != nil {
 		return err
 	}
 
 	// Update the cluster inventory object.
-	klog.V(4).Infof("updating inventory object: %s/%s", invInfo.GetNamespace(), invInfo.GetName())
-	_, err = namespacedClient.Update(context.TODO(), invInfo, metav1.UpdateOptions{})
+	klog.FromContext(ctx).V(4).Info("updating inventory object", "inventory", klog.KObj(invInfo))
+	_, err = namespacedClient.Update(ctx, invInfo, metav1.UpdateOptions{})
 	return err
 }
 
@@ -212,6 +273,45 @@ func buildObjMap(objMetas object.ObjMetadataSet, objStatus []actuation.ObjectSta
 	return objMap
 }
 
+// compressObjMap encodes the objMap as gzip-compressed, base64-encoded JSON.
+func compressObjMap(objMap map[string]string) (string, error) {
+	data, err := json.Marshal(objMap)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return "", err
+	}
+	if err := zw.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// decompressObjMap decodes an objMap encoded by compressObjMap.
+func decompressObjMap(encoded string) (map[string]string, error) {
+	compressed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, err
+	}
+	objMap := map[string]string{}
+	if err := json.Unmarshal(data, &objMap); err != nil {
+		return nil, err
+	}
+	return objMap, nil
+}
+
 func stringFrom(status actuation.ObjectStatus) string {
 	tmp := map[string]string{
 		"strategy":  status.Strategy.String(),
@@ -7,6 +7,10 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
@@ -70,3 +74,52 @@ func TestBuildObjMap(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigMapCompressed(t *testing.T) {
+	objSet := object.ObjMetadataSet{
+		{GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"}, Namespace: "ns", Name: "foo"},
+		{GroupKind: schema.GroupKind{Kind: "Namespace"}, Name: "ns"},
+	}
+	legacy := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":      "inventory",
+				"namespace": "ns",
+			},
+			"data": map[string]interface{}{
+				"ns_foo_apps_Deployment": "",
+				"_ns__Namespace":         "",
+			},
+		},
+	}
+
+	// The legacy format is read by compressed inventories.
+	icm := WrapCompressedInventoryObj(legacy)
+	objs, err := icm.Load()
+	require.NoError(t, err)
+	assert.True(t, objSet.Equal(objs), "expected %v, got %v", objSet, objs)
+
+	// Stored objects are compressed.
+	require.NoError(t, icm.Store(objSet, nil))
+	invObj, err := icm.GetObject()
+	require.NoError(t, err)
+	data, _, err := unstructured.NestedStringMap(invObj.Object, "data")
+	require.NoError(t, err)
+	assert.Len(t, data, 2)
+	assert.Equal(t, ConfigMapFormatCompressed, data[ConfigMapFormatVersionKey])
+	assert.NotEmpty(t, data[ConfigMapObjectsKey])
+
+	// Compressed inventories are read by all ConfigMaps.
+	objs, err = WrapInventoryObj(invObj).Load()
+	require.NoError(t, err)
+	assert.True(t, objSet.Equal(objs), "expected %v, got %v", objSet, objs)
+
+	// Unknown formats fail.
+	invObj.Object["data"] = map[string]interface{}{
+		ConfigMapFormatVersionKey: "99",
+	}
+	_, err = WrapInventoryObj(invObj).Load()
+	assert.EqualError(t, err, `unsupported inventory format version: "99"`)
+}
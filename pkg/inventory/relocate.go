@@ -0,0 +1,98 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Relocate moves the inventory from the inventory object of from to the
+// inventory object of to, like when the inventories are consolidated into a
+// management namespace. The namespace of to must exist.
+//
+// The objects of the inventory are stored in the new inventory object
+// first. Then, if the IDs differ, the owning-inventory annotations of the
+// objects owned by from are updated to the ID of to. The old inventory
+// object is deleted last. The Kubernetes API can't do these steps in one
+// transaction, but each step can be repeated, so if Relocate fails, calling
+// it again completes the move, and the objects are in at least one of the
+// inventories in the meantime.
+func Relocate(ctx context.Context, invClient Client, dc dynamic.Interface, mapper meta.RESTMapper,
+	from, to Info, dryRun common.DryRunStrategy) error {
+	if from == nil || to == nil {
+		return fmt.Errorf("the inventories to relocate from and to must be specified")
+	}
+	if from.Namespace() == to.Namespace() && from.Name() == to.Name() {
+		return fmt.Errorf("inventory %s/%s can't be relocated to itself", from.Namespace(), from.Name())
+	}
+	clusterInv, err := invClient.GetClusterInventoryInfo(ctx, from)
+	if err != nil {
+		return err
+	}
+	if clusterInv == nil {
+		return fmt.Errorf("inventory object %s/%s not found", from.Namespace(), from.Name())
+	}
+	objs, err := invClient.GetClusterObjs(ctx, from)
+	if err != nil {
+		return err
+	}
+
+	logger := klog.FromContext(ctx)
+	logger.V(4).Info("relocating inventory", "from", klog.KRef(from.Namespace(), from.Name()),
+		"to", klog.KRef(to.Namespace(), to.Name()), "objects", len(objs))
+	if _, err := invClient.Merge(ctx, to, objs, dryRun); err != nil {
+		return fmt.Errorf("failed to store the objects in the new inventory: %w", err)
+	}
+	if from.ID() != to.ID() {
+		for _, id := range objs {
+			if err := updateOwningInventory(ctx, dc, mapper, id, from, to, dryRun); err != nil {
+				return err
+			}
+		}
+	}
+	if err := invClient.DeleteInventoryObj(ctx, from, dryRun); err != nil {
+		return fmt.Errorf("failed to delete the old inventory: %w", err)
+	}
+	return nil
+}
+
+// updateOwningInventory sets the owning-inventory annotation of the object
+// to the ID of to, if it is owned by from. Objects not found in the cluster
+// are skipped.
+func updateOwningInventory(ctx context.Context, dc dynamic.Interface, mapper meta.RESTMapper, id object.ObjMetadata,
+	from, to Info, dryRun common.DryRunStrategy) error {
+	mapping, err := mapper.RESTMapping(id.GroupKind)
+	if err != nil {
+		return err
+	}
+	client := dc.Resource(mapping.Resource).Namespace(id.Namespace)
+	obj, err := client.Get(ctx, id.Name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", id, err)
+	}
+	if IDMatch(from, obj) != Match {
+		return nil
+	}
+	if dryRun.ClientOrServerDryRun() {
+		klog.FromContext(ctx).V(4).Info("dry-run update owning inventory: not updated", "object", id)
+		return nil
+	}
+	AddInventoryIDAnnotation(obj, to)
+	if _, err := client.Update(ctx, obj, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to update the owning inventory of %s: %w", id, err)
+	}
+	return nil
+}
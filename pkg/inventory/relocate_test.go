@@ -0,0 +1,128 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+// relocateClient records the inventories merged into and deleted.
+type relocateClient struct {
+	*FakeClient
+	merged  []Info
+	deleted []Info
+}
+
+func (c *relocateClient) GetClusterInventoryInfo(context.Context, Info) (*unstructured.Unstructured, error) {
+	return &unstructured.Unstructured{}, nil
+}
+
+func (c *relocateClient) Merge(ctx context.Context, inv Info, objs object.ObjMetadataSet, dryRun common.DryRunStrategy) (object.ObjMetadataSet, error) {
+	c.merged = append(c.merged, inv)
+	return c.FakeClient.Merge(ctx, inv, objs, dryRun)
+}
+
+func (c *relocateClient) DeleteInventoryObj(ctx context.Context, inv Info, dryRun common.DryRunStrategy) error {
+	c.deleted = append(c.deleted, inv)
+	return c.FakeClient.DeleteInventoryObj(ctx, inv, dryRun)
+}
+
+func TestRelocate(t *testing.T) {
+	invObj := func(namespace, id string) Info {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace(namespace)
+		u.SetName("inv")
+		u.SetLabels(map[string]string{common.InventoryLabel: id})
+		return WrapInventoryInfoObj(u)
+	}
+	configMap := func(name, owner string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetNamespace("default")
+		u.SetName(name)
+		u.SetAnnotations(map[string]string{OwningInventoryKey: owner})
+		return u
+	}
+	owned := configMap("owned", "old-id")
+	adopted := configMap("adopted", "other-id")
+	missing := configMap("missing", "old-id")
+	objs := object.ObjMetadataSet{
+		object.UnstructuredToObjMetadata(owned),
+		object.UnstructuredToObjMetadata(adopted),
+		object.UnstructuredToObjMetadata(missing),
+	}
+
+	testCases := map[string]struct {
+		from           Info
+		to             Info
+		dryRun         common.DryRunStrategy
+		expectedOwners map[string]string
+	}{
+		"same ID": {
+			from: invObj("default", "old-id"),
+			to:   invObj("management", "old-id"),
+			expectedOwners: map[string]string{
+				"owned":   "old-id",
+				"adopted": "other-id",
+			},
+		},
+		"new ID": {
+			from: invObj("default", "old-id"),
+			to:   invObj("management", "new-id"),
+			expectedOwners: map[string]string{
+				"owned":   "new-id",
+				"adopted": "other-id",
+			},
+		},
+		"dry run": {
+			from:   invObj("default", "old-id"),
+			to:     invObj("management", "new-id"),
+			dryRun: common.DryRunClient,
+			expectedOwners: map[string]string{
+				"owned":   "old-id",
+				"adopted": "other-id",
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			invClient := &relocateClient{FakeClient: NewFakeClient(objs)}
+			dc := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, owned.DeepCopy(), adopted.DeepCopy())
+			mapper := testutil.NewFakeRESTMapper(schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"})
+
+			err := Relocate(context.Background(), invClient, dc, mapper, tc.from, tc.to, tc.dryRun)
+			require.NoError(t, err)
+
+			assert.Equal(t, []Info{tc.to}, invClient.merged)
+			assert.Equal(t, []Info{tc.from}, invClient.deleted)
+			for name, owner := range tc.expectedOwners {
+				obj, err := dc.Resource(configMapGVR).Namespace("default").Get(context.Background(), name, metav1.GetOptions{})
+				require.NoError(t, err)
+				assert.Equal(t, owner, obj.GetAnnotations()[OwningInventoryKey], name)
+			}
+		})
+	}
+}
+
+func TestRelocate_ToItself(t *testing.T) {
+	inv := WrapInventoryInfoObj(&unstructured.Unstructured{})
+	err := Relocate(context.Background(), NewFakeClient(nil), nil, nil, inv, inv, common.DryRunNone)
+	assert.EqualError(t, err, "inventory / can't be relocated to itself")
+}
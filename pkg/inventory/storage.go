@@ -12,6 +12,7 @@
 package inventory
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -40,12 +41,12 @@ type Storage interface {
 	GetObject() (*unstructured.Unstructured, error)
 	// Apply applies the inventory object. This utility function is used
 	// in InventoryClient.Merge and merges the metadata, spec and status.
-	Apply(dynamic.Interface, meta.RESTMapper, StatusPolicy) error
+	Apply(context.Context, dynamic.Interface, meta.RESTMapper, StatusPolicy) error
 	// ApplyWithPrune applies the inventory object with a set of pruneIDs of
 	// objects to be pruned (object.ObjMetadataSet). This function is used in
 	// InventoryClient.Replace. pruneIDs are required for enabling custom logic
 	// handling of multiple ResourceGroup inventories.
-	ApplyWithPrune(dynamic.Interface, meta.RESTMapper, StatusPolicy, object.ObjMetadataSet) error
+	ApplyWithPrune(context.Context, dynamic.Interface, meta.RESTMapper, StatusPolicy, object.ObjMetadataSet) error
 }
 
 // StorageFactoryFunc creates the object which implements the Inventory
@@ -91,6 +92,23 @@ func retrieveInventoryLabel(obj *unstructured.Unstructured) (string, error) {
 	return inventoryLabel, nil
 }
 
+// ValidateInventoryID returns an IDMismatchError if the inventory-id label
+// of the inventory object in the cluster differs from the ID of the
+// inventory.
+func ValidateInventoryID(clusterInv *unstructured.Unstructured, inv Info) error {
+	clusterID := clusterInv.GetLabels()[common.InventoryLabel]
+	if clusterID == inv.ID() {
+		return nil
+	}
+	return &IDMismatchError{
+		Namespace:  clusterInv.GetNamespace(),
+		Name:       clusterInv.GetName(),
+		ProvidedID: inv.ID(),
+		ClusterID:  clusterID,
+		Created:    clusterInv.GetCreationTimestamp().Time,
+	}
+}
+
 // ValidateNoInventory takes a set of unstructured.Unstructured objects and
 // validates that no inventory object is in the input slice.
 func ValidateNoInventory(objs object.UnstructuredSet) error {
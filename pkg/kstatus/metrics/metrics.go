@@ -0,0 +1,61 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics defines the interface used by the status poller and the
+// status watcher to report metrics, so operators can tune the poll and
+// resync intervals and see the load that status tracking puts on the
+// apiserver. Implementations can forward the metrics to Prometheus,
+// OpenTelemetry or any other metrics library.
+package metrics
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Request verbs passed to Recorder.RecordRequest.
+const (
+	VerbGet   = "get"
+	VerbList  = "list"
+	VerbWatch = "watch"
+)
+
+// Recorder records the metrics of the status poller and watcher. It must be
+// safe for concurrent use.
+type Recorder interface {
+	// RecordPollDuration records how long a poll cycle of the poller took.
+	RecordPollDuration(d time.Duration)
+
+	// RecordRequest counts a request to the cluster for resources of the
+	// GroupKind, with one of the VerbGet, VerbList or VerbWatch verbs.
+	RecordRequest(gk schema.GroupKind, verb string)
+
+	// RecordWatchRestart counts a watch of the GroupKind that was started
+	// again after it ended.
+	RecordWatchRestart(gk schema.GroupKind)
+
+	// RecordPendingEventSenders records the number of informers of the
+	// watcher that are blocked sending an event, because the events are not
+	// received as fast as they are sent. The events are not buffered, so
+	// each sender has one pending event.
+	RecordPendingEventSenders(senders int)
+}
+
+// NoopRecorder is a Recorder that drops the metrics.
+type NoopRecorder struct{}
+
+var _ Recorder = NoopRecorder{}
+
+func (NoopRecorder) RecordPollDuration(time.Duration)       {}
+func (NoopRecorder) RecordRequest(schema.GroupKind, string) {}
+func (NoopRecorder) RecordWatchRestart(schema.GroupKind)    {}
+func (NoopRecorder) RecordPendingEventSenders(int)          {}
+
+// OrNoop returns the recorder, or a NoopRecorder if the recorder is nil.
+func OrNoop(r Recorder) Recorder {
+	if r == nil {
+		return NoopRecorder{}
+	}
+	return r
+}
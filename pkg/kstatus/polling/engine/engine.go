@@ -11,6 +11,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/metrics"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -44,6 +45,7 @@ type PollerEngine struct {
 // cancellation.
 func (s *PollerEngine) Poll(ctx context.Context, identifiers object.ObjMetadataSet, options Options) <-chan event.Event {
 	eventChannel := make(chan event.Event)
+	identifiers = event.FilterIdentifiers(identifiers, options.Filters...)
 
 	go func() {
 		defer close(eventChannel)
@@ -54,7 +56,12 @@ func (s *PollerEngine) Poll(ctx context.Context, identifiers object.ObjMetadataS
 			return
 		}
 
-		clusterReader, err := s.ClusterReaderFactory.New(s.Reader, s.Mapper, identifiers)
+		recorder := metrics.OrNoop(options.Metrics)
+		var reader client.Reader = s.Reader
+		if options.Metrics != nil {
+			reader = &instrumentedReader{Reader: s.Reader, metrics: recorder}
+		}
+		clusterReader, err := s.ClusterReaderFactory.New(reader, s.Mapper, identifiers)
 		if err != nil {
 			handleError(eventChannel, fmt.Errorf("error creating new ClusterReader: %w", err))
 			return
@@ -68,6 +75,12 @@ func (s *PollerEngine) Poll(ctx context.Context, identifiers object.ObjMetadataS
 			previousResourceStatuses: make(map[object.ObjMetadata]*event.ResourceStatus),
 			eventChannel:             eventChannel,
 			pollingInterval:          options.PollInterval,
+			mapper:                   s.Mapper,
+			discoveryInterval:        options.DiscoveryInterval,
+			metrics:                  recorder,
+		}
+		if runner.discoveryInterval == 0 {
+			runner.discoveryInterval = defaultDiscoveryInterval
 		}
 		runner.Run(ctx)
 	}()
@@ -112,8 +125,25 @@ type Options struct {
 	// PollInterval defines how often the PollerEngine should poll the cluster for the latest
 	// state of the resources.
 	PollInterval time.Duration
+
+	// DiscoveryInterval defines how often the PollerEngine should reset the
+	// RESTMapper while some of the resource types have no REST mapping, so
+	// polling of the resources starts once their CRDs are applied. The
+	// default is 10 seconds.
+	DiscoveryInterval time.Duration
+
+	// Metrics records the duration of the poll cycles and the requests to
+	// the cluster. Optional.
+	Metrics metrics.Recorder
+
+	// Filters select the resources to poll. Events are only sent for the
+	// resources selected by all the filters. Optional.
+	Filters []event.ResourceFilter
 }
 
+// defaultDiscoveryInterval is the DiscoveryInterval used if none is set.
+const defaultDiscoveryInterval = 10 * time.Second
+
 // statusPollerRunner is responsible for polling of a set of resources. Each call to Poll will create
 // a new statusPollerRunner, which means we can keep state in the runner and all data will only be accessed
 // by a single goroutine, meaning we don't need synchronization.
@@ -151,6 +181,19 @@ type statusPollerRunner struct {
 	// pollingInterval determines how often we should poll the cluster for
 	// the latest state of resources.
 	pollingInterval time.Duration
+
+	// mapper is reset when the identifiers contain resource types that
+	// don't have a REST mapping.
+	mapper meta.RESTMapper
+
+	// discoveryInterval determines how often the mapper can be reset.
+	discoveryInterval time.Duration
+
+	// lastDiscovery is when the mapper was last reset.
+	lastDiscovery time.Time
+
+	// metrics records the duration of the poll cycles.
+	metrics metrics.Recorder
 }
 
 // Run starts the polling loop of the statusReaders.
@@ -198,6 +241,11 @@ func (r *statusPollerRunner) handleSyncAndPollErr(err error) {
 }
 
 func (r *statusPollerRunner) syncAndPoll(ctx context.Context) error {
+	start := time.Now()
+	defer func() {
+		r.metrics.RecordPollDuration(time.Since(start))
+	}()
+	r.recheckDiscovery()
 	// First trigger a sync of the ClusterReader. This may or may not actually
 	// result in calls to the cluster, depending on the implementation.
 	// If this call fails, there is no clean way to recover, so we just return an ErrorEvent
@@ -239,6 +287,28 @@ func (r *statusPollerRunner) pollStatusForAllResources(ctx context.Context) erro
 	return nil
 }
 
+// recheckDiscovery resets the mapper if any of the resource types has no
+// REST mapping, at most once every discoveryInterval. The resources of these
+// types are reported as NotFound by the status readers until their CRDs are
+// applied and discovered.
+func (r *statusPollerRunner) recheckDiscovery() {
+	if r.mapper == nil || time.Since(r.lastDiscovery) < r.discoveryInterval {
+		return
+	}
+	seen := make(map[schema.GroupKind]bool)
+	for _, id := range r.identifiers {
+		if seen[id.GroupKind] {
+			continue
+		}
+		seen[id.GroupKind] = true
+		if _, err := r.mapper.RESTMapping(id.GroupKind); meta.IsNoMatchError(err) {
+			meta.MaybeResetRESTMapper(r.mapper)
+			r.lastDiscovery = time.Now()
+			return
+		}
+	}
+}
+
 func (r *statusPollerRunner) statusReaderForGroupKind(gk schema.GroupKind) StatusReader {
 	for _, sr := range r.statusReaders {
 		if sr.Supports(gk) {
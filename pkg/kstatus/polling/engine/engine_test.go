@@ -6,6 +6,7 @@ package engine
 import (
 	"context"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/metrics"
 	fakecr "sigs.k8s.io/cli-utils/pkg/kstatus/polling/clusterreader/fake"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
@@ -271,6 +273,145 @@ func TestNewStatusPollerRunnerIdentifierValidation(t *testing.T) {
 	}
 }
 
+func TestStatusPollerRunnerDiscovery(t *testing.T) {
+	identifiers := object.ObjMetadataSet{
+		{
+			GroupKind: schema.GroupKind{
+				Group: "custom.io",
+				Kind:  "Custom",
+			},
+			Name:      "foo",
+			Namespace: "default",
+		},
+	}
+	mapper := &resettableMapper{
+		RESTMapper: fakemapper.NewFakeRESTMapper(),
+	}
+
+	engine := PollerEngine{
+		Mapper: mapper,
+		DefaultStatusReader: &fakeStatusReader{
+			resourceStatuses: map[schema.GroupKind][]status.Status{
+				identifiers[0].GroupKind: {
+					status.NotFoundStatus,
+					status.CurrentStatus,
+				},
+			},
+			resourceStatusCount: make(map[schema.GroupKind]int),
+		},
+		ClusterReaderFactory: ClusterReaderFactoryFunc(func(client.Reader, meta.RESTMapper, object.ObjMetadataSet) (ClusterReader, error) {
+			return fakecr.NewNoopClusterReader(), nil
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventChannel := engine.Poll(ctx, identifiers, Options{
+		PollInterval:      10 * time.Millisecond,
+		DiscoveryInterval: 10 * time.Millisecond,
+	})
+
+	var statuses []status.Status
+	for e := range eventChannel {
+		if e.Type != event.ResourceUpdateEvent {
+			t.Fatalf("unexpected event %s: %v", e.Type, e.Error)
+		}
+		statuses = append(statuses, e.Resource.Status)
+		if len(statuses) == 2 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, []status.Status{status.NotFoundStatus, status.CurrentStatus}, statuses)
+	assert.Positive(t, mapper.resets)
+}
+
+func TestStatusPollerRunnerMetrics(t *testing.T) {
+	deploymentGK := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	identifiers := object.ObjMetadataSet{
+		{
+			GroupKind: deploymentGK,
+			Name:      "foo",
+			Namespace: "default",
+		},
+	}
+	recorder := &fakeRecorder{requests: make(map[schema.GroupKind]int)}
+
+	engine := PollerEngine{
+		Reader: &noopReader{},
+		Mapper: fakemapper.NewFakeRESTMapper(appsv1.SchemeGroupVersion.WithKind("Deployment")),
+		DefaultStatusReader: &fakeStatusReader{
+			resourceStatuses: map[schema.GroupKind][]status.Status{
+				deploymentGK: {status.CurrentStatus},
+			},
+			resourceStatusCount: make(map[schema.GroupKind]int),
+		},
+		ClusterReaderFactory: ClusterReaderFactoryFunc(func(r client.Reader, _ meta.RESTMapper, _ object.ObjMetadataSet) (ClusterReader, error) {
+			list := &unstructured.UnstructuredList{}
+			list.SetGroupVersionKind(appsv1.SchemeGroupVersion.WithKind("DeploymentList"))
+			if err := r.List(context.Background(), list); err != nil {
+				return nil, err
+			}
+			return fakecr.NewNoopClusterReader(), nil
+		}),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventChannel := engine.Poll(ctx, identifiers, Options{
+		PollInterval: time.Second,
+		Metrics:      recorder,
+	})
+	for e := range eventChannel {
+		assert.Equal(t, event.ResourceUpdateEvent, e.Type)
+		cancel()
+	}
+
+	assert.Equal(t, 1, recorder.pollCycles)
+	assert.Equal(t, map[schema.GroupKind]int{deploymentGK: 1}, recorder.requests)
+}
+
+// noopReader is a client.Reader that finds nothing.
+type noopReader struct{}
+
+func (noopReader) Get(context.Context, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return nil
+}
+
+func (noopReader) List(context.Context, client.ObjectList, ...client.ListOption) error {
+	return nil
+}
+
+// fakeRecorder counts the poll cycles and requests.
+type fakeRecorder struct {
+	metrics.NoopRecorder
+	mu         sync.Mutex
+	pollCycles int
+	requests   map[schema.GroupKind]int
+}
+
+func (r *fakeRecorder) RecordPollDuration(time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pollCycles++
+}
+
+func (r *fakeRecorder) RecordRequest(gk schema.GroupKind, _ string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests[gk]++
+}
+
+// resettableMapper is a RESTMapper that counts the calls to Reset.
+type resettableMapper struct {
+	meta.RESTMapper
+	resets int
+}
+
+func (m *resettableMapper) Reset() {
+	m.resets++
+}
+
 type fakeStatusReader struct {
 	resourceStatuses    map[schema.GroupKind][]status.Status
 	resourceStatusCount map[schema.GroupKind]int
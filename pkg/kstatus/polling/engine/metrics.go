@@ -0,0 +1,31 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package engine
+
+import (
+	"context"
+	"strings"
+
+	"sigs.k8s.io/cli-utils/pkg/kstatus/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// instrumentedReader is a client.Reader that counts the requests made by
+// the ClusterReader to the cluster.
+type instrumentedReader struct {
+	client.Reader
+	metrics metrics.Recorder
+}
+
+func (r *instrumentedReader) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	r.metrics.RecordRequest(obj.GetObjectKind().GroupVersionKind().GroupKind(), metrics.VerbGet)
+	return r.Reader.Get(ctx, key, obj, opts...)
+}
+
+func (r *instrumentedReader) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	gk := list.GetObjectKind().GroupVersionKind().GroupKind()
+	gk.Kind = strings.TrimSuffix(gk.Kind, "List")
+	r.metrics.RecordRequest(gk, metrics.VerbList)
+	return r.Reader.List(ctx, list, opts...)
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// ResourceFilter selects the resources that the status poller and watcher
+// report the status of. The resources that aren't selected are not polled
+// or watched, so no events are sent for them.
+type ResourceFilter interface {
+	// Include returns true if the status of the resource should be reported.
+	Include(id object.ObjMetadata) bool
+}
+
+// GroupKindFilter selects the resources of the GroupKinds.
+type GroupKindFilter struct {
+	GroupKinds []schema.GroupKind
+}
+
+var _ ResourceFilter = GroupKindFilter{}
+
+// Include returns true if the resource has one of the GroupKinds.
+func (f GroupKindFilter) Include(id object.ObjMetadata) bool {
+	for _, gk := range f.GroupKinds {
+		if id.GroupKind == gk {
+			return true
+		}
+	}
+	return false
+}
+
+// NamespaceFilter selects the resources in the namespaces. Cluster-scoped
+// resources are only selected if one of the namespaces is empty.
+type NamespaceFilter struct {
+	Namespaces []string
+}
+
+var _ ResourceFilter = NamespaceFilter{}
+
+// Include returns true if the resource is in one of the namespaces.
+func (f NamespaceFilter) Include(id object.ObjMetadata) bool {
+	for _, ns := range f.Namespaces {
+		if id.Namespace == ns {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterIdentifiers returns the identifiers selected by all the filters.
+func FilterIdentifiers(ids object.ObjMetadataSet, filters ...ResourceFilter) object.ObjMetadataSet {
+	if len(filters) == 0 {
+		return ids
+	}
+	var included object.ObjMetadataSet
+	for _, id := range ids {
+		if includeAll(id, filters) {
+			included = append(included, id)
+		}
+	}
+	return included
+}
+
+func includeAll(id object.ObjMetadata, filters []ResourceFilter) bool {
+	for _, f := range filters {
+		if !f.Include(id) {
+			return false
+		}
+	}
+	return true
+}
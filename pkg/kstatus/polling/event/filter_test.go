@@ -0,0 +1,63 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package event
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+func TestFilterIdentifiers(t *testing.T) {
+	deploymentGK := schema.GroupKind{Group: "apps", Kind: "Deployment"}
+	configMapGK := schema.GroupKind{Kind: "ConfigMap"}
+	namespaceGK := schema.GroupKind{Kind: "Namespace"}
+	deployment := object.ObjMetadata{GroupKind: deploymentGK, Namespace: "apps", Name: "shop"}
+	configMap := object.ObjMetadata{GroupKind: configMapGK, Namespace: "apps", Name: "shop-config"}
+	otherDeployment := object.ObjMetadata{GroupKind: deploymentGK, Namespace: "other", Name: "shop"}
+	namespace := object.ObjMetadata{GroupKind: namespaceGK, Name: "apps"}
+	ids := object.ObjMetadataSet{deployment, configMap, otherDeployment, namespace}
+
+	testCases := map[string]struct {
+		filters  []ResourceFilter
+		expected object.ObjMetadataSet
+	}{
+		"no filters": {
+			expected: ids,
+		},
+		"group kinds": {
+			filters: []ResourceFilter{
+				GroupKindFilter{GroupKinds: []schema.GroupKind{deploymentGK, namespaceGK}},
+			},
+			expected: object.ObjMetadataSet{deployment, otherDeployment, namespace},
+		},
+		"namespaces": {
+			filters: []ResourceFilter{
+				NamespaceFilter{Namespaces: []string{"apps"}},
+			},
+			expected: object.ObjMetadataSet{deployment, configMap},
+		},
+		"cluster-scoped": {
+			filters: []ResourceFilter{
+				NamespaceFilter{Namespaces: []string{""}},
+			},
+			expected: object.ObjMetadataSet{namespace},
+		},
+		"group kinds and namespaces": {
+			filters: []ResourceFilter{
+				GroupKindFilter{GroupKinds: []schema.GroupKind{deploymentGK}},
+				NamespaceFilter{Namespaces: []string{"other"}},
+			},
+			expected: object.ObjMetadataSet{otherDeployment},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FilterIdentifiers(ids, tc.filters...))
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
 	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/metrics"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/clusterreader"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/engine"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
@@ -42,6 +43,20 @@ func NewStatusPoller(reader client.Reader, mapper meta.RESTMapper, o Options) *S
 	}
 }
 
+// NewStatusPollerWithClusterReader creates a new StatusPoller that uses the
+// given ClusterReader for all reads of resources, instead of creating one
+// for each call to Poll. Controllers can use it with a ClusterReader backed
+// by their existing cache, such as a clusterreader.DirectClusterReader that
+// wraps the client of a controller-runtime manager. The ClusterReaderFactory
+// of the options is ignored.
+func NewStatusPollerWithClusterReader(clusterReader engine.ClusterReader, mapper meta.RESTMapper, o Options) *StatusPoller {
+	o.ClusterReaderFactory = engine.ClusterReaderFactoryFunc(
+		func(client.Reader, meta.RESTMapper, object.ObjMetadataSet) (engine.ClusterReader, error) {
+			return clusterReader, nil
+		})
+	return NewStatusPoller(nil, mapper, o)
+}
+
 // NewStatusPollerFromFactory creates a new StatusPoller instance from the
 // passed in factory.
 func NewStatusPollerFromFactory(f cmdutil.Factory, o Options) (*StatusPoller, error) {
@@ -91,7 +106,10 @@ type StatusPoller struct {
 // context passed in.
 func (s *StatusPoller) Poll(ctx context.Context, identifiers object.ObjMetadataSet, options PollOptions) <-chan event.Event {
 	return s.engine.Poll(ctx, identifiers, engine.Options{
-		PollInterval: options.PollInterval,
+		PollInterval:      options.PollInterval,
+		DiscoveryInterval: options.DiscoveryInterval,
+		Metrics:           options.Metrics,
+		Filters:           options.Filters,
 	})
 }
 
@@ -101,6 +119,18 @@ type PollOptions struct {
 	// PollInterval defines how often the PollerEngine should poll the cluster for the latest
 	// state of the resources.
 	PollInterval time.Duration
+
+	// DiscoveryInterval defines how often the RESTMapper is reset while some
+	// of the resource types have no REST mapping. The default is 10 seconds.
+	DiscoveryInterval time.Duration
+
+	// Metrics records the duration of the poll cycles and the requests to
+	// the cluster. Optional.
+	Metrics metrics.Recorder
+
+	// Filters select the resources to poll. Events are only sent for the
+	// resources selected by all the filters. Optional.
+	Filters []event.ResourceFilter
 }
 
 // createStatusReaders creates an instance of all the statusreaders. This includes a set of statusreaders for
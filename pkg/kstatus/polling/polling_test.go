@@ -0,0 +1,49 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package polling
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakecr "sigs.k8s.io/cli-utils/pkg/kstatus/polling/clusterreader/fake"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestNewStatusPollerWithClusterReader(t *testing.T) {
+	cm := &unstructured.Unstructured{}
+	cm.SetGroupVersionKind(v1.SchemeGroupVersion.WithKind("ConfigMap"))
+	cm.SetNamespace("default")
+	cm.SetName("cm")
+	clusterReader := &fakecr.ClusterReader{GetResource: cm}
+
+	poller := NewStatusPollerWithClusterReader(clusterReader,
+		testutil.NewFakeRESTMapper(v1.SchemeGroupVersion.WithKind("ConfigMap")), Options{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	eventCh := poller.Poll(ctx, object.ObjMetadataSet{
+		{
+			GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+			Namespace: "default",
+			Name:      "cm",
+		},
+	}, PollOptions{PollInterval: time.Second})
+
+	var statuses []status.Status
+	for e := range eventCh {
+		assert.Equal(t, event.ResourceUpdateEvent, e.Type)
+		statuses = append(statuses, e.Resource.Status)
+		cancel()
+	}
+	assert.Equal(t, []status.Status{status.CurrentStatus}, statuses)
+}
@@ -203,6 +203,16 @@ func errIdentifierToResourceStatus(err error, identifier object.ObjMetadata) (*e
 			Message:    "Resource not found",
 		}, nil
 	}
+	// If the resource type has no REST mapping, the resource can't exist.
+	// This happens when CRDs and CRs are applied at the same time, so the
+	// resource is polled again once the CRD is discovered.
+	if meta.IsNoMatchError(err) {
+		return &event.ResourceStatus{
+			Identifier: identifier,
+			Status:     status.NotFoundStatus,
+			Message:    "Resource type not found",
+		}, nil
+	}
 	return &event.ResourceStatus{
 		Identifier: identifier,
 		Status:     status.UnknownStatus,
@@ -18,6 +18,7 @@ import (
 	fakecr "sigs.k8s.io/cli-utils/pkg/kstatus/polling/clusterreader/fake"
 	fakesr "sigs.k8s.io/cli-utils/pkg/kstatus/polling/statusreaders/fake"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/testutil"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	fakemapper "sigs.k8s.io/cli-utils/pkg/testutil"
 )
@@ -107,6 +108,25 @@ func TestLookupResource(t *testing.T) {
 	}
 }
 
+func TestReadStatus_UnknownType(t *testing.T) {
+	identifier := object.ObjMetadata{
+		GroupKind: schema.GroupKind{
+			Group: "custom.io",
+			Kind:  "Custom",
+		},
+		Name:      "Bar",
+		Namespace: "default",
+	}
+	statusReader := NewGenericStatusReader(fakemapper.NewFakeRESTMapper(deploymentGVK), status.Compute)
+
+	rs, err := statusReader.ReadStatus(context.Background(), &fakecr.ClusterReader{}, identifier)
+	require.NoError(t, err)
+	assert.Equal(t, identifier, rs.Identifier)
+	assert.Equal(t, status.NotFoundStatus, rs.Status)
+	assert.Equal(t, "Resource type not found", rs.Message)
+	assert.NoError(t, rs.Error)
+}
+
 func TestStatusForGeneratedResources(t *testing.T) {
 	testCases := map[string]struct {
 		manifest    string
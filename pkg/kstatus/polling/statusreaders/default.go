@@ -26,7 +26,18 @@ func NewDefaultStatusReader(mapper meta.RESTMapper) engine.StatusReader {
 // NewStatusReader returns a DelegatingStatusReader that includes the statusreaders
 // for the build-in Kubernetes resources and also any provided custom status readers.
 func NewStatusReader(mapper meta.RESTMapper, statusReaders ...engine.StatusReader) engine.StatusReader {
-	defaultStatusReader := NewGenericStatusReader(mapper, status.Compute)
+	return NewStatusReaderWithFallback(mapper, status.Compute, statusReaders...)
+}
+
+// NewStatusReaderWithFallback returns a DelegatingStatusReader like
+// NewStatusReader, but the status of the resources that no other status
+// reader supports is computed with the provided fallback StatusFunc. Use
+// status.ComputeWithReadyFallback to wait for the Ready condition of the
+// custom resources of some kinds, instead of reporting them as Current
+// right away, and status.ComputeWithDaemonSetNoNodesStatus to report the
+// DaemonSets scheduled on no nodes with another status than Current.
+func NewStatusReaderWithFallback(mapper meta.RESTMapper, fallback StatusFunc, statusReaders ...engine.StatusReader) engine.StatusReader {
+	defaultStatusReader := NewGenericStatusReader(mapper, fallback)
 
 	replicaSetStatusReader := NewReplicaSetStatusReader(mapper, defaultStatusReader)
 	deploymentStatusReader := NewDeploymentResourceReader(mapper, replicaSetStatusReader)
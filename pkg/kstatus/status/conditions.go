@@ -0,0 +1,97 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"regexp"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// conditionReasonRegexp matches the reasons accepted by the API server for
+// a metav1.Condition.
+var conditionReasonRegexp = regexp.MustCompile(`^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$`)
+
+// maxConditionReasonLength is the maximum length of the reason of a
+// metav1.Condition.
+const maxConditionReasonLength = 1024
+
+// ToConditions returns the Reconciling, Stalled and Ready conditions that
+// a controller following the kstatus conventions sets for the computed
+// status. The reason of the Reconciling or Stalled condition that is True
+// is taken from the condition of the same type of the result, if any. The
+// reason of the other conditions, and of a True Ready condition, is the
+// name of the status, like "InProgress". The message of all the conditions
+// is the result message.
+//
+// Reasons that the API server would reject, like reasons with spaces, are
+// replaced by the name of the status, or by "Unknown" if it is not valid
+// either.
+//
+// The conditions have no ObservedGeneration and LastTransitionTime. Set the
+// generation of the resource and use meta.SetStatusCondition to add them to
+// a status, so the transition time is only changed when the condition
+// status changes.
+func ToConditions(result *Result) []metav1.Condition {
+	statusReason := conditionReason(string(result.Status), string(UnknownStatus))
+
+	reconciling := metav1.ConditionFalse
+	stalled := metav1.ConditionFalse
+	ready := metav1.ConditionFalse
+	switch result.Status {
+	case CurrentStatus:
+		ready = metav1.ConditionTrue
+	case InProgressStatus, TerminatingStatus:
+		reconciling = metav1.ConditionTrue
+	case FailedStatus:
+		stalled = metav1.ConditionTrue
+	default:
+		// The status of the resource isn't known, which includes resources
+		// that were not found.
+		reconciling = metav1.ConditionUnknown
+		stalled = metav1.ConditionUnknown
+		ready = metav1.ConditionUnknown
+	}
+
+	return []metav1.Condition{
+		newMetaCondition(string(ConditionReconciling), reconciling,
+			resultReason(result, ConditionReconciling, reconciling, statusReason), result.Message),
+		newMetaCondition(string(ConditionStalled), stalled,
+			resultReason(result, ConditionStalled, stalled, statusReason), result.Message),
+		newMetaCondition("Ready", ready, statusReason, result.Message),
+	}
+}
+
+// resultReason returns the reason of the condition of the type in the
+// result, if the status of the condition is True, or else the reason of the
+// status.
+func resultReason(result *Result, condType ConditionType, status metav1.ConditionStatus, statusReason string) string {
+	if status != metav1.ConditionTrue {
+		return statusReason
+	}
+	for _, cond := range result.Conditions {
+		if cond.Type == condType && cond.Reason != "" {
+			return conditionReason(cond.Reason, statusReason)
+		}
+	}
+	return statusReason
+}
+
+// conditionReason returns the reason, if it is a valid reason of a
+// metav1.Condition, or else the fallback.
+func conditionReason(reason, fallback string) string {
+	if len(reason) > maxConditionReasonLength || !conditionReasonRegexp.MatchString(reason) {
+		return fallback
+	}
+	return reason
+}
+
+func newMetaCondition(condType string, status metav1.ConditionStatus, reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    condType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}
+}
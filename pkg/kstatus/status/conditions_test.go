@@ -0,0 +1,97 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestToConditions(t *testing.T) {
+	testCases := map[string]struct {
+		result   *Result
+		expected []metav1.Condition
+	}{
+		"current": {
+			result: &Result{
+				Status:     CurrentStatus,
+				Message:    "Resource is current",
+				Conditions: []Condition{},
+			},
+			expected: []metav1.Condition{
+				{Type: "Reconciling", Status: metav1.ConditionFalse, Reason: "Current", Message: "Resource is current"},
+				{Type: "Stalled", Status: metav1.ConditionFalse, Reason: "Current", Message: "Resource is current"},
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Current", Message: "Resource is current"},
+			},
+		},
+		"in progress": {
+			result: newInProgressStatus("LessReady", "Ready: 1/2"),
+			expected: []metav1.Condition{
+				{Type: "Reconciling", Status: metav1.ConditionTrue, Reason: "LessReady", Message: "Ready: 1/2"},
+				{Type: "Stalled", Status: metav1.ConditionFalse, Reason: "InProgress", Message: "Ready: 1/2"},
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "InProgress", Message: "Ready: 1/2"},
+			},
+		},
+		"failed": {
+			result: newFailedStatus("JobFailed", "Job failed"),
+			expected: []metav1.Condition{
+				{Type: "Reconciling", Status: metav1.ConditionFalse, Reason: "Failed", Message: "Job failed"},
+				{Type: "Stalled", Status: metav1.ConditionTrue, Reason: "JobFailed", Message: "Job failed"},
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "Failed", Message: "Job failed"},
+			},
+		},
+		"invalid reason": {
+			result: newInProgressStatus("Waiting for rollout", "Ready: 1/2"),
+			expected: []metav1.Condition{
+				{Type: "Reconciling", Status: metav1.ConditionTrue, Reason: "InProgress", Message: "Ready: 1/2"},
+				{Type: "Stalled", Status: metav1.ConditionFalse, Reason: "InProgress", Message: "Ready: 1/2"},
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "InProgress", Message: "Ready: 1/2"},
+			},
+		},
+		"reason of another condition type": {
+			result: &Result{
+				Status:  InProgressStatus,
+				Message: "Ready: 1/2",
+				Conditions: []Condition{
+					{Type: ConditionStalled, Status: corev1.ConditionFalse, Reason: "NotStalled"},
+				},
+			},
+			expected: []metav1.Condition{
+				{Type: "Reconciling", Status: metav1.ConditionTrue, Reason: "InProgress", Message: "Ready: 1/2"},
+				{Type: "Stalled", Status: metav1.ConditionFalse, Reason: "InProgress", Message: "Ready: 1/2"},
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "InProgress", Message: "Ready: 1/2"},
+			},
+		},
+		"terminating": {
+			result: &Result{
+				Status:  TerminatingStatus,
+				Message: "Resource scheduled for deletion",
+			},
+			expected: []metav1.Condition{
+				{Type: "Reconciling", Status: metav1.ConditionTrue, Reason: "Terminating", Message: "Resource scheduled for deletion"},
+				{Type: "Stalled", Status: metav1.ConditionFalse, Reason: "Terminating", Message: "Resource scheduled for deletion"},
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "Terminating", Message: "Resource scheduled for deletion"},
+			},
+		},
+		"unknown": {
+			result: &Result{
+				Status: UnknownStatus,
+			},
+			expected: []metav1.Condition{
+				{Type: "Reconciling", Status: metav1.ConditionUnknown, Reason: "Unknown"},
+				{Type: "Stalled", Status: metav1.ConditionUnknown, Reason: "Unknown"},
+				{Type: "Ready", Status: metav1.ConditionUnknown, Reason: "Unknown"},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ToConditions(tc.result))
+		})
+	}
+}
@@ -44,6 +44,7 @@ const (
 	tooFewUpdated   = "LessUpdated"
 	tooFewReplicas  = "LessReplicas"
 	extraPods       = "ExtraPods"
+	noNodes         = "NoNodesScheduled"
 
 	onDeleteUpdateStrategy = "OnDelete"
 
@@ -83,16 +84,6 @@ func alwaysReady(u *unstructured.Unstructured) (*Result, error) {
 func stsConditions(u *unstructured.Unstructured) (*Result, error) {
 	obj := u.UnstructuredContent()
 
-	// updateStrategy==ondelete is a user managed statefulset.
-	updateStrategy := GetStringField(obj, ".spec.updateStrategy.type", "")
-	if updateStrategy == onDeleteUpdateStrategy {
-		return &Result{
-			Status:     CurrentStatus,
-			Message:    "StatefulSet is using the ondelete update strategy",
-			Conditions: []Condition{},
-		}, nil
-	}
-
 	// Replicas
 	specReplicas := GetIntField(obj, ".spec.replicas", 1)
 	readyReplicas := GetIntField(obj, ".status.readyReplicas", 0)
@@ -116,10 +107,26 @@ func stsConditions(u *unstructured.Unstructured) (*Result, error) {
 		return newInProgressStatus(extraPods, message), nil
 	}
 
+	// updateStrategy==ondelete is a user managed statefulset. The pods are
+	// only updated when they are deleted, so the revisions are not waited on.
+	updateStrategy := GetStringField(obj, ".spec.updateStrategy.type", "")
+	if updateStrategy == onDeleteUpdateStrategy {
+		return &Result{
+			Status:     CurrentStatus,
+			Message:    "StatefulSet is using the ondelete update strategy",
+			Conditions: []Condition{},
+		}, nil
+	}
+
 	// https://kubernetes.io/docs/concepts/workloads/controllers/statefulset/#partitions
+	// Only the pods with an ordinal of at least the partition are updated.
 	if partition != -1 {
-		if updatedReplicas < (specReplicas - partition) {
-			message := fmt.Sprintf("updated: %d/%d", updatedReplicas, specReplicas-partition)
+		expectedUpdated := specReplicas - partition
+		if expectedUpdated < 0 {
+			expectedUpdated = 0
+		}
+		if updatedReplicas < expectedUpdated {
+			message := fmt.Sprintf("updated: %d/%d", updatedReplicas, expectedUpdated)
 			return newInProgressStatus("PartitionRollout", message), nil
 		}
 		// Partition case All ok
@@ -301,8 +308,16 @@ func replicasetConditions(u *unstructured.Unstructured) (*Result, error) {
 	}, nil
 }
 
-// daemonsetConditions return standardized Conditions for DaemonSet
+// daemonsetConditions return standardized Conditions for DaemonSet. A
+// DaemonSet scheduled on no nodes is Current, since it may run on nodes
+// that are added later.
 func daemonsetConditions(u *unstructured.Unstructured) (*Result, error) {
+	return daemonsetNoNodesConditions(u, CurrentStatus)
+}
+
+// daemonsetNoNodesConditions return standardized Conditions for DaemonSet,
+// with the given status if the DaemonSet is scheduled on no nodes.
+func daemonsetNoNodesConditions(u *unstructured.Unstructured, noNodesStatus Status) (*Result, error) {
 	// We check that the latest generation is equal to observed generation as
 	// part of checking generic properties but in that case, we are lenient and
 	// skip the check if those fields are unset. For daemonset, we know that if
@@ -327,13 +342,26 @@ func daemonsetConditions(u *unstructured.Unstructured) (*Result, error) {
 		return newInProgressStatus("NoDesiredNumber", message), nil
 	}
 
+	if desiredNumberScheduled == 0 {
+		return noNodesResult(noNodesStatus), nil
+	}
+
 	if desiredNumberScheduled > currentNumberScheduled {
 		message := fmt.Sprintf("Current: %d/%d", currentNumberScheduled, desiredNumberScheduled)
 		return newInProgressStatus("LessCurrent", message), nil
 	}
 
+	// The controller counts the oldest pod on each node. With maxSurge, the
+	// updated pod is started next to the old pod, which stays available
+	// until the updated pod is. So the nodes are only updated, and their
+	// available and ready pods are updated pods, once the old pods are gone.
 	if desiredNumberScheduled > updatedNumberScheduled {
 		message := fmt.Sprintf("Updated: %d/%d", updatedNumberScheduled, desiredNumberScheduled)
+		// maxSurge is either a number or a percentage.
+		maxSurge, found, _ := unstructured.NestedFieldNoCopy(obj, "spec", "updateStrategy", "rollingUpdate", "maxSurge")
+		if surge := fmt.Sprint(maxSurge); found && surge != "0" && surge != "0%" {
+			message = fmt.Sprintf("%s, surging pods with maxSurge %s", message, surge)
+		}
 		return newInProgressStatus(tooFewUpdated, message), nil
 	}
 
@@ -355,6 +383,24 @@ func daemonsetConditions(u *unstructured.Unstructured) (*Result, error) {
 	}, nil
 }
 
+// noNodesResult returns the result for a DaemonSet that should run on no
+// nodes, with the given status.
+func noNodesResult(noNodesStatus Status) *Result {
+	message := "No nodes match the DaemonSet node selector"
+	switch noNodesStatus {
+	case InProgressStatus:
+		return newInProgressStatus(noNodes, message)
+	case FailedStatus:
+		return newFailedStatus(noNodes, message)
+	default:
+		return &Result{
+			Status:     noNodesStatus,
+			Message:    fmt.Sprintf("%s, no pods scheduled", message),
+			Conditions: []Condition{},
+		}
+	}
+}
+
 // checkGenerationSet checks that the metadata.generation and
 // status.observedGeneration fields are set.
 func checkGenerationSet(u *unstructured.Unstructured) (*Result, error) {
@@ -0,0 +1,99 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"runtime"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ManyResult contains the results of a call to compute the status of
+// multiple resources.
+type ManyResult struct {
+	// Results contains the result for each resource, in the same order as
+	// the resources. It is nil for the resources whose status could not be
+	// computed.
+	Results []*Result
+	// Errors contains the error for each resource, in the same order as
+	// the resources. It is nil for the resources whose status was computed.
+	Errors []error
+	// Status is the aggregate status of the resources.
+	Status Status
+}
+
+// ComputeMany computes the status of each of the resources with Compute,
+// using a pool of workers, and the aggregate status of the resources.
+// The aggregate status is
+//   - Failed if any of the resources is Failed
+//   - Unknown if none is Failed, but the status of at least one could not
+//     be computed
+//   - Current if all the resources are Current
+//   - InProgress otherwise
+func ComputeMany(objs []*unstructured.Unstructured) *ManyResult {
+	mr := &ManyResult{
+		Results: make([]*Result, len(objs)),
+		Errors:  make([]error, len(objs)),
+	}
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(objs) {
+		workers = len(objs)
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Each worker writes to the indexes it receives only.
+			for i := range indexes {
+				mr.Results[i], mr.Errors[i] = Compute(objs[i])
+			}
+		}()
+	}
+	for i := range objs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	mr.Status = aggregate(mr)
+	return mr
+}
+
+// ComputeList computes the status of the items of the list with ComputeMany.
+func ComputeList(list *unstructured.UnstructuredList) *ManyResult {
+	objs := make([]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		objs[i] = &list.Items[i]
+	}
+	return ComputeMany(objs)
+}
+
+func aggregate(mr *ManyResult) Status {
+	allCurrent := true
+	anyUnknown := false
+	for i, res := range mr.Results {
+		if mr.Errors[i] != nil {
+			anyUnknown = true
+			continue
+		}
+		if res.Status == FailedStatus {
+			return FailedStatus
+		}
+		if res.Status == UnknownStatus {
+			anyUnknown = true
+		}
+		if res.Status != CurrentStatus {
+			allCurrent = false
+		}
+	}
+	if anyUnknown {
+		return UnknownStatus
+	}
+	if allCurrent {
+		return CurrentStatus
+	}
+	return InProgressStatus
+}
@@ -0,0 +1,79 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+var crdBadConditions = `
+apiVersion: something/v1
+kind: MyCR
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   conditions: invalid
+`
+
+func TestComputeMany(t *testing.T) {
+	testCases := map[string]struct {
+		specs            []string
+		expectedStatuses []Status
+		expectedStatus   Status
+	}{
+		"no resources": {
+			expectedStatuses: []Status{},
+			expectedStatus:   CurrentStatus,
+		},
+		"all current": {
+			specs:            []string{dsOK, stsOK, crdReady},
+			expectedStatuses: []Status{CurrentStatus, CurrentStatus, CurrentStatus},
+			expectedStatus:   CurrentStatus,
+		},
+		"in progress": {
+			specs:            []string{dsOK, stsLessReady, crdNotReady},
+			expectedStatuses: []Status{CurrentStatus, InProgressStatus, InProgressStatus},
+			expectedStatus:   InProgressStatus,
+		},
+		"failed": {
+			specs:            []string{stsLessReady, jobFailed},
+			expectedStatuses: []Status{InProgressStatus, FailedStatus},
+			expectedStatus:   FailedStatus,
+		},
+		"error": {
+			specs:            []string{dsOK, crdBadConditions},
+			expectedStatuses: []Status{CurrentStatus, ""},
+			expectedStatus:   UnknownStatus,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			list := &unstructured.UnstructuredList{}
+			for _, spec := range tc.specs {
+				list.Items = append(list.Items, *y2u(t, spec))
+			}
+
+			mr := ComputeList(list)
+
+			statuses := []Status{}
+			for i, res := range mr.Results {
+				if res == nil {
+					assert.Error(t, mr.Errors[i])
+					statuses = append(statuses, "")
+					continue
+				}
+				assert.NoError(t, mr.Errors[i])
+				statuses = append(statuses, res.Status)
+			}
+			assert.Equal(t, tc.expectedStatuses, statuses)
+			assert.Equal(t, tc.expectedStatus, mr.Status)
+		})
+	}
+}
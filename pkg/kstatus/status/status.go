@@ -10,6 +10,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 const (
@@ -138,6 +139,126 @@ func Compute(u *unstructured.Unstructured) (*Result, error) {
 	}, err
 }
 
+// ComputeWithReadyFallback returns a function that finds the status of a
+// given unstructured resource like Compute, except for the resources of the
+// given kinds that have neither the standard conditions nor type-specific
+// rules. Instead of assuming they are current, it waits for the Ready
+// condition, which most controllers set, to be True. A Ready condition with
+// an observedGeneration that is older than the generation of the resource is
+// treated as missing.
+//
+// Only list the kinds whose controllers are known to set the Ready
+// condition, since the resources of the other kinds are never current. The
+// resources that have the Reconciling or Stalled condition follow the
+// standard conditions, so their status is computed like with Compute.
+func ComputeWithReadyFallback(groupKinds ...schema.GroupKind) func(*unstructured.Unstructured) (*Result, error) {
+	readyKinds := make(map[schema.GroupKind]bool, len(groupKinds))
+	for _, gk := range groupKinds {
+		readyKinds[gk] = true
+	}
+	return func(u *unstructured.Unstructured) (*Result, error) {
+		if !readyKinds[u.GroupVersionKind().GroupKind()] {
+			return Compute(u)
+		}
+		standard, err := hasStandardConditions(u)
+		if err != nil {
+			return nil, err
+		}
+		if standard {
+			return Compute(u)
+		}
+
+		res, err := checkGenericProperties(u)
+		if err != nil {
+			return nil, err
+		}
+		if res != nil {
+			return res, nil
+		}
+
+		fn := GetLegacyConditionsFn(u)
+		if fn != nil {
+			return fn(u)
+		}
+
+		return checkObservedReadyCondition(u)
+	}
+}
+
+// ComputeWithDaemonSetNoNodesStatus returns a function that finds the
+// status of a given unstructured resource like compute, except for the
+// DaemonSets whose node selector or tolerations match no nodes, so no pods
+// are scheduled. Those have the given status instead of Current. Use
+// InProgress or Failed to catch misconfigured node selectors.
+//
+// The compute function is usually Compute or the function returned by
+// ComputeWithReadyFallback.
+func ComputeWithDaemonSetNoNodesStatus(noNodesStatus Status, compute func(*unstructured.Unstructured) (*Result, error)) func(*unstructured.Unstructured) (*Result, error) {
+	return func(u *unstructured.Unstructured) (*Result, error) {
+		switch u.GroupVersionKind().GroupKind() {
+		case schema.GroupKind{Group: "apps", Kind: "DaemonSet"},
+			schema.GroupKind{Group: "extensions", Kind: "DaemonSet"}:
+		default:
+			return compute(u)
+		}
+
+		res, err := checkGenericProperties(u)
+		if err != nil {
+			return nil, err
+		}
+		if res != nil {
+			return res, nil
+		}
+		return daemonsetNoNodesConditions(u, noNodesStatus)
+	}
+}
+
+// hasStandardConditions returns true if the resource has the Reconciling or
+// the Stalled condition, whatever their status.
+func hasStandardConditions(u *unstructured.Unstructured) (bool, error) {
+	objWithConditions, err := GetObjectWithConditions(u.Object)
+	if err != nil {
+		return false, err
+	}
+	for _, cond := range objWithConditions.Status.Conditions {
+		if cond.Type == ConditionReconciling.String() || cond.Type == ConditionStalled.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// checkObservedReadyCondition computes the status from the Ready condition,
+// which must be set and must have observed the latest generation of the
+// resource, if it has an observedGeneration.
+func checkObservedReadyCondition(u *unstructured.Unstructured) (*Result, error) {
+	conditions, _, err := unstructured.NestedSlice(u.Object, "status", "conditions")
+	if err != nil {
+		return nil, fmt.Errorf("looking up status.conditions from resource: %w", err)
+	}
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || GetStringField(cond, "type", "") != "Ready" {
+			continue
+		}
+		observedGeneration := GetIntField(cond, "observedGeneration", -1)
+		if observedGeneration != -1 && int64(observedGeneration) < u.GetGeneration() {
+			message := fmt.Sprintf("Ready condition observed generation %d, but the latest generation is %d",
+				observedGeneration, u.GetGeneration())
+			return newInProgressStatus("ReadyNotObserved", message), nil
+		}
+		if GetStringField(cond, "status", "") == string(corev1.ConditionTrue) {
+			return &Result{
+				Status:     CurrentStatus,
+				Message:    "Resource is Ready",
+				Conditions: []Condition{},
+			}, nil
+		}
+		return newInProgressStatus(GetStringField(cond, "reason", ""), GetStringField(cond, "message", "")), nil
+	}
+	return newInProgressStatus("NoReadyCondition", "Waiting for the Ready condition"), nil
+}
+
 // checkReadyCondition checks if a resource has a Ready condition, and
 // if so, it will use the value of this condition to determine the
 // status.
@@ -9,8 +9,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"sigs.k8s.io/yaml"
 )
 
@@ -388,6 +390,91 @@ status:
    replicas: 8
 `
 
+var stsOnDeleteOutdated = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+   generation: 1
+   name: test
+   namespace: qual
+spec:
+   replicas: 4
+   updateStrategy:
+      type: OnDelete
+status:
+   observedGeneration: 1
+   currentReplicas: 2
+   updatedReplicas: 2
+   readyReplicas: 4
+   replicas: 4
+   currentRevision: test-1
+   updateRevision: test-2
+`
+
+var stsOnDeleteLessReady = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+   generation: 1
+   name: test
+   namespace: qual
+spec:
+   replicas: 4
+   updateStrategy:
+      type: OnDelete
+status:
+   observedGeneration: 1
+   currentReplicas: 4
+   readyReplicas: 2
+   replicas: 4
+`
+
+var stsPartitionRollout = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+   generation: 1
+   name: test
+   namespace: qual
+spec:
+   replicas: 4
+   updateStrategy:
+      type: RollingUpdate
+      rollingUpdate:
+         partition: 2
+status:
+   observedGeneration: 1
+   currentReplicas: 3
+   updatedReplicas: 1
+   readyReplicas: 4
+   replicas: 4
+   currentRevision: test-1
+   updateRevision: test-2
+`
+
+var stsPartitionComplete = `
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+   generation: 1
+   name: test
+   namespace: qual
+spec:
+   replicas: 4
+   updateStrategy:
+      type: RollingUpdate
+      rollingUpdate:
+         partition: 2
+status:
+   observedGeneration: 1
+   currentReplicas: 2
+   updatedReplicas: 2
+   readyReplicas: 4
+   replicas: 4
+   currentRevision: test-1
+   updateRevision: test-2
+`
+
 func TestStsStatus(t *testing.T) {
 	testCases := map[string]testSpec{
 		"stsNoStatus": {
@@ -459,6 +546,48 @@ func TestStsStatus(t *testing.T) {
 				ConditionStalled,
 			},
 		},
+		"stsOnDeleteOutdated": {
+			spec:               stsOnDeleteOutdated,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+				ConditionReconciling,
+			},
+		},
+		"stsOnDeleteLessReady": {
+			spec:           stsOnDeleteLessReady,
+			expectedStatus: InProgressStatus,
+			expectedConditions: []Condition{{
+				Type:   ConditionReconciling,
+				Status: corev1.ConditionTrue,
+				Reason: "LessReady",
+			}},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+			},
+		},
+		"stsPartitionRollout": {
+			spec:           stsPartitionRollout,
+			expectedStatus: InProgressStatus,
+			expectedConditions: []Condition{{
+				Type:   ConditionReconciling,
+				Status: corev1.ConditionTrue,
+				Reason: "PartitionRollout",
+			}},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+			},
+		},
+		"stsPartitionComplete": {
+			spec:               stsPartitionComplete,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+				ConditionReconciling,
+			},
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -550,6 +679,43 @@ status:
    numberReady: 4
 `
 
+var dsSurgeRollout = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+   name: test
+   namespace: qual
+   generation: 2
+spec:
+   updateStrategy:
+      type: RollingUpdate
+      rollingUpdate:
+         maxSurge: 1
+         maxUnavailable: 0
+status:
+   observedGeneration: 2
+   desiredNumberScheduled: 4
+   currentNumberScheduled: 4
+   updatedNumberScheduled: 2
+   numberAvailable: 4
+   numberReady: 4
+`
+var dsNoNodes = `
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   observedGeneration: 1
+   desiredNumberScheduled: 0
+   currentNumberScheduled: 0
+   updatedNumberScheduled: 0
+   numberAvailable: 0
+   numberReady: 0
+`
+
 func TestDaemonsetStatus(t *testing.T) {
 	testCases := map[string]testSpec{
 		"dsNoStatus": {
@@ -621,6 +787,28 @@ func TestDaemonsetStatus(t *testing.T) {
 				ConditionStalled,
 			},
 		},
+		"dsSurgeRollout": {
+			spec:           dsSurgeRollout,
+			expectedStatus: InProgressStatus,
+			expectedConditions: []Condition{{
+				Type:    ConditionReconciling,
+				Status:  corev1.ConditionTrue,
+				Reason:  "LessUpdated",
+				Message: "Updated: 2/4, surging pods with maxSurge 1",
+			}},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+			},
+		},
+		"dsNoNodes": {
+			spec:               dsNoNodes,
+			expectedStatus:     CurrentStatus,
+			expectedConditions: []Condition{},
+			absentConditionTypes: []ConditionType{
+				ConditionStalled,
+				ConditionReconciling,
+			},
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -631,6 +819,57 @@ func TestDaemonsetStatus(t *testing.T) {
 	}
 }
 
+func TestDaemonsetNoNodesStatus(t *testing.T) {
+	testCases := map[string]struct {
+		noNodesStatus      Status
+		expectedMessage    string
+		expectedConditions []Condition
+	}{
+		"current": {
+			noNodesStatus:      CurrentStatus,
+			expectedMessage:    "No nodes match the DaemonSet node selector, no pods scheduled",
+			expectedConditions: []Condition{},
+		},
+		"in progress": {
+			noNodesStatus:   InProgressStatus,
+			expectedMessage: "No nodes match the DaemonSet node selector",
+			expectedConditions: []Condition{{
+				Type:    ConditionReconciling,
+				Status:  corev1.ConditionTrue,
+				Reason:  "NoNodesScheduled",
+				Message: "No nodes match the DaemonSet node selector",
+			}},
+		},
+		"failed": {
+			noNodesStatus:   FailedStatus,
+			expectedMessage: "No nodes match the DaemonSet node selector",
+			expectedConditions: []Condition{{
+				Type:    ConditionStalled,
+				Status:  corev1.ConditionTrue,
+				Reason:  "NoNodesScheduled",
+				Message: "No nodes match the DaemonSet node selector",
+			}},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			compute := ComputeWithDaemonSetNoNodesStatus(tc.noNodesStatus, Compute)
+
+			res, err := compute(y2u(t, dsNoNodes))
+			require.NoError(t, err)
+			assert.Equal(t, tc.noNodesStatus, res.Status)
+			assert.Equal(t, tc.expectedMessage, res.Message)
+			assert.Equal(t, tc.expectedConditions, res.Conditions)
+
+			// other resources are computed with the compute function
+			res, err = compute(y2u(t, depOK))
+			require.NoError(t, err)
+			assert.Equal(t, CurrentStatus, res.Status)
+		})
+	}
+}
+
 var depNoStatus = `
 apiVersion: apps/v1
 kind: Deployment
@@ -1151,6 +1390,126 @@ func TestCRDGenericStatus(t *testing.T) {
 	}
 }
 
+var crdReadyOldGeneration = `
+apiVersion: something/v1
+kind: MyCR
+metadata:
+   name: test
+   namespace: qual
+   generation: 2
+status:
+   conditions:
+    - type: Ready
+      status: "True"
+      observedGeneration: 1
+`
+
+func TestComputeWithReadyFallback(t *testing.T) {
+	testCases := map[string]struct {
+		spec           string
+		expectedStatus Status
+		expectedReason string
+	}{
+		"no status": {
+			spec:           crdNoStatus,
+			expectedStatus: InProgressStatus,
+			expectedReason: "NoReadyCondition",
+		},
+		"no Ready condition": {
+			spec:           crdNoCondition,
+			expectedStatus: InProgressStatus,
+			expectedReason: "NoReadyCondition",
+		},
+		"ready": {
+			spec:           crdReady,
+			expectedStatus: CurrentStatus,
+		},
+		"not ready": {
+			spec:           crdNotReady,
+			expectedStatus: InProgressStatus,
+			expectedReason: "NotReadyYet",
+		},
+		"ready for an old generation": {
+			spec:           crdReadyOldGeneration,
+			expectedStatus: InProgressStatus,
+			expectedReason: "ReadyNotObserved",
+		},
+		"generation not observed": {
+			spec:           crdMismatchStatusGeneration,
+			expectedStatus: InProgressStatus,
+			expectedReason: "LatestGenerationNotObserved",
+		},
+		"built-in type": {
+			spec:           dsOK,
+			expectedStatus: CurrentStatus,
+		},
+		"built-in type without rules": {
+			spec:           namespaceActive,
+			expectedStatus: CurrentStatus,
+		},
+		"standard conditions without Ready": {
+			spec:           crdStandardConditions,
+			expectedStatus: CurrentStatus,
+		},
+		"other kind without Ready": {
+			spec:           otherCRNoCondition,
+			expectedStatus: CurrentStatus,
+		},
+	}
+
+	fallback := ComputeWithReadyFallback(schema.GroupKind{Group: "something", Kind: "MyCR"})
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			res, err := fallback(y2u(t, tc.spec))
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, res.Status)
+			if tc.expectedReason == "" {
+				assert.Empty(t, res.Conditions)
+				return
+			}
+			require.Len(t, res.Conditions, 1)
+			assert.Equal(t, ConditionReconciling, res.Conditions[0].Type)
+			assert.Equal(t, tc.expectedReason, res.Conditions[0].Reason)
+		})
+	}
+}
+
+var namespaceActive = `
+apiVersion: v1
+kind: Namespace
+metadata:
+   name: test
+status:
+   phase: Active
+`
+
+var crdStandardConditions = `
+apiVersion: something/v1
+kind: MyCR
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   observedGeneration: 1
+   conditions:
+   - type: Reconciling
+     status: "False"
+   - type: Stalled
+     status: "False"
+`
+
+var otherCRNoCondition = `
+apiVersion: other.example.com/v1
+kind: MyCR
+metadata:
+   name: test
+   namespace: qual
+   generation: 1
+status:
+   observedGeneration: 1
+`
+
 var jobNoStatus = `
 apiVersion: batch/v1
 kind: Job
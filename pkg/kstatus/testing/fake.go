@@ -0,0 +1,173 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package testing provides a fake status watcher and a fake status poller
+// that replay scripted events, to unit test the consumers of the events
+// without a cluster.
+package testing
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Step is an event of a script, sent after a delay.
+type Step struct {
+	// Delay is how long to wait before sending the event, after the
+	// previous event was received.
+	Delay time.Duration
+	// Event is the event to send.
+	Event event.Event
+}
+
+// Script returns the steps that send the events without delays.
+func Script(events ...event.Event) []Step {
+	steps := make([]Step, len(events))
+	for i, e := range events {
+		steps[i] = Step{Event: e}
+	}
+	return steps
+}
+
+// fake holds the state shared by the fake watcher and poller.
+type fake struct {
+	mu      sync.Mutex
+	start   chan struct{}
+	started bool
+	calls   []object.ObjMetadataSet
+}
+
+// Start releases the scripted events of the calls waiting for Start.
+// Calling it again has no effect.
+func (f *fake) Start() {
+	start := f.startChannel()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.started {
+		f.started = true
+		close(start)
+	}
+}
+
+// Calls returns the identifiers of each call, in order.
+func (f *fake) Calls() []object.ObjMetadataSet {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]object.ObjMetadataSet{}, f.calls...)
+}
+
+func (f *fake) startChannel() chan struct{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.start == nil {
+		f.start = make(chan struct{})
+	}
+	return f.start
+}
+
+func (f *fake) record(ids object.ObjMetadataSet) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, ids)
+}
+
+// replay sends the steps on the channel, then waits for the context to be
+// done to close it, like the real watcher and poller.
+func (f *fake) replay(ctx context.Context, eventCh chan<- event.Event, sendSync, waitForStart bool, steps []Step) {
+	defer close(eventCh)
+	if sendSync && !send(ctx, eventCh, event.Event{Type: event.SyncEvent}) {
+		return
+	}
+	if waitForStart {
+		select {
+		case <-f.startChannel():
+		case <-ctx.Done():
+			return
+		}
+	}
+	for _, step := range steps {
+		if step.Delay > 0 {
+			timer := time.NewTimer(step.Delay)
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
+		}
+		if !send(ctx, eventCh, step.Event) {
+			return
+		}
+	}
+	<-ctx.Done()
+}
+
+func send(ctx context.Context, eventCh chan<- event.Event, e event.Event) bool {
+	select {
+	case eventCh <- e:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// FakeStatusWatcher is a watcher.StatusWatcher that sends a SyncEvent and
+// then the scripted steps on each call to Watch. The event channel is
+// closed when the context is done.
+type FakeStatusWatcher struct {
+	fake
+
+	// Steps are the events sent after the SyncEvent.
+	Steps []Step
+	// WaitForStart makes Watch wait for a call to Start before sending the
+	// steps, so tests can control when the status events are seen.
+	WaitForStart bool
+}
+
+var _ watcher.StatusWatcher = &FakeStatusWatcher{}
+
+// NewFakeStatusWatcher returns a FakeStatusWatcher that sends the steps
+// right away.
+func NewFakeStatusWatcher(steps ...Step) *FakeStatusWatcher {
+	return &FakeStatusWatcher{Steps: steps}
+}
+
+// Watch sends the scripted events on the returned channel.
+func (f *FakeStatusWatcher) Watch(ctx context.Context, ids object.ObjMetadataSet, _ watcher.Options) <-chan event.Event {
+	f.record(ids)
+	eventCh := make(chan event.Event)
+	go f.replay(ctx, eventCh, true, f.WaitForStart, f.Steps)
+	return eventCh
+}
+
+// FakeStatusPoller is a status poller that sends the scripted steps on each
+// call to Poll. The event channel is closed when the context is done.
+type FakeStatusPoller struct {
+	fake
+
+	// Steps are the events sent by Poll.
+	Steps []Step
+	// WaitForStart makes Poll wait for a call to Start before sending the
+	// steps, so tests can control when the status events are seen.
+	WaitForStart bool
+}
+
+// NewFakeStatusPoller returns a FakeStatusPoller that sends the steps right
+// away.
+func NewFakeStatusPoller(steps ...Step) *FakeStatusPoller {
+	return &FakeStatusPoller{Steps: steps}
+}
+
+// Poll sends the scripted events on the returned channel.
+func (f *FakeStatusPoller) Poll(ctx context.Context, ids object.ObjMetadataSet, _ polling.PollOptions) <-chan event.Event {
+	f.record(ids)
+	eventCh := make(chan event.Event)
+	go f.replay(ctx, eventCh, false, f.WaitForStart, f.Steps)
+	return eventCh
+}
@@ -0,0 +1,73 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package testing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/watcher"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+var deployment = object.ObjMetadata{
+	GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+	Namespace: "default",
+	Name:      "app",
+}
+
+func update(s status.Status) event.Event {
+	return event.Event{
+		Type: event.ResourceUpdateEvent,
+		Resource: &event.ResourceStatus{
+			Identifier: deployment,
+			Status:     s,
+		},
+	}
+}
+
+func TestFakeStatusWatcher(t *testing.T) {
+	w := &FakeStatusWatcher{
+		Steps: []Step{
+			{Event: update(status.InProgressStatus)},
+			{Delay: 10 * time.Millisecond, Event: update(status.CurrentStatus)},
+		},
+		WaitForStart: true,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventCh := w.Watch(ctx, object.ObjMetadataSet{deployment}, watcher.Options{})
+	assert.Equal(t, event.SyncEvent, (<-eventCh).Type)
+	select {
+	case e := <-eventCh:
+		t.Fatalf("unexpected event before Start: %v", e)
+	case <-time.After(10 * time.Millisecond):
+	}
+	w.Start()
+	w.Start()
+	assert.Equal(t, update(status.InProgressStatus), <-eventCh)
+	assert.Equal(t, update(status.CurrentStatus), <-eventCh)
+	cancel()
+	_, open := <-eventCh
+	assert.False(t, open)
+	assert.Equal(t, []object.ObjMetadataSet{{deployment}}, w.Calls())
+}
+
+func TestFakeStatusPoller(t *testing.T) {
+	p := NewFakeStatusPoller(Script(update(status.CurrentStatus))...)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	eventCh := p.Poll(ctx, object.ObjMetadataSet{deployment}, polling.PollOptions{})
+	assert.Equal(t, update(status.CurrentStatus), <-eventCh)
+	cancel()
+	_, open := <-eventCh
+	assert.False(t, open)
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/go-logr/logr"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
@@ -33,6 +34,12 @@ type DefaultStatusWatcher struct {
 	// in case any events were missed.
 	ResyncPeriod time.Duration
 
+	// InformerFactory builds the informers of the watches, for example with
+	// the cache of the caller. Optional. Defaults to a
+	// DynamicInformerFactory with the DynamicClient and ResyncPeriod, which
+	// also records the requests of the informers in Options.Metrics.
+	InformerFactory InformerFactory
+
 	// StatusReader specifies a custom implementation of the
 	// engine.StatusReader interface that will be used to compute reconcile
 	// status for resource objects.
@@ -43,6 +50,10 @@ type DefaultStatusWatcher struct {
 	// required for computing parent object status, to compensate for
 	// controllers that aren't following status conventions.
 	ClusterReader engine.ClusterReader
+
+	// Logger is the logger of the watches, overriding the logger of the
+	// context passed to Watch. Optional.
+	Logger logr.Logger
 }
 
 var _ StatusWatcher = &DefaultStatusWatcher{}
@@ -63,10 +74,25 @@ func NewDefaultStatusWatcher(dynamicClient dynamic.Interface, mapper meta.RESTMa
 	}
 }
 
+// NewDefaultStatusWatcherWithClusterReader constructs a DefaultStatusWatcher
+// like NewDefaultStatusWatcher, but the generated objects used to compute
+// the status of their parent objects are looked up with the given
+// ClusterReader, which can be backed by an existing cache of the caller.
+func NewDefaultStatusWatcherWithClusterReader(dynamicClient dynamic.Interface, mapper meta.RESTMapper, clusterReader engine.ClusterReader) *DefaultStatusWatcher {
+	w := NewDefaultStatusWatcher(dynamicClient, mapper)
+	w.ClusterReader = clusterReader
+	return w
+}
+
 // Watch the cluster for changes made to the specified objects.
 // Returns an event channel on which these updates (and errors) will be reported.
 // Each update event includes the computed status of the object.
 func (w *DefaultStatusWatcher) Watch(ctx context.Context, ids object.ObjMetadataSet, opts Options) <-chan event.Event {
+	if w.Logger.GetSink() != nil {
+		ctx = klog.NewContext(ctx, w.Logger)
+	}
+	logger := klog.FromContext(ctx)
+	ids = event.FilterIdentifiers(ids, opts.Filters...)
 	strategy := opts.RESTScopeStrategy
 	if strategy == RESTScopeAutomatic {
 		strategy = autoSelectRESTScopeStrategy(ids)
@@ -78,23 +104,35 @@ func (w *DefaultStatusWatcher) Watch(ctx context.Context, ids object.ObjMetadata
 	case RESTScopeRoot:
 		scope = meta.RESTScopeRoot
 		targets = rootScopeGKNs(ids)
-		klog.V(3).Infof("DynamicStatusWatcher starting in root-scoped mode (targets: %d)", len(targets))
+		logger.V(3).Info("DynamicStatusWatcher starting in root-scoped mode", "targets", len(targets))
 	case RESTScopeNamespace:
 		scope = meta.RESTScopeNamespace
 		targets = namespaceScopeGKNs(ids)
-		klog.V(3).Infof("DynamicStatusWatcher starting in namespace-scoped mode (targets: %d)", len(targets))
+		logger.V(3).Info("DynamicStatusWatcher starting in namespace-scoped mode", "targets", len(targets))
 	default:
 		return handleFatalError(fmt.Errorf("invalid RESTScopeStrategy: %v", strategy))
 	}
 
+	informerFactory := w.InformerFactory
+	if informerFactory == nil {
+		dynamicInformerFactory := NewDynamicInformerFactory(w.DynamicClient, w.ResyncPeriod)
+		dynamicInformerFactory.Metrics = opts.Metrics
+		informerFactory = dynamicInformerFactory
+	}
+	clusterReader := w.ClusterReader
+	if opts.Metrics != nil {
+		clusterReader = &instrumentedClusterReader{ClusterReader: clusterReader, metrics: opts.Metrics}
+	}
+
 	informer := &ObjectStatusReporter{
-		InformerFactory: NewDynamicInformerFactory(w.DynamicClient, w.ResyncPeriod),
+		InformerFactory: informerFactory,
 		Mapper:          w.Mapper,
 		StatusReader:    w.StatusReader,
-		ClusterReader:   w.ClusterReader,
+		ClusterReader:   clusterReader,
 		Targets:         targets,
 		ObjectFilter:    &AllowListObjectFilter{AllowList: ids},
 		RESTScope:       scope,
+		Metrics:         opts.Metrics,
 	}
 	return informer.Start(ctx)
 }
@@ -19,6 +19,7 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	dynamicfake "k8s.io/client-go/dynamic/fake"
 	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	"k8s.io/kubectl/pkg/scheme"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
@@ -882,6 +883,45 @@ func TestDefaultStatusWatcher(t *testing.T) {
 	}
 }
 
+// countingInformerFactory counts the informers it builds with the
+// DynamicInformerFactory.
+type countingInformerFactory struct {
+	*DynamicInformerFactory
+	informers int
+}
+
+func (f *countingInformerFactory) NewInformer(ctx context.Context, mapping *meta.RESTMapping, namespace string) cache.SharedIndexInformer {
+	f.informers++
+	return f.DynamicInformerFactory.NewInformer(ctx, mapping, namespace)
+}
+
+func TestDefaultStatusWatcher_InformerFactory(t *testing.T) {
+	deployment1 := yamlToUnstructured(t, deployment1Yaml)
+	deployment1ID := object.UnstructuredToObjMetadata(deployment1)
+	fakeMapper := testutil.NewFakeRESTMapper(
+		appsv1.SchemeGroupVersion.WithKind("Deployment"),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	fakeClient := dynamicfake.NewSimpleDynamicClient(scheme.Scheme)
+	informerFactory := &countingInformerFactory{
+		DynamicInformerFactory: NewDynamicInformerFactory(fakeClient, 0),
+	}
+	statusWatcher := NewDefaultStatusWatcher(fakeClient, fakeMapper)
+	statusWatcher.InformerFactory = informerFactory
+	eventCh := statusWatcher.Watch(ctx, object.ObjMetadataSet{deployment1ID}, Options{})
+
+	// The watcher is synchronized once the informer has listed the objects.
+	e := <-eventCh
+	require.Equal(t, event.SyncEvent, e.Type)
+	cancel()
+	for range eventCh {
+	}
+	require.Equal(t, 1, informerFactory.informers)
+}
+
 func getGVR(t *testing.T, mapper meta.RESTMapper, obj *unstructured.Unstructured) schema.GroupVersionResource {
 	gvk := obj.GroupVersionKind()
 	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
@@ -5,6 +5,7 @@ package watcher
 
 import (
 	"context"
+	"sync/atomic"
 	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -14,12 +15,30 @@ import (
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/metrics"
 )
 
+// InformerFactory builds the informers that watch the objects of a
+// GroupKind and namespace. The informer must be new: the watcher sets its
+// handlers, runs it, and stops it when the watch ends. Implementations can
+// back the list and watch of the informer with an existing cache of the
+// caller.
+type InformerFactory interface {
+	NewInformer(ctx context.Context, mapping *meta.RESTMapping, namespace string) cache.SharedIndexInformer
+}
+
+var _ InformerFactory = &DynamicInformerFactory{}
+
+// DynamicInformerFactory builds informers that list and watch the objects
+// with a dynamic client.
 type DynamicInformerFactory struct {
 	Client       dynamic.Interface
 	ResyncPeriod time.Duration
 	Indexers     cache.Indexers
+
+	// Metrics records the list and watch requests of the informers, and
+	// the watches that are started again. Optional.
+	Metrics metrics.Recorder
 }
 
 func NewDynamicInformerFactory(client dynamic.Interface, resyncPeriod time.Duration) *DynamicInformerFactory {
@@ -37,14 +56,25 @@ func (f *DynamicInformerFactory) NewInformer(ctx context.Context, mapping *meta.
 	example := &unstructured.Unstructured{}
 	example.SetGroupVersionKind(mapping.GroupVersionKind)
 
+	recorder := metrics.OrNoop(f.Metrics)
+	gk := mapping.GroupVersionKind.GroupKind()
+	// The reflector of the informer starts a new watch whenever the
+	// previous one ends.
+	var watches int32
+
 	return cache.NewSharedIndexInformer(
 		&cache.ListWatch{
 			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				recorder.RecordRequest(gk, metrics.VerbList)
 				return f.Client.Resource(mapping.Resource).
 					Namespace(namespace).
 					List(ctx, options)
 			},
 			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				recorder.RecordRequest(gk, metrics.VerbWatch)
+				if atomic.AddInt32(&watches, 1) > 1 {
+					recorder.RecordWatchRestart(gk)
+				}
 				return f.Client.Resource(mapping.Resource).
 					Namespace(namespace).
 					Watch(ctx, options)
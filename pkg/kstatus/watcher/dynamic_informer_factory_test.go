@@ -6,6 +6,7 @@ package watcher
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	clienttesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/metrics"
 	"sigs.k8s.io/cli-utils/pkg/testutil"
 )
 
@@ -230,3 +232,72 @@ func newGenericServerResponse(action clienttesting.Action, statusError *apierror
 	// https://github.com/kubernetes/apimachinery/blob/v0.24.0/pkg/api/errors/errors.go#L435
 	return apierrors.NewGenericServerResponse(errorCode, verb, qualifiedResource, name, statusError.Error(), -1, false)
 }
+
+func TestDynamicInformerFactoryMetrics(t *testing.T) {
+	carpGVK := schema.GroupVersionKind{
+		Group:   "foo",
+		Version: "v1",
+		Kind:    "Carp",
+	}
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(carpGVK.GroupVersion(), &testapigroup.Carp{}, &testapigroup.CarpList{}, &test.List{})
+	fakeClient := dynamicfake.NewSimpleDynamicClient(scheme)
+	// End the first watch right away, so the informer starts it again.
+	firstWatch := true
+	fakeClient.PrependWatchReactor("*", func(clienttesting.Action) (bool, watch.Interface, error) {
+		w := watch.NewFake()
+		if firstWatch {
+			firstWatch = false
+			w.Stop()
+		}
+		return true, w, nil
+	})
+
+	recorder := &fakeRecorder{requests: make(map[string]int)}
+	informerFactory := NewDynamicInformerFactory(fakeClient, 0) // disable re-sync
+	informerFactory.Metrics = recorder
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	mapping, err := testutil.NewFakeRESTMapper(carpGVK).RESTMapping(carpGVK.GroupKind())
+	require.NoError(t, err)
+	informer := informerFactory.NewInformer(ctx, mapping, "example-ns")
+	go informer.Run(ctx.Done())
+
+	assert.Eventually(t, func() bool {
+		return recorder.watchRestarts() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	cancel()
+
+	recorder.mu.Lock()
+	defer recorder.mu.Unlock()
+	assert.Positive(t, recorder.requests[metrics.VerbList])
+	assert.Equal(t, recorder.restarts+1, recorder.requests[metrics.VerbWatch])
+}
+
+// fakeRecorder counts the requests by verb and the watch restarts.
+type fakeRecorder struct {
+	metrics.NoopRecorder
+	mu       sync.Mutex
+	requests map[string]int
+	restarts int
+}
+
+func (r *fakeRecorder) RecordRequest(_ schema.GroupKind, verb string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests[verb]++
+}
+
+func (r *fakeRecorder) RecordWatchRestart(schema.GroupKind) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.restarts++
+}
+
+func (r *fakeRecorder) watchRestarts() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.restarts
+}
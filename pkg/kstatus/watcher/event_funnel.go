@@ -6,8 +6,10 @@ package watcher
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 
 	"k8s.io/klog/v2"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/metrics"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 )
 
@@ -24,21 +26,27 @@ type eventFunnel struct {
 	doneCh chan struct{}
 	// counterCh is used to track the number of open input channels.
 	counterCh chan int
+	// pendingSenders is the number of input channel drains blocked sending
+	// an event to outCh.
+	pendingSenders int64
+	// metrics records the number of pending senders.
+	metrics metrics.Recorder
 }
 
-func newEventFunnel(ctx context.Context) *eventFunnel {
+func newEventFunnel(ctx context.Context, recorder metrics.Recorder) *eventFunnel {
 	funnel := &eventFunnel{
 		ctx:       ctx,
 		outCh:     make(chan event.Event),
 		doneCh:    make(chan struct{}),
 		counterCh: make(chan int),
+		metrics:   metrics.OrNoop(recorder),
 	}
 	// Wait until the context is done and all input channels are closed.
 	// Then close out and done channels to signal completion.
 	go func() {
 		defer func() {
 			// Don't close counterCh, otherwise AddInputChannel may panic.
-			klog.V(5).Info("Closing funnel")
+			klog.FromContext(ctx).V(5).Info("Closing funnel")
 			close(funnel.outCh)
 			close(funnel.doneCh)
 		}()
@@ -50,7 +58,7 @@ func newEventFunnel(ctx context.Context) *eventFunnel {
 			select {
 			case delta := <-funnel.counterCh:
 				inputs += delta
-				klog.V(5).Infof("Funnel input channels (%+d): %d", delta, inputs)
+				klog.FromContext(ctx).V(5).Info("Funnel input channels", "delta", delta, "inputs", inputs)
 			case <-ctxDoneCh:
 				// Stop waiting for context closure.
 				// Nil channel avoids busy waiting.
@@ -97,7 +105,11 @@ func (m *eventFunnel) drain(inCh <-chan event.Event, outCh chan<- event.Event) {
 		m.counterCh <- -1 // decrement counter
 	}()
 	for event := range inCh {
+		// outCh is not buffered, so the drain is blocked until the event
+		// is received.
+		m.metrics.RecordPendingEventSenders(int(atomic.AddInt64(&m.pendingSenders, 1)))
 		outCh <- event
+		m.metrics.RecordPendingEventSenders(int(atomic.AddInt64(&m.pendingSenders, -1)))
 	}
 }
 
@@ -0,0 +1,45 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package watcher
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/metrics"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/engine"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// instrumentedClusterReader is an engine.ClusterReader that counts the
+// requests made to look up generated objects.
+type instrumentedClusterReader struct {
+	engine.ClusterReader
+	metrics metrics.Recorder
+}
+
+func (r *instrumentedClusterReader) Get(ctx context.Context, key client.ObjectKey, obj *unstructured.Unstructured) error {
+	r.metrics.RecordRequest(obj.GroupVersionKind().GroupKind(), metrics.VerbGet)
+	return r.ClusterReader.Get(ctx, key, obj)
+}
+
+func (r *instrumentedClusterReader) ListNamespaceScoped(ctx context.Context, list *unstructured.UnstructuredList,
+	namespace string, selector labels.Selector) error {
+	r.recordList(list)
+	return r.ClusterReader.ListNamespaceScoped(ctx, list, namespace, selector)
+}
+
+func (r *instrumentedClusterReader) ListClusterScoped(ctx context.Context, list *unstructured.UnstructuredList,
+	selector labels.Selector) error {
+	r.recordList(list)
+	return r.ClusterReader.ListClusterScoped(ctx, list, selector)
+}
+
+func (r *instrumentedClusterReader) recordList(list *unstructured.UnstructuredList) {
+	gk := list.GroupVersionKind().GroupKind()
+	gk.Kind = strings.TrimSuffix(gk.Kind, "List")
+	r.metrics.RecordRequest(gk, metrics.VerbList)
+}
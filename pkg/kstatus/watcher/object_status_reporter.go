@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -19,6 +20,7 @@ import (
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/clock"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/metrics"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/engine"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
@@ -69,7 +71,7 @@ func (gkn GroupKindNamespace) GroupKind() schema.GroupKind {
 // TODO: Retry with backoff if in namespace-scoped mode, to allow CRDs & namespaces to be created asynchronously
 type ObjectStatusReporter struct {
 	// InformerFactory is used to build informers
-	InformerFactory *DynamicInformerFactory
+	InformerFactory InformerFactory
 
 	// Mapper is used to map from GroupKind to GroupVersionKind.
 	Mapper meta.RESTMapper
@@ -96,6 +98,9 @@ type ObjectStatusReporter struct {
 	// namespace scope may require fewer permissions.
 	RESTScope meta.RESTScope
 
+	// Metrics records the number of events waiting to be received. Optional.
+	Metrics metrics.Recorder
+
 	// lock guards modification of the subsequent stateful fields
 	lock sync.Mutex
 
@@ -111,6 +116,9 @@ type ObjectStatusReporter struct {
 	// context will be cancelled when the reporter should stop.
 	context context.Context
 
+	// logger is the logger of the context the reporter was started with.
+	logger logr.Logger
+
 	// cancel function that stops the context.
 	// This should only be called after the terminal error event has been sent.
 	cancel context.CancelFunc
@@ -175,12 +183,13 @@ func (w *ObjectStatusReporter) Start(ctx context.Context) <-chan event.Event {
 	ctx, cancel := context.WithCancel(ctx)
 	w.context = ctx
 	w.cancel = cancel
+	w.logger = klog.FromContext(ctx)
 
 	// Use an event funnel to multiplex events through multiple input channels
 	// into out output channel. We can't use the normal fan-in pattern, because
 	// we need to be able to add and remove new input channels at runtime, as
 	// new informers are created and destroyed.
-	w.funnel = newEventFunnel(ctx)
+	w.funnel = newEventFunnel(ctx, w.Metrics)
 
 	// Send start requests.
 	for _, gkn := range w.Targets {
@@ -224,7 +233,7 @@ func (w *ObjectStatusReporter) Start(ctx context.Context) <-chan event.Event {
 // Stop triggers the cancellation of the reporter context, and closure of the
 // event channel without sending an error event.
 func (w *ObjectStatusReporter) Stop() {
-	klog.V(4).Info("Stopping reporter")
+	w.logger.V(4).Info("Stopping reporter")
 	w.cancel()
 }
 
@@ -247,7 +256,7 @@ func (w *ObjectStatusReporter) HasSynced() bool {
 		}
 	}
 	if len(pending) > 0 {
-		klog.V(5).Infof("Informers pending synchronization: %v", pending)
+		w.logger.V(5).Info("Informers pending synchronization", "pending", pending)
 		return false
 	}
 	return true
@@ -258,7 +267,7 @@ func (w *ObjectStatusReporter) HasSynced() bool {
 func (w *ObjectStatusReporter) startInformer(gkn GroupKindNamespace) {
 	ctx, ok := w.informerRefs[gkn].Start(w.context)
 	if !ok {
-		klog.V(5).Infof("Watch start skipped (already started): %v", gkn)
+		w.logger.V(5).Info("Watch start skipped (already started)", "target", gkn)
 		// already started
 		return
 	}
@@ -285,7 +294,7 @@ func (w *ObjectStatusReporter) startInformerWithRetry(ctx context.Context, gkn G
 			if meta.IsNoMatchError(err) {
 				// CRD (or api extension) not installed
 				// TODO: retry if CRDs are not being watched
-				klog.V(3).Infof("Watch start error (blocking until CRD is added): %v: %v", gkn, err)
+				w.logger.V(3).Info("Watch start error (blocking until CRD is added)", "target", gkn, "error", err)
 				// Cancel the parent context, which will stop the retries too.
 				w.stopInformer(gkn)
 				return
@@ -298,7 +307,7 @@ func (w *ObjectStatusReporter) startInformerWithRetry(ctx context.Context, gkn G
 			if err != nil {
 				// Reporter already stopped.
 				// This is fine. 🔥
-				klog.V(5).Infof("Informer failed to start: %v", err)
+				w.logger.V(5).Info("Informer failed to start", "error", err)
 				return
 			}
 			// Send error event and stop the reporter!
@@ -360,9 +369,9 @@ func (w *ObjectStatusReporter) startInformerNow(
 	// Start the informer in the background.
 	// Informer will be stopped when the context is cancelled.
 	go func() {
-		klog.V(3).Infof("Watch starting: %v", gkn)
+		w.logger.V(3).Info("Watch starting", "target", gkn)
 		informer.Run(ctx.Done())
-		klog.V(3).Infof("Watch stopped: %v", gkn)
+		w.logger.V(3).Info("Watch stopped", "target", gkn)
 		// Signal to the caller there will be no more events for this GroupKind.
 		close(eventCh)
 	}()
@@ -447,10 +456,10 @@ func (w *ObjectStatusReporter) eventHandler(
 		}
 		id := object.UnstructuredToObjMetadata(obj)
 		if w.ObjectFilter.Filter(obj) {
-			klog.V(7).Infof("Watch Event Skipped: AddFunc: %s", id)
+			w.logger.V(7).Info("Watch Event Skipped: AddFunc", "object", id)
 			return
 		}
-		klog.V(5).Infof("AddFunc: Computing status for object: %s", id)
+		w.logger.V(5).Info("AddFunc: Computing status", "object", id)
 
 		// cancel any scheduled status update for this object
 		w.taskManager.Cancel(id)
@@ -463,21 +472,21 @@ func (w *ObjectStatusReporter) eventHandler(
 		}
 
 		if object.IsNamespace(obj) {
-			klog.V(5).Infof("AddFunc: Namespace added: %v", id)
+			w.logger.V(5).Info("AddFunc: Namespace added", "object", id)
 			w.onNamespaceAdd(obj)
 		} else if object.IsCRD(obj) {
-			klog.V(5).Infof("AddFunc: CRD added: %v", id)
+			w.logger.V(5).Info("AddFunc: CRD added", "object", id)
 			w.onCRDAdd(obj)
 		}
 
 		if isObjectUnschedulable(rs) {
-			klog.V(5).Infof("AddFunc: object unschedulable: %v", id)
+			w.logger.V(5).Info("AddFunc: object unschedulable", "object", id)
 			// schedule delayed status update
 			w.taskManager.Schedule(ctx, id, status.ScheduleWindow,
 				w.newStatusCheckTaskFunc(ctx, eventCh, id))
 		}
 
-		klog.V(7).Infof("AddFunc: sending update event: %v", rs)
+		w.logger.V(7).Info("AddFunc: sending update event", "status", rs)
 		eventCh <- event.Event{
 			Type:     event.ResourceUpdateEvent,
 			Resource: rs,
@@ -496,10 +505,10 @@ func (w *ObjectStatusReporter) eventHandler(
 		}
 		id := object.UnstructuredToObjMetadata(obj)
 		if w.ObjectFilter.Filter(obj) {
-			klog.V(7).Infof("UpdateFunc: Watch Event Skipped: %s", id)
+			w.logger.V(7).Info("UpdateFunc: Watch Event Skipped", "object", id)
 			return
 		}
-		klog.V(5).Infof("UpdateFunc: Computing status for object: %s", id)
+		w.logger.V(5).Info("UpdateFunc: Computing status", "object", id)
 
 		// cancel any scheduled status update for this object
 		w.taskManager.Cancel(id)
@@ -512,21 +521,21 @@ func (w *ObjectStatusReporter) eventHandler(
 		}
 
 		if object.IsNamespace(obj) {
-			klog.V(5).Infof("UpdateFunc: Namespace updated: %v", id)
+			w.logger.V(5).Info("UpdateFunc: Namespace updated", "object", id)
 			w.onNamespaceUpdate(obj)
 		} else if object.IsCRD(obj) {
-			klog.V(5).Infof("UpdateFunc: CRD updated: %v", id)
+			w.logger.V(5).Info("UpdateFunc: CRD updated", "object", id)
 			w.onCRDUpdate(obj)
 		}
 
 		if isObjectUnschedulable(rs) {
-			klog.V(5).Infof("UpdateFunc: object unschedulable: %v", id)
+			w.logger.V(5).Info("UpdateFunc: object unschedulable", "object", id)
 			// schedule delayed status update
 			w.taskManager.Schedule(ctx, id, status.ScheduleWindow,
 				w.newStatusCheckTaskFunc(ctx, eventCh, id))
 		}
 
-		klog.V(7).Infof("UpdateFunc: sending update event: %v", rs)
+		w.logger.V(7).Info("UpdateFunc: sending update event", "status", rs)
 		eventCh <- event.Event{
 			Type:     event.ResourceUpdateEvent,
 			Resource: rs,
@@ -550,24 +559,24 @@ func (w *ObjectStatusReporter) eventHandler(
 		}
 		id := object.UnstructuredToObjMetadata(obj)
 		if w.ObjectFilter.Filter(obj) {
-			klog.V(7).Infof("DeleteFunc: Watch Event Skipped: %s", id)
+			w.logger.V(7).Info("DeleteFunc: Watch Event Skipped", "object", id)
 			return
 		}
-		klog.V(5).Infof("DeleteFunc: Computing status for object: %s", id)
+		w.logger.V(5).Info("DeleteFunc: Computing status", "object", id)
 
 		// cancel any scheduled status update for this object
 		w.taskManager.Cancel(id)
 
 		if object.IsNamespace(obj) {
-			klog.V(5).Infof("DeleteFunc: Namespace deleted: %v", id)
+			w.logger.V(5).Info("DeleteFunc: Namespace deleted", "object", id)
 			w.onNamespaceDelete(obj)
 		} else if object.IsCRD(obj) {
-			klog.V(5).Infof("DeleteFunc: CRD deleted: %v", id)
+			w.logger.V(5).Info("DeleteFunc: CRD deleted", "object", id)
 			w.onCRDDelete(obj)
 		}
 
 		rs := deletedStatus(id)
-		klog.V(7).Infof("DeleteFunc: sending update event: %v", rs)
+		w.logger.V(7).Info("DeleteFunc: sending update event", "status", rs)
 		eventCh <- event.Event{
 			Type:     event.ResourceUpdateEvent,
 			Resource: rs,
@@ -582,14 +591,14 @@ func (w *ObjectStatusReporter) onCRDAdd(obj *unstructured.Unstructured) {
 	gk, found := object.GetCRDGroupKind(obj)
 	if !found {
 		id := object.UnstructuredToObjMetadata(obj)
-		klog.Warningf("Invalid CRD added: missing group and/or kind: %v", id)
+		w.logger.Info("Invalid CRD added: missing group and/or kind", "object", id)
 		// Don't return an error, because this should not inturrupt the task queue.
 		// TODO: Allow non-fatal errors to be reported using a specific error type.
 		return
 	}
-	klog.V(3).Infof("CRD added for %s", gk)
+	w.logger.V(3).Info("CRD added", "groupKind", gk)
 
-	klog.V(3).Info("Resetting RESTMapper")
+	w.logger.V(3).Info("Resetting RESTMapper")
 	// Reset mapper to invalidate cache.
 	meta.MaybeResetRESTMapper(w.Mapper)
 
@@ -603,14 +612,14 @@ func (w *ObjectStatusReporter) onCRDUpdate(newObj *unstructured.Unstructured) {
 	gk, found := object.GetCRDGroupKind(newObj)
 	if !found {
 		id := object.UnstructuredToObjMetadata(newObj)
-		klog.Warningf("Invalid CRD updated: missing group and/or kind: %v", id)
+		w.logger.Info("Invalid CRD updated: missing group and/or kind", "object", id)
 		// Don't return an error, because this should not inturrupt the task queue.
 		// TODO: Allow non-fatal errors to be reported using a specific error type.
 		return
 	}
-	klog.V(3).Infof("CRD updated for %s", gk)
+	w.logger.V(3).Info("CRD updated", "groupKind", gk)
 
-	klog.V(3).Info("Resetting RESTMapper")
+	w.logger.V(3).Info("Resetting RESTMapper")
 	// Reset mapper to invalidate cache.
 	meta.MaybeResetRESTMapper(w.Mapper)
 
@@ -624,18 +633,18 @@ func (w *ObjectStatusReporter) onCRDDelete(oldObj *unstructured.Unstructured) {
 	gk, found := object.GetCRDGroupKind(oldObj)
 	if !found {
 		id := object.UnstructuredToObjMetadata(oldObj)
-		klog.Warningf("Invalid CRD deleted: missing group and/or kind: %v", id)
+		w.logger.Info("Invalid CRD deleted: missing group and/or kind", "object", id)
 		// Don't return an error, because this should not inturrupt the task queue.
 		// TODO: Allow non-fatal errors to be reported using a specific error type.
 		return
 	}
-	klog.V(3).Infof("CRD deleted for %s", gk)
+	w.logger.V(3).Info("CRD deleted", "groupKind", gk)
 
 	w.forEachTargetWithGroupKind(gk, func(gkn GroupKindNamespace) {
 		w.stopInformer(gkn)
 	})
 
-	klog.V(3).Info("Resetting RESTMapper")
+	w.logger.V(3).Info("Resetting RESTMapper")
 	// Reset mapper to invalidate cache.
 	meta.MaybeResetRESTMapper(w.Mapper)
 }
@@ -693,7 +702,7 @@ func (w *ObjectStatusReporter) newStatusCheckTaskFunc(
 	id object.ObjMetadata,
 ) taskFunc {
 	return func() {
-		klog.V(5).Infof("Re-reading object status: %s", status.ScheduleWindow, id)
+		w.logger.V(5).Info("Re-reading object status", "object", id)
 		// check again
 		rs, err := w.readStatusFromCluster(ctx, id)
 		if err != nil {
@@ -710,7 +719,7 @@ func (w *ObjectStatusReporter) newStatusCheckTaskFunc(
 }
 
 func (w *ObjectStatusReporter) handleFatalError(eventCh chan<- event.Event, err error) {
-	klog.V(5).Infof("Reporter error: %v", err)
+	w.logger.V(5).Info("Reporter error", "error", err)
 	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
 		return
 	}
@@ -728,40 +737,40 @@ func (w *ObjectStatusReporter) watchErrorHandler(gkn GroupKindNamespace, eventCh
 	switch {
 	// Stop channel closed
 	case err == io.EOF:
-		klog.V(5).Infof("ListAndWatch error (termination expected): %v: %v", gkn, err)
+		w.logger.V(5).Info("ListAndWatch error (termination expected)", "target", gkn, "error", err)
 
 	// Watch connection closed
 	case err == io.ErrUnexpectedEOF:
-		klog.V(1).Infof("ListAndWatch error (retry expected): %v: %v", gkn, err)
+		w.logger.V(1).Info("ListAndWatch error (retry expected)", "target", gkn, "error", err)
 
 	// Context done
 	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
-		klog.V(5).Infof("ListAndWatch error (termination expected): %v: %v", gkn, err)
+		w.logger.V(5).Info("ListAndWatch error (termination expected)", "target", gkn, "error", err)
 
 	// resourceVersion too old
 	case apierrors.IsResourceExpired(err):
 		// Keep retrying
-		klog.V(5).Infof("ListAndWatch error (retry expected): %v: %v", gkn, err)
+		w.logger.V(5).Info("ListAndWatch error (retry expected)", "target", gkn, "error", err)
 
 	// Resource unregistered (DEPRECATED, see NotFound)
 	case apierrors.IsGone(err):
-		klog.V(5).Infof("ListAndWatch error (retry expected): %v: %v", gkn, err)
+		w.logger.V(5).Info("ListAndWatch error (retry expected)", "target", gkn, "error", err)
 
 	// Resource not registered
 	case apierrors.IsNotFound(err):
-		klog.V(3).Infof("ListAndWatch error (termination expected): %v: stopping all informers for this GroupKind: %v", gkn, err)
+		w.logger.V(3).Info("ListAndWatch error (termination expected): stopping all informers for this GroupKind", "target", gkn, "error", err)
 		w.forEachTargetWithGroupKind(gkn.GroupKind(), func(gkn GroupKindNamespace) {
 			w.stopInformer(gkn)
 		})
 
 	// Insufficient permissions
 	case apierrors.IsForbidden(err):
-		klog.V(3).Infof("ListAndWatch error (termination expected): %v: stopping all informers: %v", gkn, err)
+		w.logger.V(3).Info("ListAndWatch error (termination expected): stopping all informers", "target", gkn, "error", err)
 		w.handleFatalError(eventCh, err)
 
 	// Unexpected error
 	default:
-		klog.Warningf("ListAndWatch error (retry expected): %v: %v", gkn, err)
+		w.logger.Info("ListAndWatch error (retry expected)", "target", gkn, "error", err)
 	}
 }
 
@@ -862,14 +871,14 @@ func (tm *taskManager) Schedule(parentCtx context.Context, id object.ObjMetadata
 	tm.cancelFuncs[id] = cancel
 
 	go func() {
-		klog.V(5).Infof("Task scheduled (%v) for object (%s)", delay, id)
+		klog.FromContext(parentCtx).V(5).Info("Task scheduled", "delay", delay, "object", id)
 		select {
 		case <-parentCtx.Done():
 			// stop waiting
 			cancel()
 		case <-taskCtx.Done():
 			if taskCtx.Err() == context.DeadlineExceeded {
-				klog.V(5).Infof("Task executing (after %v) for object (%v)", delay, id)
+				klog.FromContext(parentCtx).V(5).Info("Task executing", "delay", delay, "object", id)
 				task()
 			}
 			// else stop waiting
@@ -6,6 +6,7 @@ package watcher
 import (
 	"context"
 
+	"sigs.k8s.io/cli-utils/pkg/kstatus/metrics"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
@@ -26,6 +27,14 @@ type Options struct {
 	// RESTScopeStrategy specifies which strategy to use when listing and
 	// watching resources. By default, the strategy is selected automatically.
 	RESTScopeStrategy RESTScopeStrategy
+
+	// Metrics records the requests to the cluster, the watch restarts and
+	// the number of events waiting to be received. Optional.
+	Metrics metrics.Recorder
+
+	// Filters select the objects to watch. Events are only sent for the
+	// objects selected by all the filters. Optional.
+	Filters []event.ResourceFilter
 }
 
 //go:generate stringer -type=RESTScopeStrategy -linecomment
@@ -4,9 +4,12 @@
 package manifestreader
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -45,6 +48,19 @@ func (e *NamespaceMismatchError) Error() string {
 		e.Namespace, e.RequiredNamespace)
 }
 
+// MissingNamespaceError is returned if the NamespacePolicy of a namespaced
+// resource is NamespacePolicyError, and the resource doesn't have the
+// namespace set.
+type MissingNamespaceError struct {
+	GroupKind schema.GroupKind
+	Name      string
+}
+
+func (e *MissingNamespaceError) Error() string {
+	return fmt.Sprintf("namespace-scoped resource %s %q must have the namespace set",
+		e.GroupKind, e.Name)
+}
+
 // SetNamespaces verifies that every namespaced resource has the namespace
 // set, and if one does not, it will set the namespace to the provided
 // defaultNamespace.
@@ -54,6 +70,16 @@ func (e *NamespaceMismatchError) Error() string {
 // it will look for CRDs in the provided Unstructureds.
 func SetNamespaces(mapper meta.RESTMapper, objs []*unstructured.Unstructured,
 	defaultNamespace string, enforceNamespace bool) error {
+	return ReaderOptions{
+		Mapper:           mapper,
+		Namespace:        defaultNamespace,
+		EnforceNamespace: enforceNamespace,
+	}.setNamespaces(objs)
+}
+
+// setNamespaces is like SetNamespaces, but handles resources without the
+// namespace set according to the NamespacePolicy of the options.
+func (o ReaderOptions) setNamespaces(objs []*unstructured.Unstructured) error {
 	var crdObjs []*unstructured.Unstructured
 
 	// find any crds in the set of resources.
@@ -63,6 +89,12 @@ func SetNamespaces(mapper meta.RESTMapper, objs []*unstructured.Unstructured,
 		}
 	}
 
+	return o.setNamespacesWithCRDs(objs, crdObjs)
+}
+
+// setNamespacesWithCRDs is like setNamespaces, but looks up the scope of
+// types that are not in the RESTMapper in the provided CRDs.
+func (o ReaderOptions) setNamespacesWithCRDs(objs, crdObjs []*unstructured.Unstructured) error {
 	var unknownGVKs []schema.GroupVersionKind
 	for _, obj := range objs {
 		// Exclude any inventory objects here since we don't want to change
@@ -71,9 +103,21 @@ func SetNamespaces(mapper meta.RESTMapper, objs []*unstructured.Unstructured,
 			continue
 		}
 
+		ns := obj.GetNamespace()
+		gk := obj.GroupVersionKind().GroupKind()
+		policy := o.namespacePolicy(gk)
+		if policy == NamespacePolicyUnset {
+			// The scope is not needed, so leave it to the RESTMapper used
+			// when the resource is applied.
+			if err := o.checkNamespace(ns); err != nil {
+				return err
+			}
+			continue
+		}
+
 		// Look up the scope of the resource so we know if the resource
 		// should have a namespace set or not.
-		scope, err := object.LookupResourceScope(obj, crdObjs, mapper)
+		scope, err := object.LookupResourceScope(obj, crdObjs, o.Mapper)
 		if err != nil {
 			var unknownTypeError *object.UnknownTypeError
 			if errors.As(err, &unknownTypeError) {
@@ -89,19 +133,25 @@ func SetNamespaces(mapper meta.RESTMapper, objs []*unstructured.Unstructured,
 
 		switch scope {
 		case meta.RESTScopeNamespace:
-			if obj.GetNamespace() == "" {
-				obj.SetNamespace(defaultNamespace)
-			} else {
-				ns := obj.GetNamespace()
-				if enforceNamespace && ns != defaultNamespace {
-					return &NamespaceMismatchError{
-						Namespace:         ns,
-						RequiredNamespace: defaultNamespace,
-					}
+			if ns != "" {
+				if err := o.checkNamespace(ns); err != nil {
+					return err
 				}
+				continue
+			}
+			switch policy {
+			case NamespacePolicyInject:
+				obj.SetNamespace(o.Namespace)
+			case NamespacePolicyError:
+				return object.WithSource(obj, &MissingNamespaceError{
+					GroupKind: gk,
+					Name:      obj.GetName(),
+				})
+			default:
+				return fmt.Errorf("unknown namespace policy %q", policy)
 			}
 		case meta.RESTScopeRoot:
-			if ns := obj.GetNamespace(); ns != "" {
+			if ns != "" {
 				return fmt.Errorf("resource is cluster-scoped but has a non-empty namespace %q", ns)
 			}
 		default:
@@ -116,6 +166,30 @@ func SetNamespaces(mapper meta.RESTMapper, objs []*unstructured.Unstructured,
 	return nil
 }
 
+// checkNamespace returns a NamespaceMismatchError if the namespace is set
+// and must be the Namespace of the options, but is not.
+func (o ReaderOptions) checkNamespace(ns string) error {
+	if ns != "" && o.EnforceNamespace && ns != o.Namespace {
+		return &NamespaceMismatchError{
+			Namespace:         ns,
+			RequiredNamespace: o.Namespace,
+		}
+	}
+	return nil
+}
+
+// namespacePolicy returns the NamespacePolicy for resources of the
+// GroupKind.
+func (o ReaderOptions) namespacePolicy(gk schema.GroupKind) NamespacePolicy {
+	if policy, found := o.NamespacePolicyOverrides[gk]; found {
+		return policy
+	}
+	if o.NamespacePolicy == "" {
+		return NamespacePolicyInject
+	}
+	return o.NamespacePolicy
+}
+
 // FilterLocalConfig returns a new slice of Unstructured where all resources
 // with the LocalConfig annotation is filtered out.
 func FilterLocalConfig(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
@@ -142,6 +216,49 @@ func RemoveAnnotations(n *yaml.RNode, annotations ...kioutil.AnnotationKey) erro
 	return nil
 }
 
+// setSourceLines sets the line where each resource starts in data, the
+// content of the file the resources were read from, in order, so errors
+// about the resources can refer to them. If the documents don't match the
+// resources, like when a List was unwrapped, no lines are set.
+func setSourceLines(data []byte, nodes []*yaml.RNode) error {
+	lines, err := documentLines(data)
+	if err != nil {
+		return err
+	}
+	if len(lines) != len(nodes) {
+		return nil
+	}
+	for i, n := range nodes {
+		err := n.PipeE(yaml.SetAnnotation(object.SourceLineAnnotation, strconv.Itoa(lines[i])))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// documentLines returns the line where each non-empty YAML document in
+// data starts. kio.ByteReader parses each document separately, so the
+// lines of the nodes it returns are relative to their document.
+func documentLines(data []byte) ([]int, error) {
+	var lines []int
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		err := decoder.Decode(&doc)
+		if errors.Is(err, io.EOF) {
+			return lines, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Tag == yaml.NodeTagNull {
+			continue
+		}
+		lines = append(lines, doc.Content[0].Line)
+	}
+}
+
 // KyamlNodeToUnstructured take a resource represented as a kyaml RNode and
 // turns it into an Unstructured object.
 func KyamlNodeToUnstructured(n *yaml.RNode) (*unstructured.Unstructured, error) {
@@ -190,6 +190,96 @@ func TestSetNamespaces(t *testing.T) {
 	}
 }
 
+func TestSetNamespaces_Policy(t *testing.T) {
+	deploymentGVK := schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}
+	customGVK := schema.GroupVersionKind{Group: "custom.io", Version: "v1", Kind: "Custom"}
+
+	testCases := map[string]struct {
+		objs      []*unstructured.Unstructured
+		policy    NamespacePolicy
+		overrides map[schema.GroupKind]NamespacePolicy
+
+		expectedNamespaces []string
+		expectedErr        string
+	}{
+		"inject by default": {
+			objs:               []*unstructured.Unstructured{toUnstructured(deploymentGVK, "")},
+			expectedNamespaces: []string{"foo"},
+		},
+		"error on missing namespace": {
+			objs:        []*unstructured.Unstructured{toUnstructured(deploymentGVK, "")},
+			policy:      NamespacePolicyError,
+			expectedErr: `namespace-scoped resource Deployment.apps "" must have the namespace set`,
+		},
+		"error doesn't apply to resources with namespace": {
+			objs:               []*unstructured.Unstructured{toUnstructured(deploymentGVK, "foo")},
+			policy:             NamespacePolicyError,
+			expectedNamespaces: []string{"foo"},
+		},
+		"unset doesn't look up unknown types": {
+			objs: []*unstructured.Unstructured{
+				toUnstructured(customGVK, ""),
+				toUnstructured(deploymentGVK, ""),
+			},
+			policy:             NamespacePolicyUnset,
+			expectedNamespaces: []string{"", ""},
+		},
+		"unset override for a type": {
+			objs: []*unstructured.Unstructured{
+				toUnstructured(customGVK, ""),
+				toUnstructured(deploymentGVK, ""),
+			},
+			overrides: map[schema.GroupKind]NamespacePolicy{
+				customGVK.GroupKind(): NamespacePolicyUnset,
+			},
+			expectedNamespaces: []string{"", "foo"},
+		},
+		"inject override for a type": {
+			objs: []*unstructured.Unstructured{
+				toUnstructured(deploymentGVK, ""),
+			},
+			policy: NamespacePolicyError,
+			overrides: map[schema.GroupKind]NamespacePolicy{
+				deploymentGVK.GroupKind(): NamespacePolicyInject,
+			},
+			expectedNamespaces: []string{"foo"},
+		},
+		"unset still enforces the namespace": {
+			objs:        []*unstructured.Unstructured{toUnstructured(customGVK, "bar")},
+			policy:      NamespacePolicyUnset,
+			expectedErr: `found namespace "bar", but all resources must be in namespace "foo"`,
+		},
+		"unknown policy": {
+			objs:        []*unstructured.Unstructured{toUnstructured(deploymentGVK, "")},
+			policy:      "default",
+			expectedErr: `unknown namespace policy "default"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			err := ReaderOptions{
+				Mapper:                   newStreamingTestMapper(t),
+				Namespace:                "foo",
+				EnforceNamespace:         true,
+				NamespacePolicy:          tc.policy,
+				NamespacePolicyOverrides: tc.overrides,
+			}.setNamespaces(tc.objs)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var namespaces []string
+			for _, obj := range tc.objs {
+				namespaces = append(namespaces, obj.GetNamespace())
+			}
+			assert.Equal(t, tc.expectedNamespaces, namespaces)
+		})
+	}
+}
+
 var (
 	depID = object.ObjMetadata{
 		GroupKind: schema.GroupKind{
@@ -0,0 +1,105 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+)
+
+// KustomizeManifestReader implements ManifestReader interface.
+var _ ManifestReader = &KustomizeManifestReader{}
+
+// KustomizeManifestReader builds the kustomization in the provided path
+// and returns the rendered manifests. The returned objects have the
+// path annotation set to the file they originated from, if the
+// kustomization enables origin annotations, or to the kustomization file
+// otherwise, so errors can refer to the source of an object.
+type KustomizeManifestReader struct {
+	Path string
+
+	// FileSystem is the file system the kustomization is read from.
+	// Defaults to the local disk.
+	FileSystem filesys.FileSystem
+
+	ReaderOptions
+}
+
+// Read builds the kustomization and returns the rendered manifests.
+func (k *KustomizeManifestReader) Read() ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	fSys := k.FileSystem
+	if fSys == nil {
+		fSys = filesys.MakeFsOnDisk()
+	}
+
+	kustomizationFile, found := FindKustomization(fSys, k.Path)
+	if !found {
+		return objs, fmt.Errorf("no kustomization file found in %q", k.Path)
+	}
+
+	resMap, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(fSys, k.Path)
+	if err != nil {
+		return objs, fmt.Errorf("building kustomization %q: %w", k.Path, err)
+	}
+
+	for _, res := range resMap.Resources() {
+		source := kustomizationFile
+		origin, err := res.GetOrigin()
+		if err != nil {
+			return objs, err
+		}
+		if origin != nil && origin.Path != "" && origin.Repo == "" {
+			source = filepath.Join(k.Path, origin.Path)
+		}
+
+		m, err := res.Map()
+		if err != nil {
+			return objs, err
+		}
+		u := &unstructured.Unstructured{Object: m}
+		annos := u.GetAnnotations()
+		if annos == nil {
+			annos = make(map[string]string)
+		}
+		annos[kioutil.PathAnnotation] = source
+		u.SetAnnotations(annos)
+		objs = append(objs, u)
+	}
+
+	objs = FilterLocalConfig(objs)
+
+	err = k.setNamespaces(objs)
+	return objs, err
+}
+
+// FindKustomization returns the path to the kustomization file in the
+// directory, and true if one is found.
+func FindKustomization(fSys filesys.FileSystem, dir string) (string, bool) {
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		path := filepath.Join(dir, name)
+		if fSys.Exists(path) && !fSys.IsDir(path) {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// isKustomization returns true if the path is a directory with a
+// kustomization file on the local disk.
+func isKustomization(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+	_, found := FindKustomization(filesys.MakeFsOnDisk(), path)
+	return found
+}
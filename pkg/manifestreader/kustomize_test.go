@@ -0,0 +1,109 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+)
+
+func TestKustomizeManifestReader_Read(t *testing.T) {
+	testCases := map[string]struct {
+		files     map[string]string
+		namespace string
+
+		expectedErr       string
+		expectedNames     []string
+		expectedNamespace string
+		expectedPaths     []string
+	}{
+		"kustomization with namespace": {
+			files: map[string]string{
+				"kustomization.yaml": `
+namespace: bar
+resources:
+- dep.yaml
+- cm.yaml
+`,
+				"dep.yaml": depManifest,
+				"cm.yaml":  cmManifest,
+			},
+			namespace: "foo",
+
+			expectedNames:     []string{"dep", "cm"},
+			expectedNamespace: "bar",
+			expectedPaths:     []string{"kustomization.yaml", "kustomization.yaml"},
+		},
+		"origin annotations set the source path": {
+			files: map[string]string{
+				"kustomization.yaml": `
+buildMetadata: [originAnnotations]
+resources:
+- dep.yaml
+`,
+				"dep.yaml": depManifest,
+			},
+			namespace: "foo",
+
+			expectedNames:     []string{"dep"},
+			expectedNamespace: "foo",
+			expectedPaths:     []string{"dep.yaml"},
+		},
+		"missing kustomization": {
+			files: map[string]string{
+				"dep.yaml": depManifest,
+			},
+			expectedErr: `no kustomization file found in "/pkg"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+			defer tf.Cleanup()
+
+			mapper, err := tf.ToRESTMapper()
+			require.NoError(t, err)
+
+			dir := "/pkg"
+			fSys := filesys.MakeFsInMemory()
+			for filename, content := range tc.files {
+				require.NoError(t, fSys.WriteFile(filepath.Join(dir, filename), []byte(content)))
+			}
+
+			objs, err := (&KustomizeManifestReader{
+				Path:       dir,
+				FileSystem: fSys,
+				ReaderOptions: ReaderOptions{
+					Mapper:    mapper,
+					Namespace: tc.namespace,
+				},
+			}).Read()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var names, paths []string
+			for _, obj := range objs {
+				names = append(names, obj.GetName())
+				assert.Equal(t, tc.expectedNamespace, obj.GetNamespace())
+				paths = append(paths, obj.GetAnnotations()[kioutil.PathAnnotation])
+			}
+			assert.Equal(t, tc.expectedNames, names)
+			var expectedPaths []string
+			for _, p := range tc.expectedPaths {
+				expectedPaths = append(expectedPaths, filepath.Join(dir, p))
+			}
+			assert.Equal(t, expectedPaths, paths)
+		})
+	}
+}
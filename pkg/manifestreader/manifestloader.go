@@ -17,7 +17,8 @@ type ManifestLoader interface {
 
 // manifestLoader implements the ManifestLoader interface
 type manifestLoader struct {
-	factory util.Factory
+	factory    util.Factory
+	setOptions func(*ReaderOptions)
 }
 
 // NewManifestLoader returns an instance of manifestLoader.
@@ -27,6 +28,17 @@ func NewManifestLoader(f util.Factory) ManifestLoader {
 	}
 }
 
+// NewManifestLoaderWithOptions returns a manifestLoader that calls
+// setOptions on the ReaderOptions of every reader it returns, after the
+// mapper and namespace are set, for example to set the HTTPTimeout,
+// HTTPProxy, and Checksum of the remote readers from flags.
+func NewManifestLoaderWithOptions(f util.Factory, setOptions func(*ReaderOptions)) ManifestLoader {
+	return &manifestLoader{
+		factory:    f,
+		setOptions: setOptions,
+	}
+}
+
 func (f *manifestLoader) ManifestReader(reader io.Reader, path string) (ManifestReader, error) {
 	// Fetch the namespace from the configloader. The source of this
 	// either the namespace flag or the context. If the namespace is provided
@@ -48,6 +60,9 @@ func (f *manifestLoader) ManifestReader(reader io.Reader, path string) (Manifest
 		Namespace:        namespace,
 		EnforceNamespace: enforceNamespace,
 	}
+	if f.setOptions != nil {
+		f.setOptions(&readerOptions)
+	}
 
 	return mReader(path, reader, readerOptions), nil
 }
@@ -62,6 +77,21 @@ func mReader(path string, reader io.Reader, readerOptions ReaderOptions) Manifes
 			Reader:        reader,
 			ReaderOptions: readerOptions,
 		}
+	} else if IsOCIReference(path) {
+		mReader = &OCIManifestReader{
+			Reference:     path,
+			ReaderOptions: readerOptions,
+		}
+	} else if IsURL(path) {
+		mReader = &URLManifestReader{
+			URL:           path,
+			ReaderOptions: readerOptions,
+		}
+	} else if isKustomization(path) {
+		mReader = &KustomizeManifestReader{
+			Path:          path,
+			ReaderOptions: readerOptions,
+		}
 	} else {
 		mReader = &PathManifestReader{
 			Path:          path,
@@ -70,3 +100,10 @@ func mReader(path string, reader io.Reader, readerOptions ReaderOptions) Manifes
 	}
 	return mReader
 }
+
+// IsRemotePath returns true if the path refers to manifests that are
+// fetched over the network, like an OCI artifact or an https URL, rather
+// than a local directory or stdin.
+func IsRemotePath(path string) bool {
+	return IsOCIReference(path) || IsURL(path)
+}
@@ -8,8 +8,10 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
 )
 
@@ -77,3 +79,20 @@ func TestMReader_Read(t *testing.T) {
 		})
 	}
 }
+
+func TestManifestLoaderWithOptions(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+	defer tf.Cleanup()
+
+	loader := NewManifestLoaderWithOptions(tf, func(o *ReaderOptions) {
+		o.Checksum = "sha256:1234"
+		o.HTTPTimeout = time.Second
+	})
+	reader, err := loader.ManifestReader(nil, "https://example.com/release.yaml")
+	require.NoError(t, err)
+	urlReader, ok := reader.(*URLManifestReader)
+	require.True(t, ok)
+	assert.Equal(t, "test-ns", urlReader.Namespace)
+	assert.Equal(t, "sha256:1234", urlReader.Checksum)
+	assert.Equal(t, time.Second, urlReader.HTTPTimeout)
+}
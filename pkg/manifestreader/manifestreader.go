@@ -4,8 +4,15 @@
 package manifestreader
 
 import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 // ManifestReader defines the interface for reading a set
@@ -21,4 +28,98 @@ type ReaderOptions struct {
 	Validate         bool
 	Namespace        string
 	EnforceNamespace bool
+
+	// NamespacePolicy defines how namespaced resources without the
+	// namespace set are handled. Defaults to NamespacePolicyInject.
+	NamespacePolicy NamespacePolicy
+	// NamespacePolicyOverrides overrides the NamespacePolicy for resources
+	// of specific types.
+	NamespacePolicyOverrides map[schema.GroupKind]NamespacePolicy
+
+	// HTTPTimeout is the timeout of requests made by the readers that
+	// fetch manifests over the network. Zero means DefaultHTTPTimeout.
+	HTTPTimeout time.Duration
+	// HTTPProxy is the URL of the proxy used by the readers that fetch
+	// manifests over the network. If empty, the proxy is taken from the
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY environment variables.
+	HTTPProxy string
+	// MaxRemoteSize is the maximum size in bytes of the content fetched by
+	// the readers that fetch manifests over the network, before and after
+	// it is decompressed. Zero means DefaultMaxRemoteSize.
+	MaxRemoteSize int64
+	// Checksum is the expected SHA256 checksum of the content fetched by
+	// the URLManifestReader, as a hex string with an optional "sha256:"
+	// prefix. If empty, the content is not verified.
+	Checksum string
+}
+
+const (
+	// DefaultHTTPTimeout is the default timeout of the requests made by the
+	// readers that fetch manifests over the network.
+	DefaultHTTPTimeout = 2 * time.Minute
+	// DefaultMaxRemoteSize is the default maximum size of the content
+	// fetched by the readers that fetch manifests over the network.
+	DefaultMaxRemoteSize int64 = 64 << 20
+)
+
+// NamespacePolicy defines how the readers handle namespaced resources that
+// don't have the namespace set.
+type NamespacePolicy string
+
+const (
+	// NamespacePolicyInject sets the namespace of the resources to the
+	// Namespace of the ReaderOptions.
+	NamespacePolicyInject NamespacePolicy = "inject"
+	// NamespacePolicyError returns a MissingNamespaceError for the first
+	// resource without the namespace set.
+	NamespacePolicyError NamespacePolicy = "error"
+	// NamespacePolicyUnset leaves the namespace unset without looking up
+	// the scope of the resources, which is then detected with the
+	// RESTMapper when they are applied. This allows reading cluster-scoped
+	// custom resources whose CRD is not installed yet and not in the
+	// manifests.
+	NamespacePolicyUnset NamespacePolicy = "unset"
+)
+
+// httpClient returns an HTTP client configured with the timeout and proxy
+// of the options.
+func (o ReaderOptions) httpClient() (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if o.HTTPProxy != "" {
+		proxyURL, err := url.Parse(o.HTTPProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", o.HTTPProxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	timeout := o.HTTPTimeout
+	if timeout == 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
+
+// maxRemoteSize returns the maximum size of the content fetched over the
+// network.
+func (o ReaderOptions) maxRemoteSize() int64 {
+	if o.MaxRemoteSize == 0 {
+		return DefaultMaxRemoteSize
+	}
+	return o.MaxRemoteSize
+}
+
+// readLimited reads the content of the reader, up to limit bytes. The name
+// of the content is used in the error returned if the content is larger.
+func readLimited(name string, r io.Reader, limit int64) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > limit {
+		return nil, fmt.Errorf("%s exceeds the maximum size of %d bytes", name, limit)
+	}
+	return data, nil
 }
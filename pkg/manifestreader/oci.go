@@ -0,0 +1,438 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// OCIScheme is the prefix of paths that are read from an OCI registry.
+const OCIScheme = "oci://"
+
+// Media types of the artifact manifests and layers supported by the
+// OCIManifestReader.
+const (
+	OCIManifestMediaType    = "application/vnd.oci.image.manifest.v1+json"
+	DockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+
+	OCILayerMediaType     = "application/vnd.oci.image.layer.v1.tar"
+	OCIGzipLayerMediaType = "application/vnd.oci.image.layer.v1.tar+gzip"
+	DockerLayerMediaType  = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+	FluxContentMediaType  = "application/vnd.cncf.flux.content.v1.tar+gzip"
+)
+
+// OCIManifestReader implements ManifestReader interface.
+var _ ManifestReader = &OCIManifestReader{}
+
+// OCIManifestReader pulls an artifact from an OCI registry and returns the
+// manifests in its layers. Layers must be tarballs, optionally gzipped, of
+// YAML files, like the artifacts pushed by flux or plain tarballs pushed
+// with oras. The returned objects have the path annotation set to the file
+// they were read from in the artifact. The manifest and layers of the
+// artifact, and the files in the layers, are limited to the MaxRemoteSize
+// of the ReaderOptions.
+type OCIManifestReader struct {
+	// Reference is the artifact to pull, like
+	// "registry.example.com/org/repo:tag" or
+	// "registry.example.com/org/repo@sha256:...", with an optional oci://
+	// prefix.
+	Reference string
+
+	// Digest is the expected digest of the artifact manifest, like
+	// "sha256:...". If empty, the digest in the Reference is used, if any.
+	Digest string
+
+	// Username and Password are used to authenticate with the registry,
+	// if set. Otherwise the artifact is pulled anonymously.
+	Username string
+	Password string
+
+	// PlainHTTP pulls the artifact over http instead of https.
+	PlainHTTP bool
+
+	// Client is the HTTP client used to talk to the registry.
+	// Defaults to a client configured with the HTTPTimeout and HTTPProxy of
+	// the ReaderOptions.
+	Client *http.Client
+
+	ReaderOptions
+}
+
+// ociReference is a parsed artifact reference.
+type ociReference struct {
+	Registry   string
+	Repository string
+	// Reference is the tag or digest of the artifact.
+	Reference string
+}
+
+// parseOCIReference parses a reference like "registry/repo:tag" or
+// "registry/repo@sha256:...". The tag defaults to "latest".
+func parseOCIReference(ref string) (ociReference, error) {
+	ref = strings.TrimPrefix(ref, OCIScheme)
+	registry, repo, found := strings.Cut(ref, "/")
+	if !found || registry == "" || repo == "" {
+		return ociReference{}, fmt.Errorf("invalid OCI reference %q: must be REGISTRY/REPOSITORY[:TAG|@DIGEST]", ref)
+	}
+	r := ociReference{
+		Registry:  registry,
+		Reference: "latest",
+	}
+	if name, digest, found := strings.Cut(repo, "@"); found {
+		repo = name
+		r.Reference = digest
+	} else if i := strings.LastIndex(repo, ":"); i > strings.LastIndex(repo, "/") {
+		r.Reference = repo[i+1:]
+		repo = repo[:i]
+	}
+	if repo == "" || r.Reference == "" {
+		return ociReference{}, fmt.Errorf("invalid OCI reference %q: must be REGISTRY/REPOSITORY[:TAG|@DIGEST]", ref)
+	}
+	r.Repository = repo
+	return r, nil
+}
+
+// ociDescriptor is the subset of an OCI content descriptor used to fetch
+// layers.
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Size      int64  `json:"size"`
+}
+
+// ociManifest is the subset of an OCI image manifest used to fetch layers.
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// DigestMismatchError is returned if the content pulled from the registry
+// doesn't match the expected digest.
+type DigestMismatchError struct {
+	Name     string
+	Expected string
+	Actual   string
+}
+
+func (e *DigestMismatchError) Error() string {
+	return fmt.Sprintf("digest mismatch for %s: expected %s, got %s", e.Name, e.Expected, e.Actual)
+}
+
+// Read pulls the artifact and returns the manifests in its layers.
+func (o *OCIManifestReader) Read() ([]*unstructured.Unstructured, error) {
+	return o.ReadContext(context.Background())
+}
+
+// ReadContext pulls the artifact like Read, cancelling the requests to the
+// registry when the context is done.
+func (o *OCIManifestReader) ReadContext(ctx context.Context) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	ref, err := parseOCIReference(o.Reference)
+	if err != nil {
+		return objs, err
+	}
+	expectedDigest := o.Digest
+	if expectedDigest == "" && strings.Contains(ref.Reference, ":") {
+		expectedDigest = ref.Reference
+	}
+
+	c := &registryClient{
+		client:   o.Client,
+		username: o.Username,
+		password: o.Password,
+		scheme:   "https",
+		maxSize:  o.maxRemoteSize(),
+	}
+	if c.client == nil {
+		c.client, err = o.httpClient()
+		if err != nil {
+			return objs, err
+		}
+	}
+	if o.PlainHTTP {
+		c.scheme = "http"
+	}
+
+	manifestBytes, err := c.get(ctx, ref, "manifests/"+ref.Reference,
+		strings.Join([]string{OCIManifestMediaType, DockerManifestMediaType}, ","))
+	if err != nil {
+		return objs, err
+	}
+	if expectedDigest != "" {
+		if err := verifyDigest(o.Reference, expectedDigest, manifestBytes); err != nil {
+			return objs, err
+		}
+	}
+	var manifest ociManifest
+	if err := json.Unmarshal(manifestBytes, &manifest); err != nil {
+		return objs, fmt.Errorf("decoding manifest of %s: %w", o.Reference, err)
+	}
+
+	var nodes []*kyaml.RNode
+	for _, layer := range manifest.Layers {
+		gzipped := false
+		switch layer.MediaType {
+		case OCIGzipLayerMediaType, DockerLayerMediaType, FluxContentMediaType:
+			gzipped = true
+		case OCILayerMediaType:
+		default:
+			// Skip layers that are not tarballs, like signatures or
+			// attestations.
+			continue
+		}
+		if layer.Size > c.maxSize {
+			return objs, fmt.Errorf("layer %s of %s exceeds the maximum size of %d bytes", layer.Digest, o.Reference, c.maxSize)
+		}
+		blob, err := c.get(ctx, ref, "blobs/"+layer.Digest, "")
+		if err != nil {
+			return objs, err
+		}
+		if err := verifyDigest(layer.Digest, layer.Digest, blob); err != nil {
+			return objs, err
+		}
+		layerNodes, err := readTarball(blob, gzipped, c.maxSize)
+		if err != nil {
+			return objs, fmt.Errorf("reading layer %s of %s: %w", layer.Digest, o.Reference, err)
+		}
+		nodes = append(nodes, layerNodes...)
+	}
+
+	for _, n := range nodes {
+		u, err := KyamlNodeToUnstructured(n)
+		if err != nil {
+			return objs, err
+		}
+		objs = append(objs, u)
+	}
+
+	objs = FilterLocalConfig(objs)
+
+	err = o.setNamespaces(objs)
+	return objs, err
+}
+
+// readTarball returns the objects in the YAML files of the tarball, with
+// the path annotation set to the path of the file in the tarball. The YAML
+// files are limited to limit bytes in total.
+func readTarball(data []byte, gzipped bool, limit int64) ([]*kyaml.RNode, error) {
+	var r io.Reader = bytes.NewReader(data)
+	if gzipped {
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			return nil, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var nodes []*kyaml.RNode
+	var total int64
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nodes, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		if ext := path.Ext(hdr.Name); ext != ".yaml" && ext != ".yml" {
+			continue
+		}
+		// The tar reader returns the size of the header, at most.
+		if total += hdr.Size; total > limit {
+			return nil, fmt.Errorf("the YAML files exceed the maximum size of %d bytes", limit)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", hdr.Name, err)
+		}
+		fileNodes, err := (&kio.ByteReader{
+			Reader: bytes.NewReader(content),
+			SetAnnotations: map[string]string{
+				kioutil.PathAnnotation: path.Clean(hdr.Name),
+			},
+			OmitReaderAnnotations: true,
+		}).Read()
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", hdr.Name, err)
+		}
+		if err := setSourceLines(content, fileNodes); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", hdr.Name, err)
+		}
+		nodes = append(nodes, fileNodes...)
+	}
+}
+
+func verifyDigest(name, expected string, data []byte) error {
+	algorithm, _, found := strings.Cut(expected, ":")
+	if !found || algorithm != "sha256" {
+		return fmt.Errorf("unsupported digest %q for %s: must be sha256", expected, name)
+	}
+	sum := sha256.Sum256(data)
+	actual := "sha256:" + hex.EncodeToString(sum[:])
+	if actual != expected {
+		return &DigestMismatchError{
+			Name:     name,
+			Expected: expected,
+			Actual:   actual,
+		}
+	}
+	return nil
+}
+
+// registryClient pulls content with the OCI distribution API. It supports
+// anonymous access, basic auth, and the bearer token flow used by most
+// public registries.
+type registryClient struct {
+	client   *http.Client
+	username string
+	password string
+	scheme   string
+	token    string
+	// maxSize is the maximum size of the content pulled from the registry.
+	maxSize int64
+}
+
+func (c *registryClient) get(ctx context.Context, ref ociReference, resource, accept string) ([]byte, error) {
+	u := fmt.Sprintf("%s://%s/v2/%s/%s", c.scheme, ref.Registry, ref.Repository, resource)
+	resp, err := c.do(ctx, u, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		resp.Body.Close()
+		if err := c.authenticate(ctx, challenge); err != nil {
+			return nil, fmt.Errorf("authenticating with %s: %w", ref.Registry, err)
+		}
+		resp, err = c.do(ctx, u, accept)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching %s: %s", u, resp.Status)
+	}
+	data, err := readLimited("content", resp.Body, c.maxSize)
+	if err != nil {
+		return nil, fmt.Errorf("fetching %s: %w", u, err)
+	}
+	return data, nil
+}
+
+func (c *registryClient) do(ctx context.Context, u, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	switch {
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	case c.username != "":
+		req.SetBasicAuth(c.username, c.password)
+	}
+	return c.client.Do(req)
+}
+
+// authenticate fetches a bearer token for the challenge returned by the
+// registry.
+func (c *registryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("unsupported authentication challenge %q", challenge)
+	}
+	attrs := parseChallengeParams(params)
+	realm := attrs["realm"]
+	if realm == "" {
+		return fmt.Errorf("missing realm in authentication challenge %q", challenge)
+	}
+	q := url.Values{}
+	if service := attrs["service"]; service != "" {
+		q.Set("service", service)
+	}
+	if scope := attrs["scope"]; scope != "" {
+		q.Set("scope", scope)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm+"?"+q.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.password)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("fetching token: %s", resp.Status)
+	}
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return fmt.Errorf("decoding token: %w", err)
+	}
+	c.token = body.Token
+	if c.token == "" {
+		c.token = body.AccessToken
+	}
+	if c.token == "" {
+		return errors.New("empty token")
+	}
+	return nil
+}
+
+// parseChallengeParams parses the comma-separated key="value" parameters
+// of a WWW-Authenticate challenge.
+func parseChallengeParams(params string) map[string]string {
+	attrs := make(map[string]string)
+	for params != "" {
+		var key, value string
+		key, params, _ = strings.Cut(params, "=")
+		key = strings.TrimSpace(key)
+		if strings.HasPrefix(params, `"`) {
+			value, params, _ = strings.Cut(params[1:], `"`)
+			params = strings.TrimPrefix(params, ",")
+		} else {
+			value, params, _ = strings.Cut(params, ",")
+		}
+		attrs[key] = value
+	}
+	return attrs
+}
+
+// IsOCIReference returns true if the path refers to an artifact in an OCI
+// registry, rather than a local directory or stdin.
+func IsOCIReference(path string) bool {
+	return strings.HasPrefix(path, OCIScheme)
+}
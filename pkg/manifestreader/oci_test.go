@@ -0,0 +1,248 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+)
+
+func TestParseOCIReference(t *testing.T) {
+	testCases := map[string]struct {
+		ref         string
+		expected    ociReference
+		expectedErr string
+	}{
+		"tag": {
+			ref: "oci://registry.example.com/org/repo:v1",
+			expected: ociReference{
+				Registry:   "registry.example.com",
+				Repository: "org/repo",
+				Reference:  "v1",
+			},
+		},
+		"default tag with registry port": {
+			ref: "localhost:5000/repo",
+			expected: ociReference{
+				Registry:   "localhost:5000",
+				Repository: "repo",
+				Reference:  "latest",
+			},
+		},
+		"digest": {
+			ref: "registry.example.com/repo@sha256:abc",
+			expected: ociReference{
+				Registry:   "registry.example.com",
+				Repository: "repo",
+				Reference:  "sha256:abc",
+			},
+		},
+		"missing repository": {
+			ref:         "oci://registry.example.com",
+			expectedErr: `invalid OCI reference "registry.example.com": must be REGISTRY/REPOSITORY[:TAG|@DIGEST]`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ref, err := parseOCIReference(tc.ref)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ref)
+		})
+	}
+}
+
+func TestOCIManifestReader_Read(t *testing.T) {
+	layer := tarball(t, map[string]string{
+		"manifests/dep.yaml": depManifest,
+		"manifests/cm.yaml":  cmManifest,
+		"README.md":          "not a manifest",
+	})
+	layerDigest := digestOf(layer)
+	manifest, err := json.Marshal(ociManifest{
+		MediaType: OCIManifestMediaType,
+		Layers: []ociDescriptor{
+			{
+				MediaType: FluxContentMediaType,
+				Digest:    layerDigest,
+				Size:      int64(len(layer)),
+			},
+		},
+	})
+	require.NoError(t, err)
+	manifestDigest := digestOf(manifest)
+
+	testCases := map[string]struct {
+		reference   string
+		digest      string
+		requireAuth bool
+		maxSize     int64
+
+		expectedErr   string
+		expectedNames []string
+	}{
+		"pull by tag": {
+			reference:     "repo:v1",
+			expectedNames: []string{"cm", "dep"},
+		},
+		"pull by digest": {
+			reference:     "repo@" + manifestDigest,
+			expectedNames: []string{"cm", "dep"},
+		},
+		"pull with bearer token": {
+			reference:     "repo:v1",
+			requireAuth:   true,
+			expectedNames: []string{"cm", "dep"},
+		},
+		"digest mismatch": {
+			reference:   "repo:v1",
+			digest:      "sha256:0000",
+			expectedErr: "digest mismatch for oci://%s/repo:v1: expected sha256:0000, got " + manifestDigest,
+		},
+		"manifest too large": {
+			reference:   "repo:v1",
+			maxSize:     100,
+			expectedErr: "fetching http://%s/v2/repo/manifests/v1: content exceeds the maximum size of 100 bytes",
+		},
+		"layer too large": {
+			reference: "repo:v1",
+			maxSize:   int64(len(layer) - 1),
+			expectedErr: fmt.Sprintf("layer %s of oci://%%s/repo:v1 exceeds the maximum size of %d bytes",
+				layerDigest, len(layer)-1),
+		},
+		"unknown tag": {
+			reference:   "repo:v2",
+			expectedErr: "fetching http://%s/v2/repo/manifests/v2: 404 Not Found",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/token" {
+					_, _ = fmt.Fprint(w, `{"token":"secret"}`)
+					return
+				}
+				if tc.requireAuth && r.Header.Get("Authorization") != "Bearer secret" {
+					w.Header().Set("WWW-Authenticate",
+						fmt.Sprintf(`Bearer realm="%s/token",service="test",scope="repository:repo:pull"`, server.URL))
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				switch r.URL.Path {
+				case "/v2/repo/manifests/v1", "/v2/repo/manifests/" + manifestDigest:
+					w.Header().Set("Content-Type", OCIManifestMediaType)
+					_, _ = w.Write(manifest)
+				case "/v2/repo/blobs/" + layerDigest:
+					_, _ = w.Write(layer)
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+			host := strings.TrimPrefix(server.URL, "http://")
+
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+			defer tf.Cleanup()
+			mapper, err := tf.ToRESTMapper()
+			require.NoError(t, err)
+
+			objs, err := (&OCIManifestReader{
+				Reference: OCIScheme + host + "/" + tc.reference,
+				Digest:    tc.digest,
+				PlainHTTP: true,
+				ReaderOptions: ReaderOptions{
+					Mapper:        mapper,
+					Namespace:     "foo",
+					MaxRemoteSize: tc.maxSize,
+				},
+			}).Read()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, fmt.Sprintf(tc.expectedErr, host))
+				return
+			}
+			require.NoError(t, err)
+
+			var names []string
+			for _, obj := range objs {
+				names = append(names, obj.GetName())
+				assert.Equal(t, "foo", obj.GetNamespace())
+				assert.Equal(t, "manifests/"+obj.GetName()+".yaml",
+					obj.GetAnnotations()[kioutil.PathAnnotation])
+			}
+			assert.ElementsMatch(t, tc.expectedNames, names)
+		})
+	}
+}
+
+func TestOCIManifestReader_Cancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := (&OCIManifestReader{
+		Reference: OCIScheme + strings.TrimPrefix(server.URL, "http://") + "/repo:v1",
+		PlainHTTP: true,
+	}).ReadContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestReadTarball_MaxSize(t *testing.T) {
+	layer := tarball(t, map[string]string{
+		"manifests/dep.yaml": depManifest,
+		"manifests/cm.yaml":  cmManifest,
+	})
+	_, err := readTarball(layer, true, int64(len(depManifest)+len(cmManifest)))
+	assert.NoError(t, err)
+	_, err = readTarball(layer, true, int64(len(depManifest)+len(cmManifest)-1))
+	assert.EqualError(t, err, fmt.Sprintf("the YAML files exceed the maximum size of %d bytes",
+		len(depManifest)+len(cmManifest)-1))
+}
+
+func tarball(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{
+			Name:     name,
+			Mode:     0600,
+			Size:     int64(len(content)),
+			Typeflag: tar.TypeReg,
+		}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func digestOf(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -4,32 +4,73 @@
 package manifestreader
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/kustomize/kyaml/kio"
 	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
 )
 
+// IgnoreFileName is the name of the file in the root of a package
+// directory that lists the files and directories the PathManifestReader
+// should skip, one pattern per line.
+const IgnoreFileName = ".kapplyignore"
+
 // PathManifestReader implements ManifestReader interface.
 var _ ManifestReader = &PathManifestReader{}
 
 // PathManifestReader reads manifests from the provided path
 // and returns them as Info objects. The returned Infos will not have
 // client or mapping set.
+//
+// Directories are walked recursively, and files are read in lexical
+// order of their path, so the order of the returned objects is
+// deterministic.
 type PathManifestReader struct {
 	Path string
 
+	// Include are glob patterns of the names of the files to read.
+	// Defaults to "*.yaml" and "*.yml".
+	Include []string
+
+	// Exclude are patterns of the files and directories to skip, in the
+	// same format as the patterns of an ignore file. They are combined with
+	// the patterns of the .kapplyignore file in Path, if any.
+	Exclude []string
+
 	ReaderOptions
 }
 
 // Read reads the manifests and returns them as Info objects.
 func (p *PathManifestReader) Read() ([]*unstructured.Unstructured, error) {
 	var objs []*unstructured.Unstructured
+	ignorePatterns, err := readIgnoreFile(filepath.Join(p.Path, IgnoreFileName))
+	if err != nil {
+		return objs, err
+	}
+	matcher, err := newPathMatcher(append(ignorePatterns, p.Exclude...))
+	if err != nil {
+		return objs, err
+	}
+
 	nodes, err := (&kio.LocalPackageReader{
-		PackagePath: p.Path,
+		PackagePath:    p.Path,
+		MatchFilesGlob: p.Include,
+		FileSkipFunc:   matcher.Match,
 	}).Read()
 	if err != nil {
 		return objs, err
 	}
+	if err := p.setSourceLines(nodes); err != nil {
+		return objs, err
+	}
 
 	for _, n := range nodes {
 		err = RemoveAnnotations(n, kioutil.IndexAnnotation)
@@ -45,6 +86,118 @@ func (p *PathManifestReader) Read() ([]*unstructured.Unstructured, error) {
 
 	objs = FilterLocalConfig(objs)
 
-	err = SetNamespaces(p.Mapper, objs, p.Namespace, p.EnforceNamespace)
+	err = p.setNamespaces(objs)
 	return objs, err
 }
+
+// setSourceLines sets the source lines of the nodes, which are grouped by
+// the file they were read from.
+func (p *PathManifestReader) setSourceLines(nodes []*kyaml.RNode) error {
+	base := p.Path
+	if info, err := os.Stat(p.Path); err == nil && !info.IsDir() {
+		base = filepath.Dir(p.Path)
+	}
+	for start := 0; start < len(nodes); {
+		path := nodes[start].GetAnnotations()[kioutil.PathAnnotation]
+		end := start + 1
+		for end < len(nodes) && nodes[end].GetAnnotations()[kioutil.PathAnnotation] == path {
+			end++
+		}
+		data, err := os.ReadFile(filepath.Join(base, path))
+		if err != nil {
+			return err
+		}
+		if err := setSourceLines(data, nodes[start:end]); err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		start = end
+	}
+	return nil
+}
+
+// readIgnoreFile returns the patterns in the ignore file, skipping empty
+// lines and comments. A missing file has no patterns.
+func readIgnoreFile(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var patterns []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		patterns = append(patterns, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", name, err)
+	}
+	return patterns, nil
+}
+
+// pathMatcher matches paths relative to the package directory against
+// ignore patterns. A pattern without a slash matches the name of any file
+// or directory, like "*.bak" or "testdata". A pattern with a slash matches
+// the path from the package directory, like "config/dev" or
+// "/secrets.yaml". A trailing slash only matches directories. Files in a
+// matching directory are matched too.
+type pathMatcher struct {
+	patterns []ignorePattern
+}
+
+type ignorePattern struct {
+	glob     string
+	anchored bool
+	dirOnly  bool
+}
+
+func newPathMatcher(patterns []string) (*pathMatcher, error) {
+	m := &pathMatcher{}
+	for _, p := range patterns {
+		ip := ignorePattern{}
+		if strings.HasSuffix(p, "/") {
+			ip.dirOnly = true
+			p = strings.TrimSuffix(p, "/")
+		}
+		if strings.Contains(p, "/") {
+			ip.anchored = true
+			p = strings.TrimPrefix(p, "/")
+		}
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid ignore pattern %q: %w", p, err)
+		}
+		ip.glob = p
+		m.patterns = append(m.patterns, ip)
+	}
+	return m, nil
+}
+
+// Match returns true if the file, or one of the directories it is in,
+// matches one of the patterns.
+func (m *pathMatcher) Match(relPath string) bool {
+	segments := strings.Split(filepath.ToSlash(relPath), "/")
+	for i := range segments {
+		isDir := i < len(segments)-1
+		for _, p := range m.patterns {
+			if p.dirOnly && !isDir {
+				continue
+			}
+			name := segments[i]
+			if p.anchored {
+				name = strings.Join(segments[:i+1], "/")
+			}
+			// Invalid patterns are rejected by newPathMatcher.
+			if match, _ := path.Match(p.glob, name); match {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -4,12 +4,16 @@
 package manifestreader
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
 )
 
@@ -85,3 +89,132 @@ func TestPathManifestReader_Read(t *testing.T) {
 		})
 	}
 }
+
+func TestPathManifestReader_Filter(t *testing.T) {
+	testCases := map[string]struct {
+		files   map[string]string
+		include []string
+		exclude []string
+
+		expectedPaths []string
+	}{
+		"nested directories are read in lexical order": {
+			files: map[string]string{
+				"b/dep.yaml":   depManifest,
+				"a/c/cm.yaml":  cmManifest,
+				"a/dep.yml":    depManifest,
+				"notes.txt":    "not a manifest",
+				"z-last.yaml":  cmManifest,
+				"a/b/cm.yaml":  cmManifest,
+				"b/a/dep.yaml": depManifest,
+			},
+			expectedPaths: []string{
+				"a/b/cm.yaml",
+				"a/c/cm.yaml",
+				"a/dep.yml",
+				"b/a/dep.yaml",
+				"b/dep.yaml",
+				"z-last.yaml",
+			},
+		},
+		"include globs": {
+			files: map[string]string{
+				"dep.yaml": depManifest,
+				"cm.json":  `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm"}}`,
+			},
+			include:       []string{"*.json"},
+			expectedPaths: []string{"cm.json"},
+		},
+		"exclude patterns and ignore file": {
+			files: map[string]string{
+				IgnoreFileName: `
+# generated files
+*.generated.yaml
+docs/
+/overlays/dev
+`,
+				"dep.yaml":                 depManifest,
+				"cm.generated.yaml":        cmManifest,
+				"docs/example.yaml":        cmManifest,
+				"overlays/dev/cm.yaml":     cmManifest,
+				"overlays/prod/cm.yaml":    cmManifest,
+				"overlays/prod/docs.yaml":  cmManifest,
+				"testdata/fixture.yaml":    cmManifest,
+				"nested/overlays/dev.yaml": cmManifest,
+			},
+			exclude: []string{"testdata"},
+			expectedPaths: []string{
+				"dep.yaml",
+				"nested/overlays/dev.yaml",
+				"overlays/prod/cm.yaml",
+				"overlays/prod/docs.yaml",
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+			defer tf.Cleanup()
+
+			mapper, err := tf.ToRESTMapper()
+			require.NoError(t, err)
+
+			dir := t.TempDir()
+			for filename, content := range tc.files {
+				p := filepath.Join(dir, filename)
+				require.NoError(t, os.MkdirAll(filepath.Dir(p), 0700))
+				require.NoError(t, os.WriteFile(p, []byte(content), 0600))
+			}
+
+			objs, err := (&PathManifestReader{
+				Path:    dir,
+				Include: tc.include,
+				Exclude: tc.exclude,
+				ReaderOptions: ReaderOptions{
+					Mapper:    mapper,
+					Namespace: "default",
+				},
+			}).Read()
+			require.NoError(t, err)
+
+			var paths []string
+			for _, obj := range objs {
+				paths = append(paths, obj.GetAnnotations()[kioutil.PathAnnotation])
+			}
+			assert.Equal(t, tc.expectedPaths, paths)
+		})
+	}
+}
+
+func TestPathManifestReader_SourceLine(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+	defer tf.Cleanup()
+
+	mapper, err := tf.ToRESTMapper()
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	content := "# comment\n" + depManifest + "---\n" + cmManifest
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "all.yaml"), []byte(content), 0600))
+
+	objs, err := (&PathManifestReader{
+		Path: dir,
+		ReaderOptions: ReaderOptions{
+			Mapper:    mapper,
+			Namespace: "default",
+		},
+	}).Read()
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+
+	var positions []string
+	for _, obj := range objs {
+		positions = append(positions, object.SourcePosition(obj))
+	}
+	depLines := strings.Count(depManifest, "\n")
+	assert.Equal(t, []string{
+		"all.yaml:3",
+		fmt.Sprintf("all.yaml:%d", depLines+4),
+	}, positions)
+}
@@ -4,6 +4,7 @@
 package manifestreader
 
 import (
+	"bytes"
 	"io"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -27,12 +28,19 @@ type StreamManifestReader struct {
 // Read reads the manifests and returns them as Info objects.
 func (r *StreamManifestReader) Read() ([]*unstructured.Unstructured, error) {
 	var objs []*unstructured.Unstructured
+	data, err := io.ReadAll(r.Reader)
+	if err != nil {
+		return objs, err
+	}
 	nodes, err := (&kio.ByteReader{
-		Reader: r.Reader,
+		Reader: bytes.NewReader(data),
 	}).Read()
 	if err != nil {
 		return objs, err
 	}
+	if err := setSourceLines(data, nodes); err != nil {
+		return objs, err
+	}
 
 	for _, n := range nodes {
 		err = RemoveAnnotations(n, kioutil.IndexAnnotation)
@@ -48,6 +56,6 @@ func (r *StreamManifestReader) Read() ([]*unstructured.Unstructured, error) {
 
 	objs = FilterLocalConfig(objs)
 
-	err = SetNamespaces(r.Mapper, objs, r.Namespace, r.EnforceNamespace)
+	err = r.setNamespaces(objs)
 	return objs, err
 }
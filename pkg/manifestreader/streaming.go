@@ -0,0 +1,194 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/kustomize/kyaml/kio/filters"
+)
+
+// StreamingManifestReader implements ManifestReader interface.
+var _ ManifestReader = &StreamingManifestReader{}
+
+// StreamingManifestReader reads manifests from the provided io.Reader one
+// object at a time, without buffering the whole stream in memory. The
+// stream can be multi-document YAML, or a sequence of JSON objects, like
+// newline-delimited JSON. Objects of a List kind are expanded into their
+// items.
+//
+// Objects with a type that is not in the RESTMapper are held back until
+// the end of the stream, since the CRD that defines the type can come
+// later in the stream. Next returns them after all other objects.
+type StreamingManifestReader struct {
+	ReaderName string
+	Reader     io.Reader
+
+	ReaderOptions
+
+	decoder decoder
+	// items are the remaining items of the last List read from the stream.
+	items []*unstructured.Unstructured
+	// crds are the CRDs read from the stream so far.
+	crds []*unstructured.Unstructured
+	// unknown are the objects with a type that is not in the RESTMapper or
+	// the CRDs read so far.
+	unknown  []*unstructured.Unstructured
+	eof      bool
+	resolved bool
+}
+
+// Read reads all the manifests and returns them as Unstructured objects.
+func (r *StreamingManifestReader) Read() ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for {
+		obj, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return objs, nil
+		}
+		if err != nil {
+			return objs, err
+		}
+		objs = append(objs, obj)
+	}
+}
+
+// Next returns the next object in the stream, or io.EOF if there are no
+// more objects.
+func (r *StreamingManifestReader) Next() (*unstructured.Unstructured, error) {
+	if r.decoder == nil {
+		r.decoder = newDecoder(r.Reader)
+	}
+	for !r.eof {
+		obj, err := r.nextDecoded()
+		if errors.Is(err, io.EOF) {
+			r.eof = true
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if object.IsCRD(obj) {
+			r.crds = append(r.crds, obj)
+		}
+		err = r.setNamespacesWithCRDs([]*unstructured.Unstructured{obj}, r.crds)
+		var unknownTypesErr *UnknownTypesError
+		if errors.As(err, &unknownTypesErr) {
+			r.unknown = append(r.unknown, obj)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return obj, nil
+	}
+
+	// All CRDs in the stream are known now, so look up the scope of the
+	// objects held back again.
+	if !r.resolved {
+		if err := r.setNamespacesWithCRDs(r.unknown, r.crds); err != nil {
+			return nil, err
+		}
+		r.resolved = true
+	}
+	if len(r.unknown) == 0 {
+		return nil, io.EOF
+	}
+	obj := r.unknown[0]
+	r.unknown = r.unknown[1:]
+	return obj, nil
+}
+
+// nextDecoded returns the next object in the stream that is not a List
+// and doesn't have the LocalConfig annotation.
+func (r *StreamingManifestReader) nextDecoded() (*unstructured.Unstructured, error) {
+	for {
+		if len(r.items) > 0 {
+			obj := r.items[0]
+			r.items = r.items[1:]
+			if isLocalConfig(obj) {
+				continue
+			}
+			return obj, nil
+		}
+
+		var raw json.RawMessage
+		if err := r.decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("reading %s: %w", r.ReaderName, err)
+		}
+		// Decode the numbers like the API machinery, as int64 if they are
+		// integers, instead of float64.
+		var m map[string]interface{}
+		if err := utiljson.Unmarshal(raw, &m); err != nil {
+			return nil, fmt.Errorf("reading %s: %w", r.ReaderName, err)
+		}
+		// Skip empty documents.
+		if len(m) == 0 {
+			continue
+		}
+		obj := &unstructured.Unstructured{Object: m}
+		if strings.HasSuffix(obj.GetKind(), "List") && obj.IsList() {
+			list, err := obj.ToList()
+			if err != nil {
+				return nil, fmt.Errorf("reading %s: %w", r.ReaderName, err)
+			}
+			for i := range list.Items {
+				r.items = append(r.items, &list.Items[i])
+			}
+			continue
+		}
+		if isLocalConfig(obj) {
+			continue
+		}
+		return obj, nil
+	}
+}
+
+// decoder decodes the objects in a stream.
+type decoder interface {
+	Decode(into interface{}) error
+}
+
+// newDecoder returns a JSON decoder if the stream starts with a JSON
+// object, and a YAML decoder otherwise. Unlike
+// utilyaml.NewYAMLOrJSONDecoder, it only reads up to the first
+// non-whitespace character to detect the format, so it doesn't block
+// waiting for more input from a slow stream.
+func newDecoder(r io.Reader) decoder {
+	br := bufio.NewReader(r)
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			// Let the decoder return the error.
+			return utilyaml.NewYAMLToJSONDecoder(br)
+		}
+		if unicode.IsSpace(rune(b)) {
+			continue
+		}
+		_ = br.UnreadByte()
+		if b == '{' {
+			return json.NewDecoder(br)
+		}
+		return utilyaml.NewYAMLToJSONDecoder(br)
+	}
+}
+
+func isLocalConfig(obj *unstructured.Unstructured) bool {
+	_, found := obj.GetAnnotations()[filters.LocalConfigAnnotation]
+	return found
+}
@@ -0,0 +1,187 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+var (
+	crdManifest = `
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: customs.custom.io
+spec:
+  group: custom.io
+  names:
+    kind: Custom
+  scope: Namespaced
+  versions:
+  - name: v1
+`
+	customManifest = `
+apiVersion: custom.io/v1
+kind: Custom
+metadata:
+  name: custom
+`
+)
+
+func TestStreamingManifestReader_Read(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+
+		expectedErr        string
+		expectedNames      []string
+		expectedNamespaces []string
+	}{
+		"multi-document YAML": {
+			input:              depManifest + "\n---\n---\n" + cmManifest,
+			expectedNames:      []string{"dep", "cm"},
+			expectedNamespaces: []string{"foo", "foo"},
+		},
+		"JSON List": {
+			input: `{"apiVersion": "v1", "kind": "List", "items": [
+  {"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm"}},
+  {"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "dep", "namespace": "foo"}}
+]}`,
+			expectedNames:      []string{"cm", "dep"},
+			expectedNamespaces: []string{"foo", "foo"},
+		},
+		"newline-delimited JSON": {
+			input: `{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm1"}}
+{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm2", "annotations": {"config.kubernetes.io/local-config": "true"}}}
+{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm3"}}
+`,
+			expectedNames:      []string{"cm1", "cm3"},
+			expectedNamespaces: []string{"foo", "foo"},
+		},
+		"CR before CRD is returned last": {
+			input:              customManifest + "\n---\n" + cmManifest + "\n---\n" + crdManifest,
+			expectedNames:      []string{"cm", "customs.custom.io", "custom"},
+			expectedNamespaces: []string{"foo", "", "foo"},
+		},
+		"unknown type": {
+			input:       customManifest,
+			expectedErr: "unknown resource types: custom.io/v1/Custom",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mapper := newStreamingTestMapper(t)
+
+			objs, err := (&StreamingManifestReader{
+				ReaderName: "test",
+				Reader:     strings.NewReader(tc.input),
+				ReaderOptions: ReaderOptions{
+					Mapper:    mapper,
+					Namespace: "foo",
+				},
+			}).Read()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var names, namespaces []string
+			for _, obj := range objs {
+				names = append(names, obj.GetName())
+				namespaces = append(namespaces, obj.GetNamespace())
+			}
+			assert.Equal(t, tc.expectedNames, names)
+			assert.Equal(t, tc.expectedNamespaces, namespaces)
+		})
+	}
+}
+
+func TestStreamingManifestReader_Integers(t *testing.T) {
+	testCases := map[string]string{
+		"YAML": `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+spec:
+  replicas: 3
+`,
+		"JSON": `{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "dep"}, "spec": {"replicas": 3}}`,
+	}
+
+	for tn, input := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			objs, err := (&StreamingManifestReader{
+				ReaderName: "test",
+				Reader:     strings.NewReader(input),
+				ReaderOptions: ReaderOptions{
+					Mapper:    newStreamingTestMapper(t),
+					Namespace: "foo",
+				},
+			}).Read()
+			require.NoError(t, err)
+			require.Len(t, objs, 1)
+			assert.Equal(t, int64(3), objs[0].Object["spec"].(map[string]interface{})["replicas"])
+			replicas, found, err := unstructured.NestedInt64(objs[0].Object, "spec", "replicas")
+			require.NoError(t, err)
+			assert.True(t, found)
+			assert.Equal(t, int64(3), replicas)
+		})
+	}
+}
+
+func TestStreamingManifestReader_Next(t *testing.T) {
+	pr, pw := io.Pipe()
+	r := &StreamingManifestReader{
+		ReaderName: "pipe",
+		Reader:     pr,
+		ReaderOptions: ReaderOptions{
+			Mapper:    newStreamingTestMapper(t),
+			Namespace: "foo",
+		},
+	}
+
+	// The first object is returned before the rest of the stream is
+	// written.
+	go func() {
+		_, _ = pw.Write([]byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm1"}}` + "\n"))
+	}()
+	obj, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "cm1", obj.GetName())
+
+	go func() {
+		_, _ = pw.Write([]byte(`{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "cm2"}}` + "\n"))
+		_ = pw.Close()
+	}()
+	obj, err = r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "cm2", obj.GetName())
+
+	_, err = r.Next()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func newStreamingTestMapper(t *testing.T) meta.RESTMapper {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+	t.Cleanup(tf.Cleanup)
+	mapper, err := tf.ToRESTMapper()
+	require.NoError(t, err)
+	crdGV := schema.GroupVersion{Group: "apiextensions.k8s.io", Version: "v1"}
+	crdMapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{crdGV})
+	crdMapper.AddSpecific(crdGV.WithKind("CustomResourceDefinition"),
+		crdGV.WithResource("customresourcedefinitions"),
+		crdGV.WithResource("customresourcedefinition"), meta.RESTScopeRoot)
+	return meta.MultiRESTMapper([]meta.RESTMapper{mapper, crdMapper})
+}
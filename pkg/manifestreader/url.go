@@ -0,0 +1,147 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/kyaml/kio"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+	kyaml "sigs.k8s.io/kustomize/kyaml/yaml"
+)
+
+// URLManifestReader implements ManifestReader interface.
+var _ ManifestReader = &URLManifestReader{}
+
+// URLManifestReader reads manifests from an https URL, like the manifests
+// published with a release. The URL can be a single YAML file, or a
+// tarball of YAML files, either optionally gzipped. Tarballs are detected
+// from the content, not the extension of the URL. The content is verified
+// with the Checksum of the ReaderOptions, if set.
+type URLManifestReader struct {
+	URL string
+
+	// Client is the HTTP client used to fetch the URL.
+	// Defaults to a client configured with the HTTPTimeout and HTTPProxy of
+	// the ReaderOptions.
+	Client *http.Client
+
+	ReaderOptions
+}
+
+// IsURL returns true if the path is an https URL.
+func IsURL(path string) bool {
+	return strings.HasPrefix(path, "https://")
+}
+
+// Read fetches the URL and returns the manifests.
+func (u *URLManifestReader) Read() ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	parsed, err := url.Parse(u.URL)
+	if err != nil {
+		return objs, fmt.Errorf("invalid URL %q: %w", u.URL, err)
+	}
+	if parsed.Scheme != "https" {
+		return objs, fmt.Errorf("unsupported URL scheme %q: must be https", parsed.Scheme)
+	}
+
+	client := u.Client
+	if client == nil {
+		client, err = u.httpClient()
+		if err != nil {
+			return objs, err
+		}
+	}
+	resp, err := client.Get(u.URL)
+	if err != nil {
+		return objs, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return objs, fmt.Errorf("fetching %s: %s", u.URL, resp.Status)
+	}
+	data, err := readLimited("content", resp.Body, u.maxRemoteSize())
+	if err != nil {
+		return objs, fmt.Errorf("fetching %s: %w", u.URL, err)
+	}
+
+	if u.Checksum != "" {
+		checksum := u.Checksum
+		if !strings.Contains(checksum, ":") {
+			checksum = "sha256:" + checksum
+		}
+		if err := verifyDigest(u.URL, strings.ToLower(checksum), data); err != nil {
+			return objs, err
+		}
+	}
+
+	// Gzipped content is a tarball, like a .tar.gz release, or a single
+	// manifest stream, like a .yaml.gz file.
+	if isGzip(data) {
+		data, err = gunzip(data, u.maxRemoteSize())
+		if err != nil {
+			return objs, fmt.Errorf("reading %s: %w", u.URL, err)
+		}
+	}
+	var nodes []*kyaml.RNode
+	if isTar(data) {
+		nodes, err = readTarball(data, false, u.maxRemoteSize())
+	} else {
+		nodes, err = (&kio.ByteReader{
+			Reader: bytes.NewReader(data),
+			SetAnnotations: map[string]string{
+				kioutil.PathAnnotation: u.URL,
+			},
+			OmitReaderAnnotations: true,
+		}).Read()
+		if err == nil {
+			err = setSourceLines(data, nodes)
+		}
+	}
+	if err != nil {
+		return objs, fmt.Errorf("reading %s: %w", u.URL, err)
+	}
+
+	for _, n := range nodes {
+		obj, err := KyamlNodeToUnstructured(n)
+		if err != nil {
+			return objs, err
+		}
+		objs = append(objs, obj)
+	}
+
+	objs = FilterLocalConfig(objs)
+
+	err = u.setNamespaces(objs)
+	return objs, err
+}
+
+// isGzip returns true if the data starts with the gzip magic number.
+func isGzip(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0x1f && data[1] == 0x8b
+}
+
+// gunzip returns the decompressed data, up to limit bytes.
+func gunzip(data []byte, limit int64) ([]byte, error) {
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+	return readLimited("decompressed content", gz, limit)
+}
+
+// isTar returns true if the data starts with a valid tar header. YAML
+// manifests never do, since the header has a checksum.
+func isTar(data []byte) bool {
+	_, err := tar.NewReader(bytes.NewReader(data)).Next()
+	return err == nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package manifestreader
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+)
+
+func TestURLManifestReader_Read(t *testing.T) {
+	multiDoc := depManifest + "\n---\n" + cmManifest
+	archive := tarball(t, map[string]string{
+		"release/dep.yaml": depManifest,
+		"release/cm.yml":   cmManifest,
+	})
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, err := gz.Write([]byte(multiDoc))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	testCases := map[string]struct {
+		path     string
+		checksum string
+		maxSize  int64
+
+		expectedErr   string
+		expectedNames []string
+		expectedPaths []string
+	}{
+		"single file": {
+			path:          "/release.yaml",
+			expectedNames: []string{"dep", "cm"},
+			expectedPaths: []string{"%s/release.yaml", "%s/release.yaml"},
+		},
+		"single file with checksum": {
+			path:          "/release.yaml",
+			checksum:      strings.TrimPrefix(digestOf([]byte(multiDoc)), "sha256:"),
+			expectedNames: []string{"dep", "cm"},
+			expectedPaths: []string{"%s/release.yaml", "%s/release.yaml"},
+		},
+		"tarball with checksum": {
+			path:          "/release.tar.gz",
+			checksum:      digestOf(archive),
+			expectedNames: []string{"cm", "dep"},
+			expectedPaths: []string{"release/cm.yml", "release/dep.yaml"},
+		},
+		"gzipped single file": {
+			path:          "/release.yaml.gz",
+			expectedNames: []string{"dep", "cm"},
+			expectedPaths: []string{"%s/release.yaml.gz", "%s/release.yaml.gz"},
+		},
+		"checksum mismatch": {
+			path:        "/release.yaml",
+			checksum:    "0000",
+			expectedErr: "digest mismatch for %s/release.yaml: expected sha256:0000, got " + digestOf([]byte(multiDoc)),
+		},
+		"too large": {
+			path:        "/release.yaml",
+			maxSize:     16,
+			expectedErr: "fetching %s/release.yaml: content exceeds the maximum size of 16 bytes",
+		},
+		"decompressed content too large": {
+			path:        "/release.yaml.gz",
+			maxSize:     int64(gzipped.Len()),
+			expectedErr: fmt.Sprintf("reading %%s/release.yaml.gz: decompressed content exceeds the maximum size of %d bytes", gzipped.Len()),
+		},
+		"not found": {
+			path:        "/missing.yaml",
+			expectedErr: "fetching %s/missing.yaml: 404 Not Found",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/release.yaml":
+					_, _ = fmt.Fprint(w, multiDoc)
+				case "/release.tar.gz":
+					_, _ = w.Write(archive)
+				case "/release.yaml.gz":
+					_, _ = w.Write(gzipped.Bytes())
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+			defer tf.Cleanup()
+			mapper, err := tf.ToRESTMapper()
+			require.NoError(t, err)
+
+			objs, err := (&URLManifestReader{
+				URL:    server.URL + tc.path,
+				Client: server.Client(),
+				ReaderOptions: ReaderOptions{
+					Mapper:        mapper,
+					Namespace:     "foo",
+					Checksum:      tc.checksum,
+					MaxRemoteSize: tc.maxSize,
+				},
+			}).Read()
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, fmt.Sprintf(tc.expectedErr, server.URL))
+				return
+			}
+			require.NoError(t, err)
+
+			names := make(map[string]string)
+			for _, obj := range objs {
+				assert.Equal(t, "foo", obj.GetNamespace())
+				names[obj.GetName()] = obj.GetAnnotations()[kioutil.PathAnnotation]
+			}
+			expected := make(map[string]string)
+			for i, name := range tc.expectedNames {
+				p := tc.expectedPaths[i]
+				if strings.Contains(p, "%s") {
+					p = fmt.Sprintf(p, server.URL)
+				}
+				expected[name] = p
+			}
+			assert.Equal(t, expected, names)
+		})
+	}
+}
+
+func TestURLManifestReader_Scheme(t *testing.T) {
+	_, err := (&URLManifestReader{
+		URL: "http://example.com/release.yaml",
+	}).Read()
+	assert.EqualError(t, err, `unsupported URL scheme "http": must be https`)
+}
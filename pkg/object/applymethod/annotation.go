@@ -0,0 +1,51 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package applymethod reads the annotation that overrides the apply method
+// of an object, like client-side apply for an object with a webhook that
+// does not support server-side apply.
+package applymethod
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Annotation sets the apply method of the object, instead of the method of
+// the run.
+const Annotation = "cli-utils.sigs.k8s.io/apply-method"
+
+// Method is the way an object is applied.
+type Method string
+
+const (
+	// ClientSide objects are applied with a patch computed by the client,
+	// from the last-applied-configuration annotation.
+	ClientSide Method = "client-side"
+	// ServerSide objects are applied with server-side apply.
+	ServerSide Method = "server-side"
+)
+
+// ReadAnnotation returns the apply method of the object, or an empty method
+// if the annotation is not set.
+func ReadAnnotation(u *unstructured.Unstructured) (Method, error) {
+	if u == nil {
+		return "", nil
+	}
+	value, found := u.GetAnnotations()[Annotation]
+	if !found {
+		return "", nil
+	}
+	method := Method(value)
+	switch method {
+	case ClientSide, ServerSide:
+		return method, nil
+	default:
+		return "", object.InvalidAnnotationError{
+			Annotation: Annotation,
+			Cause:      fmt.Errorf("unknown apply method %q, must be %q or %q", value, ClientSide, ServerSide),
+		}
+	}
+}
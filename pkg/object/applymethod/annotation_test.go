@@ -0,0 +1,60 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package applymethod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestReadAnnotation(t *testing.T) {
+	newObject := func(annotations map[string]string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{}
+		u.SetAPIVersion("v1")
+		u.SetKind("ConfigMap")
+		u.SetName("config")
+		u.SetAnnotations(annotations)
+		return u
+	}
+
+	testCases := map[string]struct {
+		obj           *unstructured.Unstructured
+		expected      Method
+		expectedError string
+	}{
+		"nil object": {
+			obj: nil,
+		},
+		"no annotation": {
+			obj: newObject(nil),
+		},
+		"client-side": {
+			obj:      newObject(map[string]string{Annotation: "client-side"}),
+			expected: ClientSide,
+		},
+		"server-side": {
+			obj:      newObject(map[string]string{Annotation: "server-side"}),
+			expected: ServerSide,
+		},
+		"unknown method": {
+			obj: newObject(map[string]string{Annotation: "replace"}),
+			expectedError: `invalid "cli-utils.sigs.k8s.io/apply-method" annotation: ` +
+				`unknown apply method "replace", must be "client-side" or "server-side"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			method, err := ReadAnnotation(tc.obj)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, method)
+		})
+	}
+}
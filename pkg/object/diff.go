@@ -0,0 +1,98 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package object
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// FieldDiff is a field with different values in two objects.
+type FieldDiff struct {
+	// Path is the path of the field, formatted with FieldPath.
+	Path string
+	// Old is the value of the field in the first object, or nil if the
+	// field is not set.
+	Old interface{}
+	// New is the value of the field in the second object, or nil if the
+	// field is not set.
+	New interface{}
+}
+
+// String returns the path with the old and new values, like
+// ".spec.replicas: 1 -> 3".
+func (d FieldDiff) String() string {
+	return fmt.Sprintf("%s: %s -> %s", d.Path, formatValue(d.Old), formatValue(d.New))
+}
+
+func formatValue(v interface{}) string {
+	if v == nil {
+		return "<unset>"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
+
+// DiffFields returns the fields with different values in the KRM maps a and
+// b, sorted by path. Lists are compared item by item. A field set in only
+// one of the maps is returned with its whole value.
+func DiffFields(a, b map[string]interface{}) []FieldDiff {
+	var diffs []FieldDiff
+	diffValues(nil, a, b, &diffs)
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs
+}
+
+func diffValues(path []interface{}, a, b interface{}, diffs *[]FieldDiff) {
+	switch typedA := a.(type) {
+	case map[string]interface{}:
+		if typedB, ok := b.(map[string]interface{}); ok {
+			for k, va := range typedA {
+				diffValues(appendPath(path, k), va, typedB[k], diffs)
+			}
+			for k, vb := range typedB {
+				if _, found := typedA[k]; !found {
+					diffValues(appendPath(path, k), nil, vb, diffs)
+				}
+			}
+			return
+		}
+	case []interface{}:
+		if typedB, ok := b.([]interface{}); ok {
+			for i := 0; i < len(typedA) || i < len(typedB); i++ {
+				var va, vb interface{}
+				if i < len(typedA) {
+					va = typedA[i]
+				}
+				if i < len(typedB) {
+					vb = typedB[i]
+				}
+				diffValues(appendPath(path, i), va, vb, diffs)
+			}
+			return
+		}
+	}
+	if !reflect.DeepEqual(a, b) {
+		*diffs = append(*diffs, FieldDiff{
+			Path: FieldPath(path),
+			Old:  a,
+			New:  b,
+		})
+	}
+}
+
+// appendPath returns a new path with the field appended, so the paths of
+// sibling fields don't share the same backing array.
+func appendPath(path []interface{}, field interface{}) []interface{} {
+	result := make([]interface{}, len(path), len(path)+1)
+	copy(result, path)
+	return append(result, field)
+}
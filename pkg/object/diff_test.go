@@ -0,0 +1,65 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffFields(t *testing.T) {
+	testCases := map[string]struct {
+		a, b map[string]interface{}
+
+		expected []string
+	}{
+		"equal": {
+			a: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+			b: map[string]interface{}{"spec": map[string]interface{}{"replicas": int64(1)}},
+		},
+		"changed, added, and removed fields": {
+			a: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"replicas": int64(1),
+					"paused":   true,
+				},
+			},
+			b: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"replicas": int64(3),
+					"selector": map[string]interface{}{"app": "foo"},
+				},
+			},
+			expected: []string{
+				`.spec.paused: true -> <unset>`,
+				`.spec.replicas: 1 -> 3`,
+				`.spec.selector: <unset> -> {"app":"foo"}`,
+			},
+		},
+		"lists are compared by index": {
+			a: map[string]interface{}{"args": []interface{}{"a", "b"}},
+			b: map[string]interface{}{"args": []interface{}{"a", "c", "d"}},
+			expected: []string{
+				`.args[1]: "b" -> "c"`,
+				`.args[2]: <unset> -> "d"`,
+			},
+		},
+		"type change": {
+			a:        map[string]interface{}{"data": map[string]interface{}{"key": "value"}},
+			b:        map[string]interface{}{"data": "value"},
+			expected: []string{`.data: {"key":"value"} -> "value"`},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			var diffs []string
+			for _, d := range DiffFields(tc.a, tc.b) {
+				diffs = append(diffs, d.String())
+			}
+			assert.Equal(t, tc.expected, diffs)
+		})
+	}
+}
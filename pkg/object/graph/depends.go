@@ -19,9 +19,21 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/ordering"
 )
 
+// ExistsFunc returns true if the object exists in the cluster.
+type ExistsFunc func(id object.ObjMetadata) (bool, error)
+
 // DependencyGraph returns a new graph, populated with the supplied objects as
 // vetices and edges built from their dependencies.
 func DependencyGraph(objs object.UnstructuredSet) (*Graph, error) {
+	return DependencyGraphWithExternal(objs, nil)
+}
+
+// DependencyGraphWithExternal is like DependencyGraph, but allows
+// depends-on and apply-time-mutation references to objects that are not in
+// the set, if they exist in the cluster according to exists. No edges are
+// added for these references. If exists is nil, all references to objects
+// that are not in the set are invalid.
+func DependencyGraphWithExternal(objs object.UnstructuredSet, exists ExistsFunc) (*Graph, error) {
 	g := New()
 	if len(objs) == 0 {
 		return g, nil
@@ -37,10 +49,10 @@ func DependencyGraph(objs object.UnstructuredSet) (*Graph, error) {
 	// Add dependencies as graph edges
 	addCRDEdges(g, objs, ids)
 	addNamespaceEdges(g, objs, ids)
-	if err := addDependsOnEdges(g, objs, ids); err != nil {
+	if err := addDependsOnEdges(g, objs, ids, exists); err != nil {
 		errors = append(errors, err)
 	}
-	if err := addApplyTimeMutationEdges(g, objs, ids); err != nil {
+	if err := addApplyTimeMutationEdges(g, objs, ids, exists); err != nil {
 		errors = append(errors, err)
 	}
 	if len(errors) > 0 {
@@ -149,7 +161,7 @@ func addVertices(g *Graph, ids object.ObjMetadataSet) {
 // addApplyTimeMutationEdges updates the graph with edges from objects
 // with an explicit "apply-time-mutation" annotation.
 // The objs and ids must match in order and length (optimization).
-func addApplyTimeMutationEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMetadataSet) error {
+func addApplyTimeMutationEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMetadataSet, exists ExistsFunc) error {
 	var errors []error
 	for i, obj := range objs {
 		if !mutation.HasAnnotation(obj) {
@@ -159,7 +171,7 @@ func addApplyTimeMutationEdges(g *Graph, objs object.UnstructuredSet, ids object
 		subs, err := mutation.ReadAnnotation(obj)
 		if err != nil {
 			klog.V(3).Infof("failed to add edges from: %s: %v", id, err)
-			errors = append(errors, validation.NewError(err, id))
+			errors = append(errors, validation.NewError(object.WithSource(obj, err), id))
 			continue
 		}
 		seen := make(map[object.ObjMetadata]struct{})
@@ -172,20 +184,18 @@ func addApplyTimeMutationEdges(g *Graph, objs object.UnstructuredSet, ids object
 			}
 			// Mark as seen
 			seen[dep] = struct{}{}
-			// Require dependencies to be in the same resource group.
+			// Dependencies outside the resource group must already exist.
 			// Waiting for external dependencies isn't implemented (yet).
 			if !ids.Contains(dep) {
-				err := object.InvalidAnnotationError{
-					Annotation: mutation.Annotation,
-					Cause: ExternalDependencyError{
-						Edge: Edge{
-							From: id,
-							To:   dep,
-						},
-					},
+				edge := Edge{From: id, To: dep}
+				if cause := checkExternalDependency(edge, exists); cause != nil {
+					err := object.InvalidAnnotationError{
+						Annotation: mutation.Annotation,
+						Cause:      cause,
+					}
+					objErrors = append(objErrors, err)
+					klog.V(3).Infof("failed to add edges: %v", err)
 				}
-				objErrors = append(objErrors, err)
-				klog.V(3).Infof("failed to add edges: %v", err)
 				continue
 			}
 			klog.V(3).Infof("adding edge from: %s, to: %s", id, dep)
@@ -193,7 +203,7 @@ func addApplyTimeMutationEdges(g *Graph, objs object.UnstructuredSet, ids object
 		}
 		if len(objErrors) > 0 {
 			errors = append(errors,
-				validation.NewError(multierror.Wrap(objErrors...), id))
+				validation.NewError(object.WithSource(obj, multierror.Wrap(objErrors...)), id))
 		}
 	}
 	if len(errors) > 0 {
@@ -205,7 +215,7 @@ func addApplyTimeMutationEdges(g *Graph, objs object.UnstructuredSet, ids object
 // addDependsOnEdges updates the graph with edges from objects
 // with an explicit "depends-on" annotation.
 // The objs and ids must match in order and length (optimization).
-func addDependsOnEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMetadataSet) error {
+func addDependsOnEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMetadataSet, exists ExistsFunc) error {
 	var errors []error
 	for i, obj := range objs {
 		if !dependson.HasAnnotation(obj) {
@@ -215,7 +225,7 @@ func addDependsOnEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMeta
 		deps, err := dependson.ReadAnnotation(obj)
 		if err != nil {
 			klog.V(3).Infof("failed to add edges from: %s: %v", id, err)
-			errors = append(errors, validation.NewError(err, id))
+			errors = append(errors, validation.NewError(object.WithSource(obj, err), id))
 			continue
 		}
 		seen := make(map[object.ObjMetadata]struct{})
@@ -239,20 +249,18 @@ func addDependsOnEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMeta
 			}
 			// Mark as seen
 			seen[dep] = struct{}{}
-			// Require dependencies to be in the same resource group.
+			// Dependencies outside the resource group must already exist.
 			// Waiting for external dependencies isn't implemented (yet).
 			if !ids.Contains(dep) {
-				err := object.InvalidAnnotationError{
-					Annotation: dependson.Annotation,
-					Cause: ExternalDependencyError{
-						Edge: Edge{
-							From: id,
-							To:   dep,
-						},
-					},
+				edge := Edge{From: id, To: dep}
+				if cause := checkExternalDependency(edge, exists); cause != nil {
+					err := object.InvalidAnnotationError{
+						Annotation: dependson.Annotation,
+						Cause:      cause,
+					}
+					objErrors = append(objErrors, err)
+					klog.V(3).Infof("failed to add edges: %v", err)
 				}
-				objErrors = append(objErrors, err)
-				klog.V(3).Infof("failed to add edges: %v", err)
 				continue
 			}
 			klog.V(3).Infof("adding edge from: %s, to: %s", id, dep)
@@ -260,7 +268,7 @@ func addDependsOnEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMeta
 		}
 		if len(objErrors) > 0 {
 			errors = append(errors,
-				validation.NewError(multierror.Wrap(objErrors...), id))
+				validation.NewError(object.WithSource(obj, multierror.Wrap(objErrors...)), id))
 		}
 	}
 	if len(errors) > 0 {
@@ -269,6 +277,22 @@ func addDependsOnEdges(g *Graph, objs object.UnstructuredSet, ids object.ObjMeta
 	return nil
 }
 
+// checkExternalDependency returns an ExternalDependencyError, unless the
+// target of the edge exists in the cluster.
+func checkExternalDependency(edge Edge, exists ExistsFunc) error {
+	if exists == nil {
+		return ExternalDependencyError{Edge: edge}
+	}
+	found, err := exists(edge.To)
+	if err != nil {
+		return ExternalDependencyError{Edge: edge, Err: err}
+	}
+	if !found {
+		return ExternalDependencyError{Edge: edge, Err: ErrDependencyNotFound}
+	}
+	return nil
+}
+
 // addCRDEdges adds edges to the dependency graph from custom
 // resources to their definitions to ensure the CRD's exist
 // before applying the custom resources created with the definition.
@@ -1132,7 +1132,7 @@ func TestApplyTimeMutationEdges(t *testing.T) {
 		t.Run(tn, func(t *testing.T) {
 			g := New()
 			ids := object.UnstructuredSetToObjMetadataSet(tc.objs)
-			err := addApplyTimeMutationEdges(g, tc.objs, ids)
+			err := addApplyTimeMutationEdges(g, tc.objs, ids, nil)
 			if tc.expectedError != nil {
 				assert.EqualError(t, err, tc.expectedError.Error())
 			} else {
@@ -1425,7 +1425,7 @@ func TestAddDependsOnEdges(t *testing.T) {
 		t.Run(tn, func(t *testing.T) {
 			g := New()
 			ids := object.UnstructuredSetToObjMetadataSet(tc.objs)
-			err := addDependsOnEdges(g, tc.objs, ids)
+			err := addDependsOnEdges(g, tc.objs, ids, nil)
 			if tc.expectedError != nil {
 				assert.EqualError(t, err, tc.expectedError.Error())
 			} else {
@@ -1437,6 +1437,81 @@ func TestAddDependsOnEdges(t *testing.T) {
 	}
 }
 
+func TestDependencyGraphWithExternal(t *testing.T) {
+	podID := testutil.ToIdentifier(t, resources["pod"])
+	deploymentID := testutil.ToIdentifier(t, resources["deployment"])
+	secretID := testutil.ToIdentifier(t, resources["secret"])
+	objs := []*unstructured.Unstructured{
+		testutil.Unstructured(t, resources["pod"],
+			testutil.AddDependsOn(t, deploymentID),
+			mutationutil.AddApplyTimeMutation(t, &mutation.ApplyTimeMutation{
+				{
+					SourceRef:  mutation.ResourceReferenceFromObjMetadata(secretID),
+					SourcePath: "unused",
+					TargetPath: "unused",
+				},
+			}),
+		),
+	}
+
+	testCases := map[string]struct {
+		exists        ExistsFunc
+		expectedError error
+	}{
+		"external objects exist": {
+			exists: func(object.ObjMetadata) (bool, error) {
+				return true, nil
+			},
+		},
+		"external objects not found": {
+			exists: func(id object.ObjMetadata) (bool, error) {
+				return id == secretID, nil
+			},
+			expectedError: validation.NewError(
+				object.InvalidAnnotationError{
+					Annotation: dependson.Annotation,
+					Cause: ExternalDependencyError{
+						Edge: Edge{From: podID, To: deploymentID},
+						Err:  ErrDependencyNotFound,
+					},
+				},
+				podID,
+			),
+		},
+		"lookup error": {
+			exists: func(id object.ObjMetadata) (bool, error) {
+				if id == secretID {
+					return false, errors.New("forbidden")
+				}
+				return true, nil
+			},
+			expectedError: validation.NewError(
+				object.InvalidAnnotationError{
+					Annotation: mutation.Annotation,
+					Cause: ExternalDependencyError{
+						Edge: Edge{From: podID, To: secretID},
+						Err:  errors.New("forbidden"),
+					},
+				},
+				podID,
+			),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			g, err := DependencyGraphWithExternal(objs, tc.exists)
+			if tc.expectedError != nil {
+				assert.EqualError(t, err, tc.expectedError.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			// No edges are added for external dependencies.
+			verifyEdges(t, []Edge{}, edgeMapToList(g.edges))
+		})
+	}
+}
+
 func TestAddNamespaceEdges(t *testing.T) {
 	testCases := map[string]struct {
 		objs     []*unstructured.Unstructured
@@ -5,22 +5,38 @@ package graph
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 
 	"sigs.k8s.io/cli-utils/pkg/multierror"
 	"sigs.k8s.io/cli-utils/pkg/object/mutation"
 )
 
+// ErrDependencyNotFound is the cause of an ExternalDependencyError for
+// an object that is neither in the object set nor in the cluster.
+var ErrDependencyNotFound = errors.New("not found in the object set or the cluster")
+
 // ExternalDependencyError represents an invalid graph edge caused by an
 // object that is not in the object set.
 type ExternalDependencyError struct {
 	Edge Edge
+	// Err is the reason the external dependency is invalid, if known.
+	Err error
 }
 
 func (ede ExternalDependencyError) Error() string {
-	return fmt.Sprintf("external dependency: %s -> %s",
+	msg := fmt.Sprintf("external dependency: %s -> %s",
 		mutation.ResourceReferenceFromObjMetadata(ede.Edge.From),
 		mutation.ResourceReferenceFromObjMetadata(ede.Edge.To))
+	if ede.Err != nil {
+		return fmt.Sprintf("%s: %v", msg, ede.Err)
+	}
+	return msg
+}
+
+// Unwrap returns the reason the external dependency is invalid.
+func (ede ExternalDependencyError) Unwrap() error {
+	return ede.Err
 }
 
 // CyclicDependencyError represents a cycle in the graph, making topological
@@ -39,6 +39,16 @@ func TestExternalDependencyErrorString(t *testing.T) {
 			},
 			expectedString: `external dependency: test/namespaces/ns1/foo/obj1 -> test/namespaces/ns1/foo/obj2`,
 		},
+		"not found": {
+			err: ExternalDependencyError{
+				Edge: Edge{
+					From: o1,
+					To:   o2,
+				},
+				Err: ErrDependencyNotFound,
+			},
+			expectedString: `external dependency: test/foo/obj1 -> test/foo/obj2: not found in the object set or the cluster`,
+		},
 	}
 
 	for tn, tc := range testCases {
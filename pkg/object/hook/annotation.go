@@ -0,0 +1,102 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package hook reads the annotations of hooks: Jobs and Pods that run to
+// completion at a phase of the apply, instead of being applied with the
+// other objects.
+package hook
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+const (
+	// Annotation makes the object a hook, run at the phase of its value.
+	Annotation = "cli-utils.sigs.k8s.io/hook"
+	// DeletePolicyAnnotation defines when the hook is deleted.
+	DeletePolicyAnnotation = "cli-utils.sigs.k8s.io/hook-delete-policy"
+	// DeleteOnSucceeded is the DeletePolicyAnnotation value that deletes
+	// the hook after it succeeds. Failed hooks are kept, to be inspected.
+	DeleteOnSucceeded = "hook-succeeded"
+)
+
+// Phase is the phase of the apply a hook runs at.
+type Phase string
+
+const (
+	// PreApply hooks run before the objects are applied.
+	PreApply Phase = "pre-apply"
+	// PostApply hooks run after the objects are applied and reconciled.
+	PostApply Phase = "post-apply"
+	// PrePrune hooks run before the objects are pruned. They only run if
+	// objects are pruned.
+	PrePrune Phase = "pre-prune"
+)
+
+var (
+	jobGK = schema.GroupKind{Group: "batch", Kind: "Job"}
+	podGK = schema.GroupKind{Group: "", Kind: "Pod"}
+)
+
+// HasAnnotation returns true if the hook annotation is present, false if
+// not.
+func HasAnnotation(u *unstructured.Unstructured) bool {
+	if u == nil {
+		return false
+	}
+	_, found := u.GetAnnotations()[Annotation]
+	return found
+}
+
+// ReadAnnotation returns the phase of the hook, or an empty phase if the
+// object is not a hook. Only Jobs and Pods can be hooks.
+func ReadAnnotation(u *unstructured.Unstructured) (Phase, error) {
+	if u == nil {
+		return "", nil
+	}
+	value, found := u.GetAnnotations()[Annotation]
+	if !found {
+		return "", nil
+	}
+	gk := u.GroupVersionKind().GroupKind()
+	if gk != jobGK && gk != podGK {
+		return "", object.InvalidAnnotationError{
+			Annotation: Annotation,
+			Cause:      fmt.Errorf("hooks must be Jobs or Pods, not %s", gk),
+		}
+	}
+	phase := Phase(value)
+	switch phase {
+	case PreApply, PostApply, PrePrune:
+		return phase, nil
+	default:
+		return "", object.InvalidAnnotationError{
+			Annotation: Annotation,
+			Cause: fmt.Errorf("unknown phase %q, must be one of %q, %q, or %q",
+				value, PreApply, PostApply, PrePrune),
+		}
+	}
+}
+
+// DeleteOnSuccess returns true if the hook must be deleted after it
+// succeeds.
+func DeleteOnSuccess(u *unstructured.Unstructured) (bool, error) {
+	if u == nil {
+		return false, nil
+	}
+	value, found := u.GetAnnotations()[DeletePolicyAnnotation]
+	if !found {
+		return false, nil
+	}
+	if value != DeleteOnSucceeded {
+		return false, object.InvalidAnnotationError{
+			Annotation: DeletePolicyAnnotation,
+			Cause:      fmt.Errorf("unknown delete policy %q, must be %q", value, DeleteOnSucceeded),
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package hook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func newObject(apiVersion, kind string, annotations map[string]interface{}) *unstructured.Unstructured {
+	metadata := map[string]interface{}{
+		"name":      "migrate",
+		"namespace": "default",
+	}
+	if annotations != nil {
+		metadata["annotations"] = annotations
+	}
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"metadata":   metadata,
+		},
+	}
+}
+
+func TestReadAnnotation(t *testing.T) {
+	testCases := map[string]struct {
+		obj           *unstructured.Unstructured
+		expected      Phase
+		expectedError string
+	}{
+		"nil object": {
+			obj: nil,
+		},
+		"no annotation": {
+			obj: newObject("batch/v1", "Job", nil),
+		},
+		"pre-apply job": {
+			obj:      newObject("batch/v1", "Job", map[string]interface{}{Annotation: "pre-apply"}),
+			expected: PreApply,
+		},
+		"post-apply pod": {
+			obj:      newObject("v1", "Pod", map[string]interface{}{Annotation: "post-apply"}),
+			expected: PostApply,
+		},
+		"pre-prune job": {
+			obj:      newObject("batch/v1", "Job", map[string]interface{}{Annotation: "pre-prune"}),
+			expected: PrePrune,
+		},
+		"unknown phase": {
+			obj: newObject("batch/v1", "Job", map[string]interface{}{Annotation: "post-delete"}),
+			expectedError: `invalid "cli-utils.sigs.k8s.io/hook" annotation: ` +
+				`unknown phase "post-delete", must be one of "pre-apply", "post-apply", or "pre-prune"`,
+		},
+		"not a job or pod": {
+			obj: newObject("v1", "ConfigMap", map[string]interface{}{Annotation: "pre-apply"}),
+			expectedError: `invalid "cli-utils.sigs.k8s.io/hook" annotation: ` +
+				`hooks must be Jobs or Pods, not ConfigMap`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			phase, err := ReadAnnotation(tc.obj)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, phase)
+			assert.Equal(t, tc.expected != "", HasAnnotation(tc.obj))
+		})
+	}
+}
+
+func TestDeleteOnSuccess(t *testing.T) {
+	testCases := map[string]struct {
+		annotations   map[string]interface{}
+		expected      bool
+		expectedError string
+	}{
+		"no annotation": {},
+		"hook-succeeded": {
+			annotations: map[string]interface{}{DeletePolicyAnnotation: "hook-succeeded"},
+			expected:    true,
+		},
+		"unknown policy": {
+			annotations: map[string]interface{}{DeletePolicyAnnotation: "before-hook-creation"},
+			expectedError: `invalid "cli-utils.sigs.k8s.io/hook-delete-policy" annotation: ` +
+				`unknown delete policy "before-hook-creation", must be "hook-succeeded"`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			deleteOnSuccess, err := DeleteOnSuccess(newObject("batch/v1", "Job", tc.annotations))
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, deleteOnSuccess)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package object
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// RedactedValue replaces the values of redacted fields.
+const RedactedValue = "<redacted>"
+
+// Redactor redacts sensitive values from objects before they are reported
+// in events, errors, or logs. The values of the data and stringData of
+// Secrets are always redacted. A nil Redactor only redacts Secrets.
+//
+// The same values are redacted from the last-applied-configuration
+// annotation, which contains a copy of the applied object.
+type Redactor struct {
+	// FieldPatterns are matched against the paths of the fields of every
+	// object, formatted with FieldPath, like `^\.spec\.password$`. The
+	// values of matching fields are redacted.
+	FieldPatterns []*regexp.Regexp
+}
+
+// Redact returns a copy of the object with the sensitive values redacted.
+// The object is returned unchanged if nothing is redacted.
+func (r *Redactor) Redact(u *unstructured.Unstructured) *unstructured.Unstructured {
+	if u == nil || !r.hasRedactedFields(u) {
+		return u
+	}
+	u = u.DeepCopy()
+	secret := IsSecret(u)
+	r.redactValue(secret, nil, u.Object)
+	r.redactLastApplied(secret, u)
+	return u
+}
+
+// redactLastApplied redacts the values of the redacted fields from the
+// last-applied-configuration annotation of the object. The whole annotation
+// is redacted if it can't be parsed.
+func (r *Redactor) redactLastApplied(secret bool, u *unstructured.Unstructured) {
+	annotations := u.GetAnnotations()
+	lastApplied, found := annotations[corev1.LastAppliedConfigAnnotation]
+	if !found {
+		return
+	}
+	redacted := RedactedValue
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(lastApplied), &obj); err == nil {
+		r.redactValue(secret, nil, obj)
+		// The redacted value is not escaped, so it stays readable.
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(obj); err == nil {
+			redacted = strings.TrimSuffix(buf.String(), "\n")
+		}
+	}
+	annotations[corev1.LastAppliedConfigAnnotation] = redacted
+	u.SetAnnotations(annotations)
+}
+
+// RedactDiffs returns a copy of the diffs between two versions of the
+// object, with the sensitive values redacted. Changed fields are still
+// reported, without their values.
+func (r *Redactor) RedactDiffs(u *unstructured.Unstructured, diffs []FieldDiff) []FieldDiff {
+	secret := IsSecret(u)
+	var result []FieldDiff
+	for _, d := range diffs {
+		if r.redactsPath(secret, d.Path) {
+			d.Old = redactedOrUnset(d.Old)
+			d.New = redactedOrUnset(d.New)
+		}
+		result = append(result, d)
+	}
+	return result
+}
+
+func redactedOrUnset(v interface{}) interface{} {
+	if v == nil {
+		return nil
+	}
+	return RedactedValue
+}
+
+// hasRedactedFields returns true if any field of the object is redacted, so
+// objects without sensitive values are not copied.
+func (r *Redactor) hasRedactedFields(u *unstructured.Unstructured) bool {
+	if IsSecret(u) {
+		return true
+	}
+	return r != nil && len(r.FieldPatterns) > 0
+}
+
+// redactValue replaces the values of the redacted fields under the path.
+func (r *Redactor) redactValue(secret bool, path []interface{}, value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case map[string]interface{}:
+		for k, v := range typedValue {
+			typedValue[k] = r.redactValue(secret, appendPath(path, k), v)
+		}
+		return typedValue
+	case []interface{}:
+		for i, v := range typedValue {
+			typedValue[i] = r.redactValue(secret, appendPath(path, i), v)
+		}
+		return typedValue
+	}
+	if value != nil && r.redactsPath(secret, FieldPath(path)) {
+		return RedactedValue
+	}
+	return value
+}
+
+// secretDataPathRegex matches the paths of the values in the data and
+// stringData of Secrets.
+var secretDataPathRegex = regexp.MustCompile(`^\.(data|stringData)($|\.|\[)`)
+
+// redactsPath returns true if the value of the field with the path is
+// redacted.
+func (r *Redactor) redactsPath(secret bool, path string) bool {
+	if secret && secretDataPathRegex.MatchString(path) {
+		return true
+	}
+	if r == nil {
+		return false
+	}
+	for _, pattern := range r.FieldPatterns {
+		if pattern.MatchString(path) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,213 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package object
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestRedactor_Redact(t *testing.T) {
+	testCases := map[string]struct {
+		redactor *Redactor
+		obj      map[string]interface{}
+		expected map[string]interface{}
+	}{
+		"secret data with nil redactor": {
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"data": map[string]interface{}{
+					"password": "cGFzcw==",
+				},
+				"stringData": map[string]interface{}{
+					"token": "abc",
+				},
+				"type": "Opaque",
+			},
+			expected: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"data": map[string]interface{}{
+					"password": RedactedValue,
+				},
+				"stringData": map[string]interface{}{
+					"token": RedactedValue,
+				},
+				"type": "Opaque",
+			},
+		},
+		"configmap data is not redacted": {
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"data": map[string]interface{}{
+					"key": "value",
+				},
+			},
+			expected: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "ConfigMap",
+				"data": map[string]interface{}{
+					"key": "value",
+				},
+			},
+		},
+		"secret last-applied-configuration": {
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"kubectl.kubernetes.io/last-applied-configuration": `{"apiVersion":"v1","data":{"password":"cGFzcw=="},"kind":"Secret"}`,
+					},
+				},
+				"data": map[string]interface{}{
+					"password": "cGFzcw==",
+				},
+			},
+			expected: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"kubectl.kubernetes.io/last-applied-configuration": `{"apiVersion":"v1","data":{"password":"<redacted>"},"kind":"Secret"}`,
+					},
+				},
+				"data": map[string]interface{}{
+					"password": RedactedValue,
+				},
+			},
+		},
+		"invalid last-applied-configuration": {
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"kubectl.kubernetes.io/last-applied-configuration": `{"data":`,
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Secret",
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"kubectl.kubernetes.io/last-applied-configuration": RedactedValue,
+					},
+				},
+			},
+		},
+		"last-applied-configuration fields matching patterns": {
+			redactor: &Redactor{
+				FieldPatterns: []*regexp.Regexp{
+					regexp.MustCompile(`^\.spec\.password$`),
+				},
+			},
+			obj: map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Database",
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"kubectl.kubernetes.io/last-applied-configuration": `{"spec":{"password":"pass","user":"admin"}}`,
+					},
+				},
+				"spec": map[string]interface{}{
+					"password": "pass",
+					"user":     "admin",
+				},
+			},
+			expected: map[string]interface{}{
+				"apiVersion": "example.com/v1",
+				"kind":       "Database",
+				"metadata": map[string]interface{}{
+					"annotations": map[string]interface{}{
+						"kubectl.kubernetes.io/last-applied-configuration": `{"spec":{"password":"<redacted>","user":"admin"}}`,
+					},
+				},
+				"spec": map[string]interface{}{
+					"password": RedactedValue,
+					"user":     "admin",
+				},
+			},
+		},
+		"fields matching patterns": {
+			redactor: &Redactor{
+				FieldPatterns: []*regexp.Regexp{
+					regexp.MustCompile(`\.env\[\d+\]\.value$`),
+				},
+			},
+			obj: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name": "app",
+							"env": []interface{}{
+								map[string]interface{}{
+									"name":  "PASSWORD",
+									"value": "pass",
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "Pod",
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name": "app",
+							"env": []interface{}{
+								map[string]interface{}{
+									"name":  "PASSWORD",
+									"value": RedactedValue,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: tc.obj}
+			original := obj.DeepCopy()
+			result := tc.redactor.Redact(obj)
+			assert.Equal(t, tc.expected, result.Object)
+			// the input must not be modified
+			assert.Equal(t, original, obj)
+		})
+	}
+}
+
+func TestRedactor_RedactDiffs(t *testing.T) {
+	secret := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+		},
+	}
+	diffs := []FieldDiff{
+		{Path: ".data", New: map[string]interface{}{"password": "cGFzcw=="}},
+		{Path: ".data.token", Old: "YQ=="},
+		{Path: ".metadata.labels.app", Old: "a", New: "b"},
+	}
+	expected := []FieldDiff{
+		{Path: ".data", New: RedactedValue},
+		{Path: ".data.token", Old: RedactedValue},
+		{Path: ".metadata.labels.app", Old: "a", New: "b"},
+	}
+	var redactor *Redactor
+	assert.Equal(t, expected, redactor.RedactDiffs(secret, diffs))
+}
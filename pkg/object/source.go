@@ -0,0 +1,69 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package object
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+)
+
+// SourceLineAnnotation is set by the manifest readers to the line of the
+// manifest file where the object starts. Like the kyaml path annotation,
+// it is removed before the object is applied.
+const SourceLineAnnotation = "internal.cli-utils.sigs.k8s.io/line"
+
+// SourcePosition returns the file and line the object was read from, like
+// "config/deployment.yaml:12", or only the file if the line is not known.
+// Returns an empty string if the source of the object is not known.
+func SourcePosition(u *unstructured.Unstructured) string {
+	if u == nil {
+		return ""
+	}
+	annos := u.GetAnnotations()
+	path, found := annos[kioutil.PathAnnotation]
+	if !found {
+		path = annos[kioutil.LegacyPathAnnotation] //nolint:staticcheck
+	}
+	if path == "" {
+		return ""
+	}
+	if line := annos[SourceLineAnnotation]; line != "" {
+		return fmt.Sprintf("%s:%s", path, line)
+	}
+	return path
+}
+
+// SourceError wraps an error with the source position of the object it
+// applies to.
+type SourceError struct {
+	Source string
+	Err    error
+}
+
+func (e *SourceError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Source, e.Err)
+}
+
+// Unwrap returns the wrapped error.
+func (e *SourceError) Unwrap() error {
+	return e.Err
+}
+
+// WithSource wraps the error with the source position of the object, if
+// known. Otherwise the error is returned unchanged.
+func WithSource(u *unstructured.Unstructured, err error) error {
+	if err == nil {
+		return nil
+	}
+	source := SourcePosition(u)
+	if source == "" {
+		return err
+	}
+	return &SourceError{
+		Source: source,
+		Err:    err,
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package object
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/kustomize/kyaml/kio/kioutil"
+)
+
+func TestSourcePosition(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		expected    string
+	}{
+		"no source": {
+			expected: "",
+		},
+		"path only": {
+			annotations: map[string]string{
+				kioutil.PathAnnotation: "dep.yaml",
+			},
+			expected: "dep.yaml",
+		},
+		"path and line": {
+			annotations: map[string]string{
+				kioutil.PathAnnotation: "config/dep.yaml",
+				SourceLineAnnotation:   "12",
+			},
+			expected: "config/dep.yaml:12",
+		},
+		"line without path": {
+			annotations: map[string]string{
+				SourceLineAnnotation: "12",
+			},
+			expected: "",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			u.SetAnnotations(tc.annotations)
+			assert.Equal(t, tc.expected, SourcePosition(u))
+		})
+	}
+}
+
+func TestWithSource(t *testing.T) {
+	cause := errors.New("name is required")
+
+	u := &unstructured.Unstructured{Object: map[string]interface{}{}}
+	assert.Equal(t, cause, WithSource(u, cause))
+	assert.NoError(t, WithSource(u, nil))
+
+	u.SetAnnotations(map[string]string{
+		kioutil.PathAnnotation: "dep.yaml",
+		SourceLineAnnotation:   "3",
+	})
+	err := WithSource(u, cause)
+	assert.EqualError(t, err, "dep.yaml:3: name is required")
+	assert.ErrorIs(t, err, cause)
+}
@@ -13,13 +13,16 @@ import (
 // YamlStringer delays YAML marshalling for logging until String() is called.
 type YamlStringer struct {
 	O *unstructured.Unstructured
+	// Redactor redacts the sensitive values of the object before it is
+	// marshalled. If nil, only the values of Secrets are redacted.
+	Redactor *Redactor
 }
 
 // String marshals the wrapped object to a YAML string. If serializing errors,
 // the error string will be returned instead. This is primarily for use with
-// verbose logging.
+// verbose logging, so sensitive values are redacted.
 func (ys YamlStringer) String() string {
-	yamlBytes, err := yaml.Marshal(ys.O)
+	yamlBytes, err := yaml.Marshal(ys.Redactor.Redact(ys.O))
 	if err != nil {
 		return fmt.Sprintf("<<failed to serialize as yaml: %s>>", err)
 	}
@@ -0,0 +1,123 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"strings"
+
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Image replaces the container images with the specified name. If NewName
+// is empty, the name is left unchanged. If Digest is set, it takes
+// precedence over NewTag.
+type Image struct {
+	Name    string
+	NewName string
+	NewTag  string
+	Digest  string
+}
+
+// ImageOverrides replaces the images of the containers, init containers,
+// and ephemeral containers of every object, wherever they are in the
+// object, like the pod template of a Deployment or the job template of a
+// CronJob.
+type ImageOverrides []Image
+
+var _ Transformer = ImageOverrides{}
+
+// Name returns the name of the transformer.
+func (ImageOverrides) Name() string {
+	return "image-overrides"
+}
+
+// Transform replaces the images of the objects.
+func (o ImageOverrides) Transform(objs object.UnstructuredSet) (object.UnstructuredSet, error) {
+	if len(o) == 0 {
+		return objs, nil
+	}
+	for _, obj := range objs {
+		o.walk(obj.Object)
+	}
+	return objs, nil
+}
+
+// walk replaces the images of the containers found in the value,
+// recursively.
+func (o ImageOverrides) walk(value interface{}) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isContainerList(key) {
+				o.overrideContainers(field)
+				continue
+			}
+			o.walk(field)
+		}
+	case []interface{}:
+		for _, item := range v {
+			o.walk(item)
+		}
+	}
+}
+
+func isContainerList(key string) bool {
+	return key == "containers" || key == "initContainers" || key == "ephemeralContainers"
+}
+
+func (o ImageOverrides) overrideContainers(value interface{}) {
+	containers, ok := value.([]interface{})
+	if !ok {
+		return
+	}
+	for _, c := range containers {
+		container, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		image, ok := container["image"].(string)
+		if !ok {
+			continue
+		}
+		container["image"] = o.override(image)
+	}
+}
+
+// override returns the image with the first matching override applied.
+func (o ImageOverrides) override(image string) string {
+	name, tag, digest := parseImage(image)
+	for _, img := range o {
+		if img.Name != name {
+			continue
+		}
+		if img.NewName != "" {
+			name = img.NewName
+		}
+		switch {
+		case img.Digest != "":
+			return name + "@" + img.Digest
+		case img.NewTag != "":
+			return name + ":" + img.NewTag
+		case digest != "":
+			return name + "@" + digest
+		case tag != "":
+			return name + ":" + tag
+		default:
+			return name
+		}
+	}
+	return image
+}
+
+// parseImage splits an image reference into the name, tag, and digest.
+// The tag and digest are empty if not set.
+func parseImage(image string) (name, tag, digest string) {
+	name, digest, _ = strings.Cut(image, "@")
+	// A colon after the last slash separates the tag. A colon before it is
+	// the port of the registry.
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name, tag = name[:i], name[i+1:]
+	}
+	return name, tag, digest
+}
@@ -0,0 +1,66 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+func TestImageOverrides(t *testing.T) {
+	testCases := map[string]struct {
+		overrides      ImageOverrides
+		expectedImages []string
+	}{
+		"no overrides": {
+			expectedImages: []string{"busybox", "registry.example.com:5000/app:v1", "proxy@sha256:1234"},
+		},
+		"new tag keeps the registry port": {
+			overrides:      ImageOverrides{{Name: "registry.example.com:5000/app", NewTag: "v2"}},
+			expectedImages: []string{"busybox", "registry.example.com:5000/app:v2", "proxy@sha256:1234"},
+		},
+		"new name keeps the tag and digest": {
+			overrides: ImageOverrides{
+				{Name: "registry.example.com:5000/app", NewName: "mirror.example.com/app"},
+				{Name: "proxy", NewName: "mirror.example.com/proxy"},
+			},
+			expectedImages: []string{"busybox", "mirror.example.com/app:v1", "mirror.example.com/proxy@sha256:1234"},
+		},
+		"digest replaces the tag": {
+			overrides:      ImageOverrides{{Name: "busybox", Digest: "sha256:abcd"}},
+			expectedImages: []string{"busybox@sha256:abcd", "registry.example.com:5000/app:v1", "proxy@sha256:1234"},
+		},
+		"tag replaces the digest": {
+			overrides:      ImageOverrides{{Name: "proxy", NewTag: "v3"}},
+			expectedImages: []string{"busybox", "registry.example.com:5000/app:v1", "proxy:v3"},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			dep := testutil.Unstructured(t, deploymentManifest)
+			_, err := tc.overrides.Transform(object.UnstructuredSet{dep})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expectedImages, images(t, dep))
+		})
+	}
+}
+
+func images(t *testing.T, obj *unstructured.Unstructured) []string {
+	var result []string
+	for _, field := range []string{"initContainers", "containers"} {
+		containers, _, err := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", field)
+		require.NoError(t, err)
+		for _, c := range containers {
+			result = append(result, c.(map[string]interface{})["image"].(string))
+		}
+	}
+	return result
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// CommonLabels adds the labels to the metadata of every object, overwriting
+// any existing labels with the same keys. Label selectors and pod template
+// labels are not changed, since selectors are often immutable.
+type CommonLabels map[string]string
+
+var _ Transformer = CommonLabels{}
+
+// Name returns the name of the transformer.
+func (CommonLabels) Name() string {
+	return "common-labels"
+}
+
+// Transform adds the labels to the objects.
+func (l CommonLabels) Transform(objs object.UnstructuredSet) (object.UnstructuredSet, error) {
+	for _, obj := range objs {
+		object.AddLabels(obj, l)
+	}
+	return objs, nil
+}
+
+// CommonAnnotations adds the annotations to the metadata of every object,
+// overwriting any existing annotations with the same keys.
+type CommonAnnotations map[string]string
+
+var _ Transformer = CommonAnnotations{}
+
+// Name returns the name of the transformer.
+func (CommonAnnotations) Name() string {
+	return "common-annotations"
+}
+
+// Transform adds the annotations to the objects.
+func (a CommonAnnotations) Transform(objs object.UnstructuredSet) (object.UnstructuredSet, error) {
+	for _, obj := range objs {
+		object.AddAnnotations(obj, a)
+	}
+	return objs, nil
+}
+
+// NameAffix adds a prefix and a suffix to the name of every object.
+//
+// The inventory object, CRDs, and Namespaces are not renamed, since their
+// names are referenced by the inventory, the API group of the CRD, and the
+// namespaced objects. References to the renamed objects, like in the
+// depends-on annotation, are not updated.
+type NameAffix struct {
+	Prefix string
+	Suffix string
+}
+
+var _ Transformer = NameAffix{}
+
+// Name returns the name of the transformer.
+func (NameAffix) Name() string {
+	return "name-affix"
+}
+
+// Transform renames the objects.
+func (n NameAffix) Transform(objs object.UnstructuredSet) (object.UnstructuredSet, error) {
+	for _, obj := range objs {
+		if skipRename(obj) {
+			continue
+		}
+		obj.SetName(n.Prefix + obj.GetName() + n.Suffix)
+	}
+	return objs, nil
+}
+
+func skipRename(obj *unstructured.Unstructured) bool {
+	if inventory.IsInventoryObject(obj) || object.IsCRD(obj) {
+		return true
+	}
+	gk := obj.GroupVersionKind().GroupKind()
+	return gk.Group == "" && gk.Kind == "Namespace"
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/dependson"
+)
+
+// NamespaceFanOut copies the namespaced objects into each of the
+// Namespaces, so the same baseline objects can be applied to many
+// namespaces. The namespaced objects must all be in the same template
+// namespace, usually set by the manifest reader, unless the Template is set.
+//
+// Each copy is moved to its namespace, and its depends-on references to
+// objects in the template namespace are moved with it. A Namespace object
+// named after the template namespace is copied once per namespace, with the
+// name of the namespace. The other cluster-scoped objects are kept once.
+type NamespaceFanOut struct {
+	Namespaces []string
+	// Template is the template namespace. By default, it is the namespace
+	// of the namespaced objects.
+	Template string
+}
+
+var _ Transformer = NamespaceFanOut{}
+
+// Name returns the name of the transformer.
+func (NamespaceFanOut) Name() string {
+	return "namespace-fan-out"
+}
+
+// Transform returns the cluster-scoped objects, followed by the copies of
+// the namespaced objects in each namespace, in the order of the Namespaces.
+func (f NamespaceFanOut) Transform(objs object.UnstructuredSet) (object.UnstructuredSet, error) {
+	template := f.Template
+	if template == "" {
+		var err error
+		template, err = TemplateNamespace(objs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var clusterObjs, namespacedObjs object.UnstructuredSet
+	for _, obj := range objs {
+		if obj.GetNamespace() == "" && !isTemplateNamespace(obj, template) {
+			clusterObjs = append(clusterObjs, obj)
+		} else {
+			namespacedObjs = append(namespacedObjs, obj)
+		}
+	}
+	result := clusterObjs
+	for _, namespace := range f.Namespaces {
+		for _, obj := range namespacedObjs {
+			obj, err := moveToNamespace(obj, template, namespace)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, obj)
+		}
+	}
+	return result, nil
+}
+
+// TemplateNamespace returns the namespace of the namespaced objects, or an
+// empty string if there are none. It returns an error if the objects are in
+// more than one namespace.
+func TemplateNamespace(objs object.UnstructuredSet) (string, error) {
+	namespaces := sets.New[string]()
+	for _, obj := range objs {
+		if ns := obj.GetNamespace(); ns != "" {
+			namespaces.Insert(ns)
+		}
+	}
+	switch namespaces.Len() {
+	case 0:
+		return "", nil
+	case 1:
+		return namespaces.UnsortedList()[0], nil
+	default:
+		list := namespaces.UnsortedList()
+		sort.Strings(list)
+		return "", fmt.Errorf("namespaced objects must be in one namespace to be fanned out, found %v", list)
+	}
+}
+
+func isTemplateNamespace(obj *unstructured.Unstructured, template string) bool {
+	gk := obj.GroupVersionKind().GroupKind()
+	return template != "" && gk.Group == "" && gk.Kind == "Namespace" && obj.GetName() == template
+}
+
+// moveToNamespace returns a copy of the object in the namespace.
+func moveToNamespace(obj *unstructured.Unstructured, template, namespace string) (*unstructured.Unstructured, error) {
+	obj = obj.DeepCopy()
+	if isTemplateNamespace(obj, template) {
+		obj.SetName(namespace)
+		return obj, nil
+	}
+	obj.SetNamespace(namespace)
+	if !dependson.HasAnnotation(obj) {
+		return obj, nil
+	}
+	deps, err := dependson.ReadAnnotation(obj)
+	if err != nil {
+		return nil, err
+	}
+	for i := range deps {
+		if deps[i].Namespace == template {
+			deps[i].Namespace = namespace
+		}
+	}
+	if err := dependson.WriteAnnotation(obj, deps); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
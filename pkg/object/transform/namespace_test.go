@@ -0,0 +1,76 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/dependson"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var configMapManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+  namespace: default
+`
+
+var clusterRoleManifest = `
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+`
+
+func TestNamespaceFanOut(t *testing.T) {
+	dep := testutil.Unstructured(t, deploymentManifest)
+	dep.SetAnnotations(map[string]string{
+		dependson.Annotation: "/namespaces/default/ConfigMap/config,rbac.authorization.k8s.io/ClusterRole/reader",
+	})
+	objs := object.UnstructuredSet{
+		testutil.Unstructured(t, namespaceManifest),
+		testutil.Unstructured(t, clusterRoleManifest),
+		testutil.Unstructured(t, configMapManifest),
+		dep,
+	}
+
+	result, err := NamespaceFanOut{Namespaces: []string{"a", "b"}}.Transform(objs)
+	require.NoError(t, err)
+
+	var ids []string
+	for _, obj := range result {
+		ids = append(ids, object.UnstructuredToObjMetadata(obj).String())
+	}
+	assert.Equal(t, []string{
+		"_reader_rbac.authorization.k8s.io_ClusterRole",
+		"_a__Namespace",
+		"a_config__ConfigMap",
+		"a_dep_apps_Deployment",
+		"_b__Namespace",
+		"b_config__ConfigMap",
+		"b_dep_apps_Deployment",
+	}, ids)
+
+	deps, err := dependson.ReadAnnotation(result[3])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a_config__ConfigMap", "_reader_rbac.authorization.k8s.io_ClusterRole"},
+		[]string{deps[0].String(), deps[1].String()})
+
+	// The template objects are not modified.
+	assert.Equal(t, "default", dep.GetNamespace())
+}
+
+func TestNamespaceFanOut_ManyNamespaces(t *testing.T) {
+	other := testutil.Unstructured(t, configMapManifest)
+	other.SetNamespace("other")
+	objs := object.UnstructuredSet{testutil.Unstructured(t, configMapManifest), other}
+
+	_, err := NamespaceFanOut{Namespaces: []string{"a"}}.Transform(objs)
+	assert.EqualError(t, err, "namespaced objects must be in one namespace to be fanned out, found [default other]")
+}
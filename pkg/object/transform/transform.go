@@ -0,0 +1,97 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package transform contains transformers that modify the objects read from
+// the manifests before they are validated and applied.
+package transform
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// Transformer modifies a set of objects. Transformers may modify the
+// objects in place, and may return a different set of objects, for example
+// to add or filter out objects. Run them in a Pipeline to keep the objects
+// of the caller unchanged.
+type Transformer interface {
+	// Name returns the name of the transformer, used in errors.
+	Name() string
+	// Transform returns the transformed objects.
+	Transform(objs object.UnstructuredSet) (object.UnstructuredSet, error)
+}
+
+// TransformerFunc adapts a function to the Transformer interface.
+type TransformerFunc struct {
+	TransformerName string
+	Func            func(objs object.UnstructuredSet) (object.UnstructuredSet, error)
+}
+
+var _ Transformer = TransformerFunc{}
+
+// Name returns the name of the transformer.
+func (t TransformerFunc) Name() string {
+	return t.TransformerName
+}
+
+// Transform calls the function.
+func (t TransformerFunc) Transform(objs object.UnstructuredSet) (object.UnstructuredSet, error) {
+	return t.Func(objs)
+}
+
+// Pipeline runs a sequence of transformers in order, each receiving the
+// objects returned by the previous one. A Pipeline is itself a
+// Transformer, so pipelines can be nested.
+type Pipeline struct {
+	transformers []Transformer
+}
+
+var _ Transformer = &Pipeline{}
+
+// NewPipeline returns a Pipeline that runs the transformers in order.
+func NewPipeline(transformers ...Transformer) *Pipeline {
+	p := &Pipeline{}
+	for _, t := range transformers {
+		p.Register(t)
+	}
+	return p
+}
+
+// Register adds a transformer to the end of the pipeline.
+func (p *Pipeline) Register(t Transformer) {
+	p.transformers = append(p.transformers, t)
+}
+
+// Transformers returns the transformers of the pipeline, in order.
+func (p *Pipeline) Transformers() []Transformer {
+	return append([]Transformer{}, p.transformers...)
+}
+
+// Name returns the name of the pipeline.
+func (p *Pipeline) Name() string {
+	return "pipeline"
+}
+
+// Transform runs the transformers of the pipeline on copies of the objects,
+// so the objects of the caller are not changed. It stops at the first
+// transformer that returns an error.
+func (p *Pipeline) Transform(objs object.UnstructuredSet) (object.UnstructuredSet, error) {
+	objs = deepCopy(objs)
+	for _, t := range p.transformers {
+		var err error
+		objs, err = t.Transform(objs)
+		if err != nil {
+			return nil, fmt.Errorf("transformer %q failed: %w", t.Name(), err)
+		}
+	}
+	return objs, nil
+}
+
+func deepCopy(objs object.UnstructuredSet) object.UnstructuredSet {
+	copies := make(object.UnstructuredSet, len(objs))
+	for i, obj := range objs {
+		copies[i] = obj.DeepCopy()
+	}
+	return copies
+}
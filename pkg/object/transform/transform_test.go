@@ -0,0 +1,143 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package transform
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var deploymentManifest = `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: dep
+  namespace: default
+  labels:
+    app: dep
+spec:
+  template:
+    spec:
+      initContainers:
+      - name: init
+        image: busybox
+      containers:
+      - name: app
+        image: registry.example.com:5000/app:v1
+      - name: sidecar
+        image: proxy@sha256:1234
+`
+
+var namespaceManifest = `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: default
+`
+
+var inventoryManifest = `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: inventory
+  namespace: default
+  labels:
+    ` + common.InventoryLabel + `: test
+`
+
+func TestPipeline(t *testing.T) {
+	dep := testutil.Unstructured(t, deploymentManifest)
+
+	var names []string
+	record := func(name string) Transformer {
+		return TransformerFunc{
+			TransformerName: name,
+			Func: func(objs object.UnstructuredSet) (object.UnstructuredSet, error) {
+				names = append(names, name)
+				return objs, nil
+			},
+		}
+	}
+	filter := TransformerFunc{
+		TransformerName: "filter",
+		Func: func(object.UnstructuredSet) (object.UnstructuredSet, error) {
+			return nil, nil
+		},
+	}
+
+	pipeline := NewPipeline(record("first"), filter)
+	pipeline.Register(record("second"))
+	objs, err := pipeline.Transform(object.UnstructuredSet{dep})
+	require.NoError(t, err)
+	assert.Empty(t, objs)
+	assert.Equal(t, []string{"first", "second"}, names)
+	assert.Len(t, pipeline.Transformers(), 3)
+
+	failing := NewPipeline(TransformerFunc{
+		TransformerName: "failing",
+		Func: func(object.UnstructuredSet) (object.UnstructuredSet, error) {
+			return nil, errors.New("boom")
+		},
+	}, record("never"))
+	_, err = failing.Transform(object.UnstructuredSet{dep})
+	assert.EqualError(t, err, `transformer "failing" failed: boom`)
+	assert.Equal(t, []string{"first", "second"}, names)
+}
+
+func TestCommonMetadata(t *testing.T) {
+	dep := testutil.Unstructured(t, deploymentManifest)
+	ns := testutil.Unstructured(t, namespaceManifest)
+
+	objs, err := NewPipeline(
+		CommonLabels{"app": "override", "team": "a"},
+		CommonAnnotations{"owner": "a"},
+	).Transform(object.UnstructuredSet{dep, ns})
+	require.NoError(t, err)
+	require.Len(t, objs, 2)
+
+	assert.Equal(t, map[string]string{"app": "override", "team": "a"}, objs[0].GetLabels())
+	assert.Equal(t, map[string]string{"team": "a", "app": "override"}, objs[1].GetLabels())
+	assert.Equal(t, map[string]string{"owner": "a"}, objs[0].GetAnnotations())
+	assert.Equal(t, map[string]string{"owner": "a"}, objs[1].GetAnnotations())
+
+	// The objects of the caller are not changed.
+	assert.Equal(t, map[string]string{"app": "dep"}, dep.GetLabels())
+	assert.Empty(t, ns.GetLabels())
+	assert.Empty(t, dep.GetAnnotations())
+}
+
+func TestNameAffix(t *testing.T) {
+	testCases := map[string]struct {
+		obj          *unstructured.Unstructured
+		expectedName string
+	}{
+		"deployment is renamed": {
+			obj:          testutil.Unstructured(t, deploymentManifest),
+			expectedName: "pre-dep-suf",
+		},
+		"namespace is not renamed": {
+			obj:          testutil.Unstructured(t, namespaceManifest),
+			expectedName: "default",
+		},
+		"inventory is not renamed": {
+			obj:          testutil.Unstructured(t, inventoryManifest),
+			expectedName: "inventory",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			_, err := NameAffix{Prefix: "pre-", Suffix: "-suf"}.Transform(object.UnstructuredSet{tc.obj})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedName, tc.obj.GetName())
+		})
+	}
+}
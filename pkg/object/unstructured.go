@@ -67,6 +67,17 @@ func IsNamespace(u *unstructured.Unstructured) bool {
 	return gvk.Group == "" && gvk.Kind == "Namespace"
 }
 
+// IsSecret returns true if the passed Unstructured object
+// is Secret in the core (empty string) group.
+func IsSecret(u *unstructured.Unstructured) bool {
+	if u == nil {
+		return false
+	}
+	gvk := u.GroupVersionKind()
+	// core group, any version
+	return gvk.Group == "" && gvk.Kind == "Secret"
+}
+
 // IsCRD returns true if the passed Unstructured object has
 // GroupKind == Extensions/CustomResourceDefinition; false otherwise.
 func IsCRD(u *unstructured.Unstructured) bool {
@@ -202,13 +213,50 @@ func crdDefinesVersion(crd *unstructured.Unstructured, version string) (bool, er
 	return false, nil
 }
 
-// StripKyamlAnnotations removes any path and index annotations from the
-// unstructured resource.
+// StripKyamlAnnotations removes any path, line, and index annotations from
+// the unstructured resource.
 func StripKyamlAnnotations(u *unstructured.Unstructured) {
 	annos := u.GetAnnotations()
+	delete(annos, SourceLineAnnotation)
 	delete(annos, kioutil.PathAnnotation)
 	delete(annos, kioutil.LegacyPathAnnotation) //nolint:staticcheck
 	delete(annos, kioutil.IndexAnnotation)
 	delete(annos, kioutil.LegacyIndexAnnotation) //nolint:staticcheck
 	u.SetAnnotations(annos)
 }
+
+// AddLabels adds the labels to the metadata of the object, overwriting any
+// existing labels with the same keys. Returns true if the object changed.
+func AddLabels(u *unstructured.Unstructured, labels map[string]string) bool {
+	merged, changed := mergeStringMap(u.GetLabels(), labels)
+	if changed {
+		u.SetLabels(merged)
+	}
+	return changed
+}
+
+// AddAnnotations adds the annotations to the metadata of the object,
+// overwriting any existing annotations with the same keys. Returns true if
+// the object changed.
+func AddAnnotations(u *unstructured.Unstructured, annotations map[string]string) bool {
+	merged, changed := mergeStringMap(u.GetAnnotations(), annotations)
+	if changed {
+		u.SetAnnotations(merged)
+	}
+	return changed
+}
+
+func mergeStringMap(dst, src map[string]string) (map[string]string, bool) {
+	changed := false
+	for k, v := range src {
+		if old, found := dst[k]; found && old == v {
+			continue
+		}
+		if dst == nil {
+			dst = make(map[string]string, len(src))
+		}
+		dst[k] = v
+		changed = true
+	}
+	return dst, changed
+}
@@ -334,3 +334,45 @@ func TestLookupResourceScope(t *testing.T) {
 		})
 	}
 }
+
+func TestAddLabels(t *testing.T) {
+	tests := map[string]struct {
+		labels          map[string]string
+		add             map[string]string
+		expectedLabels  map[string]string
+		expectedChanged bool
+	}{
+		"no labels to add": {
+			labels:          map[string]string{"app": "foo"},
+			expectedLabels:  map[string]string{"app": "foo"},
+			expectedChanged: false,
+		},
+		"labels added to an object without labels": {
+			add:             map[string]string{"app": "foo"},
+			expectedLabels:  map[string]string{"app": "foo"},
+			expectedChanged: true,
+		},
+		"existing label overwritten": {
+			labels:          map[string]string{"app": "foo", "tier": "web"},
+			add:             map[string]string{"app": "bar"},
+			expectedLabels:  map[string]string{"app": "bar", "tier": "web"},
+			expectedChanged: true,
+		},
+		"same labels": {
+			labels:          map[string]string{"app": "foo"},
+			add:             map[string]string{"app": "foo"},
+			expectedLabels:  map[string]string{"app": "foo"},
+			expectedChanged: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			obj := testutil.Unstructured(t, testPod)
+			obj.SetLabels(tc.labels)
+			changed := AddLabels(obj, tc.add)
+			assert.Equal(t, tc.expectedChanged, changed)
+			assert.Equal(t, tc.expectedLabels, obj.GetLabels())
+		})
+	}
+}
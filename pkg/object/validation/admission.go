@@ -0,0 +1,180 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// AdmissionOperation is the operation an object is admitted for.
+type AdmissionOperation string
+
+const (
+	// AdmissionApply is the operation of objects that will be applied.
+	AdmissionApply AdmissionOperation = "apply"
+	// AdmissionPrune is the operation of objects that will be pruned.
+	AdmissionPrune AdmissionOperation = "prune"
+)
+
+// AdmissionAction defines what happens to objects that are not allowed by an
+// AdmissionRule.
+type AdmissionAction string
+
+const (
+	// AdmissionDeny makes the objects invalid, so they are not applied or
+	// pruned, and reported as validation errors.
+	AdmissionDeny AdmissionAction = "deny"
+	// AdmissionWarn reports the objects as validation warnings, but still
+	// applies or prunes them.
+	AdmissionWarn AdmissionAction = "warn"
+)
+
+// AdmissionRule is a rule that objects must satisfy to be applied or pruned,
+// like the validations of a ValidatingAdmissionPolicy, but evaluated
+// client-side before anything is changed in the cluster. The rule is either
+// a CEL Expression or a Go Allow function.
+type AdmissionRule struct {
+	// Name identifies the rule in the errors.
+	Name string
+	// Operations are the operations the rule is evaluated for.
+	// Defaults to all operations.
+	Operations []AdmissionOperation
+	// Action defines what happens to objects that are not allowed.
+	// Defaults to AdmissionDeny.
+	Action AdmissionAction
+	// Message describes why objects are not allowed. Defaults to the
+	// Expression.
+	Message string
+	// Expression is a CEL expression that evaluates to true if the object
+	// is allowed, like the expression of a ValidatingAdmissionPolicy
+	// validation. The object is the `object` variable, and the operation is
+	// the `operation` variable, like
+	// `object.kind != 'PersistentVolumeClaim' || operation != 'prune'`.
+	Expression string
+	// Allow returns true if the object is allowed. Used instead of the
+	// Expression, for rules written in Go.
+	Allow func(obj *unstructured.Unstructured) (bool, error)
+}
+
+// compile returns the function that evaluates the rule for the operation.
+func (r AdmissionRule) compile(op AdmissionOperation) (func(*unstructured.Unstructured) (bool, error), error) {
+	switch {
+	case r.Expression != "" && r.Allow != nil:
+		return nil, fmt.Errorf("admission rule %q must set either an Expression or Allow, not both", r.Name)
+	case r.Expression != "":
+		allow, err := compileAdmissionExpression(r.Expression, op)
+		if err != nil {
+			return nil, fmt.Errorf("compiling admission rule %q: %w", r.Name, err)
+		}
+		return allow, nil
+	case r.Allow != nil:
+		return r.Allow, nil
+	default:
+		return nil, fmt.Errorf("admission rule %q must set an Expression or Allow", r.Name)
+	}
+}
+
+// message returns the Message of the rule, or the failed Expression.
+func (r AdmissionRule) message() string {
+	if r.Message == "" && r.Expression != "" {
+		return fmt.Sprintf("failed expression: %s", r.Expression)
+	}
+	return r.Message
+}
+
+// appliesTo returns true if the rule is evaluated for the operation.
+func (r AdmissionRule) appliesTo(op AdmissionOperation) bool {
+	if len(r.Operations) == 0 {
+		return true
+	}
+	for _, o := range r.Operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// AdmissionError is the error of an object that is not allowed by an
+// AdmissionRule.
+type AdmissionError struct {
+	Rule      string
+	Operation AdmissionOperation
+	Action    AdmissionAction
+	Message   string
+}
+
+func (e *AdmissionError) Error() string {
+	if e.Action == AdmissionWarn {
+		return fmt.Sprintf("%s allowed with warning by admission rule %q: %s",
+			e.Operation, e.Rule, e.Message)
+	}
+	return fmt.Sprintf("%s denied by admission rule %q: %s",
+		e.Operation, e.Rule, e.Message)
+}
+
+// AdmissionValidator evaluates AdmissionRules against the objects.
+type AdmissionValidator struct {
+	// Rules are evaluated against every object, in order.
+	Rules []AdmissionRule
+	// Collector collects the denied objects as validation errors, and the
+	// warnings as validation warnings.
+	Collector *Collector
+}
+
+// Validate evaluates the rules for the operation against the objects. The
+// expressions of the rules are compiled once. If a rule is invalid, every
+// object it is evaluated for is invalid.
+func (v *AdmissionValidator) Validate(objs object.UnstructuredSet, op AdmissionOperation) {
+	allows := make([]func(*unstructured.Unstructured) (bool, error), len(v.Rules))
+	compileErrs := make([]error, len(v.Rules))
+	for i, rule := range v.Rules {
+		if rule.appliesTo(op) {
+			allows[i], compileErrs[i] = rule.compile(op)
+		}
+	}
+	for _, obj := range objs {
+		id := object.UnstructuredToObjMetadata(obj)
+		for i, rule := range v.Rules {
+			if !rule.appliesTo(op) {
+				continue
+			}
+			if compileErrs[i] != nil {
+				v.Collector.Collect(NewError(object.WithSource(obj, compileErrs[i]), id))
+				continue
+			}
+			allowed, err := allows[i](obj)
+			if err != nil {
+				v.Collector.Collect(NewError(object.WithSource(obj,
+					fmt.Errorf("evaluating admission rule %q: %w", rule.Name, err)), id))
+				continue
+			}
+			if allowed {
+				continue
+			}
+			action := rule.Action
+			if action == "" {
+				action = AdmissionDeny
+			}
+			admissionErr := object.WithSource(obj, &AdmissionError{
+				Rule:      rule.Name,
+				Operation: op,
+				Action:    action,
+				Message:   rule.message(),
+			})
+			switch action {
+			case AdmissionDeny:
+				v.Collector.Collect(NewError(admissionErr, id))
+			case AdmissionWarn:
+				v.Collector.Warn(NewError(admissionErr, id))
+			default:
+				v.Collector.Collect(NewError(fmt.Errorf("admission rule %q has unknown action %q",
+					rule.Name, action), id))
+			}
+		}
+	}
+}
@@ -0,0 +1,250 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var podLatest = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: pod
+  namespace: default
+spec:
+  containers:
+  - name: app
+    image: nginx:latest
+`
+
+var pvc = `
+apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+  namespace: default
+`
+
+var noLatestImages = validation.AdmissionRule{
+	Name:    "no-latest-images",
+	Message: "images must not use the latest tag",
+	Allow: func(obj *unstructured.Unstructured) (bool, error) {
+		containers, _, err := unstructured.NestedSlice(obj.Object, "spec", "containers")
+		if err != nil {
+			return false, err
+		}
+		for _, c := range containers {
+			image, _ := c.(map[string]interface{})["image"].(string)
+			if strings.HasSuffix(image, ":latest") {
+				return false, nil
+			}
+		}
+		return true, nil
+	},
+}
+
+var noPVCDeletion = validation.AdmissionRule{
+	Name:       "no-pvc-deletion",
+	Operations: []validation.AdmissionOperation{validation.AdmissionPrune},
+	Message:    "PersistentVolumeClaims must not be deleted",
+	Allow: func(obj *unstructured.Unstructured) (bool, error) {
+		return obj.GetKind() != "PersistentVolumeClaim", nil
+	},
+}
+
+var noLatestImagesExpression = validation.AdmissionRule{
+	Name:       "no-latest-images",
+	Expression: `!has(object.spec) || !has(object.spec.containers) || object.spec.containers.all(c, !c.image.endsWith(':latest'))`,
+}
+
+var noPVCDeletionExpression = validation.AdmissionRule{
+	Name:       "no-pvc-deletion",
+	Expression: `object.kind != 'PersistentVolumeClaim' || operation != 'prune'`,
+	Message:    "PersistentVolumeClaims must not be deleted",
+}
+
+func TestAdmissionValidator(t *testing.T) {
+	testCases := map[string]struct {
+		rules     []validation.AdmissionRule
+		manifest  string
+		operation validation.AdmissionOperation
+
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		"allowed": {
+			rules:     []validation.AdmissionRule{noLatestImages},
+			manifest:  pvc,
+			operation: validation.AdmissionApply,
+		},
+		"apply denied": {
+			rules:     []validation.AdmissionRule{noLatestImages},
+			manifest:  podLatest,
+			operation: validation.AdmissionApply,
+			expectedErrors: []string{
+				`invalid object: "default_pod__Pod": apply denied by admission rule "no-latest-images": images must not use the latest tag`,
+			},
+		},
+		"apply warning": {
+			rules: []validation.AdmissionRule{
+				func() validation.AdmissionRule {
+					rule := noLatestImages
+					rule.Action = validation.AdmissionWarn
+					return rule
+				}(),
+			},
+			manifest:  podLatest,
+			operation: validation.AdmissionApply,
+			expectedWarnings: []string{
+				`invalid object: "default_pod__Pod": apply allowed with warning by admission rule "no-latest-images": images must not use the latest tag`,
+			},
+		},
+		"prune denied": {
+			rules:     []validation.AdmissionRule{noPVCDeletion},
+			manifest:  pvc,
+			operation: validation.AdmissionPrune,
+			expectedErrors: []string{
+				`invalid object: "default_data__PersistentVolumeClaim": prune denied by admission rule "no-pvc-deletion": PersistentVolumeClaims must not be deleted`,
+			},
+		},
+		"rule not evaluated for apply": {
+			rules:     []validation.AdmissionRule{noPVCDeletion},
+			manifest:  pvc,
+			operation: validation.AdmissionApply,
+		},
+		"evaluation error": {
+			rules: []validation.AdmissionRule{
+				{
+					Name: "broken",
+					Allow: func(*unstructured.Unstructured) (bool, error) {
+						return false, errors.New("no such key")
+					},
+				},
+			},
+			manifest:  pvc,
+			operation: validation.AdmissionApply,
+			expectedErrors: []string{
+				`invalid object: "default_data__PersistentVolumeClaim": evaluating admission rule "broken": no such key`,
+			},
+		},
+		"expression allowed": {
+			rules:     []validation.AdmissionRule{noLatestImagesExpression},
+			manifest:  pvc,
+			operation: validation.AdmissionApply,
+		},
+		"expression apply denied": {
+			rules:     []validation.AdmissionRule{noLatestImagesExpression},
+			manifest:  podLatest,
+			operation: validation.AdmissionApply,
+			expectedErrors: []string{
+				`invalid object: "default_pod__Pod": apply denied by admission rule "no-latest-images": ` +
+					`failed expression: !has(object.spec) || !has(object.spec.containers) || object.spec.containers.all(c, !c.image.endsWith(':latest'))`,
+			},
+		},
+		"expression apply warning": {
+			rules: []validation.AdmissionRule{
+				func() validation.AdmissionRule {
+					rule := noLatestImagesExpression
+					rule.Action = validation.AdmissionWarn
+					rule.Message = "images must not use the latest tag"
+					return rule
+				}(),
+			},
+			manifest:  podLatest,
+			operation: validation.AdmissionApply,
+			expectedWarnings: []string{
+				`invalid object: "default_pod__Pod": apply allowed with warning by admission rule "no-latest-images": images must not use the latest tag`,
+			},
+		},
+		"expression with operation, prune denied": {
+			rules:     []validation.AdmissionRule{noPVCDeletionExpression},
+			manifest:  pvc,
+			operation: validation.AdmissionPrune,
+			expectedErrors: []string{
+				`invalid object: "default_data__PersistentVolumeClaim": prune denied by admission rule "no-pvc-deletion": PersistentVolumeClaims must not be deleted`,
+			},
+		},
+		"expression with operation, apply allowed": {
+			rules:     []validation.AdmissionRule{noPVCDeletionExpression},
+			manifest:  pvc,
+			operation: validation.AdmissionApply,
+		},
+		"expression evaluation error": {
+			rules: []validation.AdmissionRule{
+				{
+					Name:       "broken",
+					Expression: `object.spec.storageClassName == 'fast'`,
+				},
+			},
+			manifest:  pvc,
+			operation: validation.AdmissionApply,
+			expectedErrors: []string{
+				`invalid object: "default_data__PersistentVolumeClaim": evaluating admission rule "broken": no such key: spec`,
+			},
+		},
+		"expression not evaluating to a bool": {
+			rules: []validation.AdmissionRule{
+				{
+					Name:       "not-bool",
+					Expression: `object.kind`,
+				},
+			},
+			manifest:  pvc,
+			operation: validation.AdmissionApply,
+			expectedErrors: []string{
+				`invalid object: "default_data__PersistentVolumeClaim": evaluating admission rule "not-bool": expression must evaluate to a bool, not string`,
+			},
+		},
+		"expression not compiling": {
+			rules: []validation.AdmissionRule{
+				{
+					Name:       "not-bool",
+					Expression: `1 + 1`,
+				},
+			},
+			manifest:  pvc,
+			operation: validation.AdmissionApply,
+			expectedErrors: []string{
+				`invalid object: "default_data__PersistentVolumeClaim": compiling admission rule "not-bool": expression must evaluate to a bool, not int`,
+			},
+		},
+		"expression and allow": {
+			rules: []validation.AdmissionRule{
+				func() validation.AdmissionRule {
+					rule := noLatestImages
+					rule.Expression = noLatestImagesExpression.Expression
+					return rule
+				}(),
+			},
+			manifest:  pvc,
+			operation: validation.AdmissionApply,
+			expectedErrors: []string{
+				`invalid object: "default_data__PersistentVolumeClaim": admission rule "no-latest-images" must set either an Expression or Allow, not both`,
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			vCollector := &validation.Collector{}
+			validator := &validation.AdmissionValidator{
+				Rules:     tc.rules,
+				Collector: vCollector,
+			}
+			validator.Validate([]*unstructured.Unstructured{testutil.Unstructured(t, tc.manifest)}, tc.operation)
+
+			assert.Equal(t, tc.expectedErrors, errorStrings(vCollector.Errors))
+			assert.Equal(t, tc.expectedWarnings, errorStrings(vCollector.Warnings))
+			assert.Len(t, vCollector.InvalidIds, len(tc.expectedErrors))
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// The variables of the CEL expressions of the AdmissionRules.
+const (
+	// celObjectVariable is the object, as an unstructured map.
+	celObjectVariable = "object"
+	// celOperationVariable is the AdmissionOperation, as a string.
+	celOperationVariable = "operation"
+)
+
+var (
+	celEnvOnce sync.Once
+	celEnv     *cel.Env
+	celEnvErr  error
+)
+
+// admissionEnv returns the CEL environment of the expressions of the
+// AdmissionRules. It is created once, since it is immutable and expensive
+// to create.
+func admissionEnv() (*cel.Env, error) {
+	celEnvOnce.Do(func() {
+		celEnv, celEnvErr = cel.NewEnv(
+			cel.Variable(celObjectVariable, cel.DynType),
+			cel.Variable(celOperationVariable, cel.StringType),
+		)
+	})
+	return celEnv, celEnvErr
+}
+
+// compileAdmissionExpression compiles the CEL expression of an AdmissionRule
+// into a function that returns true if the object is allowed for the
+// operation. The expression must evaluate to a bool.
+func compileAdmissionExpression(expression string, op AdmissionOperation) (func(*unstructured.Unstructured) (bool, error), error) {
+	env, err := admissionEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if err := issues.Err(); err != nil {
+		return nil, err
+	}
+	// Dynamic expressions, like the fields of the object, are checked when
+	// they are evaluated.
+	if outputType := ast.OutputType(); !outputType.IsAssignableType(cel.BoolType) {
+		return nil, fmt.Errorf("expression must evaluate to a bool, not %s", outputType)
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+	return func(obj *unstructured.Unstructured) (bool, error) {
+		out, _, err := program.Eval(map[string]interface{}{
+			celObjectVariable:    obj.Object,
+			celOperationVariable: string(op),
+		})
+		if err != nil {
+			return false, err
+		}
+		allowed, ok := out.Value().(bool)
+		if !ok {
+			return false, fmt.Errorf("expression must evaluate to a bool, not %s", out.Type().TypeName())
+		}
+		return allowed, nil
+	}, nil
+}
@@ -15,6 +15,8 @@ import (
 type Collector struct {
 	Errors     []error
 	InvalidIds object.ObjMetadataSet
+	// Warnings are problems that don't make the objects invalid.
+	Warnings []error
 }
 
 // Collect unwraps MultiErrors, adds them to Errors, extracts invalid object
@@ -25,6 +27,12 @@ func (c *Collector) Collect(err error) {
 	c.Errors = append(c.Errors, errs...)
 }
 
+// Warn unwraps MultiErrors and adds them to Warnings. The IDs of the
+// objects are not added to InvalidIds.
+func (c *Collector) Warn(err error) {
+	c.Warnings = append(c.Warnings, multierror.Unwrap(err)...)
+}
+
 // ToError returns the list of errors as a single error.
 func (c *Collector) ToError() error {
 	return multierror.Wrap(c.Errors...)
@@ -0,0 +1,262 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+//go:generate stringer -type=DeprecatedAPIPolicy
+type DeprecatedAPIPolicy int
+
+const (
+	// IgnoreDeprecatedAPIs policy doesn't check the API versions of the
+	// objects.
+	IgnoreDeprecatedAPIs DeprecatedAPIPolicy = iota
+
+	// WarnDeprecatedAPIs policy treats objects with API versions removed in
+	// the version of the cluster as invalid, and warns about objects with
+	// deprecated API versions. The Applier sends the warnings as validation
+	// events with the WarningSeverity.
+	WarnDeprecatedAPIs
+
+	// RejectDeprecatedAPIs policy treats objects with API versions
+	// deprecated or removed in the version of the cluster as invalid.
+	RejectDeprecatedAPIs
+)
+
+// DeprecatedAPI is an API version of a kind that is deprecated, and
+// removed in a later Kubernetes version.
+type DeprecatedAPI struct {
+	GroupVersionKind schema.GroupVersionKind
+	// DeprecatedIn is the Kubernetes version the API version was
+	// deprecated in, like "1.21".
+	DeprecatedIn string
+	// RemovedIn is the Kubernetes version the API version was removed in,
+	// like "1.25".
+	RemovedIn string
+	// Replacement is the API version to use instead.
+	Replacement schema.GroupVersion
+}
+
+// DefaultDeprecatedAPIs is the deprecation and removal schedule of the
+// built-in Kubernetes API versions.
+var DefaultDeprecatedAPIs = []DeprecatedAPI{
+	deprecatedAPI("extensions/v1beta1", "DaemonSet", "1.8", "1.16", "apps/v1"),
+	deprecatedAPI("extensions/v1beta1", "Deployment", "1.8", "1.16", "apps/v1"),
+	deprecatedAPI("extensions/v1beta1", "ReplicaSet", "1.8", "1.16", "apps/v1"),
+	deprecatedAPI("extensions/v1beta1", "NetworkPolicy", "1.9", "1.16", "networking.k8s.io/v1"),
+	deprecatedAPI("extensions/v1beta1", "PodSecurityPolicy", "1.10", "1.16", "policy/v1beta1"),
+	deprecatedAPI("extensions/v1beta1", "Ingress", "1.14", "1.22", "networking.k8s.io/v1"),
+	deprecatedAPI("apps/v1beta1", "Deployment", "1.9", "1.16", "apps/v1"),
+	deprecatedAPI("apps/v1beta1", "StatefulSet", "1.9", "1.16", "apps/v1"),
+	deprecatedAPI("apps/v1beta2", "DaemonSet", "1.9", "1.16", "apps/v1"),
+	deprecatedAPI("apps/v1beta2", "Deployment", "1.9", "1.16", "apps/v1"),
+	deprecatedAPI("apps/v1beta2", "ReplicaSet", "1.9", "1.16", "apps/v1"),
+	deprecatedAPI("apps/v1beta2", "StatefulSet", "1.9", "1.16", "apps/v1"),
+	deprecatedAPI("admissionregistration.k8s.io/v1beta1", "MutatingWebhookConfiguration", "1.16", "1.22", "admissionregistration.k8s.io/v1"),
+	deprecatedAPI("admissionregistration.k8s.io/v1beta1", "ValidatingWebhookConfiguration", "1.16", "1.22", "admissionregistration.k8s.io/v1"),
+	deprecatedAPI("apiextensions.k8s.io/v1beta1", "CustomResourceDefinition", "1.16", "1.22", "apiextensions.k8s.io/v1"),
+	deprecatedAPI("apiregistration.k8s.io/v1beta1", "APIService", "1.19", "1.22", "apiregistration.k8s.io/v1"),
+	deprecatedAPI("certificates.k8s.io/v1beta1", "CertificateSigningRequest", "1.19", "1.22", "certificates.k8s.io/v1"),
+	deprecatedAPI("coordination.k8s.io/v1beta1", "Lease", "1.19", "1.22", "coordination.k8s.io/v1"),
+	deprecatedAPI("networking.k8s.io/v1beta1", "Ingress", "1.19", "1.22", "networking.k8s.io/v1"),
+	deprecatedAPI("networking.k8s.io/v1beta1", "IngressClass", "1.19", "1.22", "networking.k8s.io/v1"),
+	deprecatedAPI("rbac.authorization.k8s.io/v1beta1", "ClusterRole", "1.17", "1.22", "rbac.authorization.k8s.io/v1"),
+	deprecatedAPI("rbac.authorization.k8s.io/v1beta1", "ClusterRoleBinding", "1.17", "1.22", "rbac.authorization.k8s.io/v1"),
+	deprecatedAPI("rbac.authorization.k8s.io/v1beta1", "Role", "1.17", "1.22", "rbac.authorization.k8s.io/v1"),
+	deprecatedAPI("rbac.authorization.k8s.io/v1beta1", "RoleBinding", "1.17", "1.22", "rbac.authorization.k8s.io/v1"),
+	deprecatedAPI("scheduling.k8s.io/v1beta1", "PriorityClass", "1.14", "1.22", "scheduling.k8s.io/v1"),
+	deprecatedAPI("storage.k8s.io/v1beta1", "CSIDriver", "1.19", "1.22", "storage.k8s.io/v1"),
+	deprecatedAPI("storage.k8s.io/v1beta1", "CSINode", "1.17", "1.22", "storage.k8s.io/v1"),
+	deprecatedAPI("storage.k8s.io/v1beta1", "StorageClass", "1.19", "1.22", "storage.k8s.io/v1"),
+	deprecatedAPI("storage.k8s.io/v1beta1", "VolumeAttachment", "1.19", "1.22", "storage.k8s.io/v1"),
+	deprecatedAPI("batch/v1beta1", "CronJob", "1.21", "1.25", "batch/v1"),
+	deprecatedAPI("discovery.k8s.io/v1beta1", "EndpointSlice", "1.21", "1.25", "discovery.k8s.io/v1"),
+	deprecatedAPI("events.k8s.io/v1beta1", "Event", "1.21", "1.25", "events.k8s.io/v1"),
+	deprecatedAPI("autoscaling/v2beta1", "HorizontalPodAutoscaler", "1.22", "1.25", "autoscaling/v2"),
+	deprecatedAPI("policy/v1beta1", "PodDisruptionBudget", "1.21", "1.25", "policy/v1"),
+	deprecatedAPI("policy/v1beta1", "PodSecurityPolicy", "1.21", "1.25", ""),
+	deprecatedAPI("node.k8s.io/v1beta1", "RuntimeClass", "1.20", "1.25", "node.k8s.io/v1"),
+	deprecatedAPI("autoscaling/v2beta2", "HorizontalPodAutoscaler", "1.23", "1.26", "autoscaling/v2"),
+	deprecatedAPI("flowcontrol.apiserver.k8s.io/v1beta1", "FlowSchema", "1.23", "1.26", "flowcontrol.apiserver.k8s.io/v1beta3"),
+	deprecatedAPI("flowcontrol.apiserver.k8s.io/v1beta1", "PriorityLevelConfiguration", "1.23", "1.26", "flowcontrol.apiserver.k8s.io/v1beta3"),
+	deprecatedAPI("storage.k8s.io/v1beta1", "CSIStorageCapacity", "1.24", "1.27", "storage.k8s.io/v1"),
+	deprecatedAPI("flowcontrol.apiserver.k8s.io/v1beta2", "FlowSchema", "1.26", "1.29", "flowcontrol.apiserver.k8s.io/v1beta3"),
+	deprecatedAPI("flowcontrol.apiserver.k8s.io/v1beta2", "PriorityLevelConfiguration", "1.26", "1.29", "flowcontrol.apiserver.k8s.io/v1beta3"),
+}
+
+func deprecatedAPI(apiVersion, kind, deprecatedIn, removedIn, replacement string) DeprecatedAPI {
+	gv, err := schema.ParseGroupVersion(apiVersion)
+	if err != nil {
+		panic(err)
+	}
+	var replacementGV schema.GroupVersion
+	if replacement != "" {
+		replacementGV, err = schema.ParseGroupVersion(replacement)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return DeprecatedAPI{
+		GroupVersionKind: gv.WithKind(kind),
+		DeprecatedIn:     deprecatedIn,
+		RemovedIn:        removedIn,
+		Replacement:      replacementGV,
+	}
+}
+
+// DeprecatedAPIError is returned for an object with an API version that is
+// deprecated or removed in the version of the cluster, or that is not
+// served by the cluster.
+type DeprecatedAPIError struct {
+	GroupVersionKind schema.GroupVersionKind
+	// ServerVersion is the version of the cluster.
+	ServerVersion string
+	// DeprecatedIn is the Kubernetes version the API version was
+	// deprecated in, if known.
+	DeprecatedIn string
+	// RemovedIn is the Kubernetes version the API version was removed
+	// in, if known.
+	RemovedIn string
+	// Removed is true if the API version is not served by the cluster.
+	Removed bool
+	// Replacement is the API version to use instead, if known.
+	Replacement schema.GroupVersion
+}
+
+func (e *DeprecatedAPIError) Error() string {
+	gv := e.GroupVersionKind.GroupVersion()
+	var msg string
+	switch {
+	case e.Removed && e.RemovedIn != "":
+		msg = fmt.Sprintf("apiVersion %s of %s was removed in Kubernetes v%s, and the cluster is v%s",
+			gv, e.GroupVersionKind.Kind, e.RemovedIn, e.ServerVersion)
+	case e.Removed:
+		msg = fmt.Sprintf("apiVersion %s of %s is not served by the cluster",
+			gv, e.GroupVersionKind.Kind)
+	default:
+		msg = fmt.Sprintf("apiVersion %s of %s is deprecated since Kubernetes v%s, and will be removed in v%s",
+			gv, e.GroupVersionKind.Kind, e.DeprecatedIn, e.RemovedIn)
+	}
+	if !e.Replacement.Empty() {
+		msg = fmt.Sprintf("%s: use %s instead", msg, e.Replacement)
+	}
+	return msg
+}
+
+// DeprecationValidator checks the API versions of the objects against the
+// deprecation and removal schedule of the version of the cluster, and
+// against the API versions served by the cluster, if a RESTMapper is
+// provided.
+type DeprecationValidator struct {
+	// ServerVersion is the version of the cluster.
+	ServerVersion *version.Version
+	// DeprecatedAPIs is the deprecation and removal schedule.
+	// Defaults to DefaultDeprecatedAPIs.
+	DeprecatedAPIs []DeprecatedAPI
+	// Mapper is used to find the API versions served by the cluster.
+	// Optional.
+	Mapper meta.RESTMapper
+	// Policy defines whether deprecated API versions are invalid, or only
+	// warned about.
+	Policy    DeprecatedAPIPolicy
+	Collector *Collector
+}
+
+// Validate collects an error for every object with a removed API version,
+// and a warning or an error, depending on the policy, for every object
+// with a deprecated API version.
+func (v *DeprecationValidator) Validate(objs []*unstructured.Unstructured) {
+	if v.Policy == IgnoreDeprecatedAPIs {
+		return
+	}
+	deprecatedAPIs := v.DeprecatedAPIs
+	if deprecatedAPIs == nil {
+		deprecatedAPIs = DefaultDeprecatedAPIs
+	}
+	schedule := make(map[schema.GroupVersionKind]DeprecatedAPI, len(deprecatedAPIs))
+	for _, api := range deprecatedAPIs {
+		schedule[api.GroupVersionKind] = api
+	}
+	// The versions of the types defined by CRDs in the set may not be
+	// served yet.
+	crdGroupKinds := make(map[schema.GroupKind]bool)
+	for _, crd := range findCRDs(objs) {
+		if gk, found := object.GetCRDGroupKind(crd); found {
+			crdGroupKinds[gk] = true
+		}
+	}
+
+	for _, obj := range objs {
+		gvk := obj.GroupVersionKind()
+		if gvk.Kind == "" {
+			continue
+		}
+		var err *DeprecatedAPIError
+		if api, found := schedule[gvk]; found {
+			err = v.checkSchedule(api)
+		}
+		if err == nil && v.Mapper != nil && !crdGroupKinds[gvk.GroupKind()] {
+			err = v.checkServed(gvk)
+		}
+		if err == nil {
+			continue
+		}
+		id := object.UnstructuredToObjMetadata(obj)
+		if !err.Removed && v.Policy == WarnDeprecatedAPIs {
+			v.Collector.Warn(NewError(object.WithSource(obj, err), id))
+			continue
+		}
+		v.Collector.Collect(NewError(object.WithSource(obj, err), id))
+	}
+}
+
+// checkSchedule returns an error if the API version is deprecated or
+// removed in the version of the cluster.
+func (v *DeprecationValidator) checkSchedule(api DeprecatedAPI) *DeprecatedAPIError {
+	err := &DeprecatedAPIError{
+		GroupVersionKind: api.GroupVersionKind,
+		ServerVersion:    v.ServerVersion.String(),
+		DeprecatedIn:     api.DeprecatedIn,
+		RemovedIn:        api.RemovedIn,
+		Replacement:      api.Replacement,
+	}
+	switch {
+	case api.RemovedIn != "" && v.ServerVersion.AtLeast(version.MustParseGeneric(api.RemovedIn)):
+		err.Removed = true
+		return err
+	case api.DeprecatedIn != "" && v.ServerVersion.AtLeast(version.MustParseGeneric(api.DeprecatedIn)):
+		return err
+	default:
+		return nil
+	}
+}
+
+// checkServed returns an error if the cluster serves the kind, but not
+// with the API version.
+func (v *DeprecationValidator) checkServed(gvk schema.GroupVersionKind) *DeprecatedAPIError {
+	_, err := v.Mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err == nil || !meta.IsNoMatchError(err) {
+		return nil
+	}
+	mapping, err := v.Mapper.RESTMapping(gvk.GroupKind())
+	if err != nil {
+		// The kind is not served at all, which is reported as an unknown
+		// type by the Validator.
+		return nil
+	}
+	return &DeprecatedAPIError{
+		GroupVersionKind: gvk,
+		ServerVersion:    v.ServerVersion.String(),
+		Removed:          true,
+		Replacement:      mapping.GroupVersionKind.GroupVersion(),
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/version"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var cronJobV1beta1 = `
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: cron
+  namespace: default
+`
+
+var customV1alpha1 = `
+apiVersion: custom.io/v1alpha1
+kind: Custom
+metadata:
+  name: custom
+  namespace: default
+`
+
+func TestDeprecationValidator(t *testing.T) {
+	testCases := map[string]struct {
+		serverVersion string
+		policy        validation.DeprecatedAPIPolicy
+		manifest      string
+
+		expectedErrors   []string
+		expectedWarnings []string
+	}{
+		"ignored": {
+			serverVersion: "1.25",
+			policy:        validation.IgnoreDeprecatedAPIs,
+			manifest:      cronJobV1beta1,
+		},
+		"not deprecated yet": {
+			serverVersion: "1.20",
+			policy:        validation.RejectDeprecatedAPIs,
+			manifest:      cronJobV1beta1,
+		},
+		"deprecated warning": {
+			serverVersion: "1.21",
+			policy:        validation.WarnDeprecatedAPIs,
+			manifest:      cronJobV1beta1,
+			expectedWarnings: []string{
+				`invalid object: "default_cron_batch_CronJob": apiVersion batch/v1beta1 of CronJob is deprecated since Kubernetes v1.21, and will be removed in v1.25: use batch/v1 instead`,
+			},
+		},
+		"deprecated rejected": {
+			serverVersion: "1.24.3",
+			policy:        validation.RejectDeprecatedAPIs,
+			manifest:      cronJobV1beta1,
+			expectedErrors: []string{
+				`invalid object: "default_cron_batch_CronJob": apiVersion batch/v1beta1 of CronJob is deprecated since Kubernetes v1.21, and will be removed in v1.25: use batch/v1 instead`,
+			},
+		},
+		"removed": {
+			serverVersion: "1.25.0",
+			policy:        validation.WarnDeprecatedAPIs,
+			manifest:      cronJobV1beta1,
+			expectedErrors: []string{
+				`invalid object: "default_cron_batch_CronJob": apiVersion batch/v1beta1 of CronJob was removed in Kubernetes v1.25, and the cluster is v1.25.0: use batch/v1 instead`,
+			},
+		},
+		"not served by the cluster": {
+			serverVersion: "1.25",
+			policy:        validation.WarnDeprecatedAPIs,
+			manifest:      customV1alpha1,
+			expectedErrors: []string{
+				`invalid object: "default_custom_custom.io_Custom": apiVersion custom.io/v1alpha1 of Custom is not served by the cluster: use custom.io/v1 instead`,
+			},
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			vCollector := &validation.Collector{}
+			validator := &validation.DeprecationValidator{
+				ServerVersion: version.MustParseGeneric(tc.serverVersion),
+				Mapper: testutil.NewFakeRESTMapper(
+					schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"},
+					schema.GroupVersionKind{Group: "batch", Version: "v1beta1", Kind: "CronJob"},
+					schema.GroupVersionKind{Group: "custom.io", Version: "v1", Kind: "Custom"},
+				),
+				Policy:    tc.policy,
+				Collector: vCollector,
+			}
+			validator.Validate([]*unstructured.Unstructured{testutil.Unstructured(t, tc.manifest)})
+
+			assert.Equal(t, tc.expectedErrors, errorStrings(vCollector.Errors))
+			assert.Equal(t, tc.expectedWarnings, errorStrings(vCollector.Warnings))
+			assert.Len(t, vCollector.InvalidIds, len(tc.expectedErrors))
+		})
+	}
+}
+
+func errorStrings(errs []error) []string {
+	var result []string
+	for _, err := range errs {
+		result = append(result, err.Error())
+	}
+	return result
+}
@@ -7,6 +7,9 @@ import (
 	"fmt"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"sigs.k8s.io/cli-utils/pkg/object"
 )
 
@@ -51,3 +54,60 @@ func (ve *Error) Error() string {
 		return b.String()
 	}
 }
+
+// DuplicateError is the cause of the validation error for an object with
+// the same ID as an earlier object in the set.
+type DuplicateError struct {
+	// Sources are the source positions of the earlier and the duplicate
+	// object. A source position is empty if not known.
+	Sources []string
+	// Diff is the fields that differ between the earlier and the duplicate
+	// object.
+	Diff []object.FieldDiff
+}
+
+func (de *DuplicateError) Error() string {
+	var b strings.Builder
+	b.WriteString("duplicate object")
+	var sources []string
+	for _, source := range de.Sources {
+		if source != "" {
+			sources = append(sources, source)
+		}
+	}
+	if len(sources) > 0 {
+		_, _ = fmt.Fprintf(&b, " found in %s", strings.Join(sources, " and "))
+	}
+	if len(de.Diff) == 0 {
+		b.WriteString(": objects are identical")
+		return b.String()
+	}
+	b.WriteString(": fields differ:")
+	for _, d := range de.Diff {
+		_, _ = fmt.Fprintf(&b, " %s;", d)
+	}
+	return strings.TrimSuffix(b.String(), ";")
+}
+
+// ScopeError is the cause of the validation error for an object with a
+// namespace that doesn't match the scope of its type: a namespaced object
+// without a namespace, or a cluster-scoped object with one.
+type ScopeError struct {
+	GroupKind schema.GroupKind
+	// Namespace is the namespace of the object, if set.
+	Namespace string
+	// Scope is the expected scope of the object, from the RESTMapping of
+	// its type.
+	Scope meta.RESTScopeName
+	// Err is the error for the namespace field.
+	Err *field.Error
+}
+
+func (se *ScopeError) Error() string {
+	return se.Err.Error()
+}
+
+// Unwrap returns the error for the namespace field.
+func (se *ScopeError) Unwrap() error {
+	return se.Err
+}
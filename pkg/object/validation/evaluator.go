@@ -0,0 +1,74 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"context"
+	"fmt"
+
+	"sigs.k8s.io/cli-utils/pkg/multierror"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// PolicyEvaluator evaluates policies, like OPA Gatekeeper constraints,
+// against all the objects of a run at once, so policies can take the
+// relationships between the objects into account.
+type PolicyEvaluator interface {
+	// Evaluate returns the violations of the policies by the objects that
+	// will be applied and the objects that will be pruned.
+	Evaluate(ctx context.Context, applyObjs, pruneObjs object.UnstructuredSet) ([]PolicyViolation, error)
+}
+
+// PolicyViolation is a violation of a policy by a set of objects.
+type PolicyViolation struct {
+	// Policy is the name of the violated policy.
+	Policy string
+	// Message describes the violation.
+	Message string
+	// Identifiers are the objects which violate the policy. They are handled
+	// like invalid objects, according to the validation Policy of the run.
+	Identifiers object.ObjMetadataSet
+	// Abort stops the run before anything is changed in the cluster,
+	// regardless of the validation Policy.
+	Abort bool
+}
+
+// PolicyViolationError is the error of a PolicyViolation.
+type PolicyViolationError struct {
+	Policy  string
+	Message string
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("policy %q violated: %s", e.Policy, e.Message)
+}
+
+// PolicyValidator collects the violations of a PolicyEvaluator as validation
+// errors.
+type PolicyValidator struct {
+	Evaluator PolicyEvaluator
+	Collector *Collector
+}
+
+// Validate evaluates the policies against the objects. Returns an error if
+// the evaluation failed, or if any violation aborts the run.
+func (v *PolicyValidator) Validate(ctx context.Context, applyObjs, pruneObjs object.UnstructuredSet) error {
+	violations, err := v.Evaluator.Evaluate(ctx, applyObjs, pruneObjs)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate policies: %w", err)
+	}
+	var abortErrs []error
+	for _, violation := range violations {
+		err := NewError(&PolicyViolationError{
+			Policy:  violation.Policy,
+			Message: violation.Message,
+		}, violation.Identifiers...)
+		if violation.Abort {
+			abortErrs = append(abortErrs, err)
+			continue
+		}
+		v.Collector.Collect(err)
+	}
+	return multierror.Wrap(abortErrs...)
+}
@@ -0,0 +1,103 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+type fakePolicyEvaluator struct {
+	violations []validation.PolicyViolation
+	err        error
+}
+
+func (f fakePolicyEvaluator) Evaluate(_ context.Context, _, _ object.UnstructuredSet) ([]validation.PolicyViolation, error) {
+	return f.violations, f.err
+}
+
+func TestPolicyValidator(t *testing.T) {
+	podID := testutil.ToIdentifier(t, podLatest)
+	pvcID := testutil.ToIdentifier(t, pvc)
+
+	testCases := map[string]struct {
+		evaluator validation.PolicyEvaluator
+
+		expectedError      string
+		expectedErrors     []string
+		expectedInvalidIds object.ObjMetadataSet
+	}{
+		"no violations": {
+			evaluator: fakePolicyEvaluator{},
+		},
+		"violations": {
+			evaluator: fakePolicyEvaluator{
+				violations: []validation.PolicyViolation{
+					{
+						Policy:      "required-labels",
+						Message:     "missing label app",
+						Identifiers: object.ObjMetadataSet{podID, pvcID},
+					},
+				},
+			},
+			expectedErrors: []string{
+				`invalid objects: ["default_pod__Pod", "default_data__PersistentVolumeClaim"] policy "required-labels" violated: missing label app`,
+			},
+			expectedInvalidIds: object.ObjMetadataSet{podID, pvcID},
+		},
+		"abort": {
+			evaluator: fakePolicyEvaluator{
+				violations: []validation.PolicyViolation{
+					{
+						Policy:      "required-labels",
+						Message:     "missing label app",
+						Identifiers: object.ObjMetadataSet{pvcID},
+					},
+					{
+						Policy:  "max-objects",
+						Message: "too many objects",
+						Abort:   true,
+					},
+				},
+			},
+			expectedError: `validation error: policy "max-objects" violated: too many objects`,
+			expectedErrors: []string{
+				`invalid object: "default_data__PersistentVolumeClaim": policy "required-labels" violated: missing label app`,
+			},
+			expectedInvalidIds: object.ObjMetadataSet{pvcID},
+		},
+		"evaluation error": {
+			evaluator: fakePolicyEvaluator{
+				err: errors.New("connection refused"),
+			},
+			expectedError: "failed to evaluate policies: connection refused",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			vCollector := &validation.Collector{}
+			validator := &validation.PolicyValidator{
+				Evaluator: tc.evaluator,
+				Collector: vCollector,
+			}
+			err := validator.Validate(context.TODO(),
+				object.UnstructuredSet{testutil.Unstructured(t, podLatest)},
+				object.UnstructuredSet{testutil.Unstructured(t, pvc)})
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectedErrors, errorStrings(vCollector.Errors))
+			assert.Equal(t, tc.expectedInvalidIds, vCollector.InvalidIds)
+		})
+	}
+}
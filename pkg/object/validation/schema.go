@@ -0,0 +1,126 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/client-go/openapi3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+	"sigs.k8s.io/cli-utils/pkg/multierror"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/structured-merge-diff/v4/typed"
+)
+
+// gvkExtension is the extension of the OpenAPI schemas that lists the
+// GroupVersionKinds of the schema.
+const gvkExtension = "x-kubernetes-group-version-kind"
+
+// SchemaValidator validates objects against the OpenAPI v3 schemas published
+// by the cluster, which include the structural schemas of the installed
+// CRDs, so unknown fields and values of the wrong type are found before
+// apply.
+type SchemaValidator struct {
+	// OpenAPI is the OpenAPI v3 document of the cluster, like
+	// openapi3.NewRoot(discoveryClient.OpenAPIV3()).
+	OpenAPI   openapi3.Root
+	Collector *Collector
+}
+
+// gvSchema is the schema of the types of a GroupVersion.
+type gvSchema struct {
+	converter managedfields.TypeConverter
+	kinds     map[schema.GroupVersionKind]bool
+	err       error
+}
+
+// Validate collects an error for every object that doesn't match the
+// schema of its type. Objects of types that are not in the schema, like
+// custom resources of CRDs that are not installed yet, are skipped.
+func (v *SchemaValidator) Validate(objs []*unstructured.Unstructured) {
+	// The schemas are fetched once per GroupVersion.
+	schemas := make(map[schema.GroupVersion]*gvSchema)
+	for _, obj := range objs {
+		gvk := obj.GroupVersionKind()
+		if gvk.Kind == "" {
+			continue
+		}
+		gvs, found := schemas[gvk.GroupVersion()]
+		if !found {
+			gvs = v.schema(gvk.GroupVersion())
+			schemas[gvk.GroupVersion()] = gvs
+		}
+		id := object.UnstructuredToObjMetadata(obj)
+		if gvs.err != nil {
+			v.Collector.Collect(NewError(object.WithSource(obj, gvs.err), id))
+			continue
+		}
+		if !gvs.kinds[gvk] {
+			continue
+		}
+		_, err := gvs.converter.ObjectToTyped(obj)
+		if err == nil {
+			continue
+		}
+		var validationErrs typed.ValidationErrors
+		if errors.As(err, &validationErrs) {
+			errs := make([]error, len(validationErrs))
+			for i := range validationErrs {
+				errs[i] = validationErrs[i]
+			}
+			err = multierror.Wrap(errs...)
+		}
+		v.Collector.Collect(NewError(object.WithSource(obj, err), id))
+	}
+}
+
+// schema returns the schema of the types of the GroupVersion. The schema has
+// no kinds if the GroupVersion is not served.
+func (v *SchemaValidator) schema(gv schema.GroupVersion) *gvSchema {
+	doc, err := v.OpenAPI.GVSpec(gv)
+	if err != nil {
+		var notFoundErr *openapi3.GroupVersionNotFoundError
+		if errors.As(err, &notFoundErr) {
+			return &gvSchema{}
+		}
+		return &gvSchema{err: fmt.Errorf("failed to get the OpenAPI schema of %s: %w", gv, err)}
+	}
+	if doc.Components == nil {
+		return &gvSchema{}
+	}
+	converter, err := managedfields.NewTypeConverter(doc.Components.Schemas, false)
+	if err != nil {
+		return &gvSchema{err: fmt.Errorf("failed to read the OpenAPI schema of %s: %w", gv, err)}
+	}
+	return &gvSchema{
+		converter: converter,
+		kinds:     schemaKinds(doc.Components.Schemas),
+	}
+}
+
+// schemaKinds returns the GroupVersionKinds of the schemas.
+func schemaKinds(schemas map[string]*spec.Schema) map[schema.GroupVersionKind]bool {
+	kinds := make(map[schema.GroupVersionKind]bool)
+	for _, s := range schemas {
+		gvks, ok := s.Extensions[gvkExtension].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, gvk := range gvks {
+			m, ok := gvk.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			group, _ := m["group"].(string)
+			version, _ := m["version"].(string)
+			kind, _ := m["kind"].(string)
+			kinds[schema.GroupVersionKind{Group: group, Version: version, Kind: kind}] = true
+		}
+	}
+	return kinds
+}
@@ -0,0 +1,185 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/openapi3"
+	"k8s.io/kube-openapi/pkg/spec3"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var openAPIV3 = `{
+  "openapi": "3.0.0",
+  "info": {"title": "test", "version": "v1"},
+  "paths": {},
+  "components": {
+    "schemas": {
+      "io.k8s.api.core.v1.ConfigMap": {
+        "type": "object",
+        "properties": {
+          "apiVersion": {"type": "string"},
+          "kind": {"type": "string"},
+          "metadata": {
+            "allOf": [{"$ref": "#/components/schemas/io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta"}]
+          },
+          "data": {
+            "type": "object",
+            "additionalProperties": {"type": "string"}
+          },
+          "immutable": {"type": "boolean"}
+        },
+        "x-kubernetes-group-version-kind": [
+          {"group": "", "kind": "ConfigMap", "version": "v1"}
+        ]
+      },
+      "io.k8s.apimachinery.pkg.apis.meta.v1.ObjectMeta": {
+        "type": "object",
+        "properties": {
+          "name": {"type": "string"},
+          "namespace": {"type": "string"}
+        }
+      }
+    }
+  }
+}`
+
+// fakeOpenAPIRoot serves the OpenAPI v3 documents of the GroupVersions.
+type fakeOpenAPIRoot struct {
+	docs map[schema.GroupVersion]string
+}
+
+func (r *fakeOpenAPIRoot) GroupVersions() ([]schema.GroupVersion, error) {
+	var gvs []schema.GroupVersion
+	for gv := range r.docs {
+		gvs = append(gvs, gv)
+	}
+	return gvs, nil
+}
+
+func (r *fakeOpenAPIRoot) GVSpec(gv schema.GroupVersion) (*spec3.OpenAPI, error) {
+	doc, found := r.docs[gv]
+	if !found {
+		return nil, &openapi3.GroupVersionNotFoundError{}
+	}
+	var openAPI spec3.OpenAPI
+	if err := json.Unmarshal([]byte(doc), &openAPI); err != nil {
+		return nil, err
+	}
+	return &openAPI, nil
+}
+
+func (r *fakeOpenAPIRoot) GVSpecAsMap(gv schema.GroupVersion) (map[string]interface{}, error) {
+	doc, found := r.docs[gv]
+	if !found {
+		return nil, &openapi3.GroupVersionNotFoundError{}
+	}
+	var m map[string]interface{}
+	err := json.Unmarshal([]byte(doc), &m)
+	return m, err
+}
+
+func TestSchemaValidator(t *testing.T) {
+	testCases := map[string]struct {
+		manifest       string
+		expectedErrors []string
+	}{
+		"valid": {
+			manifest: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: default
+data:
+  key: value
+`,
+		},
+		"unknown field": {
+			manifest: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: default
+dta:
+  key: value
+`,
+			expectedErrors: []string{
+				`invalid object: "default_cm__ConfigMap": .dta: field not declared in schema`,
+			},
+		},
+		"wrong type": {
+			manifest: `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+  namespace: default
+immutable: "yes"
+`,
+			expectedErrors: []string{
+				`invalid object: "default_cm__ConfigMap": .immutable: expected boolean, got &{yes}`,
+			},
+		},
+		"schema not readable": {
+			manifest: `
+apiVersion: bad.io/v1
+kind: Bad
+metadata:
+  name: bad
+  namespace: default
+`,
+			expectedErrors: []string{
+				`invalid object: "default_bad_bad.io_Bad": failed to get the OpenAPI schema of bad.io/v1: ` +
+					`invalid character 'o' in literal null (expecting 'u')`,
+			},
+		},
+		"kind not in the schema of the group version": {
+			manifest: `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: secret
+  namespace: default
+unknown: field
+`,
+		},
+		"type not in the schema": {
+			manifest: `
+apiVersion: custom.io/v1
+kind: Custom
+metadata:
+  name: custom
+  namespace: default
+unknown: field
+`,
+		},
+	}
+
+	root := &fakeOpenAPIRoot{
+		docs: map[schema.GroupVersion]string{
+			{Version: "v1"}:                  openAPIV3,
+			{Group: "bad.io", Version: "v1"}: "not json",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			vCollector := &validation.Collector{}
+			validator := &validation.SchemaValidator{
+				OpenAPI:   root,
+				Collector: vCollector,
+			}
+			validator.Validate([]*unstructured.Unstructured{testutil.Unstructured(t, tc.manifest)})
+			assert.Equal(t, tc.expectedErrors, errorStrings(vCollector.Errors))
+		})
+	}
+}
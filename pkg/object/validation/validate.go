@@ -4,11 +4,15 @@
 package validation
 
 import (
+	"fmt"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/multierror"
 	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/applymethod"
 )
 
 // Validator contains functionality for validating a set of resources prior
@@ -17,6 +21,9 @@ import (
 type Validator struct {
 	Mapper    meta.RESTMapper
 	Collector *Collector
+	// Redactor redacts the sensitive values of the objects from the errors.
+	// If nil, only the values of Secrets are redacted.
+	Redactor *object.Redactor
 }
 
 // Validate validates the provided resources. A RESTMapper will be used
@@ -34,14 +41,49 @@ func (v *Validator) Validate(objs []*unstructured.Unstructured) {
 		if err := v.validateNamespace(obj, crds); err != nil {
 			objErrors = append(objErrors, err)
 		}
+		if _, err := applymethod.ReadAnnotation(obj); err != nil {
+			objErrors = append(objErrors, err)
+		}
 		if len(objErrors) > 0 {
 			// one error per object
 			v.Collector.Collect(NewError(
-				multierror.Wrap(objErrors...),
+				object.WithSource(obj, multierror.Wrap(objErrors...)),
 				object.UnstructuredToObjMetadata(obj),
 			))
 		}
 	}
+	v.validateDuplicates(objs)
+}
+
+// validateDuplicates collects an error for every object with the same ID as
+// an earlier object, instead of letting the later object silently win.
+// Objects without kind or name are skipped, since they are already invalid.
+func (v *Validator) validateDuplicates(objs []*unstructured.Unstructured) {
+	seen := make(map[object.ObjMetadata]*unstructured.Unstructured, len(objs))
+	for _, obj := range objs {
+		if obj.GetKind() == "" || obj.GetName() == "" {
+			continue
+		}
+		id := object.UnstructuredToObjMetadata(obj)
+		first, found := seen[id]
+		if !found {
+			seen[id] = obj
+			continue
+		}
+		v.Collector.Collect(NewError(&DuplicateError{
+			Sources: []string{object.SourcePosition(first), object.SourcePosition(obj)},
+			Diff:    v.Redactor.RedactDiffs(obj, diffObjects(first, obj)),
+		}, id))
+	}
+}
+
+// diffObjects returns the fields that differ between the objects, ignoring
+// the annotations set by the readers.
+func diffObjects(a, b *unstructured.Unstructured) []object.FieldDiff {
+	a, b = a.DeepCopy(), b.DeepCopy()
+	object.StripKyamlAnnotations(a)
+	object.StripKyamlAnnotations(b)
+	return object.DiffFields(a.Object, b.Object)
 }
 
 // findCRDs looks through the provided resources and returns a slice with
@@ -66,6 +108,15 @@ func (v *Validator) validateKind(u *unstructured.Unstructured) error {
 
 // validateName validates the value of the name field of the resource.
 func (v *Validator) validateName(u *unstructured.Unstructured) error {
+	if u.GetName() == "" && u.GetGenerateName() != "" {
+		// Names are generated for the objects with a generateName, unless
+		// an earlier object has the same key.
+		key, found := u.GetAnnotations()[common.GenerateNameAnnotation]
+		if !found {
+			key = u.GetGenerateName()
+		}
+		return field.Duplicate(field.NewPath("metadata", "annotations").Key(common.GenerateNameAnnotation), key)
+	}
 	if u.GetName() == "" {
 		return field.Required(field.NewPath("metadata", "name"), "name is required")
 	}
@@ -84,11 +135,23 @@ func (v *Validator) validateNamespace(u *unstructured.Unstructured, crds []*unst
 	}
 
 	ns := u.GetNamespace()
+	gk := u.GroupVersionKind().GroupKind()
 	if scope == meta.RESTScopeNamespace && ns == "" {
-		return field.Required(field.NewPath("metadata", "namespace"), "namespace is required")
+		return &ScopeError{
+			GroupKind: gk,
+			Scope:     scope.Name(),
+			Err: field.Required(field.NewPath("metadata", "namespace"),
+				fmt.Sprintf("namespace is required for namespace-scoped type %s", gk)),
+		}
 	}
 	if scope == meta.RESTScopeRoot && ns != "" {
-		return field.Invalid(field.NewPath("metadata", "namespace"), ns, "namespace must be empty")
+		return &ScopeError{
+			GroupKind: gk,
+			Namespace: ns,
+			Scope:     scope.Name(),
+			Err: field.Invalid(field.NewPath("metadata", "namespace"), ns,
+				fmt.Sprintf("namespace must be empty for cluster-scoped type %s", gk)),
+		}
 	}
 	return nil
 }
@@ -4,6 +4,7 @@
 package validation_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -74,7 +75,7 @@ func TestValidate(t *testing.T) {
 						Type:     field.ErrorTypeRequired,
 						Field:    "metadata.namespace",
 						BadValue: "",
-						Detail:   "namespace is required",
+						Detail:   "namespace is required for namespace-scoped type Deployment.apps",
 					},
 				),
 				object.ObjMetadata{
@@ -87,6 +88,63 @@ func TestValidate(t *testing.T) {
 				},
 			),
 		},
+		"generateName without a generated name": {
+			resources: []*unstructured.Unstructured{
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "v1",
+						"kind":       "ConfigMap",
+						"metadata": map[string]interface{}{
+							"generateName": "foo-",
+							"namespace":    "default",
+						},
+					},
+				},
+			},
+			expectedError: validation.NewError(
+				&field.Error{
+					Type:     field.ErrorTypeDuplicate,
+					Field:    "metadata.annotations[cli-utils.sigs.k8s.io/generate-name]",
+					BadValue: "foo-",
+				},
+				object.ObjMetadata{
+					GroupKind: schema.GroupKind{
+						Kind: "ConfigMap",
+					},
+					Namespace: "default",
+				},
+			),
+		},
+		"unknown apply method": {
+			resources: []*unstructured.Unstructured{
+				{
+					Object: map[string]interface{}{
+						"apiVersion": "v1",
+						"kind":       "ConfigMap",
+						"metadata": map[string]interface{}{
+							"name":      "foo",
+							"namespace": "default",
+							"annotations": map[string]interface{}{
+								"cli-utils.sigs.k8s.io/apply-method": "replace",
+							},
+						},
+					},
+				},
+			},
+			expectedError: validation.NewError(
+				object.InvalidAnnotationError{
+					Annotation: "cli-utils.sigs.k8s.io/apply-method",
+					Cause:      errors.New(`unknown apply method "replace", must be "client-side" or "server-side"`),
+				},
+				object.ObjMetadata{
+					GroupKind: schema.GroupKind{
+						Kind: "ConfigMap",
+					},
+					Name:      "foo",
+					Namespace: "default",
+				},
+			),
+		},
 		"one error in multiple object": {
 			resources: []*unstructured.Unstructured{
 				{
@@ -161,7 +219,7 @@ func TestValidate(t *testing.T) {
 					Type:     field.ErrorTypeInvalid,
 					Field:    "metadata.namespace",
 					BadValue: "default",
-					Detail:   "namespace must be empty",
+					Detail:   "namespace must be empty for cluster-scoped type Namespace",
 				},
 				object.ObjMetadata{
 					GroupKind: schema.GroupKind{
@@ -190,7 +248,7 @@ func TestValidate(t *testing.T) {
 					Type:     field.ErrorTypeRequired,
 					Field:    "metadata.namespace",
 					BadValue: "",
-					Detail:   "namespace is required",
+					Detail:   "namespace is required for namespace-scoped type Deployment.apps",
 				},
 				object.ObjMetadata{
 					GroupKind: schema.GroupKind{
@@ -232,7 +290,7 @@ metadata:
 					Type:     field.ErrorTypeInvalid,
 					Field:    "metadata.namespace",
 					BadValue: "default",
-					Detail:   "namespace must be empty",
+					Detail:   "namespace must be empty for cluster-scoped type Custom.custom.io",
 				},
 				object.ObjMetadata{
 					GroupKind: schema.GroupKind{
@@ -244,6 +302,122 @@ metadata:
 				},
 			),
 		},
+		"duplicate objects": {
+			resources: []*unstructured.Unstructured{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+  annotations:
+    config.kubernetes.io/path: a.yaml
+    internal.cli-utils.sigs.k8s.io/line: "3"
+data:
+  key: a
+`,
+				),
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+  annotations:
+    config.kubernetes.io/path: b.yaml
+    internal.cli-utils.sigs.k8s.io/line: "1"
+data:
+  key: b
+  other: c
+`,
+				),
+			},
+			expectedError: validation.NewError(
+				&validation.DuplicateError{
+					Sources: []string{"a.yaml:3", "b.yaml:1"},
+					Diff: []object.FieldDiff{
+						{Path: ".data.key", Old: "a", New: "b"},
+						{Path: ".data.other", New: "c"},
+					},
+				},
+				object.ObjMetadata{
+					GroupKind: schema.GroupKind{
+						Kind: "ConfigMap",
+					},
+					Name:      "foo",
+					Namespace: "default",
+				},
+			),
+		},
+		"duplicate secrets are redacted": {
+			resources: []*unstructured.Unstructured{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: foo
+  namespace: default
+data:
+  password: YQ==
+`,
+				),
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: Secret
+metadata:
+  name: foo
+  namespace: default
+data:
+  password: Yg==
+`,
+				),
+			},
+			expectedError: validation.NewError(
+				&validation.DuplicateError{
+					Sources: []string{"", ""},
+					Diff: []object.FieldDiff{
+						{Path: ".data.password", Old: object.RedactedValue, New: object.RedactedValue},
+					},
+				},
+				object.ObjMetadata{
+					GroupKind: schema.GroupKind{
+						Kind: "Secret",
+					},
+					Name:      "foo",
+					Namespace: "default",
+				},
+			),
+		},
+		"identical duplicate objects without source": {
+			resources: []*unstructured.Unstructured{
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+`,
+				),
+				testutil.Unstructured(t, `
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: foo
+  namespace: default
+`,
+				),
+			},
+			expectedError: validation.NewError(
+				&validation.DuplicateError{},
+				object.ObjMetadata{
+					GroupKind: schema.GroupKind{
+						Kind: "ConfigMap",
+					},
+					Name:      "foo",
+					Namespace: "default",
+				},
+			),
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -275,3 +449,48 @@ metadata:
 		})
 	}
 }
+
+func TestDuplicateError(t *testing.T) {
+	err := &validation.DuplicateError{
+		Sources: []string{"a.yaml:3", ""},
+		Diff: []object.FieldDiff{
+			{Path: ".data.key", Old: "a", New: "b"},
+			{Path: ".data.other", New: "c"},
+		},
+	}
+	assert.EqualError(t, err, `duplicate object found in a.yaml:3: fields differ: .data.key: "a" -> "b"; .data.other: <unset> -> "c"`)
+
+	err = &validation.DuplicateError{Sources: []string{"a.yaml:3", "b.yaml:1"}}
+	assert.EqualError(t, err, `duplicate object found in a.yaml:3 and b.yaml:1: objects are identical`)
+}
+
+func TestValidate_ScopeError(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test-ns")
+	defer tf.Cleanup()
+	mapper, err := tf.ToRESTMapper()
+	require.NoError(t, err)
+
+	vCollector := &validation.Collector{}
+	validator := &validation.Validator{
+		Mapper:    mapper,
+		Collector: vCollector,
+	}
+	validator.Validate([]*unstructured.Unstructured{
+		testutil.Unstructured(t, `
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: foo
+  namespace: default
+`,
+		),
+	})
+	require.Len(t, vCollector.Errors, 1)
+
+	var scopeErr *validation.ScopeError
+	require.ErrorAs(t, vCollector.Errors[0], &scopeErr)
+	assert.Equal(t, schema.GroupKind{Kind: "Namespace"}, scopeErr.GroupKind)
+	assert.Equal(t, "default", scopeErr.Namespace)
+	assert.Equal(t, meta.RESTScopeNameRoot, scopeErr.Scope)
+	assert.Equal(t, field.ErrorTypeInvalid, scopeErr.Err.Type)
+}
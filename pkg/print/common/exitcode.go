@@ -0,0 +1,60 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"errors"
+)
+
+// Exit codes returned by commands, so scripts can distinguish the outcome
+// of a run without parsing the output.
+const (
+	// ExitCodeSuccess means all objects were applied, pruned, deleted, and
+	// reconciled, or skipped.
+	ExitCodeSuccess = 0
+	// ExitCodeError means the run failed with a fatal error, or the command
+	// could not be run.
+	ExitCodeError = 1
+	// ExitCodeApplyFailed means one or more objects failed to apply.
+	ExitCodeApplyFailed = 2
+	// ExitCodePruneFailed means one or more objects failed to be pruned or
+	// deleted, but no objects failed to apply.
+	ExitCodePruneFailed = 3
+	// ExitCodeReconcileFailed means one or more objects failed to reconcile
+	// or timed out waiting to reconcile, but no objects failed actuation.
+	ExitCodeReconcileFailed = 4
+	// ExitCodeTimeout means the run did not finish before the timeout of
+	// the command, so some objects may not have been actuated.
+	ExitCodeTimeout = 5
+)
+
+// ExitCodeFromError returns the exit code for the error returned by a
+// command. A ResultError returns the exit code of the most severe failure:
+// apply failures first, then prune and delete failures, then reconcile
+// failures and timeouts. A run stopped by the timeout of the command
+// returns ExitCodeTimeout. All other errors return ExitCodeError.
+func ExitCodeFromError(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ExitCodeTimeout
+	}
+	var resultErr *ResultError
+	if !errors.As(err, &resultErr) {
+		return ExitCodeError
+	}
+	s := resultErr.Stats
+	switch {
+	case s.ApplyStats.Failed > 0:
+		return ExitCodeApplyFailed
+	case s.PruneStats.Failed > 0 || s.DeleteStats.Failed > 0:
+		return ExitCodePruneFailed
+	case s.FailedReconciliationSum() > 0:
+		return ExitCodeReconcileFailed
+	default:
+		return ExitCodeError
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+)
+
+func TestExitCodeFromError(t *testing.T) {
+	testCases := map[string]struct {
+		err      error
+		expected int
+	}{
+		"no error": {
+			err:      nil,
+			expected: ExitCodeSuccess,
+		},
+		"fatal error": {
+			err:      errors.New("inventory not found"),
+			expected: ExitCodeError,
+		},
+		"apply failed": {
+			err: &ResultError{Stats: stats.Stats{
+				ApplyStats: stats.ApplyStats{Failed: 1},
+				PruneStats: stats.PruneStats{Failed: 1},
+				WaitStats:  stats.WaitStats{Timeout: 1},
+			}},
+			expected: ExitCodeApplyFailed,
+		},
+		"prune failed": {
+			err: &ResultError{Stats: stats.Stats{
+				PruneStats: stats.PruneStats{Failed: 1},
+				WaitStats:  stats.WaitStats{Timeout: 1},
+			}},
+			expected: ExitCodePruneFailed,
+		},
+		"delete failed": {
+			err: &ResultError{Stats: stats.Stats{
+				DeleteStats: stats.DeleteStats{Failed: 2},
+			}},
+			expected: ExitCodePruneFailed,
+		},
+		"reconcile timeout": {
+			err: &ResultError{Stats: stats.Stats{
+				WaitStats: stats.WaitStats{Timeout: 1},
+			}},
+			expected: ExitCodeReconcileFailed,
+		},
+		"run timeout": {
+			err:      context.DeadlineExceeded,
+			expected: ExitCodeTimeout,
+		},
+		"wrapped run timeout": {
+			err:      fmt.Errorf("polling for status failed: %w", context.DeadlineExceeded),
+			expected: ExitCodeTimeout,
+		},
+		"wrapped result error": {
+			err: fmt.Errorf("apply: %w", &ResultError{Stats: stats.Stats{
+				WaitStats: stats.WaitStats{Failed: 1},
+			}}),
+			expected: ExitCodeReconcileFailed,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ExitCodeFromError(tc.err))
+		})
+	}
+}
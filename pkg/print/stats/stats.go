@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
 )
 
 // Stats captures the summarized numbers from apply/prune/delete and
@@ -17,6 +18,14 @@ type Stats struct {
 	PruneStats  PruneStats
 	DeleteStats DeleteStats
 	WaitStats   WaitStats
+	// Warnings are the warnings sent by the server for all the objects.
+	Warnings []Warning
+}
+
+// Warning is a warning sent by the server for an object.
+type Warning struct {
+	Identifier object.ObjMetadata
+	Message    string
 }
 
 // FailedActuationSum returns the number of resources that failed actuation.
@@ -34,6 +43,12 @@ func (s *Stats) Handle(e event.Event) {
 	switch e.Type {
 	case event.ApplyType:
 		s.ApplyStats.Inc(e.ApplyEvent.Status)
+		for _, w := range e.ApplyEvent.Warnings {
+			s.Warnings = append(s.Warnings, Warning{
+				Identifier: e.ApplyEvent.Identifier,
+				Message:    w,
+			})
+		}
 	case event.PruneType:
 		s.PruneStats.Inc(e.PruneEvent.Status)
 	case event.DeleteType:
@@ -51,6 +66,8 @@ type ApplyStats struct {
 
 func (a *ApplyStats) Inc(op event.ApplyEventStatus) {
 	switch op {
+	case event.ApplyPending:
+		// ignore - the apply is retried, and replaced by one of the others
 	case event.ApplySuccessful:
 		a.Successful++
 	case event.ApplySkipped:
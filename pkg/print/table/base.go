@@ -6,6 +6,7 @@ package table
 import (
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"unicode/utf8"
 
@@ -46,6 +47,53 @@ type Resource interface {
 type BaseTablePrinter struct {
 	IOStreams genericclioptions.IOStreams
 	Columns   []ColumnDefinition
+	// GroupBy optionally groups the top-level resources, printing a
+	// header line before each group.
+	GroupBy GroupBy
+}
+
+// GroupBy defines how resources are grouped in the table.
+type GroupBy string
+
+const (
+	// GroupByNone prints resources in the order they are provided.
+	GroupByNone GroupBy = ""
+	// GroupByNamespace groups resources by namespace. Cluster-scoped
+	// resources are grouped together.
+	GroupByNamespace GroupBy = "namespace"
+	// GroupByGroupKind groups resources by API group and kind.
+	GroupByGroupKind GroupBy = "groupkind"
+)
+
+// ParseGroupBy returns the GroupBy with the provided name, or "none" for
+// GroupByNone.
+func ParseGroupBy(s string) (GroupBy, error) {
+	switch g := GroupBy(strings.ToLower(s)); g {
+	case GroupByNamespace, GroupByGroupKind:
+		return g, nil
+	case GroupByNone, "none":
+		return GroupByNone, nil
+	default:
+		return GroupByNone, fmt.Errorf("unknown group-by value %q: must be one of none, %s, %s",
+			s, GroupByNamespace, GroupByGroupKind)
+	}
+}
+
+// groupKey returns the key identifying the group of the resource, and the
+// text of the group header.
+func (g GroupBy) groupKey(r Resource) (string, string) {
+	id := r.Identifier()
+	switch g {
+	case GroupByNamespace:
+		if id.Namespace == "" {
+			return "", "(cluster-scoped)"
+		}
+		return id.Namespace, "namespace: " + id.Namespace
+	case GroupByGroupKind:
+		return id.GroupKind.String(), "kind: " + id.GroupKind.String()
+	default:
+		return "", ""
+	}
 }
 
 // PrintTable prints the resources defined in ResourceStates. It will
@@ -72,7 +120,26 @@ func (t *BaseTablePrinter) PrintTable(rs ResourceStates,
 		}
 	}
 
-	for _, resource := range rs.Resources() {
+	resources := rs.Resources()
+	if t.GroupBy != GroupByNone {
+		resources = append([]Resource{}, resources...)
+		sort.SliceStable(resources, func(i, j int) bool {
+			ki, _ := t.GroupBy.groupKey(resources[i])
+			kj, _ := t.GroupBy.groupKey(resources[j])
+			return ki < kj
+		})
+	}
+
+	var prevKey string
+	for j, resource := range resources {
+		if t.GroupBy != GroupByNone {
+			key, header := t.GroupBy.groupKey(resource)
+			if j == 0 || key != prevKey {
+				t.printOrDie("%s\n", header)
+				linePrintCount++
+			}
+			prevKey = key
+		}
 		for i, column := range t.Columns {
 			written, err := column.PrintResource(t.IOStreams.Out, column.Width(), resource)
 			if err != nil {
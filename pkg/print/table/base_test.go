@@ -31,6 +31,7 @@ var (
 func TestBaseTablePrinter_PrintTable(t *testing.T) {
 	testCases := map[string]struct {
 		columnDefinitions []ColumnDefinition
+		groupBy           GroupBy
 		resources         []Resource
 		expectedOutput    string
 	}{
@@ -127,6 +128,49 @@ Deployment/Foo                            end
 			expectedOutput: `
 RESOURCE                                  END
 Deployment/VeryLongNameThatShouldBeTrimm  end
+`,
+		},
+		"grouped by namespace": {
+			columnDefinitions: []ColumnDefinition{
+				MustColumn("resource"),
+				endColumnDef,
+			},
+			groupBy: GroupByNamespace,
+			resources: []Resource{
+				newFakeResource("foo", "apps", "Deployment", "Foo"),
+				newFakeResource("", "", "Namespace", "foo"),
+				newFakeResource("bar", "", "ConfigMap", "Bar"),
+				newFakeResource("foo", "", "ConfigMap", "Baz"),
+			},
+			expectedOutput: `
+RESOURCE                                  END
+(cluster-scoped)
+Namespace/foo                             end
+namespace: bar
+ConfigMap/Bar                             end
+namespace: foo
+Deployment/Foo                            end
+ConfigMap/Baz                             end
+`,
+		},
+		"grouped by groupkind": {
+			columnDefinitions: []ColumnDefinition{
+				MustColumn("resource"),
+				endColumnDef,
+			},
+			groupBy: GroupByGroupKind,
+			resources: []Resource{
+				newFakeResource("foo", "apps", "Deployment", "Foo"),
+				newFakeResource("bar", "", "ConfigMap", "Bar"),
+				newFakeResource("foo", "", "ConfigMap", "Baz"),
+			},
+			expectedOutput: `
+RESOURCE                                  END
+kind: ConfigMap
+ConfigMap/Bar                             end
+ConfigMap/Baz                             end
+kind: Deployment.apps
+Deployment/Foo                            end
 `,
 		},
 	}
@@ -138,6 +182,7 @@ Deployment/VeryLongNameThatShouldBeTrimm  end
 			printer := &BaseTablePrinter{
 				IOStreams: ioStreams,
 				Columns:   tc.columnDefinitions,
+				GroupBy:   tc.groupBy,
 			}
 
 			resourceStates := &fakeResourceStates{
@@ -165,6 +210,21 @@ func (r *fakeResourceStates) Error() error {
 	return nil
 }
 
+func newFakeResource(namespace, group, kind, name string) *fakeResource {
+	return &fakeResource{
+		resourceStatus: &pe.ResourceStatus{
+			Identifier: object.ObjMetadata{
+				Namespace: namespace,
+				Name:      name,
+				GroupKind: schema.GroupKind{
+					Group: group,
+					Kind:  kind,
+				},
+			},
+		},
+	}
+}
+
 type fakeResource struct {
 	resourceStatus *pe.ResourceStatus
 }
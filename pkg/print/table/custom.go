@@ -0,0 +1,138 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package table
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// DefaultCustomColumnWidth is the width of custom columns, unless the
+// header is wider.
+const DefaultCustomColumnWidth = 20
+
+const conditionPrefix = "condition="
+
+// NewJSONPathColumn returns a column that outputs the result of evaluating
+// the JSONPath template against the latest known state of the resource,
+// such as "{.spec.replicas}". Multiple results are comma separated.
+// A "-" is printed if the object is not known or the path is not found.
+func NewJSONPathColumn(header, template string, width int) (ColumnDef, error) {
+	jp := jsonpath.New(header).AllowMissingKeys(true)
+	if err := jp.Parse(template); err != nil {
+		return ColumnDef{}, fmt.Errorf("invalid JSONPath %q for column %q: %w", template, header, err)
+	}
+	return ColumnDef{
+		ColumnName:   strings.ToLower(header),
+		ColumnHeader: header,
+		ColumnWidth:  width,
+		PrintResourceFunc: func(w io.Writer, width int, r Resource) (int, error) {
+			text := truncate(evalJSONPath(jp, r), width)
+			_, err := fmt.Fprint(w, text)
+			return utf8.RuneCountInString(text), err
+		},
+	}, nil
+}
+
+// truncate returns the first width runes of the text, so multi-byte
+// characters are not split.
+func truncate(text string, width int) string {
+	if utf8.RuneCountInString(text) <= width {
+		return text
+	}
+	return string([]rune(text)[:width])
+}
+
+// NewConditionColumn returns a column that outputs the status of the
+// condition with the provided type, such as "True" for the "Ready"
+// condition. A "-" is printed if the condition is not found.
+func NewConditionColumn(header, conditionType string, width int) (ColumnDef, error) {
+	template := fmt.Sprintf(`{.status.conditions[?(@.type==%q)].status}`, conditionType)
+	return NewJSONPathColumn(header, template, width)
+}
+
+func evalJSONPath(jp *jsonpath.JSONPath, r Resource) string {
+	rs := r.ResourceStatus()
+	if rs == nil || rs.Resource == nil {
+		return "-"
+	}
+	results, err := jp.FindResults(rs.Resource.Object)
+	if err != nil {
+		return "-"
+	}
+	var values []string
+	for _, result := range results {
+		for _, v := range result {
+			var buf bytes.Buffer
+			if err := jp.PrintResults(&buf, []reflect.Value{v}); err != nil {
+				return "-"
+			}
+			values = append(values, buf.String())
+		}
+	}
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ",")
+}
+
+// ParseColumns parses a comma separated list of column specs into column
+// definitions. Each spec is one of:
+//
+//	NAME                   a pre-defined column, like "namespace" or "status"
+//	HEADER:{.json.path}    a column that outputs a field of the object
+//	HEADER:condition=TYPE  a column that outputs the status of a condition
+//
+// Columns in extra take precedence over the pre-defined columns with the
+// same name, which allows printers to expose their own columns.
+func ParseColumns(spec string, extra map[string]ColumnDefinition) ([]ColumnDefinition, error) {
+	var columns []ColumnDefinition
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		header, expr, found := strings.Cut(s, ":")
+		if !found {
+			if c, ok := extra[s]; ok {
+				columns = append(columns, c)
+				continue
+			}
+			c, ok := columnDefinitions[s]
+			if !ok {
+				return nil, fmt.Errorf("unknown column name %q", s)
+			}
+			columns = append(columns, c)
+			continue
+		}
+		if header == "" {
+			return nil, fmt.Errorf("missing header for column %q", s)
+		}
+		width := DefaultCustomColumnWidth
+		if len(header) > width {
+			width = len(header)
+		}
+		var c ColumnDef
+		var err error
+		if strings.HasPrefix(expr, conditionPrefix) {
+			c, err = NewConditionColumn(header, strings.TrimPrefix(expr, conditionPrefix), width)
+		} else {
+			c, err = NewJSONPathColumn(header, expr, width)
+		}
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("no columns specified")
+	}
+	return columns, nil
+}
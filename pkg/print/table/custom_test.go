@@ -0,0 +1,140 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package table
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pe "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+)
+
+func TestParseColumns(t *testing.T) {
+	resource := &fakeResource{
+		resourceStatus: &pe.ResourceStatus{
+			Resource: &unstructured.Unstructured{
+				Object: map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"namespace": "default",
+					},
+					"spec": map[string]interface{}{
+						"replicas":    int64(3),
+						"description": strings.Repeat("ü", 25),
+					},
+					"status": map[string]interface{}{
+						"conditions": []interface{}{
+							map[string]interface{}{
+								"type":   "Available",
+								"status": "False",
+							},
+							map[string]interface{}{
+								"type":   "Ready",
+								"status": "True",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	extra := map[string]ColumnDefinition{
+		"foo": endColumnDef,
+	}
+
+	testCases := map[string]struct {
+		spec            string
+		expectedHeaders []string
+		expectedOutput  []string
+		expectedErr     string
+	}{
+		"pre-defined and extra columns": {
+			spec:            "namespace, foo",
+			expectedHeaders: []string{"NAMESPACE", "END"},
+		},
+		"jsonpath column": {
+			spec:            "REPLICAS:{.spec.replicas},NS:{.metadata.namespace}",
+			expectedHeaders: []string{"REPLICAS", "NS"},
+			expectedOutput:  []string{"3", "default"},
+		},
+		"missing field": {
+			spec:            "MISSING:{.spec.missing}",
+			expectedHeaders: []string{"MISSING"},
+			expectedOutput:  []string{"-"},
+		},
+		"multi-byte text is truncated by rune": {
+			spec:            "DESCRIPTION:{.spec.description}",
+			expectedHeaders: []string{"DESCRIPTION"},
+			expectedOutput:  []string{strings.Repeat("ü", DefaultCustomColumnWidth)},
+		},
+		"condition column": {
+			spec:            "READY:condition=Ready",
+			expectedHeaders: []string{"READY"},
+			expectedOutput:  []string{"True"},
+		},
+		"unknown column": {
+			spec:        "namespace,bar",
+			expectedErr: `unknown column name "bar"`,
+		},
+		"invalid jsonpath": {
+			spec:        "BAD:{.spec[",
+			expectedErr: `invalid JSONPath "{.spec[" for column "BAD"`,
+		},
+		"missing header": {
+			spec:        ":{.spec}",
+			expectedErr: `missing header for column ":{.spec}"`,
+		},
+		"empty": {
+			spec:        " , ",
+			expectedErr: "no columns specified",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			columns, err := ParseColumns(tc.spec, extra)
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			var headers []string
+			for _, c := range columns {
+				headers = append(headers, c.Header())
+			}
+			assert.Equal(t, tc.expectedHeaders, headers)
+
+			for i, expected := range tc.expectedOutput {
+				var buf bytes.Buffer
+				written, err := columns[i].PrintResource(&buf, columns[i].Width(), resource)
+				require.NoError(t, err)
+				assert.Equal(t, expected, buf.String())
+				assert.Equal(t, utf8.RuneCountInString(expected), written)
+			}
+		})
+	}
+}
+
+func TestParseGroupBy(t *testing.T) {
+	for s, expected := range map[string]GroupBy{
+		"":          GroupByNone,
+		"none":      GroupByNone,
+		"Namespace": GroupByNamespace,
+		"groupkind": GroupByGroupKind,
+	} {
+		groupBy, err := ParseGroupBy(s)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, groupBy)
+	}
+
+	_, err := ParseGroupBy("name")
+	assert.EqualError(t, err,
+		`unknown group-by value "name": must be one of none, namespace, groupkind`)
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package printers
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/term"
+	"sigs.k8s.io/cli-utils/pkg/printers/events"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+)
+
+// ColorMode controls whether printers highlight output with ANSI colors.
+type ColorMode string
+
+const (
+	// ColorNever disables colors. This is the default.
+	ColorNever ColorMode = "never"
+	// ColorAuto enables colors if the output is a terminal that supports
+	// them, and the NO_COLOR environment variable is not set.
+	ColorAuto ColorMode = "auto"
+	// ColorAlways enables colors, regardless of the output.
+	ColorAlways ColorMode = "always"
+)
+
+// ColorModes returns the accepted values for ParseColorMode.
+func ColorModes() []string {
+	return []string{string(ColorNever), string(ColorAuto), string(ColorAlways)}
+}
+
+// ParseColorMode returns the ColorMode with the provided name. An empty
+// name returns ColorNever.
+func ParseColorMode(s string) (ColorMode, error) {
+	switch m := ColorMode(s); m {
+	case ColorNever, ColorAuto, ColorAlways:
+		return m, nil
+	case "":
+		return ColorNever, nil
+	default:
+		return ColorNever, fmt.Errorf("unknown color mode %q: must be one of %s", s, strings.Join(ColorModes(), ", "))
+	}
+}
+
+// Enabled returns true if colors should be used when writing to w.
+func (m ColorMode) Enabled(w io.Writer) bool {
+	switch m {
+	case ColorAlways:
+		return true
+	case ColorAuto:
+		return term.AllowsColorOutput(w)
+	default:
+		return false
+	}
+}
+
+// Options configures the printers returned by GetPrinterWithOptions.
+type Options struct {
+	// Color controls highlighting in the events and quiet printers. Other
+	// output formats are not colored.
+	Color ColorMode
+}
+
+// GetPrinterWithOptions returns a new Printer for the specified output
+// format, like GetPrinter, configured with the provided options.
+func GetPrinterWithOptions(printerType string, ioStreams genericclioptions.IOStreams, opts Options) printer.Printer {
+	if !ValidatePrinterType(printerType) {
+		printerType = DefaultPrinter()
+	}
+	if opts.Color.Enabled(ioStreams.Out) {
+		switch printerType {
+		case EventsPrinter:
+			return events.NewColorPrinter(ioStreams)
+		case QuietPrinter:
+			return events.NewQuietColorPrinter(ioStreams)
+		}
+	}
+	return GetPrinter(printerType, ioStreams)
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package printers
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseColorMode(t *testing.T) {
+	testCases := map[string]struct {
+		input       string
+		expected    ColorMode
+		expectedErr string
+	}{
+		"empty":  {input: "", expected: ColorNever},
+		"never":  {input: "never", expected: ColorNever},
+		"auto":   {input: "auto", expected: ColorAuto},
+		"always": {input: "always", expected: ColorAlways},
+		"unknown": {
+			input:       "sometimes",
+			expected:    ColorNever,
+			expectedErr: `unknown color mode "sometimes": must be one of never, auto, always`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			mode, err := ParseColorMode(tc.input)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, mode)
+		})
+	}
+}
+
+func TestColorModeEnabled(t *testing.T) {
+	// A buffer is never a terminal.
+	var buf bytes.Buffer
+	assert.False(t, ColorNever.Enabled(&buf))
+	assert.False(t, ColorAuto.Enabled(&buf))
+	assert.True(t, ColorAlways.Enabled(&buf))
+}
@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/common"
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
 	"sigs.k8s.io/cli-utils/pkg/print/list"
 	"sigs.k8s.io/cli-utils/pkg/print/stats"
 )
@@ -24,8 +25,19 @@ func NewFormatter(ioStreams genericclioptions.IOStreams,
 	}
 }
 
+// NewColorFormatter returns a Formatter that highlights lines with ANSI
+// colors, by severity.
+func NewColorFormatter(ioStreams genericclioptions.IOStreams,
+	_ common.DryRunStrategy) list.Formatter {
+	return &formatter{
+		ioStreams: ioStreams,
+		color:     true,
+	}
+}
+
 type formatter struct {
 	ioStreams genericclioptions.IOStreams
+	color     bool
 }
 
 func (ef *formatter) FormatValidationEvent(ve event.ValidationEvent) error {
@@ -35,6 +47,14 @@ func (ef *formatter) FormatValidationEvent(ve event.ValidationEvent) error {
 		err = vErr.Unwrap()
 	}
 
+	// Warnings don't make the objects invalid.
+	color := printcommon.RED
+	prefix := "Invalid object"
+	if ve.Severity == event.WarningSeverity {
+		color = printcommon.YELLOW
+		prefix = "Warning for object"
+	}
+
 	switch {
 	case len(ve.Identifiers) == 0:
 		// no objects, invalid event
@@ -42,18 +62,18 @@ func (ef *formatter) FormatValidationEvent(ve event.ValidationEvent) error {
 	case len(ve.Identifiers) == 1:
 		// only 1 object, unwrap for similarity with status event
 		id := ve.Identifiers[0]
-		ef.print("Invalid object (%s): %v",
+		ef.printColor(color, "%s (%s): %v", prefix,
 			resourceIDToString(id.GroupKind, id.Name), err.Error())
 	default:
 		// more than 1 object, wrap list in brackets
 		var sb strings.Builder
 		id := ve.Identifiers[0]
-		_, _ = fmt.Fprintf(&sb, "Invalid objects (%s", resourceIDToString(id.GroupKind, id.Name))
+		_, _ = fmt.Fprintf(&sb, "%ss (%s", prefix, resourceIDToString(id.GroupKind, id.Name))
 		for _, id := range ve.Identifiers[1:] {
 			_, _ = fmt.Fprintf(&sb, ", %s", resourceIDToString(id.GroupKind, id.Name))
 		}
 		_, _ = fmt.Fprintf(&sb, "): %v", err)
-		ef.print(sb.String())
+		ef.printColor(color, "%s", sb.String())
 	}
 	return nil
 }
@@ -61,13 +81,25 @@ func (ef *formatter) FormatValidationEvent(ve event.ValidationEvent) error {
 func (ef *formatter) FormatApplyEvent(e event.ApplyEvent) error {
 	gk := e.Identifier.GroupKind
 	name := e.Identifier.Name
-	if e.Error != nil {
-		ef.print("%s apply %s: %s", resourceIDToString(gk, name),
+	color := colorForActuation(e.Status == event.ApplyFailed, e.Status == event.ApplySkipped)
+	switch {
+	case e.Error != nil:
+		ef.printColor(color, "%s apply %s: %s", resourceIDToString(gk, name),
 			strings.ToLower(e.Status.String()), e.Error.Error())
-	} else {
-		ef.print("%s apply %s", resourceIDToString(gk, name),
+	case e.Status == event.ApplySuccessful && e.Operation != event.UnknownApplyOperation:
+		ef.printColor(color, "%s apply %s (%s)", resourceIDToString(gk, name),
+			strings.ToLower(e.Status.String()), strings.ToLower(e.Operation.String()))
+	default:
+		ef.printColor(color, "%s apply %s", resourceIDToString(gk, name),
 			strings.ToLower(e.Status.String()))
 	}
+	// Print the changed fields under the object, indented.
+	for _, d := range e.Diff {
+		ef.print("  %s", d)
+	}
+	for _, w := range e.Warnings {
+		ef.printColor(printcommon.YELLOW, "%s apply warning: %s", resourceIDToString(gk, name), w)
+	}
 	return nil
 }
 
@@ -80,11 +112,12 @@ func (ef *formatter) FormatStatusEvent(se event.StatusEvent) error {
 func (ef *formatter) FormatPruneEvent(e event.PruneEvent) error {
 	gk := e.Identifier.GroupKind
 	name := e.Identifier.Name
+	color := colorForActuation(e.Status == event.PruneFailed, e.Status == event.PruneSkipped)
 	if e.Error != nil {
-		ef.print("%s prune %s: %s", resourceIDToString(gk, name),
+		ef.printColor(color, "%s prune %s: %s", resourceIDToString(gk, name),
 			strings.ToLower(e.Status.String()), e.Error.Error())
 	} else {
-		ef.print("%s prune %s", resourceIDToString(gk, name),
+		ef.printColor(color, "%s prune %s", resourceIDToString(gk, name),
 			strings.ToLower(e.Status.String()))
 	}
 	return nil
@@ -93,11 +126,12 @@ func (ef *formatter) FormatPruneEvent(e event.PruneEvent) error {
 func (ef *formatter) FormatDeleteEvent(e event.DeleteEvent) error {
 	gk := e.Identifier.GroupKind
 	name := e.Identifier.Name
+	color := colorForActuation(e.Status == event.DeleteFailed, e.Status == event.DeleteSkipped)
 	if e.Error != nil {
-		ef.print("%s delete %s: %s", resourceIDToString(gk, name),
+		ef.printColor(color, "%s delete %s: %s", resourceIDToString(gk, name),
 			strings.ToLower(e.Status.String()), e.Error.Error())
 	} else {
-		ef.print("%s delete %s", resourceIDToString(gk, name),
+		ef.printColor(color, "%s delete %s", resourceIDToString(gk, name),
 			strings.ToLower(e.Status.String()))
 	}
 	return nil
@@ -106,7 +140,16 @@ func (ef *formatter) FormatDeleteEvent(e event.DeleteEvent) error {
 func (ef *formatter) FormatWaitEvent(e event.WaitEvent) error {
 	gk := e.Identifier.GroupKind
 	name := e.Identifier.Name
-	ef.print("%s reconcile %s", resourceIDToString(gk, name),
+	var color printcommon.Color
+	switch e.Status {
+	case event.ReconcileSuccessful:
+		color = printcommon.GREEN
+	case event.ReconcileSkipped:
+		color = printcommon.YELLOW
+	case event.ReconcileFailed, event.ReconcileTimeout:
+		color = printcommon.RED
+	}
+	ef.printColor(color, "%s reconcile %s", resourceIDToString(gk, name),
 		strings.ToLower(e.Status.String()))
 	return nil
 }
@@ -141,29 +184,41 @@ func (ef *formatter) FormatActionGroupEvent(
 func (ef *formatter) FormatSummary(s stats.Stats) error {
 	if s.ApplyStats != (stats.ApplyStats{}) {
 		as := s.ApplyStats
-		ef.print("apply result: %d attempted, %d successful, %d skipped, %d failed",
+		ef.printColor(colorForActuation(as.Failed > 0, false),
+			"apply result: %d attempted, %d successful, %d skipped, %d failed",
 			as.Sum(), as.Successful, as.Skipped, as.Failed)
 	}
 	if s.PruneStats != (stats.PruneStats{}) {
 		ps := s.PruneStats
-		ef.print("prune result: %d attempted, %d successful, %d skipped, %d failed",
+		ef.printColor(colorForActuation(ps.Failed > 0, false),
+			"prune result: %d attempted, %d successful, %d skipped, %d failed",
 			ps.Sum(), ps.Successful, ps.Skipped, ps.Failed)
 	}
 	if s.DeleteStats != (stats.DeleteStats{}) {
 		ds := s.DeleteStats
-		ef.print("delete result: %d attempted, %d successful, %d skipped, %d failed",
+		ef.printColor(colorForActuation(ds.Failed > 0, false),
+			"delete result: %d attempted, %d successful, %d skipped, %d failed",
 			ds.Sum(), ds.Successful, ds.Skipped, ds.Failed)
 	}
 	if s.WaitStats != (stats.WaitStats{}) {
 		ws := s.WaitStats
-		ef.print("reconcile result: %d attempted, %d successful, %d skipped, %d failed, %d timed out",
+		ef.printColor(colorForActuation(ws.Failed > 0 || ws.Timeout > 0, false),
+			"reconcile result: %d attempted, %d successful, %d skipped, %d failed, %d timed out",
 			ws.Sum(), ws.Successful, ws.Skipped, ws.Failed, ws.Timeout)
 	}
+	if len(s.Warnings) > 0 {
+		ef.printColor(printcommon.YELLOW, "%d warnings:", len(s.Warnings))
+		for _, w := range s.Warnings {
+			ef.printColor(printcommon.YELLOW, "  %s: %s",
+				resourceIDToString(w.Identifier.GroupKind, w.Identifier.Name), w.Message)
+		}
+	}
 	return nil
 }
 
 func (ef *formatter) printResourceStatus(id object.ObjMetadata, se event.StatusEvent) {
-	ef.print("%s is %s: %s", resourceIDToString(id.GroupKind, id.Name),
+	color, _ := printcommon.ColorForStatus(se.PollResourceInfo.Status)
+	ef.printColor(color, "%s is %s: %s", resourceIDToString(id.GroupKind, id.Name),
 		se.PollResourceInfo.Status.String(), se.PollResourceInfo.Message)
 }
 
@@ -171,6 +226,28 @@ func (ef *formatter) print(format string, a ...interface{}) {
 	_, _ = fmt.Fprintf(ef.ioStreams.Out, format+"\n", a...)
 }
 
+// printColor prints the line with the provided color, if colors are
+// enabled. The zero Color prints without color.
+func (ef *formatter) printColor(color printcommon.Color, format string, a ...interface{}) {
+	if !ef.color || color == 0 {
+		ef.print(format, a...)
+		return
+	}
+	_, _ = fmt.Fprintln(ef.ioStreams.Out, printcommon.SprintfWithColor(color, format, a...))
+}
+
+// colorForActuation returns red for failed and yellow for skipped objects.
+func colorForActuation(failed, skipped bool) printcommon.Color {
+	switch {
+	case failed:
+		return printcommon.RED
+	case skipped:
+		return printcommon.YELLOW
+	default:
+		return 0
+	}
+}
+
 // resourceIDToString returns the string representation of a GroupKind and a resource name.
 func resourceIDToString(gk schema.GroupKind, name string) string {
 	return fmt.Sprintf("%s/%s", strings.ToLower(gk.String()), name)
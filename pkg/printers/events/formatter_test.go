@@ -21,6 +21,7 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/object"
 	"sigs.k8s.io/cli-utils/pkg/object/graph"
 	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
 	"sigs.k8s.io/cli-utils/pkg/print/list"
 )
 
@@ -55,6 +56,30 @@ func TestFormatter_FormatApplyEvent(t *testing.T) {
 			},
 			expected: "cronjob.batch/my-cron apply successful",
 		},
+		"resource configured with client dryrun should display the diff": {
+			previewStrategy: common.DryRunClient,
+			event: event.ApplyEvent{
+				Status:     event.ApplySuccessful,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+				Operation:  event.ApplyConfigured,
+				Diff: []object.FieldDiff{
+					{Path: ".metadata.labels.app", Old: nil, New: "my-dep"},
+					{Path: ".spec.replicas", Old: int64(1), New: int64(3)},
+				},
+			},
+			expected: "deployment.apps/my-dep apply successful (configured)\n" +
+				"  .metadata.labels.app: <unset> -> \"my-dep\"\n" +
+				"  .spec.replicas: 1 -> 3",
+		},
+		"resource unchanged with server dryrun": {
+			previewStrategy: common.DryRunServer,
+			event: event.ApplyEvent{
+				Status:     event.ApplySuccessful,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+				Operation:  event.ApplyUnchanged,
+			},
+			expected: "deployment.apps/my-dep apply successful (unchanged)",
+		},
 		"apply event with error should display the error": {
 			previewStrategy: common.DryRunServer,
 			event: event.ApplyEvent{
@@ -73,6 +98,16 @@ func TestFormatter_FormatApplyEvent(t *testing.T) {
 			},
 			expected: "deployment.apps/my-dep apply skipped: this is a test error",
 		},
+		"apply event with warnings should display the warnings": {
+			previewStrategy: common.DryRunNone,
+			event: event.ApplyEvent{
+				Status:     event.ApplySuccessful,
+				Identifier: createIdentifier("batch", "CronJob", "foo", "my-cron"),
+				Warnings:   []string{"batch/v1beta1 CronJob is deprecated in v1.21+, unavailable in v1.25+; use batch/v1 CronJob"},
+			},
+			expected: "cronjob.batch/my-cron apply successful\n" +
+				"cronjob.batch/my-cron apply warning: batch/v1beta1 CronJob is deprecated in v1.21+, unavailable in v1.25+; use batch/v1 CronJob",
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -406,6 +441,34 @@ func TestFormatter_FormatValidationEvent(t *testing.T) {
 			},
 			expected: "Invalid object (deployment.apps/bar): metadata.namespace: Required value: namespace is required",
 		},
+		"one object, warning": {
+			previewStrategy: common.DryRunNone,
+			event: event.ValidationEvent{
+				Identifiers: object.ObjMetadataSet{
+					{
+						GroupKind: schema.GroupKind{
+							Group: "batch",
+							Kind:  "CronJob",
+						},
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+				Error: validation.NewError(
+					errors.New("batch/v1beta1 CronJob is deprecated"),
+					object.ObjMetadata{
+						GroupKind: schema.GroupKind{
+							Group: "batch",
+							Kind:  "CronJob",
+						},
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				),
+				Severity: event.WarningSeverity,
+			},
+			expected: "Warning for object (cronjob.batch/bar): batch/v1beta1 CronJob is deprecated",
+		},
 		"two objects, cyclic dependency": {
 			previewStrategy: common.DryRunNone,
 			event: event.ValidationEvent{
@@ -530,3 +593,72 @@ func createIdentifier(group, kind, namespace, name string) object.ObjMetadata {
 		},
 	}
 }
+
+func TestColorFormatter(t *testing.T) {
+	id := createIdentifier("apps", "Deployment", "default", "my-dep")
+	red := func(s string) string { return printcommon.SprintfWithColor(printcommon.RED, "%s", s) }
+	yellow := func(s string) string { return printcommon.SprintfWithColor(printcommon.YELLOW, "%s", s) }
+	green := func(s string) string { return printcommon.SprintfWithColor(printcommon.GREEN, "%s", s) }
+
+	testCases := map[string]struct {
+		format   func(list.Formatter) error
+		expected string
+	}{
+		"successful apply is not colored": {
+			format: func(f list.Formatter) error {
+				return f.FormatApplyEvent(event.ApplyEvent{
+					Status:     event.ApplySuccessful,
+					Identifier: id,
+				})
+			},
+			expected: "deployment.apps/my-dep apply successful",
+		},
+		"failed apply is red": {
+			format: func(f list.Formatter) error {
+				return f.FormatApplyEvent(event.ApplyEvent{
+					Status:     event.ApplyFailed,
+					Identifier: id,
+					Error:      errors.New("forbidden"),
+				})
+			},
+			expected: red("deployment.apps/my-dep apply failed: forbidden"),
+		},
+		"skipped prune is yellow": {
+			format: func(f list.Formatter) error {
+				return f.FormatPruneEvent(event.PruneEvent{
+					Status:     event.PruneSkipped,
+					Identifier: id,
+				})
+			},
+			expected: yellow("deployment.apps/my-dep prune skipped"),
+		},
+		"reconciled is green": {
+			format: func(f list.Formatter) error {
+				return f.FormatWaitEvent(event.WaitEvent{
+					Status:     event.ReconcileSuccessful,
+					Identifier: id,
+				})
+			},
+			expected: green("deployment.apps/my-dep reconcile successful"),
+		},
+		"reconcile timeout is red": {
+			format: func(f list.Formatter) error {
+				return f.FormatWaitEvent(event.WaitEvent{
+					Status:     event.ReconcileTimeout,
+					Identifier: id,
+				})
+			},
+			expected: red("deployment.apps/my-dep reconcile timeout"),
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams() //nolint:dogsled
+			formatter := NewColorFormatter(ioStreams, common.DryRunNone)
+			err := tc.format(formatter)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, strings.TrimSuffix(out.String(), "\n"))
+		})
+	}
+}
@@ -17,3 +17,13 @@ func NewPrinter(ioStreams genericclioptions.IOStreams) printer.Printer {
 		},
 	}
 }
+
+// NewColorPrinter returns an events printer that highlights errors and
+// timeouts in red, skips in yellow, and reconciled objects in green.
+func NewColorPrinter(ioStreams genericclioptions.IOStreams) printer.Printer {
+	return &list.BaseListPrinter{
+		FormatterFactory: func(previewStrategy common.DryRunStrategy) list.Formatter {
+			return NewColorFormatter(ioStreams, previewStrategy)
+		},
+	}
+}
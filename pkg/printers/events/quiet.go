@@ -0,0 +1,86 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/print/list"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+)
+
+// NewQuietPrinter returns an events printer that only prints invalid,
+// failed, and skipped objects, followed by the summary. A run where every
+// object succeeds only prints the summary.
+func NewQuietPrinter(ioStreams genericclioptions.IOStreams) printer.Printer {
+	return &list.BaseListPrinter{
+		FormatterFactory: func(previewStrategy common.DryRunStrategy) list.Formatter {
+			return NewQuietFormatter(NewFormatter(ioStreams, previewStrategy))
+		},
+	}
+}
+
+// NewQuietColorPrinter returns a quiet events printer that highlights lines
+// with colors, like NewColorPrinter.
+func NewQuietColorPrinter(ioStreams genericclioptions.IOStreams) printer.Printer {
+	return &list.BaseListPrinter{
+		FormatterFactory: func(previewStrategy common.DryRunStrategy) list.Formatter {
+			return NewQuietFormatter(NewColorFormatter(ioStreams, previewStrategy))
+		},
+	}
+}
+
+// NewQuietFormatter returns a Formatter that delegates to the provided
+// Formatter, but drops successful and pending object events, status events,
+// and action group events.
+func NewQuietFormatter(f list.Formatter) list.Formatter {
+	return &quietFormatter{
+		Formatter: f,
+	}
+}
+
+type quietFormatter struct {
+	list.Formatter
+}
+
+func (qf *quietFormatter) FormatApplyEvent(e event.ApplyEvent) error {
+	if e.Status != event.ApplyFailed && e.Status != event.ApplySkipped {
+		return nil
+	}
+	return qf.Formatter.FormatApplyEvent(e)
+}
+
+func (qf *quietFormatter) FormatStatusEvent(event.StatusEvent) error {
+	return nil
+}
+
+func (qf *quietFormatter) FormatPruneEvent(e event.PruneEvent) error {
+	if e.Status != event.PruneFailed && e.Status != event.PruneSkipped {
+		return nil
+	}
+	return qf.Formatter.FormatPruneEvent(e)
+}
+
+func (qf *quietFormatter) FormatDeleteEvent(e event.DeleteEvent) error {
+	if e.Status != event.DeleteFailed && e.Status != event.DeleteSkipped {
+		return nil
+	}
+	return qf.Formatter.FormatDeleteEvent(e)
+}
+
+func (qf *quietFormatter) FormatWaitEvent(e event.WaitEvent) error {
+	// Reconcile skips are a consequence of failed or skipped actuation,
+	// which is already printed.
+	if e.Status != event.ReconcileFailed && e.Status != event.ReconcileTimeout {
+		return nil
+	}
+	return qf.Formatter.FormatWaitEvent(e)
+}
+
+func (qf *quietFormatter) FormatActionGroupEvent(event.ActionGroupEvent, []event.ActionGroup,
+	stats.Stats, list.Collector) error {
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+	printertesting "sigs.k8s.io/cli-utils/pkg/printers/testutil"
+)
+
+func TestQuietPrinter(t *testing.T) {
+	dep := createIdentifier("apps", "Deployment", "default", "my-dep")
+	cm := createIdentifier("", "ConfigMap", "default", "my-cm")
+	secret := createIdentifier("", "Secret", "default", "my-secret")
+
+	testCases := map[string]struct {
+		events         []event.Event
+		expectedOutput string
+		expectedErr    string
+	}{
+		"clean run only prints the summary": {
+			events: []event.Event{
+				{
+					Type: event.ActionGroupType,
+					ActionGroupEvent: event.ActionGroupEvent{
+						GroupName: "apply-0",
+						Action:    event.ApplyAction,
+						Status:    event.Started,
+					},
+				},
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						GroupName:  "apply-0",
+						Identifier: dep,
+						Status:     event.ApplySuccessful,
+					},
+				},
+				{
+					Type: event.WaitType,
+					WaitEvent: event.WaitEvent{
+						GroupName:  "wait-0",
+						Identifier: dep,
+						Status:     event.ReconcileSuccessful,
+					},
+				},
+			},
+			expectedOutput: `
+apply result: 1 attempted, 1 successful, 0 skipped, 0 failed
+reconcile result: 1 attempted, 1 successful, 0 skipped, 0 failed, 0 timed out
+`,
+		},
+		"failures and skips are printed": {
+			events: []event.Event{
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						GroupName:  "apply-0",
+						Identifier: dep,
+						Status:     event.ApplyFailed,
+						Error:      errors.New("forbidden"),
+					},
+				},
+				{
+					Type: event.PruneType,
+					PruneEvent: event.PruneEvent{
+						GroupName:  "prune-0",
+						Identifier: cm,
+						Status:     event.PruneSkipped,
+						Error:      errors.New("annotation prevents deletion"),
+					},
+				},
+				{
+					Type: event.PruneType,
+					PruneEvent: event.PruneEvent{
+						GroupName:  "prune-0",
+						Identifier: secret,
+						Status:     event.PruneSuccessful,
+					},
+				},
+				{
+					Type: event.WaitType,
+					WaitEvent: event.WaitEvent{
+						GroupName:  "wait-0",
+						Identifier: dep,
+						Status:     event.ReconcileSkipped,
+					},
+				},
+				{
+					Type: event.WaitType,
+					WaitEvent: event.WaitEvent{
+						GroupName:  "wait-1",
+						Identifier: secret,
+						Status:     event.ReconcileTimeout,
+					},
+				},
+			},
+			expectedOutput: `
+deployment.apps/my-dep apply failed: forbidden
+configmap/my-cm prune skipped: annotation prevents deletion
+secret/my-secret reconcile timeout
+apply result: 1 attempted, 0 successful, 0 skipped, 1 failed
+prune result: 2 attempted, 1 successful, 1 skipped, 0 failed
+reconcile result: 2 attempted, 0 successful, 1 skipped, 0 failed, 1 timed out
+`,
+			expectedErr: "1 resources failed, 1 resources failed to reconcile before timeout",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			p := NewQuietPrinter(ioStreams)
+
+			ch := make(chan event.Event, len(tc.events))
+			for _, e := range tc.events {
+				ch <- e
+			}
+			close(ch)
+
+			err := p.Print(ch, common.DryRunNone, true)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, strings.TrimSpace(tc.expectedOutput), strings.TrimSpace(out.String()))
+		})
+	}
+}
+
+func TestQuietPrinterResultError(t *testing.T) {
+	printertesting.PrintResultErrorTest(t, func() printer.Printer {
+		ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+		return NewQuietPrinter(ioStreams)
+	})
+}
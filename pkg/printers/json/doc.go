@@ -18,6 +18,7 @@
 //   - wait - WaitEvent
 //   - status - StatusEvent
 //   - summary - aggregate stats collected by the printer
+//   - warning - warnings collected by the printer
 //
 // Validation events correspond to zero or more objects. For these events, the
 // objects field includes a list of object identifiers. These generally fire
@@ -33,6 +34,8 @@
 // * timestamp (string) - ISO-8601 format
 // * type (string) - "validation"
 // * error (string) - a fatal error message specific to these objects
+// * severity (string, optional) - "Warning" if the error is not fatal, and
+// the objects are still applied or deleted
 //
 // Error events corespond to a fatal error received outside of a specific task
 // or operation.
@@ -65,6 +68,16 @@
 //   - timestamp (string) - ISO-8601 format
 //   - type (string) - "apply", "prune", "delete", or "wait"
 //   - error (string, optional) - A non-fatal error message specific to this object
+//   - operation (string, optional) - One of: "Created", "Configured", or
+//     "Unchanged". The change made by the apply, if known. Only set for apply
+//     events.
+//   - diff (array of objects, optional) - The changed fields, if field diffs
+//     are enabled. Only set for apply events.
+//   - path (string) - The path of the field, like ".spec.replicas".
+//   - old - The value in the cluster before the apply, or null if unset.
+//   - new - The applied value, or null if unset.
+//   - warnings (array of strings, optional) - The warnings sent by the server
+//     when the object was applied. Only set for apply events.
 //
 // Status types are asynchronous events that correspond to status updates for
 // a specific object.
@@ -94,4 +107,16 @@
 // * timeout (number, optional) - Number of objects for which the action timed out.
 // * timestamp (string) - ISO-8601 format
 // * type (string) - "summary"
+//
+// Warning types are meta-events sent by the printer after the summary events,
+// one for every warning sent by the server when the objects were applied.
+//
+// Warning events have the following fields:
+//   - group (string, optional) - The object's API group.
+//   - kind (string) - The object's kind.
+//   - name (string) - The object's name.
+//   - namespace (string, optional) - The object's namespace.
+//   - message (string) - The warning sent by the server.
+//   - timestamp (string) - ISO-8601 format
+//   - type (string) - "warning"
 package json
@@ -44,10 +44,14 @@ func (jf *formatter) FormatValidationEvent(ve event.ValidationEvent) error {
 	for i, id := range ve.Identifiers {
 		objects[i] = jf.baseResourceEvent(id)
 	}
-	return jf.printEvent("validation", map[string]interface{}{
+	eventInfo := map[string]interface{}{
 		"objects": objects,
 		"error":   err.Error(),
-	})
+	}
+	if ve.Severity == event.WarningSeverity {
+		eventInfo["severity"] = ve.Severity.String()
+	}
+	return jf.printEvent("validation", eventInfo)
 }
 
 func (jf *formatter) FormatApplyEvent(e event.ApplyEvent) error {
@@ -56,6 +60,23 @@ func (jf *formatter) FormatApplyEvent(e event.ApplyEvent) error {
 		eventInfo["error"] = e.Error.Error()
 	}
 	eventInfo["status"] = e.Status.String()
+	if e.Operation != event.UnknownApplyOperation {
+		eventInfo["operation"] = e.Operation.String()
+	}
+	if len(e.Diff) > 0 {
+		diff := make([]map[string]interface{}, 0, len(e.Diff))
+		for _, d := range e.Diff {
+			diff = append(diff, map[string]interface{}{
+				"path": d.Path,
+				"old":  d.Old,
+				"new":  d.New,
+			})
+		}
+		eventInfo["diff"] = diff
+	}
+	if len(e.Warnings) > 0 {
+		eventInfo["warnings"] = e.Warnings
+	}
 	return jf.printEvent("apply", eventInfo)
 }
 
@@ -208,6 +229,13 @@ func (jf *formatter) FormatSummary(s stats.Stats) error {
 			return err
 		}
 	}
+	for _, w := range s.Warnings {
+		eventInfo := jf.baseResourceEvent(w.Identifier)
+		eventInfo["message"] = w.Message
+		if err := jf.printEvent("warning", eventInfo); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -87,6 +87,34 @@ func TestFormatter_FormatApplyEvent(t *testing.T) {
 				},
 			},
 		},
+		"resource configured with diff": {
+			previewStrategy: common.DryRunClient,
+			event: event.ApplyEvent{
+				Status:     event.ApplySuccessful,
+				Identifier: createIdentifier("apps", "Deployment", "default", "my-dep"),
+				Operation:  event.ApplyConfigured,
+				Diff: []object.FieldDiff{
+					{Path: ".metadata.labels.app", Old: nil, New: "my-dep"},
+					{Path: ".spec.replicas", Old: int64(1), New: int64(3)},
+				},
+			},
+			expected: []map[string]interface{}{
+				{
+					"group":     "apps",
+					"kind":      "Deployment",
+					"name":      "my-dep",
+					"namespace": "default",
+					"status":    "Successful",
+					"operation": "Configured",
+					"diff": []interface{}{
+						map[string]interface{}{"path": ".metadata.labels.app", "old": nil, "new": "my-dep"},
+						map[string]interface{}{"path": ".spec.replicas", "old": float64(1), "new": float64(3)},
+					},
+					"timestamp": "",
+					"type":      "apply",
+				},
+			},
+		},
 		"resource apply failed": {
 			previewStrategy: common.DryRunNone,
 			event: event.ApplyEvent{
@@ -685,6 +713,47 @@ func TestFormatter_FormatValidationEvent(t *testing.T) {
 				"error": "metadata.namespace: Required value: namespace is required",
 			},
 		},
+		"one object, warning": {
+			previewStrategy: common.DryRunNone,
+			event: event.ValidationEvent{
+				Identifiers: object.ObjMetadataSet{
+					{
+						GroupKind: schema.GroupKind{
+							Group: "batch",
+							Kind:  "CronJob",
+						},
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				},
+				Error: validation.NewError(
+					errors.New("batch/v1beta1 CronJob is deprecated"),
+					object.ObjMetadata{
+						GroupKind: schema.GroupKind{
+							Group: "batch",
+							Kind:  "CronJob",
+						},
+						Namespace: "foo",
+						Name:      "bar",
+					},
+				),
+				Severity: event.WarningSeverity,
+			},
+			expected: map[string]interface{}{
+				"type":      "validation",
+				"timestamp": "",
+				"objects": []interface{}{
+					map[string]interface{}{
+						"group":     "batch",
+						"kind":      "CronJob",
+						"name":      "bar",
+						"namespace": "foo",
+					},
+				},
+				"error":    "batch/v1beta1 CronJob is deprecated",
+				"severity": "Warning",
+			},
+		},
 		"two objects, cyclic dependency": {
 			previewStrategy: common.DryRunNone,
 			event: event.ValidationEvent{
@@ -862,6 +931,43 @@ func TestFormatter_FormatSummary(t *testing.T) {
 				},
 			},
 		},
+		"apply warnings": {
+			statsCollector: stats.Stats{
+				ApplyStats: stats.ApplyStats{
+					Successful: 1,
+				},
+				Warnings: []stats.Warning{
+					{
+						Identifier: object.ObjMetadata{
+							GroupKind: schema.GroupKind{Group: "batch", Kind: "CronJob"},
+							Namespace: "foo",
+							Name:      "my-cron",
+						},
+						Message: "batch/v1beta1 CronJob is deprecated",
+					},
+				},
+			},
+			expected: []map[string]interface{}{
+				{
+					"action":     "Apply",
+					"count":      float64(1),
+					"successful": float64(1),
+					"skipped":    float64(0),
+					"failed":     float64(0),
+					"timestamp":  nowStr,
+					"type":       "summary",
+				},
+				{
+					"group":     "batch",
+					"kind":      "CronJob",
+					"namespace": "foo",
+					"name":      "my-cron",
+					"message":   "batch/v1beta1 CronJob is deprecated",
+					"timestamp": nowStr,
+					"type":      "warning",
+				},
+			},
+		},
 	}
 
 	for tn, tc := range testCases {
@@ -0,0 +1,80 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jsonv2 provides a printer that outputs the event stream as a stream
+// of JSON records, one per line, with a stable, versioned schema.
+//
+// Unlike the json printer, field names and enum values are defined by the
+// exported types in this package (see Record), rather than derived from the
+// event types, so consumers can unmarshal each line into a Record. Fields and
+// enum values will not be removed or renamed without changing SchemaVersion,
+// but new fields and enum values may be added, so consumers should ignore
+// unknown fields and treat unknown enum values as opaque.
+//
+// Every record has the following fields:
+//   - schemaVersion (string) - "v2"
+//   - timestamp (string) - RFC3339 formatted UTC timestamp of the event,
+//     or of the time the record was printed if the event has none.
+//   - type (string) - One of: "validation", "error", "group", "object",
+//     "status", or "summary".
+//
+// Validation records have the following additional fields:
+//   - objects (array) - The invalid object identifiers, each with the
+//     group, kind, namespace, and name fields (empty if not applicable).
+//   - error (object) - The validation error.
+//   - severity (string) - One of: "error" (the objects are not applied or
+//     deleted), or "warning" (the objects are still applied or deleted).
+//
+// Error records report a fatal error. They are always followed by the
+// summary record. They have the following additional field:
+//   - error (object) - The fatal error.
+//
+// Group records report the start and finish of a task group. They have the
+// following additional fields:
+//   - action (string) - One of: "apply", "prune", "delete", "wait", or
+//     "inventory".
+//   - groupName (string) - The task group name.
+//   - phase (string) - One of: "started" or "finished".
+//
+// Object records report the outcome of an action on a single object. They
+// have the following additional fields:
+//   - action (string) - One of: "apply", "prune", "delete", or "wait".
+//   - groupName (string) - The task group name.
+//   - object (object) - The object identifier, with the group, kind,
+//     namespace, and name fields.
+//   - operation (string) - One of: "pending", "successful", "skipped",
+//     "failed", or "timeout".
+//   - change (string, optional) - One of: "created", "configured", or
+//     "unchanged". The change made by a successful apply, if known. Only
+//     set for apply records.
+//   - diff (array, optional) - The fields changed by the apply, if field
+//     diffs are enabled, each with the path, old, and new fields. Unset
+//     values are null. Only set for apply records.
+//   - error (object, optional) - Why the action failed or was skipped.
+//
+// Status records are asynchronous status updates for a single object. They
+// are only printed if status printing is enabled. They have the following
+// additional fields:
+//   - object (object) - The object identifier.
+//   - status (string) - One of: "InProgress", "Failed", "Current",
+//     "Terminating", "NotFound", or "Unknown".
+//   - message (string, optional) - Human readable description of the status.
+//   - error (object, optional) - Why the status could not be computed.
+//
+// The summary record is always the last record. It has the following
+// additional field:
+//   - summary (object)
+//   - result (string) - One of: "success", "failure" (one or more objects
+//     failed or timed out), or "error" (a fatal error stopped the run).
+//   - dryRun (string) - One of: "None", "Client", or "Server".
+//   - actions (array) - One entry per action performed, in the order:
+//     apply, prune, delete, wait. Each entry has the action, total,
+//     successful, skipped, failed, and timeout fields.
+//
+// Error objects have the following fields:
+//   - code (string) - A machine-readable classification of the error.
+//     See the ErrorCode constants. Unrecognized errors have code "Unknown".
+//   - message (string) - The human readable error message.
+//   - hint (string) - Optional. How to fix the error, if it is a well-known
+//     failure.
+package jsonv2
@@ -0,0 +1,81 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonv2
+
+import (
+	"context"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	applyerror "sigs.k8s.io/cli-utils/pkg/apply/error"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+)
+
+// ErrorCodeFor returns the ErrorCode that best describes the error.
+// Returns UnknownErrorCode if the error is not recognized.
+func ErrorCodeFor(err error) ErrorCode {
+	var validationErr *validation.Error
+	var policyErr *inventory.PolicyPreventedActuationError
+	var depPreventedErr *filter.DependencyPreventedActuationError
+	var depMismatchErr *filter.DependencyActuationMismatchError
+	var annotationErr *filter.AnnotationPreventedDeletionError
+	var kindErr *filter.KindPreventedDeletionError
+	var applyPreventedErr *filter.ApplyPreventedDeletionError
+	var namespaceErr *filter.NamespaceInUseError
+	var staleErr *filter.StaleInventoryEntryError
+	var scopeErr *filter.ScopePreventedDeletionError
+	switch {
+	case errors.As(err, &validationErr):
+		return ValidationErrorCode
+	case errors.As(err, &policyErr):
+		return InventoryPolicyErrorCode
+	case errors.As(err, &depPreventedErr), errors.As(err, &depMismatchErr):
+		return DependencyErrorCode
+	case errors.As(err, &annotationErr):
+		return AnnotationPreventedDeletionErrorCode
+	case errors.As(err, &kindErr):
+		return KindPreventedDeletionErrorCode
+	case errors.As(err, &applyPreventedErr):
+		return ApplyPreventedDeletionErrorCode
+	case errors.As(err, &namespaceErr):
+		return NamespaceInUseErrorCode
+	case errors.As(err, &staleErr):
+		return StaleInventoryEntryErrorCode
+	case errors.As(err, &scopeErr):
+		return ScopePreventedDeletionErrorCode
+	case errors.Is(err, context.DeadlineExceeded), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return TimeoutErrorCode
+	case errors.Is(err, context.Canceled):
+		return CanceledErrorCode
+	case meta.IsNoMatchError(err):
+		return NoMatchErrorCode
+	case apierrors.IsConflict(err):
+		return ConflictErrorCode
+	case apierrors.IsForbidden(err):
+		return ForbiddenErrorCode
+	case apierrors.IsInvalid(err):
+		return InvalidErrorCode
+	case apierrors.IsNotFound(err):
+		return NotFoundErrorCode
+	default:
+		return UnknownErrorCode
+	}
+}
+
+func newError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	e := &Error{
+		Code:    ErrorCodeFor(err),
+		Message: err.Error(),
+	}
+	if hint := applyerror.HintFor(err); hint != nil {
+		e.Hint = hint.Message
+	}
+	return e
+}
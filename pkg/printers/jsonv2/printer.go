@@ -0,0 +1,320 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonv2
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+)
+
+// NewPrinter returns a Printer that writes one Record per line, as JSON, to
+// the output stream.
+func NewPrinter(ioStreams genericclioptions.IOStreams) printer.Printer {
+	return &Printer{
+		IOStreams: ioStreams,
+		now:       time.Now,
+	}
+}
+
+// Printer writes events as versioned JSON records. See the package
+// documentation for the schema.
+type Printer struct {
+	IOStreams genericclioptions.IOStreams
+	now       func() time.Time
+}
+
+// Print writes a record for each event received from the channel, followed
+// by a summary record. Status records are only written if printStatus is
+// true. This function will block until the channel is closed.
+//
+// Returns the fatal error from an ErrorEvent, if received, or a
+// ResultError if any objects failed, like the other printers.
+func (p *Printer) Print(ch <-chan event.Event, previewStrategy common.DryRunStrategy, printStatus bool) error {
+	var s stats.Stats
+	for e := range ch {
+		s.Handle(e)
+		if e.Type == event.StatusType && !printStatus {
+			continue
+		}
+		r, ok := p.toRecord(e)
+		if !ok {
+			continue
+		}
+		if err := p.write(r); err != nil {
+			return err
+		}
+		if e.Type == event.ErrorType {
+			if err := p.write(p.summaryRecord(s, previewStrategy, ErrorResult)); err != nil {
+				return err
+			}
+			return e.ErrorEvent.Err
+		}
+	}
+	resultErr := printcommon.ResultErrorFromStats(s)
+	result := SuccessResult
+	if resultErr != nil {
+		result = FailureResult
+	}
+	if err := p.write(p.summaryRecord(s, previewStrategy, result)); err != nil {
+		return err
+	}
+	return resultErr
+}
+
+// toRecord converts an event to a Record. Returns false if the event
+// should not be printed.
+func (p *Printer) toRecord(e event.Event) (Record, bool) {
+	r := p.newRecord(e.Timestamp)
+	switch e.Type {
+	case event.ValidationType:
+		r.Type = ValidationRecord
+		for _, id := range e.ValidationEvent.Identifiers {
+			r.Objects = append(r.Objects, newObjectReference(id))
+		}
+		r.Severity = severities[e.ValidationEvent.Severity]
+		err := e.ValidationEvent.Error
+		r.Error = newError(err)
+		// Unwrap validation errors, to avoid repeating the identifiers
+		// in the message.
+		if vErr, ok := err.(*validation.Error); ok && r.Error != nil {
+			r.Error.Message = vErr.Unwrap().Error()
+		}
+	case event.ErrorType:
+		r.Type = ErrorRecord
+		r.Error = newError(e.ErrorEvent.Err)
+	case event.ActionGroupType:
+		r.Type = GroupRecord
+		r.Action = newAction(e.ActionGroupEvent.Action)
+		r.GroupName = e.ActionGroupEvent.GroupName
+		r.Phase = newPhase(e.ActionGroupEvent.Status)
+		r.Inventory = dispositions[e.ActionGroupEvent.Inventory]
+	case event.ApplyType:
+		r.Type = ObjectRecord
+		r.Action = ApplyAction
+		r.GroupName = e.ApplyEvent.GroupName
+		r.Object = objectReferencePtr(e.ApplyEvent.Identifier)
+		r.Operation = applyOperations[e.ApplyEvent.Status]
+		r.Change = applyChanges[e.ApplyEvent.Operation]
+		r.Diff = newFieldDiffs(e.ApplyEvent.Diff)
+		r.Error = newError(e.ApplyEvent.Error)
+	case event.PruneType:
+		r.Type = ObjectRecord
+		r.Action = PruneAction
+		r.GroupName = e.PruneEvent.GroupName
+		r.Object = objectReferencePtr(e.PruneEvent.Identifier)
+		r.Operation = pruneOperations[e.PruneEvent.Status]
+		r.Error = newError(e.PruneEvent.Error)
+	case event.DeleteType:
+		r.Type = ObjectRecord
+		r.Action = DeleteAction
+		r.GroupName = e.DeleteEvent.GroupName
+		r.Object = objectReferencePtr(e.DeleteEvent.Identifier)
+		r.Operation = deleteOperations[e.DeleteEvent.Status]
+		r.Error = newError(e.DeleteEvent.Error)
+	case event.WaitType:
+		r.Type = ObjectRecord
+		r.Action = WaitAction
+		r.GroupName = e.WaitEvent.GroupName
+		r.Object = objectReferencePtr(e.WaitEvent.Identifier)
+		r.Operation = waitOperations[e.WaitEvent.Status]
+	case event.StatusType:
+		r.Type = StatusRecord
+		r.Object = objectReferencePtr(e.StatusEvent.Identifier)
+		if info := e.StatusEvent.PollResourceInfo; info != nil {
+			r.Status = info.Status.String()
+			r.Message = info.Message
+		}
+		r.Error = newError(e.StatusEvent.Error)
+	default:
+		// InitEvents are not printed
+		return r, false
+	}
+	return r, true
+}
+
+func (p *Printer) summaryRecord(s stats.Stats, previewStrategy common.DryRunStrategy, result Result) Record {
+	r := p.newRecord(time.Time{})
+	r.Type = SummaryRecord
+	summary := &Summary{
+		Result:  result,
+		DryRun:  dryRunName(previewStrategy),
+		Actions: []ActionSummary{},
+	}
+	if as := s.ApplyStats; as != (stats.ApplyStats{}) {
+		summary.Actions = append(summary.Actions, ActionSummary{
+			Action:     ApplyAction,
+			Total:      as.Sum(),
+			Successful: as.Successful,
+			Skipped:    as.Skipped,
+			Failed:     as.Failed,
+		})
+	}
+	if ps := s.PruneStats; ps != (stats.PruneStats{}) {
+		summary.Actions = append(summary.Actions, ActionSummary{
+			Action:     PruneAction,
+			Total:      ps.Sum(),
+			Successful: ps.Successful,
+			Skipped:    ps.Skipped,
+			Failed:     ps.Failed,
+		})
+	}
+	if ds := s.DeleteStats; ds != (stats.DeleteStats{}) {
+		summary.Actions = append(summary.Actions, ActionSummary{
+			Action:     DeleteAction,
+			Total:      ds.Sum(),
+			Successful: ds.Successful,
+			Skipped:    ds.Skipped,
+			Failed:     ds.Failed,
+		})
+	}
+	if ws := s.WaitStats; ws != (stats.WaitStats{}) {
+		summary.Actions = append(summary.Actions, ActionSummary{
+			Action:     WaitAction,
+			Total:      ws.Sum(),
+			Successful: ws.Successful,
+			Skipped:    ws.Skipped,
+			Failed:     ws.Failed,
+			Timeout:    ws.Timeout,
+		})
+	}
+	r.Summary = summary
+	return r
+}
+
+// newRecord returns a Record with the timestamp, which is the time the event
+// was sent, or the current time if it is zero.
+func (p *Printer) newRecord(timestamp time.Time) Record {
+	if timestamp.IsZero() {
+		now := time.Now
+		if p.now != nil {
+			now = p.now
+		}
+		timestamp = now()
+	}
+	return Record{
+		SchemaVersion: SchemaVersion,
+		Timestamp:     timestamp.UTC().Format(time.RFC3339),
+	}
+}
+
+func (p *Printer) write(r Record) error {
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(p.IOStreams.Out, string(b))
+	return err
+}
+
+// The enum values in the schema are mapped explicitly, so changes to the
+// event package enums do not change the output.
+var (
+	applyOperations = map[event.ApplyEventStatus]Operation{
+		event.ApplyPending:    PendingOperation,
+		event.ApplySuccessful: SuccessfulOperation,
+		event.ApplySkipped:    SkippedOperation,
+		event.ApplyFailed:     FailedOperation,
+	}
+	severities = map[event.ValidationSeverity]Severity{
+		event.ErrorSeverity:   ErrorSeverity,
+		event.WarningSeverity: WarningSeverity,
+	}
+	applyChanges = map[event.ApplyOperation]Change{
+		event.ApplyCreated:    CreatedChange,
+		event.ApplyConfigured: ConfiguredChange,
+		event.ApplyUnchanged:  UnchangedChange,
+	}
+	pruneOperations = map[event.PruneEventStatus]Operation{
+		event.PrunePending:    PendingOperation,
+		event.PruneSuccessful: SuccessfulOperation,
+		event.PruneSkipped:    SkippedOperation,
+		event.PruneFailed:     FailedOperation,
+	}
+	deleteOperations = map[event.DeleteEventStatus]Operation{
+		event.DeletePending:    PendingOperation,
+		event.DeleteSuccessful: SuccessfulOperation,
+		event.DeleteSkipped:    SkippedOperation,
+		event.DeleteFailed:     FailedOperation,
+	}
+	waitOperations = map[event.WaitEventStatus]Operation{
+		event.ReconcilePending:    PendingOperation,
+		event.ReconcileSuccessful: SuccessfulOperation,
+		event.ReconcileSkipped:    SkippedOperation,
+		event.ReconcileFailed:     FailedOperation,
+		event.ReconcileTimeout:    TimeoutOperation,
+	}
+	dispositions = map[event.InventoryDisposition]Disposition{
+		event.InventoryUpdated:  UpdatedDisposition,
+		event.InventoryDeleted:  DeletedDisposition,
+		event.InventoryRetained: RetainedDisposition,
+	}
+	actions = map[event.ResourceAction]Action{
+		event.ApplyAction:     ApplyAction,
+		event.PruneAction:     PruneAction,
+		event.DeleteAction:    DeleteAction,
+		event.WaitAction:      WaitAction,
+		event.InventoryAction: InventoryAction,
+	}
+)
+
+func newAction(action event.ResourceAction) Action {
+	return actions[action]
+}
+
+func newPhase(status event.ActionGroupEventStatus) Phase {
+	if status == event.Finished {
+		return FinishedPhase
+	}
+	return StartedPhase
+}
+
+func dryRunName(strategy common.DryRunStrategy) string {
+	switch strategy {
+	case common.DryRunClient:
+		return "Client"
+	case common.DryRunServer:
+		return "Server"
+	default:
+		return "None"
+	}
+}
+
+func newObjectReference(id object.ObjMetadata) ObjectReference {
+	return ObjectReference{
+		Group:     id.GroupKind.Group,
+		Kind:      id.GroupKind.Kind,
+		Namespace: id.Namespace,
+		Name:      id.Name,
+	}
+}
+
+func objectReferencePtr(id object.ObjMetadata) *ObjectReference {
+	ref := newObjectReference(id)
+	return &ref
+}
+
+func newFieldDiffs(diffs []object.FieldDiff) []FieldDiff {
+	if len(diffs) == 0 {
+		return nil
+	}
+	result := make([]FieldDiff, 0, len(diffs))
+	for _, d := range diffs {
+		result = append(result, FieldDiff{
+			Path: d.Path,
+			Old:  d.Old,
+			New:  d.New,
+		})
+	}
+	return result
+}
@@ -0,0 +1,394 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonv2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apis/actuation"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/apply/filter"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/inventory"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/object/validation"
+)
+
+var (
+	depID = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "my-dep",
+	}
+	depRef = &ObjectReference{
+		Group:     "apps",
+		Kind:      "Deployment",
+		Namespace: "default",
+		Name:      "my-dep",
+	}
+	timestamp = "2023-01-02T03:04:05Z"
+)
+
+func TestPrint(t *testing.T) {
+	testCases := map[string]struct {
+		events          []event.Event
+		previewStrategy common.DryRunStrategy
+		printStatus     bool
+		expected        []Record
+		expectedErr     string
+	}{
+		"successful apply": {
+			events: []event.Event{
+				{
+					Type: event.InitType,
+				},
+				{
+					Type: event.ActionGroupType,
+					ActionGroupEvent: event.ActionGroupEvent{
+						GroupName: "apply-0",
+						Action:    event.ApplyAction,
+						Status:    event.Started,
+					},
+				},
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						GroupName:  "apply-0",
+						Identifier: depID,
+						Status:     event.ApplySuccessful,
+						Operation:  event.ApplyConfigured,
+						Diff: []object.FieldDiff{
+							{Path: ".metadata.labels.app", Old: nil, New: "my-dep"},
+							{Path: ".spec.replicas", Old: int64(1), New: int64(3)},
+						},
+					},
+				},
+				{
+					Type: event.StatusType,
+					StatusEvent: event.StatusEvent{
+						Identifier: depID,
+						PollResourceInfo: &pollevent.ResourceStatus{
+							Identifier: depID,
+							Status:     status.CurrentStatus,
+							Message:    "Deployment is available",
+						},
+					},
+				},
+				{
+					Type: event.ActionGroupType,
+					ActionGroupEvent: event.ActionGroupEvent{
+						GroupName: "apply-0",
+						Action:    event.ApplyAction,
+						Status:    event.Finished,
+					},
+				},
+			},
+			previewStrategy: common.DryRunServer,
+			printStatus:     true,
+			expected: []Record{
+				{
+					Type:      GroupRecord,
+					Action:    ApplyAction,
+					GroupName: "apply-0",
+					Phase:     StartedPhase,
+				},
+				{
+					Type:      ObjectRecord,
+					Action:    ApplyAction,
+					GroupName: "apply-0",
+					Object:    depRef,
+					Operation: SuccessfulOperation,
+					Change:    ConfiguredChange,
+					Diff: []FieldDiff{
+						{Path: ".metadata.labels.app", Old: nil, New: "my-dep"},
+						{Path: ".spec.replicas", Old: float64(1), New: float64(3)},
+					},
+				},
+				{
+					Type:    StatusRecord,
+					Object:  depRef,
+					Status:  "Current",
+					Message: "Deployment is available",
+				},
+				{
+					Type:      GroupRecord,
+					Action:    ApplyAction,
+					GroupName: "apply-0",
+					Phase:     FinishedPhase,
+				},
+				{
+					Type: SummaryRecord,
+					Summary: &Summary{
+						Result: SuccessResult,
+						DryRun: "Server",
+						Actions: []ActionSummary{
+							{Action: ApplyAction, Total: 1, Successful: 1},
+						},
+					},
+				},
+			},
+		},
+		"failures with error codes": {
+			events: []event.Event{
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						GroupName:  "apply-0",
+						Identifier: depID,
+						Status:     event.ApplyFailed,
+						Error: apierrors.NewConflict(schema.GroupResource{Group: "apps", Resource: "deployments"},
+							"my-dep", errors.New("modified")),
+					},
+				},
+				{
+					Type: event.PruneType,
+					PruneEvent: event.PruneEvent{
+						GroupName:  "prune-0",
+						Identifier: depID,
+						Status:     event.PruneSkipped,
+						Error: &inventory.PolicyPreventedActuationError{
+							Strategy: actuation.ActuationStrategyDelete,
+							Policy:   inventory.PolicyMustMatch,
+							Status:   inventory.NoMatch,
+						},
+					},
+				},
+				{
+					Type: event.WaitType,
+					WaitEvent: event.WaitEvent{
+						GroupName:  "wait-0",
+						Identifier: depID,
+						Status:     event.ReconcileTimeout,
+					},
+				},
+				{
+					Type: event.StatusType,
+				},
+			},
+			expected: []Record{
+				{
+					Type:      ObjectRecord,
+					Action:    ApplyAction,
+					GroupName: "apply-0",
+					Object:    depRef,
+					Operation: FailedOperation,
+					Error: &Error{
+						Code:    ConflictErrorCode,
+						Message: `Operation cannot be fulfilled on deployments.apps "my-dep": modified`,
+					},
+				},
+				{
+					Type:      ObjectRecord,
+					Action:    PruneAction,
+					GroupName: "prune-0",
+					Object:    depRef,
+					Operation: SkippedOperation,
+					Error: &Error{
+						Code: InventoryPolicyErrorCode,
+						Message: (&inventory.PolicyPreventedActuationError{
+							Strategy: actuation.ActuationStrategyDelete,
+							Policy:   inventory.PolicyMustMatch,
+							Status:   inventory.NoMatch,
+						}).Error(),
+						Hint: "The object is owned by another inventory. Remove it from one of the inventories, " +
+							"or, to take ownership of it, use the AdoptAll inventory policy.",
+					},
+				},
+				{
+					Type:      ObjectRecord,
+					Action:    WaitAction,
+					GroupName: "wait-0",
+					Object:    depRef,
+					Operation: TimeoutOperation,
+				},
+				{
+					Type: SummaryRecord,
+					Summary: &Summary{
+						Result: FailureResult,
+						DryRun: "None",
+						Actions: []ActionSummary{
+							{Action: ApplyAction, Total: 1, Failed: 1},
+							{Action: PruneAction, Total: 1, Skipped: 1},
+							{Action: WaitAction, Total: 1, Timeout: 1},
+						},
+					},
+				},
+			},
+			expectedErr: "1 resources failed, 1 resources failed to reconcile before timeout",
+		},
+		"validation warning": {
+			events: []event.Event{
+				{
+					Type: event.ValidationType,
+					ValidationEvent: event.ValidationEvent{
+						Identifiers: object.ObjMetadataSet{depID},
+						Error:       validation.NewError(errors.New("extensions/v1beta1 Deployment is removed"), depID),
+						Severity:    event.WarningSeverity,
+					},
+				},
+			},
+			expected: []Record{
+				{
+					Type:     ValidationRecord,
+					Objects:  []ObjectReference{*depRef},
+					Severity: WarningSeverity,
+					Error: &Error{
+						Code:    ValidationErrorCode,
+						Message: "extensions/v1beta1 Deployment is removed",
+					},
+				},
+				{
+					Type: SummaryRecord,
+					Summary: &Summary{
+						Result:  SuccessResult,
+						DryRun:  "None",
+						Actions: []ActionSummary{},
+					},
+				},
+			},
+		},
+		"retained inventory": {
+			events: []event.Event{
+				{
+					Type: event.ActionGroupType,
+					ActionGroupEvent: event.ActionGroupEvent{
+						GroupName: "delete-inventory-0",
+						Action:    event.InventoryAction,
+						Status:    event.Finished,
+						Inventory: event.InventoryRetained,
+					},
+				},
+			},
+			expected: []Record{
+				{
+					Type:      GroupRecord,
+					Action:    InventoryAction,
+					GroupName: "delete-inventory-0",
+					Phase:     FinishedPhase,
+					Inventory: RetainedDisposition,
+				},
+				{
+					Type: SummaryRecord,
+					Summary: &Summary{
+						Result:  SuccessResult,
+						DryRun:  "None",
+						Actions: []ActionSummary{},
+					},
+				},
+			},
+		},
+		"fatal error ends with summary": {
+			events: []event.Event{
+				{
+					Type: event.ErrorType,
+					ErrorEvent: event.ErrorEvent{
+						Err: fmt.Errorf("failed to list: %w", &filter.NamespaceInUseError{Namespace: "default"}),
+					},
+				},
+				{
+					Type: event.ApplyType,
+				},
+			},
+			expected: []Record{
+				{
+					Type: ErrorRecord,
+					Error: &Error{
+						Code:    NamespaceInUseErrorCode,
+						Message: "failed to list: namespace still in use: default",
+					},
+				},
+				{
+					Type: SummaryRecord,
+					Summary: &Summary{
+						Result:  ErrorResult,
+						DryRun:  "None",
+						Actions: []ActionSummary{},
+					},
+				},
+			},
+			expectedErr: "failed to list: namespace still in use: default",
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			p := &Printer{
+				IOStreams: ioStreams,
+				now: func() time.Time {
+					return time.Date(2023, 1, 2, 3, 4, 5, 0, time.FixedZone("x", 3600)).Add(time.Hour)
+				},
+			}
+			ch := make(chan event.Event, len(tc.events))
+			for _, e := range tc.events {
+				ch <- e
+			}
+			close(ch)
+
+			err := p.Print(ch, tc.previewStrategy, tc.printStatus)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			var records []Record
+			for _, line := range lines {
+				var r Record
+				require.NoError(t, json.Unmarshal([]byte(line), &r))
+				assert.Equal(t, SchemaVersion, r.SchemaVersion)
+				assert.Equal(t, timestamp, r.Timestamp)
+				r.SchemaVersion = ""
+				r.Timestamp = ""
+				records = append(records, r)
+			}
+			assert.Equal(t, tc.expected, records)
+		})
+	}
+}
+
+func TestPrint_EventTimestamp(t *testing.T) {
+	ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+	p := &Printer{
+		IOStreams: ioStreams,
+		now: func() time.Time {
+			return time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+		},
+	}
+	ch := make(chan event.Event, 1)
+	ch <- event.Event{
+		Type:      event.ApplyType,
+		Timestamp: time.Date(2023, 1, 2, 3, 4, 0, 0, time.FixedZone("x", 3600)),
+		ApplyEvent: event.ApplyEvent{
+			Identifier: depID,
+			Status:     event.ApplySuccessful,
+		},
+	}
+	close(ch)
+
+	require.NoError(t, p.Print(ch, common.DryRunNone, false))
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	var applyRecord, summaryRecord Record
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &applyRecord))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &summaryRecord))
+	// The time the event was sent, in UTC.
+	assert.Equal(t, "2023-01-02T02:04:00Z", applyRecord.Timestamp)
+	// The summary is not an event, so it has the time it was printed.
+	assert.Equal(t, timestamp, summaryRecord.Timestamp)
+}
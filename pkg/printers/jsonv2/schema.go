@@ -0,0 +1,210 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonv2
+
+// SchemaVersion is the version of the output schema. It is included in every
+// record. Fields and enum values will not be removed or renamed without
+// incrementing the version, but new fields and enum values may be added.
+const SchemaVersion = "v2"
+
+// RecordType identifies the kind of record.
+type RecordType string
+
+const (
+	// ValidationRecord reports invalid objects.
+	ValidationRecord RecordType = "validation"
+	// ErrorRecord reports a fatal error, after which the run stops.
+	ErrorRecord RecordType = "error"
+	// GroupRecord reports the start or finish of a task group.
+	GroupRecord RecordType = "group"
+	// ObjectRecord reports the result of an action on a single object.
+	ObjectRecord RecordType = "object"
+	// StatusRecord reports an asynchronous status update for an object.
+	StatusRecord RecordType = "status"
+	// SummaryRecord is always the last record, summarizing the run.
+	SummaryRecord RecordType = "summary"
+)
+
+// Action identifies what was done to an object or task group.
+type Action string
+
+const (
+	ApplyAction     Action = "apply"
+	PruneAction     Action = "prune"
+	DeleteAction    Action = "delete"
+	WaitAction      Action = "wait"
+	InventoryAction Action = "inventory"
+)
+
+// Operation is the outcome of an action on an object.
+type Operation string
+
+const (
+	PendingOperation    Operation = "pending"
+	SuccessfulOperation Operation = "successful"
+	SkippedOperation    Operation = "skipped"
+	FailedOperation     Operation = "failed"
+	TimeoutOperation    Operation = "timeout"
+)
+
+// Severity is the severity of a validation record.
+type Severity string
+
+const (
+	ErrorSeverity   Severity = "error"
+	WarningSeverity Severity = "warning"
+)
+
+// Change is the change made by a successful apply. With a dry-run, it is the
+// change the apply would make.
+type Change string
+
+const (
+	CreatedChange    Change = "created"
+	ConfiguredChange Change = "configured"
+	UnchangedChange  Change = "unchanged"
+)
+
+// Phase is the progress of a task group.
+type Phase string
+
+const (
+	StartedPhase  Phase = "started"
+	FinishedPhase Phase = "finished"
+)
+
+// Disposition is what happened to the inventory object at the end of a run.
+type Disposition string
+
+const (
+	UpdatedDisposition  Disposition = "updated"
+	DeletedDisposition  Disposition = "deleted"
+	RetainedDisposition Disposition = "retained"
+)
+
+// Result is the overall outcome of a run.
+type Result string
+
+const (
+	// SuccessResult means all objects were actuated and reconciled, or
+	// skipped.
+	SuccessResult Result = "success"
+	// FailureResult means one or more objects failed actuation or
+	// reconciliation, but the run completed.
+	FailureResult Result = "failure"
+	// ErrorResult means the run stopped early, due to a fatal error.
+	ErrorResult Result = "error"
+)
+
+// ErrorCode classifies an error, so consumers can branch without parsing
+// error messages.
+type ErrorCode string
+
+const (
+	UnknownErrorCode                     ErrorCode = "Unknown"
+	ValidationErrorCode                  ErrorCode = "Validation"
+	InventoryPolicyErrorCode             ErrorCode = "InventoryPolicy"
+	DependencyErrorCode                  ErrorCode = "Dependency"
+	AnnotationPreventedDeletionErrorCode ErrorCode = "AnnotationPreventedDeletion"
+	KindPreventedDeletionErrorCode       ErrorCode = "KindPreventedDeletion"
+	ApplyPreventedDeletionErrorCode      ErrorCode = "ApplyPreventedDeletion"
+	NamespaceInUseErrorCode              ErrorCode = "NamespaceInUse"
+	StaleInventoryEntryErrorCode         ErrorCode = "StaleInventoryEntry"
+	ScopePreventedDeletionErrorCode      ErrorCode = "ScopePreventedDeletion"
+	ConflictErrorCode                    ErrorCode = "Conflict"
+	ForbiddenErrorCode                   ErrorCode = "Forbidden"
+	InvalidErrorCode                     ErrorCode = "Invalid"
+	NotFoundErrorCode                    ErrorCode = "NotFound"
+	NoMatchErrorCode                     ErrorCode = "NoMatch"
+	TimeoutErrorCode                     ErrorCode = "Timeout"
+	CanceledErrorCode                    ErrorCode = "Canceled"
+)
+
+// Record is a single line of output.
+type Record struct {
+	// SchemaVersion is always SchemaVersion.
+	SchemaVersion string `json:"schemaVersion"`
+	// Timestamp is an RFC3339 formatted UTC timestamp of the time the event
+	// was sent, or of the time the record was printed if it is unknown.
+	Timestamp string `json:"timestamp"`
+	// Type identifies which other fields are populated.
+	Type RecordType `json:"type"`
+	// Action is populated for group, object, and summary records.
+	Action Action `json:"action,omitempty"`
+	// GroupName is the task group name, for group and object records.
+	GroupName string `json:"groupName,omitempty"`
+	// Phase is populated for group records.
+	Phase Phase `json:"phase,omitempty"`
+	// Inventory is populated for the finished group record of the task
+	// that updates or deletes the inventory at the end of the run.
+	Inventory Disposition `json:"inventory,omitempty"`
+	// Object identifies the object, for object and status records.
+	Object *ObjectReference `json:"object,omitempty"`
+	// Objects identifies the objects, for validation records.
+	Objects []ObjectReference `json:"objects,omitempty"`
+	// Severity is populated for validation records.
+	Severity Severity `json:"severity,omitempty"`
+	// Operation is populated for object records.
+	Operation Operation `json:"operation,omitempty"`
+	// Change is populated for successful apply records, if the change made
+	// by the apply is known.
+	Change Change `json:"change,omitempty"`
+	// Diff lists the changed fields, for apply records, if field diffs are
+	// enabled.
+	Diff []FieldDiff `json:"diff,omitempty"`
+	// Status is the kstatus status, for status records.
+	Status string `json:"status,omitempty"`
+	// Message is a human readable status message, for status records.
+	Message string `json:"message,omitempty"`
+	// Error is populated if the record represents an error.
+	Error *Error `json:"error,omitempty"`
+	// Summary is populated for summary records.
+	Summary *Summary `json:"summary,omitempty"`
+}
+
+// ObjectReference identifies an object.
+type ObjectReference struct {
+	Group     string `json:"group"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// FieldDiff is a field changed by an apply.
+type FieldDiff struct {
+	// Path is the path of the field, like ".spec.replicas".
+	Path string `json:"path"`
+	// Old is the value in the cluster before the apply, or null if unset.
+	Old interface{} `json:"old"`
+	// New is the applied value, or null if unset.
+	New interface{} `json:"new"`
+}
+
+// Error describes an error.
+type Error struct {
+	Code    ErrorCode `json:"code"`
+	Message string    `json:"message"`
+	// Hint describes how to fix the error, if it is a well-known failure.
+	Hint string `json:"hint,omitempty"`
+}
+
+// Summary describes the outcome of a run.
+type Summary struct {
+	Result Result `json:"result"`
+	// DryRun is one of "None", "Client", or "Server".
+	DryRun string `json:"dryRun"`
+	// Actions has one entry for each action that was performed, in the
+	// order: apply, prune, delete, wait.
+	Actions []ActionSummary `json:"actions"`
+}
+
+// ActionSummary counts the outcomes of one action.
+type ActionSummary struct {
+	Action     Action `json:"action"`
+	Total      int    `json:"total"`
+	Successful int    `json:"successful"`
+	Skipped    int    `json:"skipped"`
+	Failed     int    `json:"failed"`
+	Timeout    int    `json:"timeout"`
+}
@@ -0,0 +1,303 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package junit provides a printer that writes the results of an apply,
+// preview, or destroy as a JUnit XML report, so CI systems can display the
+// failed objects natively.
+//
+// Each object actuated or reconciled is a test case, grouped into one test
+// suite per action: "apply", "prune", "delete", and "reconcile". The test
+// case name identifies the object and the classname is the task group name.
+// Failed actuation is reported as a failure, reconciliation timeout as a
+// failure of type "Timeout", and skipped objects as skipped. Invalid objects
+// are reported as errors in the "validation" suite, validation warnings as
+// passed test cases with the warning in their system-out, and a fatal error
+// as an error in the "run" suite.
+//
+// The report is written when the event channel is closed.
+package junit
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+)
+
+const (
+	// FailureType is the type of failures caused by actuation errors.
+	FailureType = "Failed"
+	// TimeoutType is the type of failures caused by reconcile timeouts.
+	TimeoutType = "Timeout"
+	// ErrorType is the type of errors caused by invalid objects and fatal
+	// errors.
+	ErrorType = "Error"
+)
+
+// NewPrinter returns a Printer that writes a JUnit XML report to the output
+// stream.
+func NewPrinter(ioStreams genericclioptions.IOStreams) printer.Printer {
+	return &Printer{
+		IOStreams: ioStreams,
+	}
+}
+
+// Printer collects events and writes them as a JUnit XML report.
+type Printer struct {
+	IOStreams genericclioptions.IOStreams
+}
+
+// Print collects the events from the channel and writes the report when the
+// channel is closed or a fatal error is received.
+//
+// Returns the fatal error from an ErrorEvent, if received, or a ResultError
+// if any objects failed, like the other printers.
+func (p *Printer) Print(ch <-chan event.Event, _ common.DryRunStrategy, _ bool) error {
+	r := newReport()
+	var s stats.Stats
+	for e := range ch {
+		s.Handle(e)
+		r.handle(e)
+		if e.Type == event.ErrorType {
+			if err := p.write(r); err != nil {
+				return err
+			}
+			return e.ErrorEvent.Err
+		}
+	}
+	if err := p.write(r); err != nil {
+		return err
+	}
+	return printcommon.ResultErrorFromStats(s)
+}
+
+func (p *Printer) write(r *report) error {
+	b, err := xml.MarshalIndent(r.testSuites(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode junit report: %w", err)
+	}
+	_, err = fmt.Fprintf(p.IOStreams.Out, "%s%s\n", xml.Header, b)
+	return err
+}
+
+// Suite names, in the order they are written.
+const (
+	validationSuite = "validation"
+	applySuite      = "apply"
+	pruneSuite      = "prune"
+	deleteSuite     = "delete"
+	reconcileSuite  = "reconcile"
+	runSuite        = "run"
+)
+
+var suiteOrder = []string{
+	validationSuite, applySuite, pruneSuite, deleteSuite, reconcileSuite, runSuite,
+}
+
+// report accumulates test cases for each suite. The latest event for an
+// object replaces earlier events for the same object in the same suite, so
+// that pending reconcile events are superseded by the final result.
+type report struct {
+	suites map[string]*suite
+}
+
+type suite struct {
+	cases []TestCase
+	index map[object.ObjMetadata]int
+}
+
+func newReport() *report {
+	return &report{
+		suites: make(map[string]*suite),
+	}
+}
+
+func (r *report) add(suiteName string, id object.ObjMetadata, tc TestCase) {
+	s, found := r.suites[suiteName]
+	if !found {
+		s = &suite{index: make(map[object.ObjMetadata]int)}
+		r.suites[suiteName] = s
+	}
+	if i, found := s.index[id]; found {
+		s.cases[i] = tc
+		return
+	}
+	s.index[id] = len(s.cases)
+	s.cases = append(s.cases, tc)
+}
+
+func (r *report) handle(e event.Event) {
+	switch e.Type {
+	case event.ValidationType:
+		ve := e.ValidationEvent
+		for _, id := range ve.Identifiers {
+			tc := TestCase{Name: testCaseName(id)}
+			if ve.Severity == event.WarningSeverity {
+				tc.SystemOut = ve.Error.Error()
+			} else {
+				tc.Error = newFailure(ErrorType, ve.Error)
+			}
+			r.add(validationSuite, id, tc)
+		}
+	case event.ErrorType:
+		r.add(runSuite, object.ObjMetadata{}, TestCase{
+			Name:  "fatal error",
+			Error: newFailure(ErrorType, e.ErrorEvent.Err),
+		})
+	case event.ApplyType:
+		ae := e.ApplyEvent
+		tc := TestCase{Name: testCaseName(ae.Identifier), ClassName: ae.GroupName}
+		switch ae.Status {
+		case event.ApplyPending:
+			return
+		case event.ApplySkipped:
+			tc.Skipped = newSkipped(ae.Error)
+		case event.ApplyFailed:
+			tc.Failure = newFailure(FailureType, ae.Error)
+		}
+		r.add(applySuite, ae.Identifier, tc)
+	case event.PruneType:
+		pe := e.PruneEvent
+		tc := TestCase{Name: testCaseName(pe.Identifier), ClassName: pe.GroupName}
+		switch pe.Status {
+		case event.PrunePending:
+			return
+		case event.PruneSkipped:
+			tc.Skipped = newSkipped(pe.Error)
+		case event.PruneFailed:
+			tc.Failure = newFailure(FailureType, pe.Error)
+		}
+		r.add(pruneSuite, pe.Identifier, tc)
+	case event.DeleteType:
+		de := e.DeleteEvent
+		tc := TestCase{Name: testCaseName(de.Identifier), ClassName: de.GroupName}
+		switch de.Status {
+		case event.DeletePending:
+			return
+		case event.DeleteSkipped:
+			tc.Skipped = newSkipped(de.Error)
+		case event.DeleteFailed:
+			tc.Failure = newFailure(FailureType, de.Error)
+		}
+		r.add(deleteSuite, de.Identifier, tc)
+	case event.WaitType:
+		we := e.WaitEvent
+		tc := TestCase{Name: testCaseName(we.Identifier), ClassName: we.GroupName}
+		switch we.Status {
+		case event.ReconcilePending:
+			return
+		case event.ReconcileSkipped:
+			tc.Skipped = &Skipped{Message: "reconcile skipped"}
+		case event.ReconcileFailed:
+			tc.Failure = &Failure{Type: FailureType, Message: "reconcile failed"}
+		case event.ReconcileTimeout:
+			tc.Failure = &Failure{Type: TimeoutType, Message: "reconcile timed out"}
+		}
+		r.add(reconcileSuite, we.Identifier, tc)
+	}
+}
+
+func (r *report) testSuites() TestSuites {
+	var all TestSuites
+	for _, name := range suiteOrder {
+		s, found := r.suites[name]
+		if !found {
+			continue
+		}
+		ts := TestSuite{
+			Name:      name,
+			Tests:     len(s.cases),
+			TestCases: s.cases,
+		}
+		for _, tc := range s.cases {
+			switch {
+			case tc.Error != nil:
+				ts.Errors++
+			case tc.Failure != nil:
+				ts.Failures++
+			case tc.Skipped != nil:
+				ts.Skipped++
+			}
+		}
+		all.Tests += ts.Tests
+		all.Failures += ts.Failures
+		all.Errors += ts.Errors
+		all.Skipped += ts.Skipped
+		all.Suites = append(all.Suites, ts)
+	}
+	return all
+}
+
+// testCaseName returns a name that uniquely identifies the object, like
+// "default/deployment.apps/my-dep" or "namespace/my-ns".
+func testCaseName(id object.ObjMetadata) string {
+	name := fmt.Sprintf("%s/%s", strings.ToLower(id.GroupKind.String()), id.Name)
+	if id.Namespace != "" {
+		name = id.Namespace + "/" + name
+	}
+	return name
+}
+
+func newFailure(failureType string, err error) *Failure {
+	f := &Failure{Type: failureType}
+	if err != nil {
+		f.Message = err.Error()
+	}
+	return f
+}
+
+func newSkipped(err error) *Skipped {
+	s := &Skipped{}
+	if err != nil {
+		s.Message = err.Error()
+	}
+	return s
+}
+
+// TestSuites is the root element of the report.
+type TestSuites struct {
+	XMLName  xml.Name    `xml:"testsuites"`
+	Tests    int         `xml:"tests,attr"`
+	Failures int         `xml:"failures,attr"`
+	Errors   int         `xml:"errors,attr"`
+	Skipped  int         `xml:"skipped,attr"`
+	Suites   []TestSuite `xml:"testsuite"`
+}
+
+// TestSuite contains the test cases for one action.
+type TestSuite struct {
+	Name      string     `xml:"name,attr"`
+	Tests     int        `xml:"tests,attr"`
+	Failures  int        `xml:"failures,attr"`
+	Errors    int        `xml:"errors,attr"`
+	Skipped   int        `xml:"skipped,attr"`
+	TestCases []TestCase `xml:"testcase"`
+}
+
+// TestCase is the result of one action on one object.
+type TestCase struct {
+	Name      string   `xml:"name,attr"`
+	ClassName string   `xml:"classname,attr,omitempty"`
+	Failure   *Failure `xml:"failure,omitempty"`
+	Error     *Failure `xml:"error,omitempty"`
+	Skipped   *Skipped `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"`
+}
+
+// Failure describes a failed or errored test case.
+type Failure struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+// Skipped describes a skipped test case.
+type Skipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
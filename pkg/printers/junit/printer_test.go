@@ -0,0 +1,197 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package junit
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+	printertesting "sigs.k8s.io/cli-utils/pkg/printers/testutil"
+)
+
+var (
+	depID = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Group: "apps", Kind: "Deployment"},
+		Namespace: "default",
+		Name:      "my-dep",
+	}
+	nsID = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "Namespace"},
+		Name:      "my-ns",
+	}
+	cmID = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: "default",
+		Name:      "my-cm",
+	}
+)
+
+func TestPrint(t *testing.T) {
+	testCases := map[string]struct {
+		events         []event.Event
+		expectedOutput string
+		expectedErr    string
+	}{
+		"apply, prune, and reconcile": {
+			events: []event.Event{
+				{Type: event.InitType},
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						GroupName:  "apply-0",
+						Identifier: nsID,
+						Status:     event.ApplySuccessful,
+					},
+				},
+				{
+					Type: event.ApplyType,
+					ApplyEvent: event.ApplyEvent{
+						GroupName:  "apply-0",
+						Identifier: depID,
+						Status:     event.ApplyFailed,
+						Error:      errors.New("admission webhook denied the request"),
+					},
+				},
+				{
+					Type: event.PruneType,
+					PruneEvent: event.PruneEvent{
+						GroupName:  "prune-0",
+						Identifier: cmID,
+						Status:     event.PruneSkipped,
+						Error:      errors.New("annotation prevents deletion"),
+					},
+				},
+				{
+					Type: event.WaitType,
+					WaitEvent: event.WaitEvent{
+						GroupName:  "wait-0",
+						Identifier: nsID,
+						Status:     event.ReconcilePending,
+					},
+				},
+				{
+					Type: event.WaitType,
+					WaitEvent: event.WaitEvent{
+						GroupName:  "wait-0",
+						Identifier: nsID,
+						Status:     event.ReconcileTimeout,
+					},
+				},
+			},
+			expectedOutput: `
+<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="4" failures="2" errors="0" skipped="1">
+  <testsuite name="apply" tests="2" failures="1" errors="0" skipped="0">
+    <testcase name="namespace/my-ns" classname="apply-0"></testcase>
+    <testcase name="default/deployment.apps/my-dep" classname="apply-0">
+      <failure message="admission webhook denied the request" type="Failed"></failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="prune" tests="1" failures="0" errors="0" skipped="1">
+    <testcase name="default/configmap/my-cm" classname="prune-0">
+      <skipped message="annotation prevents deletion"></skipped>
+    </testcase>
+  </testsuite>
+  <testsuite name="reconcile" tests="1" failures="1" errors="0" skipped="0">
+    <testcase name="namespace/my-ns" classname="wait-0">
+      <failure message="reconcile timed out" type="Timeout"></failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+			expectedErr: "1 resources failed, 1 resources failed to reconcile before timeout",
+		},
+		"validation and fatal errors": {
+			events: []event.Event{
+				{
+					Type: event.ValidationType,
+					ValidationEvent: event.ValidationEvent{
+						Identifiers: object.ObjMetadataSet{cmID},
+						Error:       errors.New("missing name"),
+					},
+				},
+				{
+					Type: event.ErrorType,
+					ErrorEvent: event.ErrorEvent{
+						Err: errors.New("inventory not found"),
+					},
+				},
+			},
+			expectedOutput: `
+<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="2" failures="0" errors="2" skipped="0">
+  <testsuite name="validation" tests="1" failures="0" errors="1" skipped="0">
+    <testcase name="default/configmap/my-cm">
+      <error message="missing name" type="Error"></error>
+    </testcase>
+  </testsuite>
+  <testsuite name="run" tests="1" failures="0" errors="1" skipped="0">
+    <testcase name="fatal error">
+      <error message="inventory not found" type="Error"></error>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+			expectedErr: "inventory not found",
+		},
+		"validation warning": {
+			events: []event.Event{
+				{
+					Type: event.ValidationType,
+					ValidationEvent: event.ValidationEvent{
+						Identifiers: object.ObjMetadataSet{cmID},
+						Error:       errors.New("denied by admission rule"),
+						Severity:    event.WarningSeverity,
+					},
+				},
+			},
+			expectedOutput: `
+<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="1" failures="0" errors="0" skipped="0">
+  <testsuite name="validation" tests="1" failures="0" errors="0" skipped="0">
+    <testcase name="default/configmap/my-cm">
+      <system-out>denied by admission rule</system-out>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			p := NewPrinter(ioStreams)
+
+			ch := make(chan event.Event, len(tc.events))
+			for _, e := range tc.events {
+				ch <- e
+			}
+			close(ch)
+
+			err := p.Print(ch, common.DryRunNone, false)
+			if tc.expectedErr != "" {
+				assert.EqualError(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, strings.TrimSpace(tc.expectedOutput), strings.TrimSpace(out.String()))
+		})
+	}
+}
+
+func TestPrintResultError(t *testing.T) {
+	printertesting.PrintResultErrorTest(t, func() printer.Printer {
+		ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+		return NewPrinter(ioStreams)
+	})
+}
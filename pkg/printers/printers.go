@@ -9,46 +9,73 @@ import (
 	"sigs.k8s.io/cli-utils/pkg/print/list"
 	"sigs.k8s.io/cli-utils/pkg/printers/events"
 	"sigs.k8s.io/cli-utils/pkg/printers/json"
+	"sigs.k8s.io/cli-utils/pkg/printers/jsonv2"
+	"sigs.k8s.io/cli-utils/pkg/printers/junit"
 	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+	"sigs.k8s.io/cli-utils/pkg/printers/progress"
 	"sigs.k8s.io/cli-utils/pkg/printers/table"
 )
 
 const (
-	EventsPrinter = "events"
-	TablePrinter  = "table"
-	JSONPrinter   = "json"
+	EventsPrinter   = "events"
+	TablePrinter    = "table"
+	JSONPrinter     = "json"
+	JSONV2Printer   = "jsonv2"
+	JUnitPrinter    = "junit"
+	ProgressPrinter = "progress"
+	QuietPrinter    = "quiet"
 )
 
-func GetPrinter(printerType string, ioStreams genericclioptions.IOStreams) printer.Printer {
-	switch printerType { //nolint:gocritic
-	case TablePrinter:
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
+	mustRegister(r, EventsPrinter, events.NewPrinter)
+	mustRegister(r, TablePrinter, func(ioStreams genericclioptions.IOStreams) printer.Printer {
 		return &table.Printer{
 			IOStreams: ioStreams,
 		}
-	case JSONPrinter:
+	})
+	mustRegister(r, JSONPrinter, func(ioStreams genericclioptions.IOStreams) printer.Printer {
 		return &list.BaseListPrinter{
 			FormatterFactory: func(previewStrategy common.DryRunStrategy) list.Formatter {
 				return json.NewFormatter(ioStreams, previewStrategy)
 			},
 		}
-	default:
-		return events.NewPrinter(ioStreams)
+	})
+	mustRegister(r, JSONV2Printer, jsonv2.NewPrinter)
+	mustRegister(r, JUnitPrinter, junit.NewPrinter)
+	mustRegister(r, ProgressPrinter, progress.NewPrinter)
+	mustRegister(r, QuietPrinter, events.NewQuietPrinter)
+	return r
+}
+
+func mustRegister(r *Registry, format string, factory Factory) {
+	if err := r.Register(format, factory); err != nil {
+		panic(err)
 	}
 }
 
+// GetPrinter returns a new Printer for the specified output format from the
+// DefaultRegistry. If the format is not registered, the default printer is
+// returned.
+func GetPrinter(printerType string, ioStreams genericclioptions.IOStreams) printer.Printer {
+	p, err := DefaultRegistry.Printer(printerType, ioStreams)
+	if err != nil {
+		p, _ = DefaultRegistry.Printer(DefaultPrinter(), ioStreams)
+	}
+	return p
+}
+
+// SupportedPrinters returns the output formats in the DefaultRegistry.
 func SupportedPrinters() []string {
-	return []string{EventsPrinter, TablePrinter, JSONPrinter}
+	return DefaultRegistry.Formats()
 }
 
 func DefaultPrinter() string {
 	return EventsPrinter
 }
 
+// ValidatePrinterType returns true if the output format is in the
+// DefaultRegistry.
 func ValidatePrinterType(printerType string) bool {
-	for _, p := range SupportedPrinters() {
-		if printerType == p {
-			return true
-		}
-	}
-	return false
+	return DefaultRegistry.Has(printerType)
 }
@@ -0,0 +1,284 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package progress provides a printer that renders a progress bar for each
+// task group, which is more readable than a line per object when applying
+// a large set of objects.
+//
+// On a terminal the progress bars are updated in place. Otherwise, a line is
+// printed for each task group when its progress changes, at most once per
+// interval, and when it finishes.
+//
+// Failed objects are listed after the progress bars, once all tasks have
+// completed.
+package progress
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/kubectl/pkg/util/term"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	printcommon "sigs.k8s.io/cli-utils/pkg/print/common"
+	"sigs.k8s.io/cli-utils/pkg/print/stats"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+)
+
+const (
+	// DefaultInterval is how often the progress bars are updated on a
+	// terminal.
+	DefaultInterval = 500 * time.Millisecond
+	// DefaultNonInteractiveInterval is the minimum time between progress
+	// lines for the same task group, when not writing to a terminal.
+	DefaultNonInteractiveInterval = 10 * time.Second
+
+	barWidth = 20
+)
+
+// NewPrinter returns a progress Printer, which updates the progress bars
+// in place if the output stream is a terminal.
+func NewPrinter(ioStreams genericclioptions.IOStreams) printer.Printer {
+	interactive := term.IsTerminal(ioStreams.Out)
+	interval := DefaultNonInteractiveInterval
+	if interactive {
+		interval = DefaultInterval
+	}
+	return &Printer{
+		IOStreams:   ioStreams,
+		Interactive: interactive,
+		Interval:    interval,
+	}
+}
+
+// Printer renders the progress of each task group.
+type Printer struct {
+	IOStreams genericclioptions.IOStreams
+	// Interactive enables in-place updates using ANSI escape codes.
+	Interactive bool
+	// Interval is how often the output is updated.
+	Interval time.Duration
+}
+
+// Print renders the progress of the task groups until the channel is
+// closed.
+//
+// Returns the fatal error from an ErrorEvent, if received, or a ResultError
+// if any objects failed, like the other printers.
+func (p *Printer) Print(ch <-chan event.Event, _ common.DryRunStrategy, _ bool) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var s stats.Stats
+	st := &state{}
+	for {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				p.render(st)
+				p.printFailures(st)
+				return printcommon.ResultErrorFromStats(s)
+			}
+			s.Handle(e)
+			if e.Type == event.ErrorType {
+				p.render(st)
+				p.printFailures(st)
+				return e.ErrorEvent.Err
+			}
+			if finished := st.handle(e); finished != nil && !p.Interactive {
+				p.printLine(finished)
+			}
+		case <-ticker.C:
+			p.render(st)
+		}
+	}
+}
+
+// render updates the progress bars in place, if interactive, or prints a
+// line for each running task group whose progress has changed.
+func (p *Printer) render(st *state) {
+	if !p.Interactive {
+		for _, g := range st.groups {
+			if g.started && !g.finished {
+				p.printLine(g)
+			}
+		}
+		return
+	}
+	for i := 0; i < st.linesPrinted; i++ {
+		p.printf("%c[%dA", printcommon.ESC, 1)
+		p.printf("%c[2K\r", printcommon.ESC)
+	}
+	st.linesPrinted = 0
+	for _, g := range st.groups {
+		p.printf("%s\n", g.String())
+		g.printed = g.done
+		st.linesPrinted++
+	}
+}
+
+// printLine prints the progress of the task group, if it has changed since
+// it was last printed.
+func (p *Printer) printLine(g *group) {
+	if g.printed == g.done && (!g.finished || g.finishPrinted) {
+		return
+	}
+	p.printf("%s\n", g.String())
+	g.printed = g.done
+	g.finishPrinted = g.finished
+}
+
+func (p *Printer) printFailures(st *state) {
+	for _, f := range st.failures {
+		p.printf("%s\n", f)
+	}
+}
+
+func (p *Printer) printf(format string, a ...interface{}) {
+	_, _ = fmt.Fprintf(p.IOStreams.Out, format, a...)
+}
+
+// state tracks the progress of all task groups.
+type state struct {
+	groups       []*group
+	byName       map[string]*group
+	failures     []string
+	linesPrinted int
+}
+
+// group tracks the progress of one task group.
+type group struct {
+	name          string
+	action        event.ResourceAction
+	total         int
+	done          int
+	failed        int
+	started       bool
+	finished      bool
+	printed       int
+	finishPrinted bool
+}
+
+// String returns the progress of the group, like:
+// apply-0    [##########----------] 5/10 applied (1 failed)
+func (g *group) String() string {
+	filled := 0
+	if g.total > 0 {
+		filled = barWidth * g.done / g.total
+	}
+	line := fmt.Sprintf("%-12s [%s%s] %d/%d %s", g.name,
+		strings.Repeat("#", filled), strings.Repeat("-", barWidth-filled),
+		g.done, g.total, actionVerb(g.action))
+	if g.failed > 0 {
+		line += fmt.Sprintf(" (%d failed)", g.failed)
+	}
+	return line
+}
+
+// handle updates the state with the event. Returns the group if the event
+// finished it.
+func (st *state) handle(e event.Event) *group {
+	switch e.Type {
+	case event.InitType:
+		st.byName = make(map[string]*group)
+		st.groups = nil
+		for _, ag := range e.InitEvent.ActionGroups {
+			// Inventory tasks have no objects to report progress on.
+			if ag.Action == event.InventoryAction {
+				continue
+			}
+			g := &group{
+				name:   ag.Name,
+				action: ag.Action,
+				total:  len(ag.Identifiers),
+			}
+			st.groups = append(st.groups, g)
+			st.byName[ag.Name] = g
+		}
+	case event.ActionGroupType:
+		g, found := st.byName[e.ActionGroupEvent.GroupName]
+		if !found {
+			return nil
+		}
+		switch e.ActionGroupEvent.Status {
+		case event.Started:
+			g.started = true
+		case event.Finished:
+			g.finished = true
+			return g
+		}
+	case event.ApplyType:
+		ae := e.ApplyEvent
+		if ae.Status != event.ApplyPending {
+			st.record(ae.GroupName, ae.Identifier, ae.Status == event.ApplyFailed, "apply failed", ae.Error)
+		}
+	case event.PruneType:
+		pe := e.PruneEvent
+		if pe.Status != event.PrunePending {
+			st.record(pe.GroupName, pe.Identifier, pe.Status == event.PruneFailed, "prune failed", pe.Error)
+		}
+	case event.DeleteType:
+		de := e.DeleteEvent
+		if de.Status != event.DeletePending {
+			st.record(de.GroupName, de.Identifier, de.Status == event.DeleteFailed, "delete failed", de.Error)
+		}
+	case event.WaitType:
+		we := e.WaitEvent
+		switch we.Status {
+		case event.ReconcileFailed:
+			st.record(we.GroupName, we.Identifier, true, "reconcile failed", nil)
+		case event.ReconcileTimeout:
+			st.record(we.GroupName, we.Identifier, true, "reconcile timeout", nil)
+		case event.ReconcileSuccessful, event.ReconcileSkipped:
+			st.record(we.GroupName, we.Identifier, false, "", nil)
+		}
+	}
+	return nil
+}
+
+func (st *state) record(groupName string, id object.ObjMetadata, failed bool, msg string, err error) {
+	if g, found := st.byName[groupName]; found {
+		if g.done < g.total {
+			g.done++
+		}
+		if failed {
+			g.failed++
+		}
+	}
+	if !failed {
+		return
+	}
+	line := fmt.Sprintf("%s %s", resourceIDToString(id), msg)
+	if err != nil {
+		line = fmt.Sprintf("%s: %v", line, err)
+	}
+	st.failures = append(st.failures, line)
+}
+
+func actionVerb(action event.ResourceAction) string {
+	switch action {
+	case event.ApplyAction:
+		return "applied"
+	case event.PruneAction:
+		return "pruned"
+	case event.DeleteAction:
+		return "deleted"
+	case event.WaitAction:
+		return "reconciled"
+	default:
+		return strings.ToLower(action.String())
+	}
+}
+
+// resourceIDToString returns the string representation of a GroupKind and a
+// resource name, like the events printer.
+func resourceIDToString(id object.ObjMetadata) string {
+	return fmt.Sprintf("%s/%s", strings.ToLower(id.GroupKind.String()), id.Name)
+}
@@ -0,0 +1,177 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package progress
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+	printertesting "sigs.k8s.io/cli-utils/pkg/printers/testutil"
+)
+
+var (
+	cm1 = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: "default",
+		Name:      "cm-1",
+	}
+	cm2 = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: "default",
+		Name:      "cm-2",
+	}
+)
+
+func testEvents() []event.Event {
+	return []event.Event{
+		{
+			Type: event.InitType,
+			InitEvent: event.InitEvent{
+				ActionGroups: event.ActionGroupList{
+					{Name: "inventory-add-0", Action: event.InventoryAction},
+					{Name: "apply-0", Action: event.ApplyAction, Identifiers: object.ObjMetadataSet{cm1, cm2}},
+					{Name: "wait-0", Action: event.WaitAction, Identifiers: object.ObjMetadataSet{cm1, cm2}},
+				},
+			},
+		},
+		actionGroupEvent("apply-0", event.ApplyAction, event.Started),
+		{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				GroupName:  "apply-0",
+				Identifier: cm1,
+				Status:     event.ApplySuccessful,
+			},
+		},
+		{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				GroupName:  "apply-0",
+				Identifier: cm2,
+				Status:     event.ApplyFailed,
+				Error:      errors.New("forbidden"),
+			},
+		},
+		actionGroupEvent("apply-0", event.ApplyAction, event.Finished),
+		actionGroupEvent("wait-0", event.WaitAction, event.Started),
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  "wait-0",
+				Identifier: cm1,
+				Status:     event.ReconcilePending,
+			},
+		},
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  "wait-0",
+				Identifier: cm1,
+				Status:     event.ReconcileSuccessful,
+			},
+		},
+		{
+			Type: event.WaitType,
+			WaitEvent: event.WaitEvent{
+				GroupName:  "wait-0",
+				Identifier: cm2,
+				Status:     event.ReconcileSkipped,
+			},
+		},
+		actionGroupEvent("wait-0", event.WaitAction, event.Finished),
+	}
+}
+
+func actionGroupEvent(name string, action event.ResourceAction, status event.ActionGroupEventStatus) event.Event {
+	return event.Event{
+		Type: event.ActionGroupType,
+		ActionGroupEvent: event.ActionGroupEvent{
+			GroupName: name,
+			Action:    action,
+			Status:    status,
+		},
+	}
+}
+
+func TestPrint(t *testing.T) {
+	testCases := map[string]struct {
+		interactive    bool
+		expectedOutput string
+	}{
+		"non-interactive prints finished groups": {
+			interactive: false,
+			expectedOutput: `
+apply-0      [####################] 2/2 applied (1 failed)
+wait-0       [####################] 2/2 reconciled
+configmap/cm-2 apply failed: forbidden
+`,
+		},
+		"interactive renders all groups on completion": {
+			interactive: true,
+			expectedOutput: `
+apply-0      [####################] 2/2 applied (1 failed)
+wait-0       [####################] 2/2 reconciled
+configmap/cm-2 apply failed: forbidden
+`,
+		},
+	}
+
+	for tn, tc := range testCases {
+		t.Run(tn, func(t *testing.T) {
+			ioStreams, _, out, _ := genericclioptions.NewTestIOStreams()
+			p := &Printer{
+				IOStreams:   ioStreams,
+				Interactive: tc.interactive,
+				// Avoid ticks during the test, for deterministic output.
+				Interval: time.Hour,
+			}
+
+			events := testEvents()
+			ch := make(chan event.Event, len(events))
+			for _, e := range events {
+				ch <- e
+			}
+			close(ch)
+
+			err := p.Print(ch, common.DryRunNone, false)
+			assert.EqualError(t, err, "1 resources failed")
+			assert.Equal(t, strings.TrimSpace(tc.expectedOutput), strings.TrimSpace(out.String()))
+		})
+	}
+}
+
+func TestGroupString(t *testing.T) {
+	g := &group{
+		name:   "apply-0",
+		action: event.ApplyAction,
+		total:  4,
+		done:   1,
+	}
+	assert.Equal(t, "apply-0      [#####---------------] 1/4 applied", g.String())
+
+	g = &group{
+		name:   "prune-0",
+		action: event.PruneAction,
+	}
+	assert.Equal(t, "prune-0      [--------------------] 0/0 pruned", g.String())
+}
+
+func TestPrintResultError(t *testing.T) {
+	printertesting.PrintResultErrorTest(t, func() printer.Printer {
+		ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+		return &Printer{
+			IOStreams: ioStreams,
+			Interval:  time.Hour,
+		}
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package printers
+
+import (
+	"fmt"
+	"sync"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+)
+
+// Factory creates a new Printer that writes to the specified IOStreams.
+type Factory func(ioStreams genericclioptions.IOStreams) printer.Printer
+
+// Registry is a set of printer factories keyed by output format.
+// Registry is safe for concurrent use.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]Factory
+	// formats retains registration order, for stable help text.
+	formats []string
+}
+
+// NewRegistry returns a new empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		factories: make(map[string]Factory),
+	}
+}
+
+// Register adds a printer factory for the specified output format.
+// Returns an error if the format is empty or already registered.
+func (r *Registry) Register(format string, factory Factory) error {
+	if format == "" {
+		return fmt.Errorf("printer format must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("printer factory for format %q must not be nil", format)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, found := r.factories[format]; found {
+		return fmt.Errorf("printer format %q already registered", format)
+	}
+	r.factories[format] = factory
+	r.formats = append(r.formats, format)
+	return nil
+}
+
+// Printer returns a new Printer for the specified output format.
+// Returns an error if the format is not registered.
+func (r *Registry) Printer(format string, ioStreams genericclioptions.IOStreams) (printer.Printer, error) {
+	r.mu.RLock()
+	factory, found := r.factories[format]
+	r.mu.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("unknown output type %q", format)
+	}
+	return factory(ioStreams), nil
+}
+
+// Has returns true if the output format is registered.
+func (r *Registry) Has(format string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, found := r.factories[format]
+	return found
+}
+
+// Formats returns the registered output formats, in registration order.
+func (r *Registry) Formats() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]string{}, r.formats...)
+}
+
+// DefaultRegistry is the Registry used by GetPrinter, SupportedPrinters,
+// and ValidatePrinterType. It contains the built-in printers. Downstream
+// tools may register additional printers with Register.
+var DefaultRegistry = newDefaultRegistry()
+
+// Register adds a printer factory for the specified output format to the
+// DefaultRegistry.
+func Register(format string, factory Factory) error {
+	return DefaultRegistry.Register(format, factory)
+}
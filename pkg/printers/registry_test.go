@@ -0,0 +1,62 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package printers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/common"
+	"sigs.k8s.io/cli-utils/pkg/printers/printer"
+	"sigs.k8s.io/cli-utils/pkg/printers/table"
+)
+
+type fakePrinter struct {
+	ioStreams genericclioptions.IOStreams
+}
+
+func (f *fakePrinter) Print(<-chan event.Event, common.DryRunStrategy, bool) error {
+	return nil
+}
+
+func newFakePrinter(ioStreams genericclioptions.IOStreams) printer.Printer {
+	return &fakePrinter{ioStreams: ioStreams}
+}
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry()
+	assert.Empty(t, r.Formats())
+	assert.False(t, r.Has("fake"))
+
+	_, err := r.Printer("fake", genericclioptions.IOStreams{})
+	assert.EqualError(t, err, `unknown output type "fake"`)
+
+	assert.NoError(t, r.Register("fake", newFakePrinter))
+	assert.NoError(t, r.Register("other", newFakePrinter))
+	assert.EqualError(t, r.Register("fake", newFakePrinter), `printer format "fake" already registered`)
+	assert.EqualError(t, r.Register("", newFakePrinter), "printer format must not be empty")
+	assert.EqualError(t, r.Register("nil", nil), `printer factory for format "nil" must not be nil`)
+
+	assert.True(t, r.Has("fake"))
+	assert.Equal(t, []string{"fake", "other"}, r.Formats())
+
+	ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	p, err := r.Printer("fake", ioStreams)
+	assert.NoError(t, err)
+	assert.Equal(t, &fakePrinter{ioStreams: ioStreams}, p)
+}
+
+func TestDefaultRegistry(t *testing.T) {
+	assert.Equal(t, []string{EventsPrinter, TablePrinter, JSONPrinter, JSONV2Printer, JUnitPrinter, ProgressPrinter, QuietPrinter}, SupportedPrinters())
+	assert.True(t, ValidatePrinterType(JSONPrinter))
+	assert.False(t, ValidatePrinterType("unknown"))
+
+	ioStreams, _, _, _ := genericclioptions.NewTestIOStreams()
+	assert.IsType(t, &table.Printer{}, GetPrinter(TablePrinter, ioStreams))
+	// Unknown formats fall back to the default printer.
+	assert.IsType(t, GetPrinter(EventsPrinter, ioStreams), GetPrinter("unknown", ioStreams))
+	assert.NotNil(t, GetPrinter("unknown", ioStreams))
+}
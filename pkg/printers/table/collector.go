@@ -89,6 +89,10 @@ type resourceInfo struct {
 	// a resource has been applied to the cluster.
 	ApplyStatus event.ApplyEventStatus
 
+	// ApplyOperation contains the change made
+	// by a successful apply, if known.
+	ApplyOperation event.ApplyOperation
+
 	// PruneStatus contains the result after
 	// a prune operation on a resource
 	PruneStatus event.PruneEventStatus
@@ -210,6 +214,10 @@ func (r *resourceStateCollector) processEvent(ev event.Event) error {
 // for a resource.
 func (r *resourceStateCollector) processValidationEvent(e event.ValidationEvent) error {
 	klog.V(7).Infoln("processing validation event")
+	if e.Severity == event.WarningSeverity {
+		// Warnings don't make the objects invalid.
+		return nil
+	}
 	// unwrap validation errors
 	err := e.Error
 	if vErr, ok := err.(*validation.Error); ok {
@@ -259,6 +267,7 @@ func (r *resourceStateCollector) processApplyEvent(e event.ApplyEvent) {
 		previous.Error = e.Error
 	}
 	previous.ApplyStatus = e.Status
+	previous.ApplyOperation = e.Operation
 	r.stats.ApplyStats.Inc(e.Status)
 }
 
@@ -341,6 +350,7 @@ func (r *resourceStateCollector) LatestState() *ResourceState {
 			resourceStatus: ri.resourceStatus,
 			ResourceAction: ri.ResourceAction,
 			ApplyStatus:    ri.ApplyStatus,
+			ApplyOperation: ri.ApplyOperation,
 			PruneStatus:    ri.PruneStatus,
 			DeleteStatus:   ri.DeleteStatus,
 			WaitStatus:     ri.WaitStatus,
@@ -17,6 +17,11 @@ import (
 
 type Printer struct {
 	IOStreams genericclioptions.IOStreams
+	// Columns overrides the default columns, if not empty.
+	// Use ParseColumns to build columns from a user provided spec.
+	Columns []table.ColumnDefinition
+	// GroupBy optionally groups the resources in the table.
+	GroupBy table.GroupBy
 }
 
 func (t *Printer) Print(ch <-chan event.Event, _ common.DryRunStrategy, _ bool) error {
@@ -96,7 +101,11 @@ var (
 			var text string
 			switch resInfo.ResourceAction {
 			case event.ApplyAction:
-				if resInfo.ApplyStatus != event.ApplyFailed {
+				switch {
+				case resInfo.ApplyStatus == event.ApplySuccessful &&
+					resInfo.ApplyOperation != event.UnknownApplyOperation:
+					text = resInfo.ApplyOperation.String()
+				case resInfo.ApplyStatus != event.ApplyFailed:
 					text = resInfo.ApplyStatus.String()
 				}
 			case event.PruneAction:
@@ -154,8 +163,22 @@ var (
 		table.MustColumn("age"),
 		table.MustColumn("message"),
 	}
+
+	// printerColumns are the columns specific to this printer, which may
+	// be referenced by name in ParseColumns.
+	printerColumns = map[string]table.ColumnDefinition{
+		actionColumnDef.ColumnName:     actionColumnDef,
+		reconciledColumnDef.ColumnName: reconciledColumnDef,
+	}
 )
 
+// ParseColumns parses a comma separated list of column specs, as described
+// in table.ParseColumns. In addition to the pre-defined columns, the
+// "action" and "reconciled" columns may be referenced by name.
+func ParseColumns(spec string) ([]table.ColumnDefinition, error) {
+	return table.ParseColumns(spec, printerColumns)
+}
+
 // runPrintLoop starts a new goroutine that will regularly fetch the
 // latest state from the collector and update the table.
 func (t *Printer) runPrintLoop(coll *resourceStateCollector, stop chan struct{}) chan struct{} {
@@ -164,6 +187,10 @@ func (t *Printer) runPrintLoop(coll *resourceStateCollector, stop chan struct{})
 	baseTablePrinter := table.BaseTablePrinter{
 		IOStreams: t.IOStreams,
 		Columns:   columns,
+		GroupBy:   t.GroupBy,
+	}
+	if len(t.Columns) > 0 {
+		baseTablePrinter.Columns = t.Columns
 	}
 
 	linesPrinted := baseTablePrinter.PrintTable(coll.LatestState(), 0)
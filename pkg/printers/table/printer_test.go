@@ -38,6 +38,15 @@ func TestActionColumnDef(t *testing.T) {
 			columnWidth:    15,
 			expectedOutput: "Successful",
 		},
+		"applied with a known change": {
+			resource: &resourceInfo{
+				ResourceAction: event.ApplyAction,
+				ApplyStatus:    event.ApplySuccessful,
+				ApplyOperation: event.ApplyConfigured,
+			},
+			columnWidth:    15,
+			expectedOutput: "Configured",
+		},
 		"pruned": {
 			resource: &resourceInfo{
 				ResourceAction: event.PruneAction,
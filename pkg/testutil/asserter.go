@@ -9,6 +9,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
 )
 
 // Asserter provides a set of assertion methods that use a shared set of
@@ -25,8 +26,37 @@ func NewAsserter(opts ...cmp.Option) *Asserter {
 }
 
 // DefaultAsserter is a global Asserter with default comparison options:
-// - EquateErrors (compare with "Is(T) bool" method)
-var DefaultAsserter = NewAsserter(cmpopts.EquateErrors())
+// - EquateErrors (compare with errors.Is, or by type, like VerifyEvents)
+// - IgnoreEventTimes (ignore event timestamps and timings, which vary by run)
+var DefaultAsserter = NewAsserter(EquateErrors(), IgnoreEventTimes())
+
+// EquateErrors returns a cmp.Option that compares errors like VerifyEvents:
+// errors are equal if errors.Is matches them, in either direction, or if one
+// wraps an error of the type of the other with the same fields. Unlike
+// cmpopts.EquateErrors, typed errors built by the test match the errors
+// returned by the code under test.
+func EquateErrors() cmp.Option {
+	return cmp.FilterValues(areErrors, cmp.Comparer(func(x, y interface{}) bool {
+		xe, ye := x.(error), y.(error)
+		return errorsMatch(xe, ye) || errorsMatch(ye, xe)
+	}))
+}
+
+// areErrors returns true if both values are errors.
+func areErrors(x, y interface{}) bool {
+	_, xok := x.(error)
+	_, yok := y.(error)
+	return xok && yok
+}
+
+// IgnoreEventTimes returns a cmp.Option that ignores the Timestamp and the
+// Timing of events.
+func IgnoreEventTimes() cmp.Option {
+	return cmp.Options{
+		cmpopts.IgnoreFields(event.Event{}, "Timestamp"),
+		cmpopts.IgnoreTypes(event.Timing{}),
+	}
+}
 
 // EqualMatcher returns a new EqualMatcher with the Asserter's options and the
 // specified expected value.
@@ -4,11 +4,13 @@
 package testutil
 
 import (
+	"errors"
 	"fmt"
+	"reflect"
 	"sort"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
 	"sigs.k8s.io/cli-utils/pkg/apply/event"
 	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
 	"sigs.k8s.io/cli-utils/pkg/object"
@@ -26,6 +28,10 @@ type ExpEvent struct {
 	DeleteEvent      *ExpDeleteEvent
 	WaitEvent        *ExpWaitEvent
 	ValidationEvent  *ExpValidationEvent
+
+	// Predicates are additional matchers that the event must satisfy.
+	// They are only evaluated by VerifyEvents.
+	Predicates []EventPredicate
 }
 
 type ExpInitEvent struct {
@@ -79,31 +85,155 @@ type ExpWaitEvent struct {
 type ExpValidationEvent struct {
 	Identifiers object.ObjMetadataSet
 	Error       error
+	Severity    event.ValidationSeverity
 }
 
+// VerifyEvents returns an error if the expected events are not found, in
+// order, in the list of events. Other events may be interleaved between
+// the expected events.
+//
+// Expected errors are compared with errors.Is, so they may be sentinel errors
+// or matchers like EqualErrorString. A nil expected error requires that the
+// event has no error.
+//
+// On failure, the error describes the first expected event that was not
+// found, and the first event of the same type received after the last
+// matched event, with a diff and any unsatisfied predicates.
 func VerifyEvents(expEvents []ExpEvent, events []event.Event) error {
-	if len(expEvents) == 0 && len(events) == 0 {
+	if len(expEvents) == 0 {
 		return nil
 	}
 	expEventIndex := 0
+	lastMatchIndex := -1
 	for i := range events {
 		e := events[i]
 		ee := expEvents[expEventIndex]
 		if isMatch(ee, e) {
 			expEventIndex++
+			lastMatchIndex = i
 			if expEventIndex >= len(expEvents) {
 				return nil
 			}
 		}
 	}
-	return fmt.Errorf("event %s not found", expEvents[expEventIndex].EventType)
+	return notFoundError(expEvents[expEventIndex], expEventIndex, events[lastMatchIndex+1:])
+}
+
+// VerifyEventsGrouped returns an error if the expected groups of events are
+// not found, in order, in the list of events. Events within a group may be
+// received in any order, but all the events in a group must be received before
+// any event in the next group is matched. Other events may be interleaved
+// between the expected events.
+//
+// Use this instead of VerifyEvents when events within a task group are
+// emitted concurrently (ex: apply and prune events in the same group).
+func VerifyEventsGrouped(expGroups [][]ExpEvent, events []event.Event) error {
+	groupIndex := 0
+	// Skip leading empty groups.
+	for groupIndex < len(expGroups) && len(expGroups[groupIndex]) == 0 {
+		groupIndex++
+	}
+	if groupIndex >= len(expGroups) {
+		return nil
+	}
+	remaining := append([]ExpEvent{}, expGroups[groupIndex]...)
+	for _, e := range events {
+		for i, ee := range remaining {
+			if !isMatch(ee, e) {
+				continue
+			}
+			remaining = append(remaining[:i], remaining[i+1:]...)
+			break
+		}
+		for len(remaining) == 0 {
+			groupIndex++
+			if groupIndex >= len(expGroups) {
+				return nil
+			}
+			remaining = append([]ExpEvent{}, expGroups[groupIndex]...)
+		}
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "expected event group %d incomplete: %d of %d events not found",
+		groupIndex, len(remaining), len(expGroups[groupIndex]))
+	for _, ee := range remaining {
+		fmt.Fprintf(&sb, "\nmissing: %s", formatExpEvent(ee))
+	}
+	return errors.New(sb.String())
+}
+
+// formatExpEvent returns a single line description of the expected event,
+// suitable for failure messages.
+func formatExpEvent(ee ExpEvent) string {
+	var sb strings.Builder
+	sb.WriteString(ee.EventType.String())
+	var detail interface{}
+	switch {
+	case ee.ErrorEvent != nil:
+		detail = *ee.ErrorEvent
+	case ee.ActionGroupEvent != nil:
+		detail = *ee.ActionGroupEvent
+	case ee.ApplyEvent != nil:
+		detail = *ee.ApplyEvent
+	case ee.StatusEvent != nil:
+		detail = *ee.StatusEvent
+	case ee.PruneEvent != nil:
+		detail = *ee.PruneEvent
+	case ee.DeleteEvent != nil:
+		detail = *ee.DeleteEvent
+	case ee.WaitEvent != nil:
+		detail = *ee.WaitEvent
+	case ee.ValidationEvent != nil:
+		detail = *ee.ValidationEvent
+	}
+	if detail != nil {
+		fmt.Fprintf(&sb, " %+v", detail)
+	}
+	for _, p := range ee.Predicates {
+		fmt.Fprintf(&sb, " %s", p)
+	}
+	return sb.String()
+}
+
+// notFoundError builds an error describing why the expected event did not
+// match any of the remaining events.
+func notFoundError(ee ExpEvent, expEventIndex int, remaining []event.Event) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "expected event %d (%s) not found", expEventIndex, ee.EventType)
+	for _, e := range remaining {
+		if e.Type != ee.EventType {
+			continue
+		}
+		fmt.Fprintf(&sb, "\nfirst non-matching %s: %s", e.Type, e)
+		if !isFieldMatch(ee, e) {
+			fmt.Fprintf(&sb, "\ndiff (- expected, + actual):\n%s",
+				cmp.Diff(withoutPredicates(ee), EventToExpEvent(e), EquateErrors()))
+		}
+		for _, p := range failedPredicates(ee, e) {
+			fmt.Fprintf(&sb, "\nunsatisfied predicate: %s", p)
+		}
+		return errors.New(sb.String())
+	}
+	fmt.Fprintf(&sb, "\nno %s events received after the last match", ee.EventType)
+	return errors.New(sb.String())
+}
+
+func withoutPredicates(ee ExpEvent) ExpEvent {
+	ee.Predicates = nil
+	return ee
+}
+
+// isMatch returns true if the event matches the expected fields and
+// satisfies all the expected predicates.
+func isMatch(ee ExpEvent, e event.Event) bool {
+	return isFieldMatch(ee, e) && len(failedPredicates(ee, e)) == 0
 }
 
 // nolint:gocyclo
 // TODO(mortent): This function is pretty complex and with quite a bit of
 // duplication. We should see if there is a better way to provide a flexible
 // way to verify that we go the expected events.
-func isMatch(ee ExpEvent, e event.Event) bool {
+func isFieldMatch(ee ExpEvent, e event.Event) bool {
 	if ee.EventType != e.Type {
 		return false
 	}
@@ -120,9 +250,7 @@ func isMatch(ee ExpEvent, e event.Event) bool {
 		b := e.ErrorEvent
 
 		if a.Err != nil {
-			if !cmp.Equal(a.Err, b.Err, cmpopts.EquateErrors()) {
-				return false
-			}
+			return errorsMatch(a.Err, b.Err)
 		}
 		return true
 
@@ -172,10 +300,7 @@ func isMatch(ee ExpEvent, e event.Event) bool {
 			return false
 		}
 
-		if aee.Error != nil {
-			return ae.Error != nil
-		}
-		return ae.Error == nil
+		return errorsMatch(aee.Error, ae.Error)
 
 	case event.StatusType:
 		see := ee.StatusEvent
@@ -192,10 +317,7 @@ func isMatch(ee ExpEvent, e event.Event) bool {
 			return false
 		}
 
-		if see.Error != nil {
-			return se.Error != nil
-		}
-		return se.Error == nil
+		return errorsMatch(see.Error, se.Error)
 
 	case event.PruneType:
 		pee := ee.PruneEvent
@@ -220,10 +342,7 @@ func isMatch(ee ExpEvent, e event.Event) bool {
 			return false
 		}
 
-		if pee.Error != nil {
-			return pe.Error != nil
-		}
-		return pe.Error == nil
+		return errorsMatch(pee.Error, pe.Error)
 
 	case event.DeleteType:
 		dee := ee.DeleteEvent
@@ -248,10 +367,7 @@ func isMatch(ee ExpEvent, e event.Event) bool {
 			return false
 		}
 
-		if dee.Error != nil {
-			return de.Error != nil
-		}
-		return de.Error == nil
+		return errorsMatch(dee.Error, de.Error)
 
 	case event.WaitType:
 		wee := ee.WaitEvent
@@ -290,16 +406,58 @@ func isMatch(ee ExpEvent, e event.Event) bool {
 			}
 		}
 
-		if vee.Error != nil {
-			return ve.Error != nil
+		if vee.Severity != ve.Severity {
+			return false
 		}
-		return ve.Error == nil
+
+		return errorsMatch(vee.Error, ve.Error)
 
 	default:
 		return true
 	}
 }
 
+// errorsMatch returns true if both errors are nil, or if the actual error
+// matches the expected error using errors.Is, in either direction, or if the
+// actual error wraps an error of the type of the expected error with the
+// same fields.
+//
+// Matching in both directions allows the expected error to be a sentinel
+// error wrapped by the actual error, or a matcher with an Is method, like
+// EqualErrorType, EqualErrorString, or EqualError. Matching by type allows
+// the expected error to be a typed error, like an inventory.OverlapError,
+// built by the test.
+func errorsMatch(expected, actual error) bool {
+	if expected == nil || actual == nil {
+		return expected == nil && actual == nil
+	}
+	return errors.Is(actual, expected) || errors.Is(expected, actual) ||
+		typedErrorsMatch(expected, actual)
+}
+
+// opaqueErrorTypes are the types of the errors created by errors.New and
+// fmt.Errorf. They only have a message, so they are not matched by type,
+// which would compare them by message.
+var opaqueErrorTypes = map[reflect.Type]bool{
+	reflect.TypeOf(errors.New("")):                   true,
+	reflect.TypeOf(fmt.Errorf("%w", errors.New(""))): true,
+}
+
+// typedErrorsMatch returns true if the actual error, or an error it wraps,
+// has the type of the expected error and deeply equals it.
+func typedErrorsMatch(expected, actual error) bool {
+	expType := reflect.TypeOf(expected)
+	if opaqueErrorTypes[expType] {
+		return false
+	}
+	for err := actual; err != nil; err = errors.Unwrap(err) {
+		if reflect.TypeOf(err) == expType && reflect.DeepEqual(expected, err) {
+			return true
+		}
+	}
+	return false
+}
+
 func EventsToExpEvents(events []event.Event) []ExpEvent {
 	result := make([]ExpEvent, 0, len(events))
 	for _, event := range events {
@@ -396,6 +554,7 @@ func EventToExpEvent(e event.Event) ExpEvent {
 			ValidationEvent: &ExpValidationEvent{
 				Identifiers: e.ValidationEvent.Identifiers,
 				Error:       e.ValidationEvent.Error,
+				Severity:    e.ValidationEvent.Severity,
 			},
 		}
 	}
@@ -405,7 +564,7 @@ func EventToExpEvent(e event.Event) ExpEvent {
 func RemoveEqualEvents(in []ExpEvent, expected ExpEvent) ([]ExpEvent, int) {
 	matches := 0
 	for i := 0; i < len(in); i++ {
-		if cmp.Equal(in[i], expected, cmpopts.EquateErrors()) {
+		if cmp.Equal(in[i], expected, EquateErrors()) {
 			// remove event at index i
 			in = append(in[:i], in[i+1:]...)
 			matches++
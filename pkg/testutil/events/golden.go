@@ -0,0 +1,444 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package events provides helpers to record event streams from the Applier
+// and Destroyer to YAML golden files, and to replay them later, so that
+// event consumers (ex: printers) can be tested without a fake applier.
+package events
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/yaml"
+)
+
+// UpdateEnvVar is the environment variable that, when set to "true", causes
+// Record to overwrite existing golden files instead of comparing with them.
+const UpdateEnvVar = "UPDATE_GOLDEN"
+
+// Record drains the event channel and returns the received events.
+//
+// If the golden file at path does not exist, or the UPDATE_GOLDEN environment
+// variable is "true", the normalized events are written to the golden file.
+// Otherwise, the normalized events are compared with the golden file and the
+// test fails if they differ.
+func Record(t *testing.T, ch <-chan event.Event, path string) []event.Event {
+	t.Helper() // print the caller's file:line, instead of this func, on failure
+	var events []event.Event
+	for e := range ch {
+		events = append(events, e)
+	}
+	actual, err := Encode(events)
+	if err != nil {
+		t.Fatalf("failed to encode events: %v", err)
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("failed to read golden file %q: %v", path, err)
+	}
+	if err != nil || os.Getenv(UpdateEnvVar) == "true" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create golden file directory: %v", err)
+		}
+		if err := os.WriteFile(path, actual, 0o600); err != nil {
+			t.Fatalf("failed to write golden file %q: %v", path, err)
+		}
+		return events
+	}
+	if diff := cmp.Diff(string(expected), string(actual)); diff != "" {
+		t.Errorf("events do not match golden file %q (set %s=true to update)\nDiff (- Expected, + Actual):\n%s",
+			path, UpdateEnvVar, diff)
+	}
+	return events
+}
+
+// Replay reads the golden file at path and returns a closed channel buffered
+// with the recorded events, in the same form returned by Applier.Run.
+func Replay(path string) (<-chan event.Event, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read golden file %q: %w", path, err)
+	}
+	events, err := Decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode golden file %q: %w", path, err)
+	}
+	ch := make(chan event.Event, len(events))
+	for _, e := range events {
+		ch <- e
+	}
+	close(ch)
+	return ch, nil
+}
+
+// Encode serializes the events to YAML. Object fields that change between
+// runs (UIDs, timestamps, resource versions and managed fields) are removed.
+// Errors are recorded by message only.
+func Encode(events []event.Event) ([]byte, error) {
+	records := make([]eventRecord, 0, len(events))
+	for _, e := range events {
+		records = append(records, toRecord(e))
+	}
+	return yaml.Marshal(records)
+}
+
+// Decode deserializes events previously serialized with Encode. Errors are
+// recreated from their messages, so they will not match the original error
+// types.
+func Decode(data []byte) ([]event.Event, error) {
+	var records []eventRecord
+	if err := yaml.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+	events := make([]event.Event, 0, len(records))
+	for i, r := range records {
+		e, err := fromRecord(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event %d: %w", i, err)
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+// eventRecord is the serialized form of an event.Event. Only the fields
+// relevant to the event type are populated.
+type eventRecord struct {
+	Type         string                 `json:"type"`
+	ActionGroups []actionGroupRecord    `json:"actionGroups,omitempty"`
+	GroupName    string                 `json:"groupName,omitempty"`
+	Action       string                 `json:"action,omitempty"`
+	Status       string                 `json:"status,omitempty"`
+	Identifier   *identifierRecord      `json:"identifier,omitempty"`
+	Identifiers  []identifierRecord     `json:"identifiers,omitempty"`
+	Message      string                 `json:"message,omitempty"`
+	Error        string                 `json:"error,omitempty"`
+	Object       map[string]interface{} `json:"object,omitempty"`
+}
+
+type actionGroupRecord struct {
+	Name        string             `json:"name"`
+	Action      string             `json:"action"`
+	Identifiers []identifierRecord `json:"identifiers,omitempty"`
+}
+
+type identifierRecord struct {
+	Group     string `json:"group,omitempty"`
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+}
+
+func toRecord(e event.Event) eventRecord {
+	r := eventRecord{
+		Type: e.Type.String(),
+	}
+	switch e.Type {
+	case event.InitType:
+		for _, ag := range e.InitEvent.ActionGroups {
+			r.ActionGroups = append(r.ActionGroups, actionGroupRecord{
+				Name:        ag.Name,
+				Action:      ag.Action.String(),
+				Identifiers: toIdentifierRecords(ag.Identifiers),
+			})
+		}
+	case event.ErrorType:
+		r.Error = errorString(e.ErrorEvent.Err)
+	case event.ActionGroupType:
+		r.GroupName = e.ActionGroupEvent.GroupName
+		r.Action = e.ActionGroupEvent.Action.String()
+		r.Status = e.ActionGroupEvent.Status.String()
+	case event.ApplyType:
+		r.GroupName = e.ApplyEvent.GroupName
+		r.Status = e.ApplyEvent.Status.String()
+		r.Identifier = toIdentifierRecord(e.ApplyEvent.Identifier)
+		r.Error = errorString(e.ApplyEvent.Error)
+		r.Object = normalizedObject(e.ApplyEvent.Resource)
+	case event.StatusType:
+		r.Identifier = toIdentifierRecord(e.StatusEvent.Identifier)
+		r.Error = errorString(e.StatusEvent.Error)
+		if info := e.StatusEvent.PollResourceInfo; info != nil {
+			r.Status = info.Status.String()
+			r.Message = info.Message
+			r.Object = normalizedObject(info.Resource)
+		}
+	case event.PruneType:
+		r.GroupName = e.PruneEvent.GroupName
+		r.Status = e.PruneEvent.Status.String()
+		r.Identifier = toIdentifierRecord(e.PruneEvent.Identifier)
+		r.Error = errorString(e.PruneEvent.Error)
+		r.Object = normalizedObject(e.PruneEvent.Object)
+	case event.DeleteType:
+		r.GroupName = e.DeleteEvent.GroupName
+		r.Status = e.DeleteEvent.Status.String()
+		r.Identifier = toIdentifierRecord(e.DeleteEvent.Identifier)
+		r.Error = errorString(e.DeleteEvent.Error)
+		r.Object = normalizedObject(e.DeleteEvent.Object)
+	case event.WaitType:
+		r.GroupName = e.WaitEvent.GroupName
+		r.Status = e.WaitEvent.Status.String()
+		r.Identifier = toIdentifierRecord(e.WaitEvent.Identifier)
+	case event.ValidationType:
+		r.Identifiers = toIdentifierRecords(e.ValidationEvent.Identifiers)
+		r.Error = errorString(e.ValidationEvent.Error)
+	}
+	return r
+}
+
+// nolint:gocyclo
+func fromRecord(r eventRecord) (event.Event, error) {
+	var e event.Event
+	t, err := parseEnum(r.Type, eventTypes)
+	if err != nil {
+		return e, fmt.Errorf("invalid type: %w", err)
+	}
+	e.Type = t.(event.Type)
+	switch e.Type {
+	case event.InitType:
+		for _, agr := range r.ActionGroups {
+			action, err := parseEnum(agr.Action, resourceActions)
+			if err != nil {
+				return e, fmt.Errorf("invalid action group action: %w", err)
+			}
+			e.InitEvent.ActionGroups = append(e.InitEvent.ActionGroups, event.ActionGroup{
+				Name:        agr.Name,
+				Action:      action.(event.ResourceAction),
+				Identifiers: fromIdentifierRecords(agr.Identifiers),
+			})
+		}
+	case event.ErrorType:
+		e.ErrorEvent.Err = toError(r.Error)
+	case event.ActionGroupType:
+		action, err := parseEnum(r.Action, resourceActions)
+		if err != nil {
+			return e, fmt.Errorf("invalid action: %w", err)
+		}
+		s, err := parseEnum(r.Status, actionGroupStatuses)
+		if err != nil {
+			return e, fmt.Errorf("invalid status: %w", err)
+		}
+		e.ActionGroupEvent = event.ActionGroupEvent{
+			GroupName: r.GroupName,
+			Action:    action.(event.ResourceAction),
+			Status:    s.(event.ActionGroupEventStatus),
+		}
+	case event.ApplyType:
+		s, err := parseEnum(r.Status, applyStatuses)
+		if err != nil {
+			return e, fmt.Errorf("invalid status: %w", err)
+		}
+		e.ApplyEvent = event.ApplyEvent{
+			GroupName:  r.GroupName,
+			Status:     s.(event.ApplyEventStatus),
+			Identifier: fromIdentifierRecord(r.Identifier),
+			Resource:   toObject(r.Object),
+			Error:      toError(r.Error),
+		}
+	case event.StatusType:
+		id := fromIdentifierRecord(r.Identifier)
+		e.StatusEvent = event.StatusEvent{
+			Identifier: id,
+			Resource:   toObject(r.Object),
+			Error:      toError(r.Error),
+		}
+		if r.Status != "" {
+			s, err := parseStatus(r.Status)
+			if err != nil {
+				return e, fmt.Errorf("invalid status: %w", err)
+			}
+			e.StatusEvent.PollResourceInfo = &pollevent.ResourceStatus{
+				Identifier: id,
+				Status:     s,
+				Resource:   e.StatusEvent.Resource,
+				Message:    r.Message,
+				Error:      e.StatusEvent.Error,
+			}
+		}
+	case event.PruneType:
+		s, err := parseEnum(r.Status, pruneStatuses)
+		if err != nil {
+			return e, fmt.Errorf("invalid status: %w", err)
+		}
+		e.PruneEvent = event.PruneEvent{
+			GroupName:  r.GroupName,
+			Status:     s.(event.PruneEventStatus),
+			Identifier: fromIdentifierRecord(r.Identifier),
+			Object:     toObject(r.Object),
+			Error:      toError(r.Error),
+		}
+	case event.DeleteType:
+		s, err := parseEnum(r.Status, deleteStatuses)
+		if err != nil {
+			return e, fmt.Errorf("invalid status: %w", err)
+		}
+		e.DeleteEvent = event.DeleteEvent{
+			GroupName:  r.GroupName,
+			Status:     s.(event.DeleteEventStatus),
+			Identifier: fromIdentifierRecord(r.Identifier),
+			Object:     toObject(r.Object),
+			Error:      toError(r.Error),
+		}
+	case event.WaitType:
+		s, err := parseEnum(r.Status, waitStatuses)
+		if err != nil {
+			return e, fmt.Errorf("invalid status: %w", err)
+		}
+		e.WaitEvent = event.WaitEvent{
+			GroupName:  r.GroupName,
+			Status:     s.(event.WaitEventStatus),
+			Identifier: fromIdentifierRecord(r.Identifier),
+		}
+	case event.ValidationType:
+		e.ValidationEvent = event.ValidationEvent{
+			Identifiers: fromIdentifierRecords(r.Identifiers),
+			Error:       toError(r.Error),
+		}
+	}
+	return e, nil
+}
+
+var (
+	eventTypes = []fmt.Stringer{
+		event.InitType, event.ErrorType, event.ActionGroupType, event.ApplyType,
+		event.StatusType, event.PruneType, event.DeleteType, event.WaitType,
+		event.ValidationType,
+	}
+	resourceActions = []fmt.Stringer{
+		event.ApplyAction, event.PruneAction, event.DeleteAction,
+		event.WaitAction, event.InventoryAction,
+	}
+	actionGroupStatuses = []fmt.Stringer{
+		event.Started, event.Finished,
+	}
+	applyStatuses = []fmt.Stringer{
+		event.ApplyPending, event.ApplySuccessful, event.ApplySkipped, event.ApplyFailed,
+	}
+	pruneStatuses = []fmt.Stringer{
+		event.PrunePending, event.PruneSuccessful, event.PruneSkipped, event.PruneFailed,
+	}
+	deleteStatuses = []fmt.Stringer{
+		event.DeletePending, event.DeleteSuccessful, event.DeleteSkipped, event.DeleteFailed,
+	}
+	waitStatuses = []fmt.Stringer{
+		event.ReconcilePending, event.ReconcileSuccessful, event.ReconcileSkipped,
+		event.ReconcileTimeout, event.ReconcileFailed,
+	}
+)
+
+// parseEnum returns the value whose String() matches the name.
+func parseEnum(name string, values []fmt.Stringer) (fmt.Stringer, error) {
+	for _, v := range values {
+		if v.String() == name {
+			return v, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown value %q", name)
+}
+
+// parseStatus returns the kstatus Status with the specified name.
+func parseStatus(name string) (status.Status, error) {
+	for _, s := range status.Statuses {
+		if s.String() == name {
+			return s, nil
+		}
+	}
+	return "", fmt.Errorf("unknown value %q", name)
+}
+
+// normalizedFields are the object fields that are removed before recording,
+// because they are assigned by the server and change between runs.
+var normalizedFields = [][]string{
+	{"metadata", "uid"},
+	{"metadata", "resourceVersion"},
+	{"metadata", "creationTimestamp"},
+	{"metadata", "deletionTimestamp"},
+	{"metadata", "managedFields"},
+}
+
+func normalizedObject(obj *unstructured.Unstructured) map[string]interface{} {
+	if obj == nil {
+		return nil
+	}
+	content := obj.DeepCopy().Object
+	for _, fields := range normalizedFields {
+		unstructured.RemoveNestedField(content, fields...)
+	}
+	return content
+}
+
+func toObject(content map[string]interface{}) *unstructured.Unstructured {
+	if content == nil {
+		return nil
+	}
+	return &unstructured.Unstructured{Object: content}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func toError(msg string) error {
+	if msg == "" {
+		return nil
+	}
+	return errors.New(msg)
+}
+
+func toIdentifierRecord(id object.ObjMetadata) *identifierRecord {
+	if id == object.NilObjMetadata {
+		return nil
+	}
+	return &toIdentifierRecords(object.ObjMetadataSet{id})[0]
+}
+
+func toIdentifierRecords(ids object.ObjMetadataSet) []identifierRecord {
+	var records []identifierRecord
+	for _, id := range ids {
+		records = append(records, identifierRecord{
+			Group:     id.GroupKind.Group,
+			Kind:      id.GroupKind.Kind,
+			Namespace: id.Namespace,
+			Name:      id.Name,
+		})
+	}
+	return records
+}
+
+func fromIdentifierRecord(r *identifierRecord) object.ObjMetadata {
+	if r == nil {
+		return object.NilObjMetadata
+	}
+	return object.ObjMetadata{
+		GroupKind: schema.GroupKind{
+			Group: r.Group,
+			Kind:  r.Kind,
+		},
+		Namespace: r.Namespace,
+		Name:      r.Name,
+	}
+}
+
+func fromIdentifierRecords(records []identifierRecord) object.ObjMetadataSet {
+	if records == nil {
+		return nil
+	}
+	ids := make(object.ObjMetadataSet, 0, len(records))
+	for i := range records {
+		ids = append(ids, fromIdentifierRecord(&records[i]))
+	}
+	return ids
+}
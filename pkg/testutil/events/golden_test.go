@@ -0,0 +1,153 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package events
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	pollevent "sigs.k8s.io/cli-utils/pkg/kstatus/polling/event"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+	"sigs.k8s.io/cli-utils/pkg/object"
+	"sigs.k8s.io/cli-utils/pkg/testutil"
+)
+
+var cmID = object.ObjMetadata{
+	GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+	Namespace: "test-ns",
+	Name:      "cm",
+}
+
+func newConfigMap(uid string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "ConfigMap",
+			"metadata": map[string]interface{}{
+				"name":              "cm",
+				"namespace":         "test-ns",
+				"uid":               uid,
+				"resourceVersion":   "12345",
+				"creationTimestamp": "2023-01-01T00:00:00Z",
+			},
+		},
+	}
+}
+
+func testEvents(uid string) []event.Event {
+	return []event.Event{
+		{
+			Type: event.InitType,
+			InitEvent: event.InitEvent{
+				ActionGroups: event.ActionGroupList{
+					{
+						Name:        "apply-0",
+						Action:      event.ApplyAction,
+						Identifiers: object.ObjMetadataSet{cmID},
+					},
+				},
+			},
+		},
+		{
+			Type: event.ActionGroupType,
+			ActionGroupEvent: event.ActionGroupEvent{
+				GroupName: "apply-0",
+				Action:    event.ApplyAction,
+				Status:    event.Started,
+			},
+		},
+		{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				GroupName:  "apply-0",
+				Identifier: cmID,
+				Status:     event.ApplySuccessful,
+				Resource:   newConfigMap(uid),
+			},
+		},
+		{
+			Type: event.StatusType,
+			StatusEvent: event.StatusEvent{
+				Identifier: cmID,
+				PollResourceInfo: &pollevent.ResourceStatus{
+					Identifier: cmID,
+					Status:     status.CurrentStatus,
+					Resource:   newConfigMap(uid),
+					Message:    "Resource is always ready",
+				},
+				Resource: newConfigMap(uid),
+			},
+		},
+		{
+			Type: event.PruneType,
+			PruneEvent: event.PruneEvent{
+				GroupName:  "prune-0",
+				Identifier: cmID,
+				Status:     event.PruneFailed,
+				Error:      errors.New("forbidden"),
+			},
+		},
+		{
+			Type: event.ErrorType,
+			ErrorEvent: event.ErrorEvent{
+				Err: errors.New("fatal"),
+			},
+		},
+	}
+}
+
+func sendEvents(events []event.Event) <-chan event.Event {
+	ch := make(chan event.Event, len(events))
+	for _, e := range events {
+		ch <- e
+	}
+	close(ch)
+	return ch
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "events.yaml")
+
+	// First run writes the golden file.
+	recorded := Record(t, sendEvents(testEvents("uid-1")), path)
+	require.Len(t, recorded, len(testEvents("uid-1")))
+
+	// Second run compares with the golden file. UIDs are normalized, so
+	// a different UID still matches.
+	Record(t, sendEvents(testEvents("uid-2")), path)
+
+	ch, err := Replay(path)
+	require.NoError(t, err)
+	var replayed []event.Event
+	for e := range ch {
+		replayed = append(replayed, e)
+	}
+
+	expected := testutil.EventsToExpEvents(testEvents("uid-1"))
+	// Errors are replayed by message only.
+	expected[4].PruneEvent.Error = testutil.EqualErrorString("forbidden")
+	expected[5].ErrorEvent.Err = testutil.EqualErrorString("fatal")
+	testutil.AssertEqual(t, expected, testutil.EventsToExpEvents(replayed))
+
+	// Replayed objects have server-assigned fields removed.
+	obj := replayed[2].ApplyEvent.Resource
+	require.NotNil(t, obj)
+	assert.Equal(t, "cm", obj.GetName())
+	assert.Empty(t, obj.GetUID())
+	assert.Empty(t, obj.GetResourceVersion())
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	_, err := Decode([]byte("- type: BogusType\n"))
+	assert.EqualError(t, err, `invalid event 0: invalid type: unknown value "BogusType"`)
+
+	_, err = Decode([]byte("- type: ApplyType\n  status: Bogus\n"))
+	assert.EqualError(t, err, `invalid event 0: invalid status: unknown value "Bogus"`)
+}
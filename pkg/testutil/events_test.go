@@ -0,0 +1,402 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package testutil
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+var (
+	testID1 = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: "test-ns",
+		Name:      "cm-1",
+	}
+	testID2 = object.ObjMetadata{
+		GroupKind: schema.GroupKind{Kind: "ConfigMap"},
+		Namespace: "test-ns",
+		Name:      "cm-2",
+	}
+)
+
+func TestVerifyEvents(t *testing.T) {
+	events := []event.Event{
+		{
+			Type: event.InitType,
+		},
+		{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				GroupName:  "apply-0",
+				Identifier: testID1,
+				Status:     event.ApplySuccessful,
+			},
+		},
+		{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				GroupName:  "apply-0",
+				Identifier: testID2,
+				Status:     event.ApplyFailed,
+				Error:      errors.New("admission webhook denied the request"),
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		expEvents   []ExpEvent
+		expectedErr []string
+	}{
+		"no expected events": {},
+		"exact fields": {
+			expEvents: []ExpEvent{
+				{
+					EventType: event.ApplyType,
+					ApplyEvent: &ExpApplyEvent{
+						Identifier: testID1,
+						Status:     event.ApplySuccessful,
+					},
+				},
+			},
+		},
+		"predicates only": {
+			expEvents: []ExpEvent{
+				{
+					EventType: event.ApplyType,
+					Predicates: []EventPredicate{
+						IdentifierIn(object.ObjMetadataSet{testID1, testID2}),
+						ErrorMatches("webhook denied"),
+					},
+				},
+			},
+		},
+		"negated predicate": {
+			expEvents: []ExpEvent{
+				{
+					EventType: event.ApplyType,
+					Predicates: []EventPredicate{
+						GroupNameIn("apply-0"),
+						Not(IdentifierIn(object.ObjMetadataSet{testID1})),
+					},
+				},
+			},
+		},
+		"unsatisfied predicate": {
+			expEvents: []ExpEvent{
+				{
+					EventType: event.ApplyType,
+					Predicates: []EventPredicate{
+						ErrorMatches("quota exceeded"),
+					},
+				},
+			},
+			expectedErr: []string{
+				"expected event 0 (ApplyType) not found",
+				"first non-matching ApplyType",
+				`unsatisfied predicate: ErrorMatches("quota exceeded")`,
+			},
+		},
+		"field mismatch shows diff": {
+			expEvents: []ExpEvent{
+				{
+					EventType: event.ApplyType,
+					ApplyEvent: &ExpApplyEvent{
+						Identifier: testID1,
+						Status:     event.ApplySuccessful,
+					},
+				},
+				{
+					EventType: event.ApplyType,
+					ApplyEvent: &ExpApplyEvent{
+						Identifier: testID2,
+						Status:     event.ApplySuccessful,
+					},
+				},
+			},
+			expectedErr: []string{
+				"expected event 1 (ApplyType) not found",
+				"diff (- expected, + actual)",
+			},
+		},
+		"no events of the expected type": {
+			expEvents: []ExpEvent{
+				{
+					EventType: event.PruneType,
+				},
+			},
+			expectedErr: []string{
+				"expected event 0 (PruneType) not found",
+				"no PruneType events received after the last match",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyEvents(tc.expEvents, events)
+			if len(tc.expectedErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			if !assert.Error(t, err) {
+				return
+			}
+			for _, substr := range tc.expectedErr {
+				assert.Contains(t, err.Error(), substr)
+			}
+		})
+	}
+}
+
+func TestVerifyEventsGrouped(t *testing.T) {
+	applyEvent := func(id object.ObjMetadata, groupName string) event.Event {
+		return event.Event{
+			Type: event.ApplyType,
+			ApplyEvent: event.ApplyEvent{
+				GroupName:  groupName,
+				Identifier: id,
+				Status:     event.ApplySuccessful,
+			},
+		}
+	}
+	expApplyEvent := func(id object.ObjMetadata) ExpEvent {
+		return ExpEvent{
+			EventType: event.ApplyType,
+			ApplyEvent: &ExpApplyEvent{
+				Identifier: id,
+				Status:     event.ApplySuccessful,
+			},
+		}
+	}
+	expActionGroupEvent := func(groupName string, status event.ActionGroupEventStatus) ExpEvent {
+		return ExpEvent{
+			EventType: event.ActionGroupType,
+			ActionGroupEvent: &ExpActionGroupEvent{
+				GroupName: groupName,
+				Action:    event.ApplyAction,
+				Type:      status,
+			},
+		}
+	}
+	actionGroupEvent := func(groupName string, status event.ActionGroupEventStatus) event.Event {
+		return event.Event{
+			Type: event.ActionGroupType,
+			ActionGroupEvent: event.ActionGroupEvent{
+				GroupName: groupName,
+				Action:    event.ApplyAction,
+				Status:    status,
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		expGroups   [][]ExpEvent
+		events      []event.Event
+		expectedErr []string
+	}{
+		"no groups": {
+			events: []event.Event{applyEvent(testID1, "apply-0")},
+		},
+		"any order within group": {
+			expGroups: [][]ExpEvent{
+				{expActionGroupEvent("apply-0", event.Started)},
+				{expApplyEvent(testID1), expApplyEvent(testID2)},
+				{expActionGroupEvent("apply-0", event.Finished)},
+			},
+			events: []event.Event{
+				actionGroupEvent("apply-0", event.Started),
+				applyEvent(testID2, "apply-0"),
+				applyEvent(testID1, "apply-0"),
+				actionGroupEvent("apply-0", event.Finished),
+			},
+		},
+		"empty groups are skipped": {
+			expGroups: [][]ExpEvent{
+				{},
+				{expApplyEvent(testID1)},
+				{},
+			},
+			events: []event.Event{
+				applyEvent(testID1, "apply-0"),
+			},
+		},
+		"event before its group": {
+			expGroups: [][]ExpEvent{
+				{expActionGroupEvent("apply-0", event.Started)},
+				{expApplyEvent(testID1), expApplyEvent(testID2)},
+			},
+			events: []event.Event{
+				applyEvent(testID2, "apply-0"),
+				actionGroupEvent("apply-0", event.Started),
+				applyEvent(testID1, "apply-0"),
+			},
+			expectedErr: []string{
+				"expected event group 1 incomplete: 1 of 2 events not found",
+				"missing: ApplyType",
+				"cm-2",
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyEventsGrouped(tc.expGroups, tc.events)
+			if len(tc.expectedErr) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			if !assert.Error(t, err) {
+				return
+			}
+			for _, substr := range tc.expectedErr {
+				assert.Contains(t, err.Error(), substr)
+			}
+		})
+	}
+}
+
+var errSentinel = errors.New("sentinel")
+
+func TestVerifyEventsErrors(t *testing.T) {
+	pruneEvent := func(err error) event.Event {
+		return event.Event{
+			Type: event.PruneType,
+			PruneEvent: event.PruneEvent{
+				Identifier: testID1,
+				Status:     event.PruneFailed,
+				Error:      err,
+			},
+		}
+	}
+	expPruneEvent := func(err error) []ExpEvent {
+		return []ExpEvent{
+			{
+				EventType: event.PruneType,
+				PruneEvent: &ExpPruneEvent{
+					Identifier: testID1,
+					Status:     event.PruneFailed,
+					Error:      err,
+				},
+			},
+		}
+	}
+
+	tests := map[string]struct {
+		expected    error
+		actual      error
+		expectMatch bool
+	}{
+		"wrapped sentinel": {
+			expected:    errSentinel,
+			actual:      fmt.Errorf("prune failed: %w", errSentinel),
+			expectMatch: true,
+		},
+		"same message, different instance": {
+			expected:    errors.New("sentinel"),
+			actual:      errSentinel,
+			expectMatch: false,
+		},
+		"EqualErrorString matcher": {
+			expected:    EqualErrorString("sentinel"),
+			actual:      errSentinel,
+			expectMatch: true,
+		},
+		"EqualErrorType matcher": {
+			expected:    EqualErrorType(&fs.PathError{}),
+			actual:      &fs.PathError{Op: "open", Path: "x", Err: errSentinel},
+			expectMatch: true,
+		},
+		"typed error with the same fields": {
+			expected:    &fs.PathError{Op: "open", Path: "x", Err: errSentinel},
+			actual:      &fs.PathError{Op: "open", Path: "x", Err: errSentinel},
+			expectMatch: true,
+		},
+		"wrapped typed error": {
+			expected:    &fs.PathError{Op: "open", Path: "x", Err: errSentinel},
+			actual:      fmt.Errorf("prune failed: %w", &fs.PathError{Op: "open", Path: "x", Err: errSentinel}),
+			expectMatch: true,
+		},
+		"typed error with different fields": {
+			expected:    &fs.PathError{Op: "open", Path: "x", Err: errSentinel},
+			actual:      &fs.PathError{Op: "open", Path: "y", Err: errSentinel},
+			expectMatch: false,
+		},
+		"expected error, got none": {
+			expected:    errSentinel,
+			actual:      nil,
+			expectMatch: false,
+		},
+		"expected no error, got one": {
+			expected:    nil,
+			actual:      errSentinel,
+			expectMatch: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := VerifyEvents(expPruneEvent(tc.expected), []event.Event{pruneEvent(tc.actual)})
+			if tc.expectMatch {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestEquateErrors(t *testing.T) {
+	errSentinel := errors.New("sentinel")
+
+	tests := map[string]struct {
+		expected    error
+		actual      error
+		expectEqual bool
+	}{
+		"wrapped sentinel": {
+			expected:    errSentinel,
+			actual:      fmt.Errorf("failed: %w", errSentinel),
+			expectEqual: true,
+		},
+		"same message, different instance": {
+			expected:    errors.New("sentinel"),
+			actual:      errSentinel,
+			expectEqual: false,
+		},
+		"typed error with the same fields": {
+			expected:    &fs.PathError{Op: "open", Path: "x", Err: errSentinel},
+			actual:      &fs.PathError{Op: "open", Path: "x", Err: errSentinel},
+			expectEqual: true,
+		},
+		"wrapped typed error, in either order": {
+			expected:    fmt.Errorf("failed: %w", &fs.PathError{Op: "open", Path: "x", Err: errSentinel}),
+			actual:      &fs.PathError{Op: "open", Path: "x", Err: errSentinel},
+			expectEqual: true,
+		},
+		"typed error with different fields": {
+			expected:    &fs.PathError{Op: "open", Path: "x", Err: errSentinel},
+			actual:      &fs.PathError{Op: "open", Path: "y", Err: errSentinel},
+			expectEqual: false,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			type result struct {
+				Err error
+			}
+			equal := cmp.Equal(result{Err: tc.expected}, result{Err: tc.actual}, EquateErrors())
+			assert.Equal(t, tc.expectEqual, equal)
+		})
+	}
+}
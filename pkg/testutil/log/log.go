@@ -0,0 +1,236 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package log provides a logr.LogSink that captures log entries in memory,
+// and assertion helpers to verify what was logged, for code whose
+// diagnostics are only surfaced in logs.
+package log
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-logr/logr"
+	"k8s.io/klog/v2"
+)
+
+// ErrorLevel is the Level of entries logged with Error.
+const ErrorLevel = -1
+
+// Entry is a single captured log entry.
+type Entry struct {
+	// Level is the verbosity level of the entry, or ErrorLevel.
+	Level int
+	// Name is the logger name, joined by "/".
+	Name string
+	// Message is the log message, without a trailing newline.
+	Message string
+	// Error is the error passed to Error, if any.
+	Error error
+	// KeysAndValues are the structured key/value pairs of the entry,
+	// including those added with WithValues.
+	KeysAndValues []interface{}
+}
+
+// String returns a string suitable for failure messages.
+func (e Entry) String() string {
+	var sb strings.Builder
+	if e.Level == ErrorLevel {
+		sb.WriteString("E ")
+	} else {
+		fmt.Fprintf(&sb, "V(%d) ", e.Level)
+	}
+	if e.Name != "" {
+		fmt.Fprintf(&sb, "%s: ", e.Name)
+	}
+	fmt.Fprintf(&sb, "%q", e.Message)
+	if e.Error != nil {
+		fmt.Fprintf(&sb, " err=%q", e.Error)
+	}
+	for i := 0; i+1 < len(e.KeysAndValues); i += 2 {
+		fmt.Fprintf(&sb, " %v=%v", e.KeysAndValues[i], e.KeysAndValues[i+1])
+	}
+	return sb.String()
+}
+
+// Value returns the value of the specified key, and whether it was found.
+func (e Entry) Value(key string) (interface{}, bool) {
+	for i := 0; i+1 < len(e.KeysAndValues); i += 2 {
+		if e.KeysAndValues[i] == key {
+			return e.KeysAndValues[i+1], true
+		}
+	}
+	return nil, false
+}
+
+// Sink is a logr.LogSink that records log entries in memory.
+// Sink is safe for concurrent use.
+type Sink struct {
+	// Verbosity is the maximum verbosity level that is recorded.
+	Verbosity int
+
+	entries *entries
+	name    string
+	values  []interface{}
+}
+
+type entries struct {
+	mu   sync.Mutex
+	list []Entry
+}
+
+var _ logr.LogSink = &Sink{}
+
+// NewSink returns a new Sink that records entries up to the specified
+// verbosity level.
+func NewSink(verbosity int) *Sink {
+	return &Sink{
+		Verbosity: verbosity,
+		entries:   &entries{},
+	}
+}
+
+// Logger returns a logr.Logger that writes to the Sink.
+func (s *Sink) Logger() logr.Logger {
+	return logr.New(s)
+}
+
+// Init implements logr.LogSink.
+func (s *Sink) Init(logr.RuntimeInfo) {}
+
+// Enabled implements logr.LogSink.
+func (s *Sink) Enabled(level int) bool {
+	return level <= s.Verbosity
+}
+
+// Info implements logr.LogSink.
+func (s *Sink) Info(level int, msg string, keysAndValues ...interface{}) {
+	s.record(Entry{
+		Level:   level,
+		Message: msg,
+	}, keysAndValues)
+}
+
+// Error implements logr.LogSink.
+func (s *Sink) Error(err error, msg string, keysAndValues ...interface{}) {
+	s.record(Entry{
+		Level:   ErrorLevel,
+		Message: msg,
+		Error:   err,
+	}, keysAndValues)
+}
+
+// WithValues implements logr.LogSink.
+func (s *Sink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	sx := *s
+	sx.values = append(append([]interface{}{}, s.values...), keysAndValues...)
+	return &sx
+}
+
+// WithName implements logr.LogSink.
+func (s *Sink) WithName(name string) logr.LogSink {
+	sx := *s
+	if sx.name == "" {
+		sx.name = name
+	} else {
+		sx.name = sx.name + "/" + name
+	}
+	return &sx
+}
+
+func (s *Sink) record(e Entry, keysAndValues []interface{}) {
+	e.Name = s.name
+	e.Message = strings.TrimSuffix(e.Message, "\n")
+	e.KeysAndValues = append(append([]interface{}{}, s.values...), keysAndValues...)
+	s.entries.mu.Lock()
+	defer s.entries.mu.Unlock()
+	s.entries.list = append(s.entries.list, e)
+}
+
+// Entries returns a copy of the recorded entries, in the order they were
+// logged.
+func (s *Sink) Entries() []Entry {
+	s.entries.mu.Lock()
+	defer s.entries.mu.Unlock()
+	return append([]Entry{}, s.entries.list...)
+}
+
+// Reset removes all the recorded entries.
+func (s *Sink) Reset() {
+	s.entries.mu.Lock()
+	defer s.entries.mu.Unlock()
+	s.entries.list = nil
+}
+
+// Find returns the recorded entries at the specified level whose message
+// contains the specified substring.
+func (s *Sink) Find(level int, substr string) []Entry {
+	var found []Entry
+	for _, e := range s.Entries() {
+		if e.Level == level && strings.Contains(e.Message, substr) {
+			found = append(found, e)
+		}
+	}
+	return found
+}
+
+// AssertLogged fails the test if no entry at the specified level contains
+// the specified substring. Prints all the recorded entries on failure.
+func (s *Sink) AssertLogged(t *testing.T, level int, substr string) {
+	t.Helper() // print the caller's file:line, instead of this func, on failure
+	if len(s.Find(level, substr)) == 0 {
+		t.Errorf("expected log entry at level %d containing %q\n%s", level, substr, s.dump())
+	}
+}
+
+// AssertNotLogged fails the test if any entry at the specified level
+// contains the specified substring.
+func (s *Sink) AssertNotLogged(t *testing.T, level int, substr string) {
+	t.Helper() // print the caller's file:line, instead of this func, on failure
+	if found := s.Find(level, substr); len(found) > 0 {
+		t.Errorf("unexpected log entry at level %d containing %q: %s", level, substr, found[0])
+	}
+}
+
+func (s *Sink) dump() string {
+	entries := s.Entries()
+	if len(entries) == 0 {
+		return "no entries logged"
+	}
+	var sb strings.Builder
+	sb.WriteString("logged entries:")
+	for _, e := range entries {
+		sb.WriteString("\n  ")
+		sb.WriteString(e.String())
+	}
+	return sb.String()
+}
+
+// CaptureKlog redirects the global klog output to a new Sink, with the klog
+// verbosity raised to the specified level, for the duration of the test.
+// When the test completes, the klog logger is cleared and the previous
+// verbosity is restored.
+//
+// Modifying the global klog logger is not thread-safe, so tests using
+// CaptureKlog must not run in parallel.
+func CaptureKlog(t *testing.T, verbosity int) *Sink {
+	t.Helper()
+	fs := flag.NewFlagSet("klog", flag.ContinueOnError)
+	klog.InitFlags(fs)
+	prevVerbosity := fs.Lookup("v").Value.String()
+	if err := fs.Set("v", fmt.Sprint(verbosity)); err != nil {
+		t.Fatalf("failed to set klog verbosity: %v", err)
+	}
+	sink := NewSink(verbosity)
+	klog.SetLogger(sink.Logger())
+	t.Cleanup(func() {
+		klog.ClearLogger()
+		if err := fs.Set("v", prevVerbosity); err != nil {
+			t.Errorf("failed to reset klog verbosity: %v", err)
+		}
+	})
+	return sink
+}
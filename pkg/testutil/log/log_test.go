@@ -0,0 +1,59 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package log
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/klog/v2"
+)
+
+func TestSink(t *testing.T) {
+	sink := NewSink(2)
+	logger := sink.Logger().WithName("applier").WithValues("run", "abc")
+
+	logger.Info("starting", "objects", 3)
+	logger.V(2).Info("building task queue")
+	logger.V(4).Info("too verbose")
+	logger.WithName("prune").Error(errors.New("boom"), "failed to prune", "object", "ns_cm__ConfigMap")
+
+	entries := sink.Entries()
+	if !assert.Len(t, entries, 3) {
+		return
+	}
+	assert.Equal(t, Entry{
+		Level:         0,
+		Name:          "applier",
+		Message:       "starting",
+		KeysAndValues: []interface{}{"run", "abc", "objects", 3},
+	}, entries[0])
+	assert.Equal(t, 2, entries[1].Level)
+	assert.Equal(t, ErrorLevel, entries[2].Level)
+	assert.Equal(t, "applier/prune", entries[2].Name)
+	assert.EqualError(t, entries[2].Error, "boom")
+	value, found := entries[2].Value("object")
+	assert.True(t, found)
+	assert.Equal(t, "ns_cm__ConfigMap", value)
+
+	sink.AssertLogged(t, 2, "task queue")
+	sink.AssertLogged(t, ErrorLevel, "failed to prune")
+	sink.AssertNotLogged(t, 4, "too verbose")
+
+	sink.Reset()
+	assert.Empty(t, sink.Entries())
+}
+
+func TestCaptureKlog(t *testing.T) {
+	sink := CaptureKlog(t, 4)
+
+	klog.V(4).Infof("calculated %d apply objs", 2)
+	klog.V(5).Info("not captured")
+	klog.Errorf("error deleting object %q", "pod-1")
+
+	sink.AssertLogged(t, 4, "calculated 2 apply objs")
+	sink.AssertNotLogged(t, 5, "not captured")
+	sink.AssertLogged(t, ErrorLevel, `error deleting object "pod-1"`)
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 The Kubernetes Authors.
+// SPDX-License-Identifier: Apache-2.0
+
+package testutil
+
+import (
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/cli-utils/pkg/apply/event"
+	"sigs.k8s.io/cli-utils/pkg/object"
+)
+
+// EventPredicate is a matcher that can be attached to an ExpEvent to assert
+// on event fields without specifying their exact value.
+//
+// Predicates are only evaluated by VerifyEvents. They are ignored by
+// comparisons that use go-cmp (ex: testutil.Equal), which will treat an
+// ExpEvent with predicates as unequal to one without.
+type EventPredicate interface {
+	// Match returns true if the event satisfies the predicate.
+	Match(e event.Event) bool
+	// String returns a description of the predicate, used in failure
+	// messages.
+	String() string
+}
+
+// PredicateFunc returns an EventPredicate that calls the supplied function.
+// The description is used in failure messages.
+func PredicateFunc(description string, fn func(event.Event) bool) EventPredicate {
+	return predicateFunc{
+		description: description,
+		fn:          fn,
+	}
+}
+
+type predicateFunc struct {
+	description string
+	fn          func(event.Event) bool
+}
+
+func (p predicateFunc) Match(e event.Event) bool {
+	return p.fn(e)
+}
+
+func (p predicateFunc) String() string {
+	return p.description
+}
+
+// ErrorMatches returns an EventPredicate that matches events with a non-nil
+// error whose message contains the specified substring.
+func ErrorMatches(substr string) EventPredicate {
+	return PredicateFunc(fmt.Sprintf("ErrorMatches(%q)", substr), func(e event.Event) bool {
+		err := EventError(e)
+		return err != nil && strings.Contains(err.Error(), substr)
+	})
+}
+
+// IdentifierIn returns an EventPredicate that matches events whose object
+// identifier is in the specified set. ValidationEvents match if any of their
+// identifiers are in the set.
+func IdentifierIn(ids object.ObjMetadataSet) EventPredicate {
+	return PredicateFunc(fmt.Sprintf("IdentifierIn(%v)", ids), func(e event.Event) bool {
+		for _, id := range EventIdentifiers(e) {
+			if ids.Contains(id) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// GroupNameIn returns an EventPredicate that matches events whose task group
+// name is one of the specified names.
+func GroupNameIn(names ...string) EventPredicate {
+	return PredicateFunc(fmt.Sprintf("GroupNameIn(%q)", names), func(e event.Event) bool {
+		groupName := EventGroupName(e)
+		for _, name := range names {
+			if name == groupName {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Not returns an EventPredicate that matches events that do NOT match the
+// specified predicate.
+func Not(p EventPredicate) EventPredicate {
+	return PredicateFunc(fmt.Sprintf("Not(%s)", p), func(e event.Event) bool {
+		return !p.Match(e)
+	})
+}
+
+// EventError returns the error from the event, based on its type, or nil if
+// the event type has no error.
+func EventError(e event.Event) error {
+	switch e.Type {
+	case event.ErrorType:
+		return e.ErrorEvent.Err
+	case event.ApplyType:
+		return e.ApplyEvent.Error
+	case event.StatusType:
+		return e.StatusEvent.Error
+	case event.PruneType:
+		return e.PruneEvent.Error
+	case event.DeleteType:
+		return e.DeleteEvent.Error
+	case event.ValidationType:
+		return e.ValidationEvent.Error
+	default:
+		return nil
+	}
+}
+
+// EventIdentifiers returns the object identifiers from the event, based on
+// its type, or nil if the event type has no identifiers.
+func EventIdentifiers(e event.Event) object.ObjMetadataSet {
+	switch e.Type {
+	case event.ApplyType:
+		return object.ObjMetadataSet{e.ApplyEvent.Identifier}
+	case event.StatusType:
+		return object.ObjMetadataSet{e.StatusEvent.Identifier}
+	case event.PruneType:
+		return object.ObjMetadataSet{e.PruneEvent.Identifier}
+	case event.DeleteType:
+		return object.ObjMetadataSet{e.DeleteEvent.Identifier}
+	case event.WaitType:
+		return object.ObjMetadataSet{e.WaitEvent.Identifier}
+	case event.ValidationType:
+		return e.ValidationEvent.Identifiers
+	default:
+		return nil
+	}
+}
+
+// EventGroupName returns the task group name from the event, based on its
+// type, or an empty string if the event type has no group name.
+func EventGroupName(e event.Event) string {
+	switch e.Type {
+	case event.ActionGroupType:
+		return e.ActionGroupEvent.GroupName
+	case event.ApplyType:
+		return e.ApplyEvent.GroupName
+	case event.PruneType:
+		return e.PruneEvent.GroupName
+	case event.DeleteType:
+		return e.DeleteEvent.GroupName
+	case event.WaitType:
+		return e.WaitEvent.GroupName
+	default:
+		return ""
+	}
+}
+
+// failedPredicates returns the predicates of the ExpEvent that the event
+// does not satisfy.
+func failedPredicates(ee ExpEvent, e event.Event) []EventPredicate {
+	var failed []EventPredicate
+	for _, p := range ee.Predicates {
+		if !p.Match(e) {
+			failed = append(failed, p)
+		}
+	}
+	return failed
+}
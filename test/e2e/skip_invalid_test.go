@@ -98,6 +98,7 @@ func skipInvalidTest(ctx context.Context, c client.Client, invConfig invconfig.I
 										Namespace: namespaceName,
 									},
 								},
+								Err: graph.ErrDependencyNotFound,
 							},
 						},
 						object.UnstructuredToObjMetadata(pod3Obj),
@@ -124,6 +125,7 @@ func skipInvalidTest(ctx context.Context, c client.Client, invConfig invconfig.I
 										Name:      "pod-a",
 									},
 								},
+								Err: graph.ErrDependencyNotFound,
 							},
 						},
 						object.UnstructuredToObjMetadata(podBObj),